@@ -21,10 +21,13 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	AuthService_Register_FullMethodName    = "/auth.AuthService/Register"
-	AuthService_Login_FullMethodName       = "/auth.AuthService/Login"
-	AuthService_VerifyToken_FullMethodName = "/auth.AuthService/VerifyToken"
-	AuthService_Ping_FullMethodName        = "/auth.AuthService/Ping"
+	AuthService_Register_FullMethodName         = "/auth.AuthService/Register"
+	AuthService_Login_FullMethodName            = "/auth.AuthService/Login"
+	AuthService_VerifyToken_FullMethodName      = "/auth.AuthService/VerifyToken"
+	AuthService_GetProfile_FullMethodName       = "/auth.AuthService/GetProfile"
+	AuthService_UpdateProfile_FullMethodName    = "/auth.AuthService/UpdateProfile"
+	AuthService_BatchGetProfiles_FullMethodName = "/auth.AuthService/BatchGetProfiles"
+	AuthService_Ping_FullMethodName             = "/auth.AuthService/Ping"
 )
 
 // AuthServiceClient is the client API for AuthService service.
@@ -52,6 +55,25 @@ type AuthServiceClient interface {
 	// (UNAUTHENTICATED): If token is expired or invalid
 	// (INTERNAL): For server-side errors
 	VerifyToken(ctx context.Context, in *VerifyTokenRequest, opts ...grpc.CallOption) (*VerifyTokenResponse, error)
+	// GetProfile returns the profile of a single user.
+	// Errors:
+	// (INVALID_ARGUMENT): If user_id format is invalid
+	// (NOT_FOUND): If the user has no profile
+	// (UNAUTHENTICATED): If authentication is missing or invalid
+	// (INTERNAL): For server-side errors
+	GetProfile(ctx context.Context, in *GetProfileRequest, opts ...grpc.CallOption) (*Profile, error)
+	// UpdateProfile changes the profile of the authenticated user.
+	// Errors:
+	// (INVALID_ARGUMENT): If a field exceeds its length limit or the avatar URL is invalid
+	// (UNAUTHENTICATED): If authentication is missing or invalid
+	// (INTERNAL): For server-side errors
+	UpdateProfile(ctx context.Context, in *UpdateProfileRequest, opts ...grpc.CallOption) (*Profile, error)
+	// BatchGetProfiles returns the profiles of several users in one call.
+	// Errors:
+	// (INVALID_ARGUMENT): If the list is empty, too long or contains an invalid ID
+	// (UNAUTHENTICATED): If authentication is missing or invalid
+	// (INTERNAL): For server-side errors
+	BatchGetProfiles(ctx context.Context, in *BatchGetProfilesRequest, opts ...grpc.CallOption) (*ProfilesList, error)
 	// Ping checks if the service is running.
 	Ping(ctx context.Context, in *PingRequest, opts ...grpc.CallOption) (*PingResponse, error)
 }
@@ -94,6 +116,36 @@ func (c *authServiceClient) VerifyToken(ctx context.Context, in *VerifyTokenRequ
 	return out, nil
 }
 
+func (c *authServiceClient) GetProfile(ctx context.Context, in *GetProfileRequest, opts ...grpc.CallOption) (*Profile, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Profile)
+	err := c.cc.Invoke(ctx, AuthService_GetProfile_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) UpdateProfile(ctx context.Context, in *UpdateProfileRequest, opts ...grpc.CallOption) (*Profile, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Profile)
+	err := c.cc.Invoke(ctx, AuthService_UpdateProfile_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) BatchGetProfiles(ctx context.Context, in *BatchGetProfilesRequest, opts ...grpc.CallOption) (*ProfilesList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ProfilesList)
+	err := c.cc.Invoke(ctx, AuthService_BatchGetProfiles_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *authServiceClient) Ping(ctx context.Context, in *PingRequest, opts ...grpc.CallOption) (*PingResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(PingResponse)
@@ -129,6 +181,25 @@ type AuthServiceServer interface {
 	// (UNAUTHENTICATED): If token is expired or invalid
 	// (INTERNAL): For server-side errors
 	VerifyToken(context.Context, *VerifyTokenRequest) (*VerifyTokenResponse, error)
+	// GetProfile returns the profile of a single user.
+	// Errors:
+	// (INVALID_ARGUMENT): If user_id format is invalid
+	// (NOT_FOUND): If the user has no profile
+	// (UNAUTHENTICATED): If authentication is missing or invalid
+	// (INTERNAL): For server-side errors
+	GetProfile(context.Context, *GetProfileRequest) (*Profile, error)
+	// UpdateProfile changes the profile of the authenticated user.
+	// Errors:
+	// (INVALID_ARGUMENT): If a field exceeds its length limit or the avatar URL is invalid
+	// (UNAUTHENTICATED): If authentication is missing or invalid
+	// (INTERNAL): For server-side errors
+	UpdateProfile(context.Context, *UpdateProfileRequest) (*Profile, error)
+	// BatchGetProfiles returns the profiles of several users in one call.
+	// Errors:
+	// (INVALID_ARGUMENT): If the list is empty, too long or contains an invalid ID
+	// (UNAUTHENTICATED): If authentication is missing or invalid
+	// (INTERNAL): For server-side errors
+	BatchGetProfiles(context.Context, *BatchGetProfilesRequest) (*ProfilesList, error)
 	// Ping checks if the service is running.
 	Ping(context.Context, *PingRequest) (*PingResponse, error)
 	mustEmbedUnimplementedAuthServiceServer()
@@ -150,6 +221,15 @@ func (UnimplementedAuthServiceServer) Login(context.Context, *AuthRequest) (*Aut
 func (UnimplementedAuthServiceServer) VerifyToken(context.Context, *VerifyTokenRequest) (*VerifyTokenResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method VerifyToken not implemented")
 }
+func (UnimplementedAuthServiceServer) GetProfile(context.Context, *GetProfileRequest) (*Profile, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetProfile not implemented")
+}
+func (UnimplementedAuthServiceServer) UpdateProfile(context.Context, *UpdateProfileRequest) (*Profile, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateProfile not implemented")
+}
+func (UnimplementedAuthServiceServer) BatchGetProfiles(context.Context, *BatchGetProfilesRequest) (*ProfilesList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchGetProfiles not implemented")
+}
 func (UnimplementedAuthServiceServer) Ping(context.Context, *PingRequest) (*PingResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Ping not implemented")
 }
@@ -228,6 +308,60 @@ func _AuthService_VerifyToken_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AuthService_GetProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetProfileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).GetProfile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_GetProfile_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).GetProfile(ctx, req.(*GetProfileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_UpdateProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateProfileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).UpdateProfile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_UpdateProfile_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).UpdateProfile(ctx, req.(*UpdateProfileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_BatchGetProfiles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchGetProfilesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).BatchGetProfiles(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_BatchGetProfiles_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).BatchGetProfiles(ctx, req.(*BatchGetProfilesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _AuthService_Ping_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(PingRequest)
 	if err := dec(in); err != nil {
@@ -265,6 +399,18 @@ var AuthService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "VerifyToken",
 			Handler:    _AuthService_VerifyToken_Handler,
 		},
+		{
+			MethodName: "GetProfile",
+			Handler:    _AuthService_GetProfile_Handler,
+		},
+		{
+			MethodName: "UpdateProfile",
+			Handler:    _AuthService_UpdateProfile_Handler,
+		},
+		{
+			MethodName: "BatchGetProfiles",
+			Handler:    _AuthService_BatchGetProfiles_Handler,
+		},
 		{
 			MethodName: "Ping",
 			Handler:    _AuthService_Ping_Handler,
@@ -21,16 +21,49 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	GraphService_CreateUser_FullMethodName           = "/graph.GraphService/CreateUser"
-	GraphService_GetUserData_FullMethodName          = "/graph.GraphService/GetUserData"
-	GraphService_CreateEntity_FullMethodName         = "/graph.GraphService/CreateEntity"
-	GraphService_UpdateEntity_FullMethodName         = "/graph.GraphService/UpdateEntity"
-	GraphService_FindEntities_FullMethodName         = "/graph.GraphService/FindEntities"
-	GraphService_CreateConnectionType_FullMethodName = "/graph.GraphService/CreateConnectionType"
-	GraphService_FindConnectionTypes_FullMethodName  = "/graph.GraphService/FindConnectionTypes"
-	GraphService_CreatePropertyType_FullMethodName   = "/graph.GraphService/CreatePropertyType"
-	GraphService_FindPropertyTypes_FullMethodName    = "/graph.GraphService/FindPropertyTypes"
-	GraphService_Ping_FullMethodName                 = "/graph.GraphService/Ping"
+	GraphService_CreateUser_FullMethodName                = "/graph.GraphService/CreateUser"
+	GraphService_GetUserData_FullMethodName               = "/graph.GraphService/GetUserData"
+	GraphService_CreateEntity_FullMethodName              = "/graph.GraphService/CreateEntity"
+	GraphService_UpdateEntity_FullMethodName              = "/graph.GraphService/UpdateEntity"
+	GraphService_FindEntities_FullMethodName              = "/graph.GraphService/FindEntities"
+	GraphService_CreateConnectionType_FullMethodName      = "/graph.GraphService/CreateConnectionType"
+	GraphService_CreateConnection_FullMethodName          = "/graph.GraphService/CreateConnection"
+	GraphService_DeleteConnection_FullMethodName          = "/graph.GraphService/DeleteConnection"
+	GraphService_GetConnections_FullMethodName            = "/graph.GraphService/GetConnections"
+	GraphService_FindConnectionTypes_FullMethodName       = "/graph.GraphService/FindConnectionTypes"
+	GraphService_CreatePropertyType_FullMethodName        = "/graph.GraphService/CreatePropertyType"
+	GraphService_FindPropertyTypes_FullMethodName         = "/graph.GraphService/FindPropertyTypes"
+	GraphService_CreateEntityClass_FullMethodName         = "/graph.GraphService/CreateEntityClass"
+	GraphService_FindEntityClasses_FullMethodName         = "/graph.GraphService/FindEntityClasses"
+	GraphService_SetClasses_FullMethodName                = "/graph.GraphService/SetClasses"
+	GraphService_GetClasses_FullMethodName                = "/graph.GraphService/GetClasses"
+	GraphService_GetApplicableTypes_FullMethodName        = "/graph.GraphService/GetApplicableTypes"
+	GraphService_Vote_FullMethodName                      = "/graph.GraphService/Vote"
+	GraphService_GetEntityVersions_FullMethodName         = "/graph.GraphService/GetEntityVersions"
+	GraphService_GetConnectionTypeVersions_FullMethodName = "/graph.GraphService/GetConnectionTypeVersions"
+	GraphService_GetPropertyTypeVersions_FullMethodName   = "/graph.GraphService/GetPropertyTypeVersions"
+	GraphService_ProposeMerge_FullMethodName              = "/graph.GraphService/ProposeMerge"
+	GraphService_AcceptMerge_FullMethodName               = "/graph.GraphService/AcceptMerge"
+	GraphService_SplitEntity_FullMethodName               = "/graph.GraphService/SplitEntity"
+	GraphService_ListMerges_FullMethodName                = "/graph.GraphService/ListMerges"
+	GraphService_ListRevisions_FullMethodName             = "/graph.GraphService/ListRevisions"
+	GraphService_GetRevision_FullMethodName               = "/graph.GraphService/GetRevision"
+	GraphService_RevertToRevision_FullMethodName          = "/graph.GraphService/RevertToRevision"
+	GraphService_CreateWorkspace_FullMethodName           = "/graph.GraphService/CreateWorkspace"
+	GraphService_ListWorkspaces_FullMethodName            = "/graph.GraphService/ListWorkspaces"
+	GraphService_ListMembers_FullMethodName               = "/graph.GraphService/ListMembers"
+	GraphService_InviteMember_FullMethodName              = "/graph.GraphService/InviteMember"
+	GraphService_ListInvitations_FullMethodName           = "/graph.GraphService/ListInvitations"
+	GraphService_AcceptInvitation_FullMethodName          = "/graph.GraphService/AcceptInvitation"
+	GraphService_DeclineInvitation_FullMethodName         = "/graph.GraphService/DeclineInvitation"
+	GraphService_UpdateMemberRole_FullMethodName          = "/graph.GraphService/UpdateMemberRole"
+	GraphService_RemoveMember_FullMethodName              = "/graph.GraphService/RemoveMember"
+	GraphService_WatchGraph_FullMethodName                = "/graph.GraphService/WatchGraph"
+	GraphService_CreateWebhook_FullMethodName             = "/graph.GraphService/CreateWebhook"
+	GraphService_ListWebhooks_FullMethodName              = "/graph.GraphService/ListWebhooks"
+	GraphService_DeleteWebhook_FullMethodName             = "/graph.GraphService/DeleteWebhook"
+	GraphService_ListWebhookDeliveries_FullMethodName     = "/graph.GraphService/ListWebhookDeliveries"
+	GraphService_Ping_FullMethodName                      = "/graph.GraphService/Ping"
 )
 
 // GraphServiceClient is the client API for GraphService service.
@@ -39,6 +72,12 @@ const (
 //
 // GraphService provides operations for managing graph-based knowledge representation.
 // All operations require authentication via JWT token in the "authorization" metadata.
+// Graph operations (every RPC except CreateUser, Ping and the workspace and invitation RPCs) can be scoped to a shared
+// workspace by sending its ID in the "workspace-id" metadata. Reads then need the viewer role, writes the editor role,
+// webhooks the admin role, and the user_id of the returned versions is the workspace ID.
+// Workspace scope errors:
+// (INVALID_ARGUMENT): If workspace-id is not a valid UUID
+// (PERMISSION_DENIED): If the user is not a member of the workspace or their role is too low
 type GraphServiceClient interface {
 	// CreateUser initializes a new user in the graph service. This endpoint is only accessible by the auth service.
 	// Errors:
@@ -47,10 +86,11 @@ type GraphServiceClient interface {
 	// (INTERNAL): For server-side errors
 	CreateUser(ctx context.Context, in *UserRequest, opts ...grpc.CallOption) (*Empty, error)
 	// GetUserData retrieves all entities, connection types, and property types associated with the authenticated user.
+	// If as_of is set, the graph is returned as it was at that point in time.
 	// Errors:
 	// (UNAUTHENTICATED): If authentication is missing or invalid
 	// (INTERNAL): For server-side errors
-	GetUserData(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*UserData, error)
+	GetUserData(ctx context.Context, in *UserDataRequest, opts ...grpc.CallOption) (*UserData, error)
 	// CreateEntity creates a new entity or links to an existing one if ID is provided.
 	// Errors:
 	// (INVALID_ARGUMENT): If name or definition exceed length limits
@@ -65,20 +105,48 @@ type GraphServiceClient interface {
 	// (UNAUTHENTICATED): If authentication is missing or invalid
 	// (INTERNAL): For server-side errors
 	UpdateEntity(ctx context.Context, in *EntityRequest, opts ...grpc.CallOption) (*Empty, error)
-	// FindEntities searches for entities by exact name match.
+	// FindEntities searches for entities by exact name match against any user's version.
+	// Each matching entity is returned once, in its canonical (highest scoring) version, with its score and user_count.
+	// If class_id is set, only instances of the class and its subclasses are returned.
 	// Errors:
 	// (INVALID_ARGUMENT): If name is empty or too long
 	// (UNAUTHENTICATED): If authentication is missing or invalid
 	// (INTERNAL): For server-side errors
 	FindEntities(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*EntitiesList, error)
 	// CreateConnectionType creates a new connection type or links to an existing one.
+	// The semantics (inverse, symmetric, transitive, domain and range) can only be set when creating a new connection type.
 	// Errors:
-	// (INVALID_ARGUMENT): If name or definition exceed length limits
+	// (INVALID_ARGUMENT): If name or definition exceed length limits, or the semantics conflict with the inverse
 	// (NOT_FOUND): If entity ID is provided but entity doesn't exist
 	// (UNAUTHENTICATED): If authentication is missing or invalid
 	// (INTERNAL): For server-side errors
 	CreateConnectionType(ctx context.Context, in *ConnectionTypeRequest, opts ...grpc.CallOption) (*UsersConnectionType, error)
-	// FindConnectionTypes searches for connection types by exact name match.
+	// CreateConnection connects two entities in the user's graph.
+	// Connections implied by the inverse or symmetry of a connection type become visible in GetConnections without being stored.
+	// Errors:
+	// (INVALID_ARGUMENT): If an ID is invalid, an entity is outside the domain or range of the connection type,
+	//                     or the from entity is not an instance of a class the connection type applies to
+	// (NOT_FOUND): If the connection type or an entity does not exist
+	// (ALREADY_EXISTS): If the connection is already stored or implied
+	// (UNAUTHENTICATED): If authentication is missing or invalid
+	// (INTERNAL): For server-side errors
+	CreateConnection(ctx context.Context, in *ConnectionRequest, opts ...grpc.CallOption) (*Connection, error)
+	// DeleteConnection deletes a stored connection of the user's graph, with the connections it implied.
+	// Errors:
+	// (INVALID_ARGUMENT): If id is invalid
+	// (NOT_FOUND): If the user has no connection with the ID
+	// (UNAUTHENTICATED): If authentication is missing or invalid
+	// (INTERNAL): For server-side errors
+	DeleteConnection(ctx context.Context, in *IdRequest, opts ...grpc.CallOption) (*Empty, error)
+	// GetConnections lists the connections going out of an entity in the user's graph,
+	// including the ones inferred from inverse, symmetric and transitive connection types.
+	// Errors:
+	// (INVALID_ARGUMENT): If an ID is invalid
+	// (UNAUTHENTICATED): If authentication is missing or invalid
+	// (INTERNAL): For server-side errors
+	GetConnections(ctx context.Context, in *TraversalRequest, opts ...grpc.CallOption) (*ConnectionsList, error)
+	// FindConnectionTypes searches for connection types by exact name match against any user's version.
+	// Each matching connection type is returned once, in its canonical (highest scoring) version, with its score and user_count.
 	// Errors:
 	// (INVALID_ARGUMENT): If name is empty or too long
 	// (UNAUTHENTICATED): If authentication is missing or invalid
@@ -92,12 +160,230 @@ type GraphServiceClient interface {
 	// (INTERNAL): For server-side errors
 	// Example request:
 	CreatePropertyType(ctx context.Context, in *PropertyTypeRequest, opts ...grpc.CallOption) (*UsersPropertyType, error)
-	// FindPropertyTypes searches for property types by exact name match.
+	// FindPropertyTypes searches for property types by exact name match against any user's version.
+	// Each matching property type is returned once, in its canonical (highest scoring) version, with its score and user_count.
 	// Errors:
 	// (INVALID_ARGUMENT): If name is empty or too long
 	// (UNAUTHENTICATED): If authentication is missing or invalid
 	// (INTERNAL): For server-side errors
 	FindPropertyTypes(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*PropertyTypesList, error)
+	// CreateEntityClass creates a new entity class or links to an existing one.
+	// Errors:
+	// (INVALID_ARGUMENT): If name or definition exceed length limits, or parent_ids are set with id
+	// (NOT_FOUND): If the entity class or a parent class does not exist
+	// (UNAUTHENTICATED): If authentication is missing or invalid
+	// (INTERNAL): For server-side errors
+	CreateEntityClass(ctx context.Context, in *EntityClassRequest, opts ...grpc.CallOption) (*UsersEntityClass, error)
+	// FindEntityClasses searches for entity classes by exact name match against any user's version.
+	// Each matching entity class is returned once, in its canonical version.
+	// Errors:
+	// (INVALID_ARGUMENT): If name is empty or too long
+	// (UNAUTHENTICATED): If authentication is missing or invalid
+	// (INTERNAL): For server-side errors
+	FindEntityClasses(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*EntityClassesList, error)
+	// SetClasses replaces the classes of a shared node. The user must have a version of the node.
+	// Errors:
+	// (INVALID_ARGUMENT): If a field is invalid, or a class would become its own ancestor
+	// (NOT_FOUND): If the node or a class does not exist
+	// (PERMISSION_DENIED): If the user has no version of the node
+	// (UNAUTHENTICATED): If authentication is missing or invalid
+	// (INTERNAL): For server-side errors
+	SetClasses(ctx context.Context, in *ClassesRequest, opts ...grpc.CallOption) (*Empty, error)
+	// GetClasses lists the classes directly set on a shared node, in their canonical versions.
+	// Errors:
+	// (INVALID_ARGUMENT): If a field is invalid
+	// (UNAUTHENTICATED): If authentication is missing or invalid
+	// (INTERNAL): For server-side errors
+	GetClasses(ctx context.Context, in *NodeRequest, opts ...grpc.CallOption) (*EntityClassesList, error)
+	// GetApplicableTypes lists the connection and property types that apply to instances of an entity class,
+	// including the ones that apply to its superclasses.
+	// Errors:
+	// (INVALID_ARGUMENT): If id is invalid
+	// (UNAUTHENTICATED): If authentication is missing or invalid
+	// (INTERNAL): For server-side errors
+	GetApplicableTypes(ctx context.Context, in *IdRequest, opts ...grpc.CallOption) (*ApplicableTypes, error)
+	// Vote up- or downvotes another user's version of a shared entity, connection type or property type.
+	// Each user has one vote per version; voting again replaces it.
+	// Errors:
+	// (INVALID_ARGUMENT): If a field is invalid or the user votes on their own version
+	// (NOT_FOUND): If the version does not exist
+	// (UNAUTHENTICATED): If authentication is missing or invalid
+	// (INTERNAL): For server-side errors
+	Vote(ctx context.Context, in *VoteRequest, opts ...grpc.CallOption) (*Empty, error)
+	// GetEntityVersions lists all users' versions of an entity, ordered by score. The first one is the canonical version.
+	// Errors:
+	// (INVALID_ARGUMENT): If id is invalid
+	// (NOT_FOUND): If the entity does not exist
+	// (UNAUTHENTICATED): If authentication is missing or invalid
+	// (INTERNAL): For server-side errors
+	GetEntityVersions(ctx context.Context, in *IdRequest, opts ...grpc.CallOption) (*EntitiesList, error)
+	// GetConnectionTypeVersions lists all users' versions of a connection type, ordered by score. The first one is the canonical version.
+	// Errors:
+	// (INVALID_ARGUMENT): If id is invalid
+	// (NOT_FOUND): If the connection type does not exist
+	// (UNAUTHENTICATED): If authentication is missing or invalid
+	// (INTERNAL): For server-side errors
+	GetConnectionTypeVersions(ctx context.Context, in *IdRequest, opts ...grpc.CallOption) (*ConnectionTypesList, error)
+	// GetPropertyTypeVersions lists all users' versions of a property type, ordered by score. The first one is the canonical version.
+	// Errors:
+	// (INVALID_ARGUMENT): If id is invalid
+	// (NOT_FOUND): If the property type does not exist
+	// (UNAUTHENTICATED): If authentication is missing or invalid
+	// (INTERNAL): For server-side errors
+	GetPropertyTypeVersions(ctx context.Context, in *IdRequest, opts ...grpc.CallOption) (*PropertyTypesList, error)
+	// ProposeMerge proposes merging the source entity into the target entity.
+	// The user must have a version of one of the entities. Another user must accept the merge.
+	// Errors:
+	// (INVALID_ARGUMENT): If an ID is invalid or both IDs are equal
+	// (NOT_FOUND): If an entity does not exist
+	// (PERMISSION_DENIED): If the user has no version of either entity
+	// (UNAUTHENTICATED): If authentication is missing or invalid
+	// (INTERNAL): For server-side errors
+	ProposeMerge(ctx context.Context, in *MergeRequest, opts ...grpc.CallOption) (*Merge, error)
+	// AcceptMerge merges the source entity of a proposed merge into its target entity.
+	// Versions move to the target entity, unless their owner already has a version of it, in which case they are dropped.
+	// Votes move with their versions. The accepting user must have a version of one of the entities and not be the proposer.
+	// Errors:
+	// (INVALID_ARGUMENT): If id is invalid or the user proposed the merge
+	// (NOT_FOUND): If no proposed merge with the ID exists
+	// (PERMISSION_DENIED): If the user has no version of either entity
+	// (UNAUTHENTICATED): If authentication is missing or invalid
+	// (INTERNAL): For server-side errors
+	AcceptMerge(ctx context.Context, in *MergeIdRequest, opts ...grpc.CallOption) (*Merge, error)
+	// SplitEntity reverts an accepted merge (merge_id), or splits the user's version off an entity into a new entity (entity_id).
+	// Reverting requires a version of the merge's target entity. Both are recorded as merges.
+	// Errors:
+	// (INVALID_ARGUMENT): If not exactly one ID is set, or the user's version is the only version of the entity
+	// (NOT_FOUND): If no accepted merge or user's version with the ID exists
+	// (PERMISSION_DENIED): If the user has no version of the merge's target entity
+	// (UNAUTHENTICATED): If authentication is missing or invalid
+	// (INTERNAL): For server-side errors
+	SplitEntity(ctx context.Context, in *SplitRequest, opts ...grpc.CallOption) (*Merge, error)
+	// ListMerges lists the merges and splits an entity was part of, newest first.
+	// Errors:
+	// (INVALID_ARGUMENT): If id is invalid
+	// (UNAUTHENTICATED): If authentication is missing or invalid
+	// (INTERNAL): For server-side errors
+	ListMerges(ctx context.Context, in *IdRequest, opts ...grpc.CallOption) (*MergesList, error)
+	// ListRevisions lists the history of a user's version of a shared node, newest first.
+	// Errors:
+	// (INVALID_ARGUMENT): If a field is invalid
+	// (UNAUTHENTICATED): If authentication is missing or invalid
+	// (INTERNAL): For server-side errors
+	ListRevisions(ctx context.Context, in *RevisionsRequest, opts ...grpc.CallOption) (*RevisionsList, error)
+	// GetRevision gets a revision by ID, if it is one of the user's own or of their workspaces.
+	// Errors:
+	// (INVALID_ARGUMENT): If id is invalid
+	// (NOT_FOUND): If the revision does not exist or belongs to another owner
+	// (UNAUTHENTICATED): If authentication is missing or invalid
+	// (INTERNAL): For server-side errors
+	GetRevision(ctx context.Context, in *IdRequest, opts ...grpc.CallOption) (*Revision, error)
+	// RevertToRevision sets the authenticated user's version back to the name and definition of one of its revisions.
+	// A version a merge or split moved off its node is restored. The revert is recorded as a new revision, which is returned.
+	// Errors:
+	// (INVALID_ARGUMENT): If id is invalid, or the revision removed the version
+	// (NOT_FOUND): If the revision is not one of the user's, or its node no longer exists
+	// (UNAUTHENTICATED): If authentication is missing or invalid
+	// (INTERNAL): For server-side errors
+	RevertToRevision(ctx context.Context, in *IdRequest, opts ...grpc.CallOption) (*Revision, error)
+	// CreateWorkspace creates a shared workspace with the authenticated user as its admin.
+	// Errors:
+	// (INVALID_ARGUMENT): If name is empty or too long
+	// (UNAUTHENTICATED): If authentication is missing or invalid
+	// (INTERNAL): For server-side errors
+	CreateWorkspace(ctx context.Context, in *WorkspaceRequest, opts ...grpc.CallOption) (*Workspace, error)
+	// ListWorkspaces lists the workspaces the authenticated user is a member of.
+	// Errors:
+	// (UNAUTHENTICATED): If authentication is missing or invalid
+	// (INTERNAL): For server-side errors
+	ListWorkspaces(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*WorkspacesList, error)
+	// ListMembers lists the members of a workspace. Requires the viewer role.
+	// Errors:
+	// (INVALID_ARGUMENT): If workspace_id is invalid
+	// (PERMISSION_DENIED): If the user is not a member of the workspace
+	// (UNAUTHENTICATED): If authentication is missing or invalid
+	// (INTERNAL): For server-side errors
+	ListMembers(ctx context.Context, in *WorkspaceIdRequest, opts ...grpc.CallOption) (*MembersList, error)
+	// InviteMember invites a user to a workspace. Requires the admin role.
+	// Errors:
+	// (INVALID_ARGUMENT): If an ID or the role is invalid
+	// (NOT_FOUND): If the invited user does not exist
+	// (ALREADY_EXISTS): If the user is already a member or already invited
+	// (PERMISSION_DENIED): If the user is not an admin of the workspace
+	// (UNAUTHENTICATED): If authentication is missing or invalid
+	// (INTERNAL): For server-side errors
+	InviteMember(ctx context.Context, in *MemberRequest, opts ...grpc.CallOption) (*Invitation, error)
+	// ListInvitations lists the pending invitations of the authenticated user.
+	// Errors:
+	// (UNAUTHENTICATED): If authentication is missing or invalid
+	// (INTERNAL): For server-side errors
+	ListInvitations(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*InvitationsList, error)
+	// AcceptInvitation joins the workspace of an invitation addressed to the authenticated user.
+	// Errors:
+	// (INVALID_ARGUMENT): If id is invalid
+	// (NOT_FOUND): If the invitation does not exist or is addressed to another user
+	// (UNAUTHENTICATED): If authentication is missing or invalid
+	// (INTERNAL): For server-side errors
+	AcceptInvitation(ctx context.Context, in *InvitationIdRequest, opts ...grpc.CallOption) (*Workspace, error)
+	// DeclineInvitation deletes an invitation addressed to the authenticated user.
+	// Errors:
+	// (INVALID_ARGUMENT): If id is invalid
+	// (NOT_FOUND): If the invitation does not exist or is addressed to another user
+	// (UNAUTHENTICATED): If authentication is missing or invalid
+	// (INTERNAL): For server-side errors
+	DeclineInvitation(ctx context.Context, in *InvitationIdRequest, opts ...grpc.CallOption) (*Empty, error)
+	// UpdateMemberRole changes the role of a workspace member. Requires the admin role.
+	// Errors:
+	// (INVALID_ARGUMENT): If an ID or the role is invalid, or the last admin would be demoted
+	// (NOT_FOUND): If the user is not a member
+	// (PERMISSION_DENIED): If the user is not an admin of the workspace
+	// (UNAUTHENTICATED): If authentication is missing or invalid
+	// (INTERNAL): For server-side errors
+	UpdateMemberRole(ctx context.Context, in *MemberRequest, opts ...grpc.CallOption) (*Empty, error)
+	// RemoveMember removes a member from a workspace. Requires the admin role, unless users remove themselves.
+	// Errors:
+	// (INVALID_ARGUMENT): If an ID is invalid, or the last admin would be removed
+	// (NOT_FOUND): If the user is not a member
+	// (PERMISSION_DENIED): If the user is not allowed to remove the member
+	// (UNAUTHENTICATED): If authentication is missing or invalid
+	// (INTERNAL): For server-side errors
+	RemoveMember(ctx context.Context, in *MemberRequest, opts ...grpc.CallOption) (*Empty, error)
+	// WatchGraph streams the changes of the user's versions and connections as they happen.
+	// A heartbeat with the current resume_token is sent when the stream starts and periodically after.
+	// A client that reconnects with the last resume_token it received does not miss any changes.
+	// Errors:
+	// (INVALID_ARGUMENT): If the resume_token or a kind is invalid
+	// (FAILED_PRECONDITION): If the resume_token has expired. Reload with GetUserData and watch without it
+	// (UNAUTHENTICATED): If authentication is missing or invalid
+	// (INTERNAL): For server-side errors
+	WatchGraph(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[GraphEvent], error)
+	// CreateWebhook subscribes a URL to the changes of the user's versions and connections, from now on.
+	// The returned secret is needed to verify the payload signatures and is not shown again.
+	// Managing the webhooks of a workspace needs the admin role.
+	// Errors:
+	// (INVALID_ARGUMENT): If the url or a kind is invalid, or the user has too many webhooks
+	// (UNAUTHENTICATED): If authentication is missing or invalid
+	// (INTERNAL): For server-side errors
+	CreateWebhook(ctx context.Context, in *WebhookRequest, opts ...grpc.CallOption) (*Webhook, error)
+	// ListWebhooks lists the user's webhooks, without their secrets.
+	// Errors:
+	// (UNAUTHENTICATED): If authentication is missing or invalid
+	// (INTERNAL): For server-side errors
+	ListWebhooks(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*WebhooksList, error)
+	// DeleteWebhook deletes a webhook with its delivery log and dead-letter queue.
+	// Errors:
+	// (INVALID_ARGUMENT): If webhook_id is invalid
+	// (NOT_FOUND): If the user has no such webhook
+	// (UNAUTHENTICATED): If authentication is missing or invalid
+	// (INTERNAL): For server-side errors
+	DeleteWebhook(ctx context.Context, in *WebhookIdRequest, opts ...grpc.CallOption) (*Empty, error)
+	// ListWebhookDeliveries lists the delivery attempts of a webhook, newest first.
+	// Errors:
+	// (INVALID_ARGUMENT): If webhook_id or limit is invalid
+	// (NOT_FOUND): If the user has no such webhook
+	// (UNAUTHENTICATED): If authentication is missing or invalid
+	// (INTERNAL): For server-side errors
+	ListWebhookDeliveries(ctx context.Context, in *WebhookDeliveriesRequest, opts ...grpc.CallOption) (*WebhookDeliveriesList, error)
 	// Ping checks if the service is running.
 	Ping(ctx context.Context, in *PingRequest, opts ...grpc.CallOption) (*PingResponse, error)
 }
@@ -120,7 +406,7 @@ func (c *graphServiceClient) CreateUser(ctx context.Context, in *UserRequest, op
 	return out, nil
 }
 
-func (c *graphServiceClient) GetUserData(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*UserData, error) {
+func (c *graphServiceClient) GetUserData(ctx context.Context, in *UserDataRequest, opts ...grpc.CallOption) (*UserData, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(UserData)
 	err := c.cc.Invoke(ctx, GraphService_GetUserData_FullMethodName, in, out, cOpts...)
@@ -170,6 +456,36 @@ func (c *graphServiceClient) CreateConnectionType(ctx context.Context, in *Conne
 	return out, nil
 }
 
+func (c *graphServiceClient) CreateConnection(ctx context.Context, in *ConnectionRequest, opts ...grpc.CallOption) (*Connection, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Connection)
+	err := c.cc.Invoke(ctx, GraphService_CreateConnection_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *graphServiceClient) DeleteConnection(ctx context.Context, in *IdRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, GraphService_DeleteConnection_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *graphServiceClient) GetConnections(ctx context.Context, in *TraversalRequest, opts ...grpc.CallOption) (*ConnectionsList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ConnectionsList)
+	err := c.cc.Invoke(ctx, GraphService_GetConnections_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *graphServiceClient) FindConnectionTypes(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*ConnectionTypesList, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ConnectionTypesList)
@@ -200,302 +516,1550 @@ func (c *graphServiceClient) FindPropertyTypes(ctx context.Context, in *SearchRe
 	return out, nil
 }
 
-func (c *graphServiceClient) Ping(ctx context.Context, in *PingRequest, opts ...grpc.CallOption) (*PingResponse, error) {
+func (c *graphServiceClient) CreateEntityClass(ctx context.Context, in *EntityClassRequest, opts ...grpc.CallOption) (*UsersEntityClass, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(PingResponse)
-	err := c.cc.Invoke(ctx, GraphService_Ping_FullMethodName, in, out, cOpts...)
+	out := new(UsersEntityClass)
+	err := c.cc.Invoke(ctx, GraphService_CreateEntityClass_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-// GraphServiceServer is the server API for GraphService service.
-// All implementations must embed UnimplementedGraphServiceServer
-// for forward compatibility.
-//
-// GraphService provides operations for managing graph-based knowledge representation.
-// All operations require authentication via JWT token in the "authorization" metadata.
-type GraphServiceServer interface {
-	// CreateUser initializes a new user in the graph service. This endpoint is only accessible by the auth service.
-	// Errors:
-	// (INVALID_ARGUMENT): If user_id format is invalid
-	// (ALREADY_EXISTS): If user already exists
-	// (INTERNAL): For server-side errors
-	CreateUser(context.Context, *UserRequest) (*Empty, error)
-	// GetUserData retrieves all entities, connection types, and property types associated with the authenticated user.
-	// Errors:
-	// (UNAUTHENTICATED): If authentication is missing or invalid
-	// (INTERNAL): For server-side errors
-	GetUserData(context.Context, *Empty) (*UserData, error)
-	// CreateEntity creates a new entity or links to an existing one if ID is provided.
-	// Errors:
-	// (INVALID_ARGUMENT): If name or definition exceed length limits
-	// (NOT_FOUND): If entity ID is provided but entity doesn't exist
-	// (UNAUTHENTICATED): If authentication is missing or invalid
-	// (INTERNAL): For server-side errors
-	CreateEntity(context.Context, *EntityRequest) (*UsersEntity, error)
-	// UpdateEntity modifies an existing entity.
-	// Errors:
-	// (INVALID_ARGUMENT): If name or definition exceed length limits
-	// (NOT_FOUND): If entity doesn't exist
-	// (UNAUTHENTICATED): If authentication is missing or invalid
-	// (INTERNAL): For server-side errors
-	UpdateEntity(context.Context, *EntityRequest) (*Empty, error)
-	// FindEntities searches for entities by exact name match.
-	// Errors:
-	// (INVALID_ARGUMENT): If name is empty or too long
-	// (UNAUTHENTICATED): If authentication is missing or invalid
-	// (INTERNAL): For server-side errors
-	FindEntities(context.Context, *SearchRequest) (*EntitiesList, error)
-	// CreateConnectionType creates a new connection type or links to an existing one.
-	// Errors:
-	// (INVALID_ARGUMENT): If name or definition exceed length limits
-	// (NOT_FOUND): If entity ID is provided but entity doesn't exist
-	// (UNAUTHENTICATED): If authentication is missing or invalid
-	// (INTERNAL): For server-side errors
-	CreateConnectionType(context.Context, *ConnectionTypeRequest) (*UsersConnectionType, error)
-	// FindConnectionTypes searches for connection types by exact name match.
-	// Errors:
-	// (INVALID_ARGUMENT): If name is empty or too long
-	// (UNAUTHENTICATED): If authentication is missing or invalid
-	// (INTERNAL): For server-side errors
-	FindConnectionTypes(context.Context, *SearchRequest) (*ConnectionTypesList, error)
-	// CreatePropertyType creates a new property type or links to an existing one.
-	// Errors:
-	// (INVALID_ARGUMENT): If name or definition exceed length limits
-	// (NOT_FOUND): If entity ID is provided but entity doesn't exist
-	// (UNAUTHENTICATED): If authentication is missing or invalid
-	// (INTERNAL): For server-side errors
-	// Example request:
-	CreatePropertyType(context.Context, *PropertyTypeRequest) (*UsersPropertyType, error)
-	// FindPropertyTypes searches for property types by exact name match.
-	// Errors:
-	// (INVALID_ARGUMENT): If name is empty or too long
-	// (UNAUTHENTICATED): If authentication is missing or invalid
-	// (INTERNAL): For server-side errors
-	FindPropertyTypes(context.Context, *SearchRequest) (*PropertyTypesList, error)
-	// Ping checks if the service is running.
-	Ping(context.Context, *PingRequest) (*PingResponse, error)
-	mustEmbedUnimplementedGraphServiceServer()
+func (c *graphServiceClient) FindEntityClasses(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*EntityClassesList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EntityClassesList)
+	err := c.cc.Invoke(ctx, GraphService_FindEntityClasses_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-// UnimplementedGraphServiceServer must be embedded to have
-// forward compatible implementations.
-//
-// NOTE: this should be embedded by value instead of pointer to avoid a nil
-// pointer dereference when methods are called.
-type UnimplementedGraphServiceServer struct{}
+func (c *graphServiceClient) SetClasses(ctx context.Context, in *ClassesRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, GraphService_SetClasses_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
 
-func (UnimplementedGraphServiceServer) CreateUser(context.Context, *UserRequest) (*Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CreateUser not implemented")
+func (c *graphServiceClient) GetClasses(ctx context.Context, in *NodeRequest, opts ...grpc.CallOption) (*EntityClassesList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EntityClassesList)
+	err := c.cc.Invoke(ctx, GraphService_GetClasses_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedGraphServiceServer) GetUserData(context.Context, *Empty) (*UserData, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetUserData not implemented")
+
+func (c *graphServiceClient) GetApplicableTypes(ctx context.Context, in *IdRequest, opts ...grpc.CallOption) (*ApplicableTypes, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ApplicableTypes)
+	err := c.cc.Invoke(ctx, GraphService_GetApplicableTypes_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedGraphServiceServer) CreateEntity(context.Context, *EntityRequest) (*UsersEntity, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CreateEntity not implemented")
+
+func (c *graphServiceClient) Vote(ctx context.Context, in *VoteRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, GraphService_Vote_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedGraphServiceServer) UpdateEntity(context.Context, *EntityRequest) (*Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method UpdateEntity not implemented")
+
+func (c *graphServiceClient) GetEntityVersions(ctx context.Context, in *IdRequest, opts ...grpc.CallOption) (*EntitiesList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EntitiesList)
+	err := c.cc.Invoke(ctx, GraphService_GetEntityVersions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *graphServiceClient) GetConnectionTypeVersions(ctx context.Context, in *IdRequest, opts ...grpc.CallOption) (*ConnectionTypesList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ConnectionTypesList)
+	err := c.cc.Invoke(ctx, GraphService_GetConnectionTypeVersions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *graphServiceClient) GetPropertyTypeVersions(ctx context.Context, in *IdRequest, opts ...grpc.CallOption) (*PropertyTypesList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PropertyTypesList)
+	err := c.cc.Invoke(ctx, GraphService_GetPropertyTypeVersions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *graphServiceClient) ProposeMerge(ctx context.Context, in *MergeRequest, opts ...grpc.CallOption) (*Merge, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Merge)
+	err := c.cc.Invoke(ctx, GraphService_ProposeMerge_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *graphServiceClient) AcceptMerge(ctx context.Context, in *MergeIdRequest, opts ...grpc.CallOption) (*Merge, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Merge)
+	err := c.cc.Invoke(ctx, GraphService_AcceptMerge_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *graphServiceClient) SplitEntity(ctx context.Context, in *SplitRequest, opts ...grpc.CallOption) (*Merge, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Merge)
+	err := c.cc.Invoke(ctx, GraphService_SplitEntity_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *graphServiceClient) ListMerges(ctx context.Context, in *IdRequest, opts ...grpc.CallOption) (*MergesList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MergesList)
+	err := c.cc.Invoke(ctx, GraphService_ListMerges_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *graphServiceClient) ListRevisions(ctx context.Context, in *RevisionsRequest, opts ...grpc.CallOption) (*RevisionsList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RevisionsList)
+	err := c.cc.Invoke(ctx, GraphService_ListRevisions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *graphServiceClient) GetRevision(ctx context.Context, in *IdRequest, opts ...grpc.CallOption) (*Revision, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Revision)
+	err := c.cc.Invoke(ctx, GraphService_GetRevision_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *graphServiceClient) RevertToRevision(ctx context.Context, in *IdRequest, opts ...grpc.CallOption) (*Revision, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Revision)
+	err := c.cc.Invoke(ctx, GraphService_RevertToRevision_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *graphServiceClient) CreateWorkspace(ctx context.Context, in *WorkspaceRequest, opts ...grpc.CallOption) (*Workspace, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Workspace)
+	err := c.cc.Invoke(ctx, GraphService_CreateWorkspace_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *graphServiceClient) ListWorkspaces(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*WorkspacesList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WorkspacesList)
+	err := c.cc.Invoke(ctx, GraphService_ListWorkspaces_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *graphServiceClient) ListMembers(ctx context.Context, in *WorkspaceIdRequest, opts ...grpc.CallOption) (*MembersList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MembersList)
+	err := c.cc.Invoke(ctx, GraphService_ListMembers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *graphServiceClient) InviteMember(ctx context.Context, in *MemberRequest, opts ...grpc.CallOption) (*Invitation, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Invitation)
+	err := c.cc.Invoke(ctx, GraphService_InviteMember_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *graphServiceClient) ListInvitations(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*InvitationsList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(InvitationsList)
+	err := c.cc.Invoke(ctx, GraphService_ListInvitations_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *graphServiceClient) AcceptInvitation(ctx context.Context, in *InvitationIdRequest, opts ...grpc.CallOption) (*Workspace, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Workspace)
+	err := c.cc.Invoke(ctx, GraphService_AcceptInvitation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *graphServiceClient) DeclineInvitation(ctx context.Context, in *InvitationIdRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, GraphService_DeclineInvitation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *graphServiceClient) UpdateMemberRole(ctx context.Context, in *MemberRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, GraphService_UpdateMemberRole_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *graphServiceClient) RemoveMember(ctx context.Context, in *MemberRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, GraphService_RemoveMember_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *graphServiceClient) WatchGraph(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[GraphEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &GraphService_ServiceDesc.Streams[0], GraphService_WatchGraph_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchRequest, GraphEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type GraphService_WatchGraphClient = grpc.ServerStreamingClient[GraphEvent]
+
+func (c *graphServiceClient) CreateWebhook(ctx context.Context, in *WebhookRequest, opts ...grpc.CallOption) (*Webhook, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Webhook)
+	err := c.cc.Invoke(ctx, GraphService_CreateWebhook_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *graphServiceClient) ListWebhooks(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*WebhooksList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WebhooksList)
+	err := c.cc.Invoke(ctx, GraphService_ListWebhooks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *graphServiceClient) DeleteWebhook(ctx context.Context, in *WebhookIdRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, GraphService_DeleteWebhook_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *graphServiceClient) ListWebhookDeliveries(ctx context.Context, in *WebhookDeliveriesRequest, opts ...grpc.CallOption) (*WebhookDeliveriesList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WebhookDeliveriesList)
+	err := c.cc.Invoke(ctx, GraphService_ListWebhookDeliveries_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *graphServiceClient) Ping(ctx context.Context, in *PingRequest, opts ...grpc.CallOption) (*PingResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PingResponse)
+	err := c.cc.Invoke(ctx, GraphService_Ping_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GraphServiceServer is the server API for GraphService service.
+// All implementations must embed UnimplementedGraphServiceServer
+// for forward compatibility.
+//
+// GraphService provides operations for managing graph-based knowledge representation.
+// All operations require authentication via JWT token in the "authorization" metadata.
+// Graph operations (every RPC except CreateUser, Ping and the workspace and invitation RPCs) can be scoped to a shared
+// workspace by sending its ID in the "workspace-id" metadata. Reads then need the viewer role, writes the editor role,
+// webhooks the admin role, and the user_id of the returned versions is the workspace ID.
+// Workspace scope errors:
+// (INVALID_ARGUMENT): If workspace-id is not a valid UUID
+// (PERMISSION_DENIED): If the user is not a member of the workspace or their role is too low
+type GraphServiceServer interface {
+	// CreateUser initializes a new user in the graph service. This endpoint is only accessible by the auth service.
+	// Errors:
+	// (INVALID_ARGUMENT): If user_id format is invalid
+	// (ALREADY_EXISTS): If user already exists
+	// (INTERNAL): For server-side errors
+	CreateUser(context.Context, *UserRequest) (*Empty, error)
+	// GetUserData retrieves all entities, connection types, and property types associated with the authenticated user.
+	// If as_of is set, the graph is returned as it was at that point in time.
+	// Errors:
+	// (UNAUTHENTICATED): If authentication is missing or invalid
+	// (INTERNAL): For server-side errors
+	GetUserData(context.Context, *UserDataRequest) (*UserData, error)
+	// CreateEntity creates a new entity or links to an existing one if ID is provided.
+	// Errors:
+	// (INVALID_ARGUMENT): If name or definition exceed length limits
+	// (NOT_FOUND): If entity ID is provided but entity doesn't exist
+	// (UNAUTHENTICATED): If authentication is missing or invalid
+	// (INTERNAL): For server-side errors
+	CreateEntity(context.Context, *EntityRequest) (*UsersEntity, error)
+	// UpdateEntity modifies an existing entity.
+	// Errors:
+	// (INVALID_ARGUMENT): If name or definition exceed length limits
+	// (NOT_FOUND): If entity doesn't exist
+	// (UNAUTHENTICATED): If authentication is missing or invalid
+	// (INTERNAL): For server-side errors
+	UpdateEntity(context.Context, *EntityRequest) (*Empty, error)
+	// FindEntities searches for entities by exact name match against any user's version.
+	// Each matching entity is returned once, in its canonical (highest scoring) version, with its score and user_count.
+	// If class_id is set, only instances of the class and its subclasses are returned.
+	// Errors:
+	// (INVALID_ARGUMENT): If name is empty or too long
+	// (UNAUTHENTICATED): If authentication is missing or invalid
+	// (INTERNAL): For server-side errors
+	FindEntities(context.Context, *SearchRequest) (*EntitiesList, error)
+	// CreateConnectionType creates a new connection type or links to an existing one.
+	// The semantics (inverse, symmetric, transitive, domain and range) can only be set when creating a new connection type.
+	// Errors:
+	// (INVALID_ARGUMENT): If name or definition exceed length limits, or the semantics conflict with the inverse
+	// (NOT_FOUND): If entity ID is provided but entity doesn't exist
+	// (UNAUTHENTICATED): If authentication is missing or invalid
+	// (INTERNAL): For server-side errors
+	CreateConnectionType(context.Context, *ConnectionTypeRequest) (*UsersConnectionType, error)
+	// CreateConnection connects two entities in the user's graph.
+	// Connections implied by the inverse or symmetry of a connection type become visible in GetConnections without being stored.
+	// Errors:
+	// (INVALID_ARGUMENT): If an ID is invalid, an entity is outside the domain or range of the connection type,
+	//                     or the from entity is not an instance of a class the connection type applies to
+	// (NOT_FOUND): If the connection type or an entity does not exist
+	// (ALREADY_EXISTS): If the connection is already stored or implied
+	// (UNAUTHENTICATED): If authentication is missing or invalid
+	// (INTERNAL): For server-side errors
+	CreateConnection(context.Context, *ConnectionRequest) (*Connection, error)
+	// DeleteConnection deletes a stored connection of the user's graph, with the connections it implied.
+	// Errors:
+	// (INVALID_ARGUMENT): If id is invalid
+	// (NOT_FOUND): If the user has no connection with the ID
+	// (UNAUTHENTICATED): If authentication is missing or invalid
+	// (INTERNAL): For server-side errors
+	DeleteConnection(context.Context, *IdRequest) (*Empty, error)
+	// GetConnections lists the connections going out of an entity in the user's graph,
+	// including the ones inferred from inverse, symmetric and transitive connection types.
+	// Errors:
+	// (INVALID_ARGUMENT): If an ID is invalid
+	// (UNAUTHENTICATED): If authentication is missing or invalid
+	// (INTERNAL): For server-side errors
+	GetConnections(context.Context, *TraversalRequest) (*ConnectionsList, error)
+	// FindConnectionTypes searches for connection types by exact name match against any user's version.
+	// Each matching connection type is returned once, in its canonical (highest scoring) version, with its score and user_count.
+	// Errors:
+	// (INVALID_ARGUMENT): If name is empty or too long
+	// (UNAUTHENTICATED): If authentication is missing or invalid
+	// (INTERNAL): For server-side errors
+	FindConnectionTypes(context.Context, *SearchRequest) (*ConnectionTypesList, error)
+	// CreatePropertyType creates a new property type or links to an existing one.
+	// Errors:
+	// (INVALID_ARGUMENT): If name or definition exceed length limits
+	// (NOT_FOUND): If entity ID is provided but entity doesn't exist
+	// (UNAUTHENTICATED): If authentication is missing or invalid
+	// (INTERNAL): For server-side errors
+	// Example request:
+	CreatePropertyType(context.Context, *PropertyTypeRequest) (*UsersPropertyType, error)
+	// FindPropertyTypes searches for property types by exact name match against any user's version.
+	// Each matching property type is returned once, in its canonical (highest scoring) version, with its score and user_count.
+	// Errors:
+	// (INVALID_ARGUMENT): If name is empty or too long
+	// (UNAUTHENTICATED): If authentication is missing or invalid
+	// (INTERNAL): For server-side errors
+	FindPropertyTypes(context.Context, *SearchRequest) (*PropertyTypesList, error)
+	// CreateEntityClass creates a new entity class or links to an existing one.
+	// Errors:
+	// (INVALID_ARGUMENT): If name or definition exceed length limits, or parent_ids are set with id
+	// (NOT_FOUND): If the entity class or a parent class does not exist
+	// (UNAUTHENTICATED): If authentication is missing or invalid
+	// (INTERNAL): For server-side errors
+	CreateEntityClass(context.Context, *EntityClassRequest) (*UsersEntityClass, error)
+	// FindEntityClasses searches for entity classes by exact name match against any user's version.
+	// Each matching entity class is returned once, in its canonical version.
+	// Errors:
+	// (INVALID_ARGUMENT): If name is empty or too long
+	// (UNAUTHENTICATED): If authentication is missing or invalid
+	// (INTERNAL): For server-side errors
+	FindEntityClasses(context.Context, *SearchRequest) (*EntityClassesList, error)
+	// SetClasses replaces the classes of a shared node. The user must have a version of the node.
+	// Errors:
+	// (INVALID_ARGUMENT): If a field is invalid, or a class would become its own ancestor
+	// (NOT_FOUND): If the node or a class does not exist
+	// (PERMISSION_DENIED): If the user has no version of the node
+	// (UNAUTHENTICATED): If authentication is missing or invalid
+	// (INTERNAL): For server-side errors
+	SetClasses(context.Context, *ClassesRequest) (*Empty, error)
+	// GetClasses lists the classes directly set on a shared node, in their canonical versions.
+	// Errors:
+	// (INVALID_ARGUMENT): If a field is invalid
+	// (UNAUTHENTICATED): If authentication is missing or invalid
+	// (INTERNAL): For server-side errors
+	GetClasses(context.Context, *NodeRequest) (*EntityClassesList, error)
+	// GetApplicableTypes lists the connection and property types that apply to instances of an entity class,
+	// including the ones that apply to its superclasses.
+	// Errors:
+	// (INVALID_ARGUMENT): If id is invalid
+	// (UNAUTHENTICATED): If authentication is missing or invalid
+	// (INTERNAL): For server-side errors
+	GetApplicableTypes(context.Context, *IdRequest) (*ApplicableTypes, error)
+	// Vote up- or downvotes another user's version of a shared entity, connection type or property type.
+	// Each user has one vote per version; voting again replaces it.
+	// Errors:
+	// (INVALID_ARGUMENT): If a field is invalid or the user votes on their own version
+	// (NOT_FOUND): If the version does not exist
+	// (UNAUTHENTICATED): If authentication is missing or invalid
+	// (INTERNAL): For server-side errors
+	Vote(context.Context, *VoteRequest) (*Empty, error)
+	// GetEntityVersions lists all users' versions of an entity, ordered by score. The first one is the canonical version.
+	// Errors:
+	// (INVALID_ARGUMENT): If id is invalid
+	// (NOT_FOUND): If the entity does not exist
+	// (UNAUTHENTICATED): If authentication is missing or invalid
+	// (INTERNAL): For server-side errors
+	GetEntityVersions(context.Context, *IdRequest) (*EntitiesList, error)
+	// GetConnectionTypeVersions lists all users' versions of a connection type, ordered by score. The first one is the canonical version.
+	// Errors:
+	// (INVALID_ARGUMENT): If id is invalid
+	// (NOT_FOUND): If the connection type does not exist
+	// (UNAUTHENTICATED): If authentication is missing or invalid
+	// (INTERNAL): For server-side errors
+	GetConnectionTypeVersions(context.Context, *IdRequest) (*ConnectionTypesList, error)
+	// GetPropertyTypeVersions lists all users' versions of a property type, ordered by score. The first one is the canonical version.
+	// Errors:
+	// (INVALID_ARGUMENT): If id is invalid
+	// (NOT_FOUND): If the property type does not exist
+	// (UNAUTHENTICATED): If authentication is missing or invalid
+	// (INTERNAL): For server-side errors
+	GetPropertyTypeVersions(context.Context, *IdRequest) (*PropertyTypesList, error)
+	// ProposeMerge proposes merging the source entity into the target entity.
+	// The user must have a version of one of the entities. Another user must accept the merge.
+	// Errors:
+	// (INVALID_ARGUMENT): If an ID is invalid or both IDs are equal
+	// (NOT_FOUND): If an entity does not exist
+	// (PERMISSION_DENIED): If the user has no version of either entity
+	// (UNAUTHENTICATED): If authentication is missing or invalid
+	// (INTERNAL): For server-side errors
+	ProposeMerge(context.Context, *MergeRequest) (*Merge, error)
+	// AcceptMerge merges the source entity of a proposed merge into its target entity.
+	// Versions move to the target entity, unless their owner already has a version of it, in which case they are dropped.
+	// Votes move with their versions. The accepting user must have a version of one of the entities and not be the proposer.
+	// Errors:
+	// (INVALID_ARGUMENT): If id is invalid or the user proposed the merge
+	// (NOT_FOUND): If no proposed merge with the ID exists
+	// (PERMISSION_DENIED): If the user has no version of either entity
+	// (UNAUTHENTICATED): If authentication is missing or invalid
+	// (INTERNAL): For server-side errors
+	AcceptMerge(context.Context, *MergeIdRequest) (*Merge, error)
+	// SplitEntity reverts an accepted merge (merge_id), or splits the user's version off an entity into a new entity (entity_id).
+	// Reverting requires a version of the merge's target entity. Both are recorded as merges.
+	// Errors:
+	// (INVALID_ARGUMENT): If not exactly one ID is set, or the user's version is the only version of the entity
+	// (NOT_FOUND): If no accepted merge or user's version with the ID exists
+	// (PERMISSION_DENIED): If the user has no version of the merge's target entity
+	// (UNAUTHENTICATED): If authentication is missing or invalid
+	// (INTERNAL): For server-side errors
+	SplitEntity(context.Context, *SplitRequest) (*Merge, error)
+	// ListMerges lists the merges and splits an entity was part of, newest first.
+	// Errors:
+	// (INVALID_ARGUMENT): If id is invalid
+	// (UNAUTHENTICATED): If authentication is missing or invalid
+	// (INTERNAL): For server-side errors
+	ListMerges(context.Context, *IdRequest) (*MergesList, error)
+	// ListRevisions lists the history of a user's version of a shared node, newest first.
+	// Errors:
+	// (INVALID_ARGUMENT): If a field is invalid
+	// (UNAUTHENTICATED): If authentication is missing or invalid
+	// (INTERNAL): For server-side errors
+	ListRevisions(context.Context, *RevisionsRequest) (*RevisionsList, error)
+	// GetRevision gets a revision by ID, if it is one of the user's own or of their workspaces.
+	// Errors:
+	// (INVALID_ARGUMENT): If id is invalid
+	// (NOT_FOUND): If the revision does not exist or belongs to another owner
+	// (UNAUTHENTICATED): If authentication is missing or invalid
+	// (INTERNAL): For server-side errors
+	GetRevision(context.Context, *IdRequest) (*Revision, error)
+	// RevertToRevision sets the authenticated user's version back to the name and definition of one of its revisions.
+	// A version a merge or split moved off its node is restored. The revert is recorded as a new revision, which is returned.
+	// Errors:
+	// (INVALID_ARGUMENT): If id is invalid, or the revision removed the version
+	// (NOT_FOUND): If the revision is not one of the user's, or its node no longer exists
+	// (UNAUTHENTICATED): If authentication is missing or invalid
+	// (INTERNAL): For server-side errors
+	RevertToRevision(context.Context, *IdRequest) (*Revision, error)
+	// CreateWorkspace creates a shared workspace with the authenticated user as its admin.
+	// Errors:
+	// (INVALID_ARGUMENT): If name is empty or too long
+	// (UNAUTHENTICATED): If authentication is missing or invalid
+	// (INTERNAL): For server-side errors
+	CreateWorkspace(context.Context, *WorkspaceRequest) (*Workspace, error)
+	// ListWorkspaces lists the workspaces the authenticated user is a member of.
+	// Errors:
+	// (UNAUTHENTICATED): If authentication is missing or invalid
+	// (INTERNAL): For server-side errors
+	ListWorkspaces(context.Context, *Empty) (*WorkspacesList, error)
+	// ListMembers lists the members of a workspace. Requires the viewer role.
+	// Errors:
+	// (INVALID_ARGUMENT): If workspace_id is invalid
+	// (PERMISSION_DENIED): If the user is not a member of the workspace
+	// (UNAUTHENTICATED): If authentication is missing or invalid
+	// (INTERNAL): For server-side errors
+	ListMembers(context.Context, *WorkspaceIdRequest) (*MembersList, error)
+	// InviteMember invites a user to a workspace. Requires the admin role.
+	// Errors:
+	// (INVALID_ARGUMENT): If an ID or the role is invalid
+	// (NOT_FOUND): If the invited user does not exist
+	// (ALREADY_EXISTS): If the user is already a member or already invited
+	// (PERMISSION_DENIED): If the user is not an admin of the workspace
+	// (UNAUTHENTICATED): If authentication is missing or invalid
+	// (INTERNAL): For server-side errors
+	InviteMember(context.Context, *MemberRequest) (*Invitation, error)
+	// ListInvitations lists the pending invitations of the authenticated user.
+	// Errors:
+	// (UNAUTHENTICATED): If authentication is missing or invalid
+	// (INTERNAL): For server-side errors
+	ListInvitations(context.Context, *Empty) (*InvitationsList, error)
+	// AcceptInvitation joins the workspace of an invitation addressed to the authenticated user.
+	// Errors:
+	// (INVALID_ARGUMENT): If id is invalid
+	// (NOT_FOUND): If the invitation does not exist or is addressed to another user
+	// (UNAUTHENTICATED): If authentication is missing or invalid
+	// (INTERNAL): For server-side errors
+	AcceptInvitation(context.Context, *InvitationIdRequest) (*Workspace, error)
+	// DeclineInvitation deletes an invitation addressed to the authenticated user.
+	// Errors:
+	// (INVALID_ARGUMENT): If id is invalid
+	// (NOT_FOUND): If the invitation does not exist or is addressed to another user
+	// (UNAUTHENTICATED): If authentication is missing or invalid
+	// (INTERNAL): For server-side errors
+	DeclineInvitation(context.Context, *InvitationIdRequest) (*Empty, error)
+	// UpdateMemberRole changes the role of a workspace member. Requires the admin role.
+	// Errors:
+	// (INVALID_ARGUMENT): If an ID or the role is invalid, or the last admin would be demoted
+	// (NOT_FOUND): If the user is not a member
+	// (PERMISSION_DENIED): If the user is not an admin of the workspace
+	// (UNAUTHENTICATED): If authentication is missing or invalid
+	// (INTERNAL): For server-side errors
+	UpdateMemberRole(context.Context, *MemberRequest) (*Empty, error)
+	// RemoveMember removes a member from a workspace. Requires the admin role, unless users remove themselves.
+	// Errors:
+	// (INVALID_ARGUMENT): If an ID is invalid, or the last admin would be removed
+	// (NOT_FOUND): If the user is not a member
+	// (PERMISSION_DENIED): If the user is not allowed to remove the member
+	// (UNAUTHENTICATED): If authentication is missing or invalid
+	// (INTERNAL): For server-side errors
+	RemoveMember(context.Context, *MemberRequest) (*Empty, error)
+	// WatchGraph streams the changes of the user's versions and connections as they happen.
+	// A heartbeat with the current resume_token is sent when the stream starts and periodically after.
+	// A client that reconnects with the last resume_token it received does not miss any changes.
+	// Errors:
+	// (INVALID_ARGUMENT): If the resume_token or a kind is invalid
+	// (FAILED_PRECONDITION): If the resume_token has expired. Reload with GetUserData and watch without it
+	// (UNAUTHENTICATED): If authentication is missing or invalid
+	// (INTERNAL): For server-side errors
+	WatchGraph(*WatchRequest, grpc.ServerStreamingServer[GraphEvent]) error
+	// CreateWebhook subscribes a URL to the changes of the user's versions and connections, from now on.
+	// The returned secret is needed to verify the payload signatures and is not shown again.
+	// Managing the webhooks of a workspace needs the admin role.
+	// Errors:
+	// (INVALID_ARGUMENT): If the url or a kind is invalid, or the user has too many webhooks
+	// (UNAUTHENTICATED): If authentication is missing or invalid
+	// (INTERNAL): For server-side errors
+	CreateWebhook(context.Context, *WebhookRequest) (*Webhook, error)
+	// ListWebhooks lists the user's webhooks, without their secrets.
+	// Errors:
+	// (UNAUTHENTICATED): If authentication is missing or invalid
+	// (INTERNAL): For server-side errors
+	ListWebhooks(context.Context, *Empty) (*WebhooksList, error)
+	// DeleteWebhook deletes a webhook with its delivery log and dead-letter queue.
+	// Errors:
+	// (INVALID_ARGUMENT): If webhook_id is invalid
+	// (NOT_FOUND): If the user has no such webhook
+	// (UNAUTHENTICATED): If authentication is missing or invalid
+	// (INTERNAL): For server-side errors
+	DeleteWebhook(context.Context, *WebhookIdRequest) (*Empty, error)
+	// ListWebhookDeliveries lists the delivery attempts of a webhook, newest first.
+	// Errors:
+	// (INVALID_ARGUMENT): If webhook_id or limit is invalid
+	// (NOT_FOUND): If the user has no such webhook
+	// (UNAUTHENTICATED): If authentication is missing or invalid
+	// (INTERNAL): For server-side errors
+	ListWebhookDeliveries(context.Context, *WebhookDeliveriesRequest) (*WebhookDeliveriesList, error)
+	// Ping checks if the service is running.
+	Ping(context.Context, *PingRequest) (*PingResponse, error)
+	mustEmbedUnimplementedGraphServiceServer()
+}
+
+// UnimplementedGraphServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedGraphServiceServer struct{}
+
+func (UnimplementedGraphServiceServer) CreateUser(context.Context, *UserRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateUser not implemented")
+}
+func (UnimplementedGraphServiceServer) GetUserData(context.Context, *UserDataRequest) (*UserData, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUserData not implemented")
+}
+func (UnimplementedGraphServiceServer) CreateEntity(context.Context, *EntityRequest) (*UsersEntity, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateEntity not implemented")
+}
+func (UnimplementedGraphServiceServer) UpdateEntity(context.Context, *EntityRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateEntity not implemented")
+}
+func (UnimplementedGraphServiceServer) FindEntities(context.Context, *SearchRequest) (*EntitiesList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FindEntities not implemented")
+}
+func (UnimplementedGraphServiceServer) CreateConnectionType(context.Context, *ConnectionTypeRequest) (*UsersConnectionType, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateConnectionType not implemented")
+}
+func (UnimplementedGraphServiceServer) CreateConnection(context.Context, *ConnectionRequest) (*Connection, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateConnection not implemented")
+}
+func (UnimplementedGraphServiceServer) DeleteConnection(context.Context, *IdRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteConnection not implemented")
+}
+func (UnimplementedGraphServiceServer) GetConnections(context.Context, *TraversalRequest) (*ConnectionsList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetConnections not implemented")
+}
+func (UnimplementedGraphServiceServer) FindConnectionTypes(context.Context, *SearchRequest) (*ConnectionTypesList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FindConnectionTypes not implemented")
+}
+func (UnimplementedGraphServiceServer) CreatePropertyType(context.Context, *PropertyTypeRequest) (*UsersPropertyType, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreatePropertyType not implemented")
+}
+func (UnimplementedGraphServiceServer) FindPropertyTypes(context.Context, *SearchRequest) (*PropertyTypesList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FindPropertyTypes not implemented")
+}
+func (UnimplementedGraphServiceServer) CreateEntityClass(context.Context, *EntityClassRequest) (*UsersEntityClass, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateEntityClass not implemented")
+}
+func (UnimplementedGraphServiceServer) FindEntityClasses(context.Context, *SearchRequest) (*EntityClassesList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FindEntityClasses not implemented")
+}
+func (UnimplementedGraphServiceServer) SetClasses(context.Context, *ClassesRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetClasses not implemented")
+}
+func (UnimplementedGraphServiceServer) GetClasses(context.Context, *NodeRequest) (*EntityClassesList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetClasses not implemented")
+}
+func (UnimplementedGraphServiceServer) GetApplicableTypes(context.Context, *IdRequest) (*ApplicableTypes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetApplicableTypes not implemented")
+}
+func (UnimplementedGraphServiceServer) Vote(context.Context, *VoteRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Vote not implemented")
+}
+func (UnimplementedGraphServiceServer) GetEntityVersions(context.Context, *IdRequest) (*EntitiesList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetEntityVersions not implemented")
+}
+func (UnimplementedGraphServiceServer) GetConnectionTypeVersions(context.Context, *IdRequest) (*ConnectionTypesList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetConnectionTypeVersions not implemented")
+}
+func (UnimplementedGraphServiceServer) GetPropertyTypeVersions(context.Context, *IdRequest) (*PropertyTypesList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPropertyTypeVersions not implemented")
+}
+func (UnimplementedGraphServiceServer) ProposeMerge(context.Context, *MergeRequest) (*Merge, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ProposeMerge not implemented")
+}
+func (UnimplementedGraphServiceServer) AcceptMerge(context.Context, *MergeIdRequest) (*Merge, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AcceptMerge not implemented")
+}
+func (UnimplementedGraphServiceServer) SplitEntity(context.Context, *SplitRequest) (*Merge, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SplitEntity not implemented")
+}
+func (UnimplementedGraphServiceServer) ListMerges(context.Context, *IdRequest) (*MergesList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListMerges not implemented")
+}
+func (UnimplementedGraphServiceServer) ListRevisions(context.Context, *RevisionsRequest) (*RevisionsList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRevisions not implemented")
+}
+func (UnimplementedGraphServiceServer) GetRevision(context.Context, *IdRequest) (*Revision, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRevision not implemented")
+}
+func (UnimplementedGraphServiceServer) RevertToRevision(context.Context, *IdRequest) (*Revision, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevertToRevision not implemented")
+}
+func (UnimplementedGraphServiceServer) CreateWorkspace(context.Context, *WorkspaceRequest) (*Workspace, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateWorkspace not implemented")
+}
+func (UnimplementedGraphServiceServer) ListWorkspaces(context.Context, *Empty) (*WorkspacesList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListWorkspaces not implemented")
+}
+func (UnimplementedGraphServiceServer) ListMembers(context.Context, *WorkspaceIdRequest) (*MembersList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListMembers not implemented")
+}
+func (UnimplementedGraphServiceServer) InviteMember(context.Context, *MemberRequest) (*Invitation, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method InviteMember not implemented")
+}
+func (UnimplementedGraphServiceServer) ListInvitations(context.Context, *Empty) (*InvitationsList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListInvitations not implemented")
+}
+func (UnimplementedGraphServiceServer) AcceptInvitation(context.Context, *InvitationIdRequest) (*Workspace, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AcceptInvitation not implemented")
+}
+func (UnimplementedGraphServiceServer) DeclineInvitation(context.Context, *InvitationIdRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeclineInvitation not implemented")
+}
+func (UnimplementedGraphServiceServer) UpdateMemberRole(context.Context, *MemberRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateMemberRole not implemented")
+}
+func (UnimplementedGraphServiceServer) RemoveMember(context.Context, *MemberRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveMember not implemented")
+}
+func (UnimplementedGraphServiceServer) WatchGraph(*WatchRequest, grpc.ServerStreamingServer[GraphEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchGraph not implemented")
+}
+func (UnimplementedGraphServiceServer) CreateWebhook(context.Context, *WebhookRequest) (*Webhook, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateWebhook not implemented")
+}
+func (UnimplementedGraphServiceServer) ListWebhooks(context.Context, *Empty) (*WebhooksList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListWebhooks not implemented")
+}
+func (UnimplementedGraphServiceServer) DeleteWebhook(context.Context, *WebhookIdRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteWebhook not implemented")
+}
+func (UnimplementedGraphServiceServer) ListWebhookDeliveries(context.Context, *WebhookDeliveriesRequest) (*WebhookDeliveriesList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListWebhookDeliveries not implemented")
+}
+func (UnimplementedGraphServiceServer) Ping(context.Context, *PingRequest) (*PingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Ping not implemented")
+}
+func (UnimplementedGraphServiceServer) mustEmbedUnimplementedGraphServiceServer() {}
+func (UnimplementedGraphServiceServer) testEmbeddedByValue()                      {}
+
+// UnsafeGraphServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to GraphServiceServer will
+// result in compilation errors.
+type UnsafeGraphServiceServer interface {
+	mustEmbedUnimplementedGraphServiceServer()
+}
+
+func RegisterGraphServiceServer(s grpc.ServiceRegistrar, srv GraphServiceServer) {
+	// If the following call pancis, it indicates UnimplementedGraphServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&GraphService_ServiceDesc, srv)
+}
+
+func _GraphService_CreateUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GraphServiceServer).CreateUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GraphService_CreateUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GraphServiceServer).CreateUser(ctx, req.(*UserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GraphService_GetUserData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserDataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GraphServiceServer).GetUserData(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GraphService_GetUserData_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GraphServiceServer).GetUserData(ctx, req.(*UserDataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GraphService_CreateEntity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EntityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GraphServiceServer).CreateEntity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GraphService_CreateEntity_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GraphServiceServer).CreateEntity(ctx, req.(*EntityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GraphService_UpdateEntity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EntityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GraphServiceServer).UpdateEntity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GraphService_UpdateEntity_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GraphServiceServer).UpdateEntity(ctx, req.(*EntityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GraphService_FindEntities_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GraphServiceServer).FindEntities(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GraphService_FindEntities_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GraphServiceServer).FindEntities(ctx, req.(*SearchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GraphService_CreateConnectionType_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConnectionTypeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GraphServiceServer).CreateConnectionType(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GraphService_CreateConnectionType_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GraphServiceServer).CreateConnectionType(ctx, req.(*ConnectionTypeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GraphService_CreateConnection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConnectionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GraphServiceServer).CreateConnection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GraphService_CreateConnection_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GraphServiceServer).CreateConnection(ctx, req.(*ConnectionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GraphService_DeleteConnection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GraphServiceServer).DeleteConnection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GraphService_DeleteConnection_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GraphServiceServer).DeleteConnection(ctx, req.(*IdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GraphService_GetConnections_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TraversalRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GraphServiceServer).GetConnections(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GraphService_GetConnections_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GraphServiceServer).GetConnections(ctx, req.(*TraversalRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GraphService_FindConnectionTypes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GraphServiceServer).FindConnectionTypes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GraphService_FindConnectionTypes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GraphServiceServer).FindConnectionTypes(ctx, req.(*SearchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GraphService_CreatePropertyType_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PropertyTypeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GraphServiceServer).CreatePropertyType(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GraphService_CreatePropertyType_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GraphServiceServer).CreatePropertyType(ctx, req.(*PropertyTypeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GraphService_FindPropertyTypes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GraphServiceServer).FindPropertyTypes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GraphService_FindPropertyTypes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GraphServiceServer).FindPropertyTypes(ctx, req.(*SearchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GraphService_CreateEntityClass_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EntityClassRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GraphServiceServer).CreateEntityClass(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GraphService_CreateEntityClass_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GraphServiceServer).CreateEntityClass(ctx, req.(*EntityClassRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GraphService_FindEntityClasses_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GraphServiceServer).FindEntityClasses(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GraphService_FindEntityClasses_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GraphServiceServer).FindEntityClasses(ctx, req.(*SearchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GraphService_SetClasses_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClassesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GraphServiceServer).SetClasses(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GraphService_SetClasses_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GraphServiceServer).SetClasses(ctx, req.(*ClassesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GraphService_GetClasses_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GraphServiceServer).GetClasses(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GraphService_GetClasses_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GraphServiceServer).GetClasses(ctx, req.(*NodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GraphService_GetApplicableTypes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GraphServiceServer).GetApplicableTypes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GraphService_GetApplicableTypes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GraphServiceServer).GetApplicableTypes(ctx, req.(*IdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GraphService_Vote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GraphServiceServer).Vote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GraphService_Vote_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GraphServiceServer).Vote(ctx, req.(*VoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GraphService_GetEntityVersions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GraphServiceServer).GetEntityVersions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GraphService_GetEntityVersions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GraphServiceServer).GetEntityVersions(ctx, req.(*IdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GraphService_GetConnectionTypeVersions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GraphServiceServer).GetConnectionTypeVersions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GraphService_GetConnectionTypeVersions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GraphServiceServer).GetConnectionTypeVersions(ctx, req.(*IdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GraphService_GetPropertyTypeVersions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GraphServiceServer).GetPropertyTypeVersions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GraphService_GetPropertyTypeVersions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GraphServiceServer).GetPropertyTypeVersions(ctx, req.(*IdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GraphService_ProposeMerge_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MergeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GraphServiceServer).ProposeMerge(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GraphService_ProposeMerge_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GraphServiceServer).ProposeMerge(ctx, req.(*MergeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GraphService_AcceptMerge_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MergeIdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GraphServiceServer).AcceptMerge(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GraphService_AcceptMerge_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GraphServiceServer).AcceptMerge(ctx, req.(*MergeIdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedGraphServiceServer) FindEntities(context.Context, *SearchRequest) (*EntitiesList, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method FindEntities not implemented")
+
+func _GraphService_SplitEntity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SplitRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GraphServiceServer).SplitEntity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GraphService_SplitEntity_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GraphServiceServer).SplitEntity(ctx, req.(*SplitRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedGraphServiceServer) CreateConnectionType(context.Context, *ConnectionTypeRequest) (*UsersConnectionType, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CreateConnectionType not implemented")
+
+func _GraphService_ListMerges_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GraphServiceServer).ListMerges(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GraphService_ListMerges_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GraphServiceServer).ListMerges(ctx, req.(*IdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedGraphServiceServer) FindConnectionTypes(context.Context, *SearchRequest) (*ConnectionTypesList, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method FindConnectionTypes not implemented")
+
+func _GraphService_ListRevisions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevisionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GraphServiceServer).ListRevisions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GraphService_ListRevisions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GraphServiceServer).ListRevisions(ctx, req.(*RevisionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedGraphServiceServer) CreatePropertyType(context.Context, *PropertyTypeRequest) (*UsersPropertyType, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CreatePropertyType not implemented")
+
+func _GraphService_GetRevision_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GraphServiceServer).GetRevision(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GraphService_GetRevision_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GraphServiceServer).GetRevision(ctx, req.(*IdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedGraphServiceServer) FindPropertyTypes(context.Context, *SearchRequest) (*PropertyTypesList, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method FindPropertyTypes not implemented")
+
+func _GraphService_RevertToRevision_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GraphServiceServer).RevertToRevision(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GraphService_RevertToRevision_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GraphServiceServer).RevertToRevision(ctx, req.(*IdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedGraphServiceServer) Ping(context.Context, *PingRequest) (*PingResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Ping not implemented")
+
+func _GraphService_CreateWorkspace_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WorkspaceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GraphServiceServer).CreateWorkspace(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GraphService_CreateWorkspace_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GraphServiceServer).CreateWorkspace(ctx, req.(*WorkspaceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedGraphServiceServer) mustEmbedUnimplementedGraphServiceServer() {}
-func (UnimplementedGraphServiceServer) testEmbeddedByValue()                      {}
 
-// UnsafeGraphServiceServer may be embedded to opt out of forward compatibility for this service.
-// Use of this interface is not recommended, as added methods to GraphServiceServer will
-// result in compilation errors.
-type UnsafeGraphServiceServer interface {
-	mustEmbedUnimplementedGraphServiceServer()
+func _GraphService_ListWorkspaces_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GraphServiceServer).ListWorkspaces(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GraphService_ListWorkspaces_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GraphServiceServer).ListWorkspaces(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func RegisterGraphServiceServer(s grpc.ServiceRegistrar, srv GraphServiceServer) {
-	// If the following call pancis, it indicates UnimplementedGraphServiceServer was
-	// embedded by pointer and is nil.  This will cause panics if an
-	// unimplemented method is ever invoked, so we test this at initialization
-	// time to prevent it from happening at runtime later due to I/O.
-	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
-		t.testEmbeddedByValue()
+func _GraphService_ListMembers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WorkspaceIdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	s.RegisterService(&GraphService_ServiceDesc, srv)
+	if interceptor == nil {
+		return srv.(GraphServiceServer).ListMembers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GraphService_ListMembers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GraphServiceServer).ListMembers(ctx, req.(*WorkspaceIdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _GraphService_CreateUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UserRequest)
+func _GraphService_InviteMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MemberRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(GraphServiceServer).CreateUser(ctx, in)
+		return srv.(GraphServiceServer).InviteMember(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: GraphService_CreateUser_FullMethodName,
+		FullMethod: GraphService_InviteMember_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(GraphServiceServer).CreateUser(ctx, req.(*UserRequest))
+		return srv.(GraphServiceServer).InviteMember(ctx, req.(*MemberRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _GraphService_GetUserData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _GraphService_ListInvitations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(GraphServiceServer).GetUserData(ctx, in)
+		return srv.(GraphServiceServer).ListInvitations(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: GraphService_GetUserData_FullMethodName,
+		FullMethod: GraphService_ListInvitations_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(GraphServiceServer).GetUserData(ctx, req.(*Empty))
+		return srv.(GraphServiceServer).ListInvitations(ctx, req.(*Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _GraphService_CreateEntity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(EntityRequest)
+func _GraphService_AcceptInvitation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InvitationIdRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(GraphServiceServer).CreateEntity(ctx, in)
+		return srv.(GraphServiceServer).AcceptInvitation(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: GraphService_CreateEntity_FullMethodName,
+		FullMethod: GraphService_AcceptInvitation_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(GraphServiceServer).CreateEntity(ctx, req.(*EntityRequest))
+		return srv.(GraphServiceServer).AcceptInvitation(ctx, req.(*InvitationIdRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _GraphService_UpdateEntity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(EntityRequest)
+func _GraphService_DeclineInvitation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InvitationIdRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(GraphServiceServer).UpdateEntity(ctx, in)
+		return srv.(GraphServiceServer).DeclineInvitation(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: GraphService_UpdateEntity_FullMethodName,
+		FullMethod: GraphService_DeclineInvitation_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(GraphServiceServer).UpdateEntity(ctx, req.(*EntityRequest))
+		return srv.(GraphServiceServer).DeclineInvitation(ctx, req.(*InvitationIdRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _GraphService_FindEntities_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(SearchRequest)
+func _GraphService_UpdateMemberRole_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MemberRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(GraphServiceServer).FindEntities(ctx, in)
+		return srv.(GraphServiceServer).UpdateMemberRole(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: GraphService_FindEntities_FullMethodName,
+		FullMethod: GraphService_UpdateMemberRole_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(GraphServiceServer).FindEntities(ctx, req.(*SearchRequest))
+		return srv.(GraphServiceServer).UpdateMemberRole(ctx, req.(*MemberRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _GraphService_CreateConnectionType_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ConnectionTypeRequest)
+func _GraphService_RemoveMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MemberRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(GraphServiceServer).CreateConnectionType(ctx, in)
+		return srv.(GraphServiceServer).RemoveMember(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: GraphService_CreateConnectionType_FullMethodName,
+		FullMethod: GraphService_RemoveMember_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(GraphServiceServer).CreateConnectionType(ctx, req.(*ConnectionTypeRequest))
+		return srv.(GraphServiceServer).RemoveMember(ctx, req.(*MemberRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _GraphService_FindConnectionTypes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(SearchRequest)
+func _GraphService_WatchGraph_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(GraphServiceServer).WatchGraph(m, &grpc.GenericServerStream[WatchRequest, GraphEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type GraphService_WatchGraphServer = grpc.ServerStreamingServer[GraphEvent]
+
+func _GraphService_CreateWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WebhookRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(GraphServiceServer).FindConnectionTypes(ctx, in)
+		return srv.(GraphServiceServer).CreateWebhook(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: GraphService_FindConnectionTypes_FullMethodName,
+		FullMethod: GraphService_CreateWebhook_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(GraphServiceServer).FindConnectionTypes(ctx, req.(*SearchRequest))
+		return srv.(GraphServiceServer).CreateWebhook(ctx, req.(*WebhookRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _GraphService_CreatePropertyType_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(PropertyTypeRequest)
+func _GraphService_ListWebhooks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(GraphServiceServer).CreatePropertyType(ctx, in)
+		return srv.(GraphServiceServer).ListWebhooks(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: GraphService_CreatePropertyType_FullMethodName,
+		FullMethod: GraphService_ListWebhooks_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(GraphServiceServer).CreatePropertyType(ctx, req.(*PropertyTypeRequest))
+		return srv.(GraphServiceServer).ListWebhooks(ctx, req.(*Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _GraphService_FindPropertyTypes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(SearchRequest)
+func _GraphService_DeleteWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WebhookIdRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(GraphServiceServer).FindPropertyTypes(ctx, in)
+		return srv.(GraphServiceServer).DeleteWebhook(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: GraphService_FindPropertyTypes_FullMethodName,
+		FullMethod: GraphService_DeleteWebhook_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(GraphServiceServer).FindPropertyTypes(ctx, req.(*SearchRequest))
+		return srv.(GraphServiceServer).DeleteWebhook(ctx, req.(*WebhookIdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GraphService_ListWebhookDeliveries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WebhookDeliveriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GraphServiceServer).ListWebhookDeliveries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GraphService_ListWebhookDeliveries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GraphServiceServer).ListWebhookDeliveries(ctx, req.(*WebhookDeliveriesRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -549,6 +2113,18 @@ var GraphService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CreateConnectionType",
 			Handler:    _GraphService_CreateConnectionType_Handler,
 		},
+		{
+			MethodName: "CreateConnection",
+			Handler:    _GraphService_CreateConnection_Handler,
+		},
+		{
+			MethodName: "DeleteConnection",
+			Handler:    _GraphService_DeleteConnection_Handler,
+		},
+		{
+			MethodName: "GetConnections",
+			Handler:    _GraphService_GetConnections_Handler,
+		},
 		{
 			MethodName: "FindConnectionTypes",
 			Handler:    _GraphService_FindConnectionTypes_Handler,
@@ -561,11 +2137,133 @@ var GraphService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "FindPropertyTypes",
 			Handler:    _GraphService_FindPropertyTypes_Handler,
 		},
+		{
+			MethodName: "CreateEntityClass",
+			Handler:    _GraphService_CreateEntityClass_Handler,
+		},
+		{
+			MethodName: "FindEntityClasses",
+			Handler:    _GraphService_FindEntityClasses_Handler,
+		},
+		{
+			MethodName: "SetClasses",
+			Handler:    _GraphService_SetClasses_Handler,
+		},
+		{
+			MethodName: "GetClasses",
+			Handler:    _GraphService_GetClasses_Handler,
+		},
+		{
+			MethodName: "GetApplicableTypes",
+			Handler:    _GraphService_GetApplicableTypes_Handler,
+		},
+		{
+			MethodName: "Vote",
+			Handler:    _GraphService_Vote_Handler,
+		},
+		{
+			MethodName: "GetEntityVersions",
+			Handler:    _GraphService_GetEntityVersions_Handler,
+		},
+		{
+			MethodName: "GetConnectionTypeVersions",
+			Handler:    _GraphService_GetConnectionTypeVersions_Handler,
+		},
+		{
+			MethodName: "GetPropertyTypeVersions",
+			Handler:    _GraphService_GetPropertyTypeVersions_Handler,
+		},
+		{
+			MethodName: "ProposeMerge",
+			Handler:    _GraphService_ProposeMerge_Handler,
+		},
+		{
+			MethodName: "AcceptMerge",
+			Handler:    _GraphService_AcceptMerge_Handler,
+		},
+		{
+			MethodName: "SplitEntity",
+			Handler:    _GraphService_SplitEntity_Handler,
+		},
+		{
+			MethodName: "ListMerges",
+			Handler:    _GraphService_ListMerges_Handler,
+		},
+		{
+			MethodName: "ListRevisions",
+			Handler:    _GraphService_ListRevisions_Handler,
+		},
+		{
+			MethodName: "GetRevision",
+			Handler:    _GraphService_GetRevision_Handler,
+		},
+		{
+			MethodName: "RevertToRevision",
+			Handler:    _GraphService_RevertToRevision_Handler,
+		},
+		{
+			MethodName: "CreateWorkspace",
+			Handler:    _GraphService_CreateWorkspace_Handler,
+		},
+		{
+			MethodName: "ListWorkspaces",
+			Handler:    _GraphService_ListWorkspaces_Handler,
+		},
+		{
+			MethodName: "ListMembers",
+			Handler:    _GraphService_ListMembers_Handler,
+		},
+		{
+			MethodName: "InviteMember",
+			Handler:    _GraphService_InviteMember_Handler,
+		},
+		{
+			MethodName: "ListInvitations",
+			Handler:    _GraphService_ListInvitations_Handler,
+		},
+		{
+			MethodName: "AcceptInvitation",
+			Handler:    _GraphService_AcceptInvitation_Handler,
+		},
+		{
+			MethodName: "DeclineInvitation",
+			Handler:    _GraphService_DeclineInvitation_Handler,
+		},
+		{
+			MethodName: "UpdateMemberRole",
+			Handler:    _GraphService_UpdateMemberRole_Handler,
+		},
+		{
+			MethodName: "RemoveMember",
+			Handler:    _GraphService_RemoveMember_Handler,
+		},
+		{
+			MethodName: "CreateWebhook",
+			Handler:    _GraphService_CreateWebhook_Handler,
+		},
+		{
+			MethodName: "ListWebhooks",
+			Handler:    _GraphService_ListWebhooks_Handler,
+		},
+		{
+			MethodName: "DeleteWebhook",
+			Handler:    _GraphService_DeleteWebhook_Handler,
+		},
+		{
+			MethodName: "ListWebhookDeliveries",
+			Handler:    _GraphService_ListWebhookDeliveries_Handler,
+		},
 		{
 			MethodName: "Ping",
 			Handler:    _GraphService_Ping_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchGraph",
+			Handler:       _GraphService_WatchGraph_Handler,
+			ServerStreams: true,
+		},
+	},
 	Metadata: "api/proto/graph/graph.proto",
 }
@@ -3,6 +3,7 @@ package main
 
 import (
 	"context"
+	"flag"
 	"log" // Using log before logger is initialized
 	"os"
 	"os/signal"
@@ -16,9 +17,12 @@ import (
 
 	"github.com/go-playground/validator/v10"
 
+	ad "github.com/BwezB/Wikno-backend/pkg/admin"
+	au "github.com/BwezB/Wikno-backend/pkg/audit"
 	g "github.com/BwezB/Wikno-backend/pkg/graph"
 	h "github.com/BwezB/Wikno-backend/pkg/health"
 	l "github.com/BwezB/Wikno-backend/pkg/log"
+	mg "github.com/BwezB/Wikno-backend/pkg/migrate"
 	m "github.com/BwezB/Wikno-backend/pkg/metrics"
 )
 
@@ -44,19 +48,16 @@ func main() {
 		l.Fatal("Could not connect to database:", l.ErrField(err))
 	}
 	healthService.AddCheck(database) // Health check the database
-	// Drop tables if needed
-	if config.Database.DropTables {
-		if config.Environment == "production" {
-			l.Error("Cannot drop tables in production!")
-		} else {
-			if err := database.DropTables(); err != nil {
-				l.Fatal("Could not drop tables:", l.ErrField(err))
-			}
-		}
+	// "authservice [flags] <command>" runs an admin command instead of the service
+	if args := flag.Args(); len(args) > 0 {
+		runCommand(config.Environment, config.Database, database, validator, args)
+		return
 	}
-	// Auto migrate the database
-	if err := database.AutoMigrate(); err != nil {
-		l.Fatal("Could not migrate database:", l.ErrField(err))
+	// Apply pending migrations
+	if config.Database.MigrateOnStartup {
+		if err := database.Migrate(context.Background()); err != nil {
+			l.Fatal("Could not migrate database:", l.ErrField(err))
+		}
 	}
 
 	// Create the graph service
@@ -65,8 +66,12 @@ func main() {
 		l.Fatal("Could not create graph service:", l.ErrField(err))
 	}
 
+	// Create the audit log
+	auditor := au.New(database.DB, "authservice")
+	auditServer := au.NewServer(auditor, validator, config.Audit)
+
 	// Create the service
-	authService, err := service.NewAuthService(database, graphService, config.Service)
+	authService, err := service.NewAuthService(database, graphService, auditor, config.Service)
 	if err != nil {
 		l.Fatal("Could not create service:", l.ErrField(err))
 	}
@@ -75,7 +80,7 @@ func main() {
 	metrics := m.NewMetrics("authservice")
 
 	// Create the server
-	server, err := api.NewServer(authService, healthService, metrics, validator, config.Server)
+	server, err := api.NewServer(authService, healthService, metrics, auditServer, validator, config.Server)
 	if err != nil {
 		l.Fatal("Could not create server:", l.ErrField(err))
 	}
@@ -100,6 +105,59 @@ func main() {
 	}
 }
 
+// runCommand runs an admin command: migrate, reset or seed
+func runCommand(environment string, dbConfig db.DatabaseConfig, database *db.Database, validator *validator.Validate, args []string) {
+	ctx := context.Background()
+	switch args[0] {
+	case "migrate":
+		migrator, err := database.SchemaMigrator()
+		if err != nil {
+			l.Fatal("Could not load migrations:", l.ErrField(err))
+		}
+		if err := mg.Run(ctx, migrator, args[1:], os.Stdout); err != nil {
+			l.Fatal("Could not migrate database:", l.ErrField(err))
+		}
+	case "reset":
+		// Drops all tables and migrates the empty database
+		options, err := ad.ParseOptions("reset", args[1:])
+		if err == nil {
+			err = ad.CheckTarget("reset", options, environment, dbConfig.Host)
+		}
+		if err != nil {
+			l.Fatal("Could not reset database:", l.ErrField(err))
+		}
+		if err := database.DropTables(); err != nil {
+			l.Fatal("Could not drop tables:", l.ErrField(err))
+		}
+		if err := database.Migrate(ctx); err != nil {
+			l.Fatal("Could not migrate database:", l.ErrField(err))
+		}
+	case "seed":
+		options, err := ad.ParseOptions("seed", args[1:])
+		if err == nil {
+			err = ad.CheckTarget("seed", options, environment, dbConfig.Host)
+		}
+		if err == nil {
+			err = ad.CheckDevEnvironment("seed", environment)
+		}
+		if err != nil {
+			l.Fatal("Could not seed database:", l.ErrField(err))
+		}
+		if len(options.Args) != 1 {
+			l.Fatal("Usage: seed -confirm [-force] <fixtures.yaml>")
+		}
+		fixtures, err := ad.LoadFixtures(options.Args[0], validator)
+		if err != nil {
+			l.Fatal("Could not load fixtures:", l.ErrField(err))
+		}
+		if err := database.Seed(ctx, fixtures); err != nil {
+			l.Fatal("Could not seed database:", l.ErrField(err))
+		}
+	default:
+		l.Fatal("Unknown command " + args[0] + ", commands are migrate, reset and seed")
+	}
+}
+
 // TODO:
 // 6. ostali servisi
 // 7. sporocilni sistem
@@ -0,0 +1,101 @@
+// main for gateway
+package main
+
+import (
+	"context"
+	"log" // Using log before logger is initialized
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/BwezB/Wikno-backend/internal/gateway"
+	"github.com/BwezB/Wikno-backend/internal/gateway/config"
+
+	"github.com/go-playground/validator/v10"
+	"google.golang.org/grpc"
+
+	ce "github.com/BwezB/Wikno-backend/pkg/certs"
+	l "github.com/BwezB/Wikno-backend/pkg/log"
+)
+
+func main() {
+	// "gateway openapi [file]" writes the OpenAPI document instead of serving
+	if len(os.Args) > 1 && os.Args[1] == "openapi" {
+		writeOpenAPI(os.Args[2:])
+		return
+	}
+
+	// SETUP
+
+	validator := validator.New()
+	// Get configuration
+	config, err := config.New(validator)
+	if err != nil {
+		log.Fatalf("Could not get configuration: %v", err) // Using log, as logger is not yet initialized
+	}
+
+	// Set up logging
+	l.InitLogger(config.Logger)
+
+	// Connect to the services
+	authCreds, err := ce.ClientCredentials(config.Auth.TLS)
+	if err != nil {
+		l.Fatal("Could not load auth service credentials:", l.ErrField(err))
+	}
+	authConn, err := grpc.NewClient(config.Auth.GetAddress(), grpc.WithTransportCredentials(authCreds))
+	if err != nil {
+		l.Fatal("Could not connect to auth service:", l.ErrField(err))
+	}
+	defer authConn.Close()
+	graphCreds, err := ce.ClientCredentials(config.Graph.TLS)
+	if err != nil {
+		l.Fatal("Could not load graph service credentials:", l.ErrField(err))
+	}
+	graphConn, err := grpc.NewClient(config.Graph.GetAddress(), grpc.WithTransportCredentials(graphCreds))
+	if err != nil {
+		l.Fatal("Could not connect to graph service:", l.ErrField(err))
+	}
+	defer graphConn.Close()
+
+	// Create the gateway
+	server, err := gateway.New(config.Server, gateway.Services(authConn, graphConn)...)
+	if err != nil {
+		l.Fatal("Could not create gateway:", l.ErrField(err))
+	}
+
+	// START
+	server.Serve()
+
+	// SHUTDOWN
+	// Create the stop channel
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, syscall.SIGINT, syscall.SIGTERM)
+
+	// Wait for signal
+	<-stop
+	l.Info("Shutting down gateway")
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	if err := server.Shutdown(ctx); err != nil {
+		l.Fatal("Could not shutdown gateway:", l.ErrField(err))
+	}
+}
+
+// writeOpenAPI writes the OpenAPI document of the gateway to the file in args, or to stdout
+func writeOpenAPI(args []string) {
+	document, err := gateway.OpenAPI(gateway.Services(nil, nil)...)
+	if err != nil {
+		log.Fatalf("Could not build OpenAPI document: %v", err)
+	}
+	document = append(document, '\n')
+
+	if len(args) == 0 {
+		os.Stdout.Write(document)
+		return
+	}
+	if err := os.WriteFile(args[0], document, 0644); err != nil {
+		log.Fatalf("Could not write OpenAPI document: %v", err)
+	}
+}
@@ -3,6 +3,7 @@ package main
 
 import (
 	"context"
+	"flag"
 	"log" // Using log before logger is initialized
 	"os"
 	"os/signal"
@@ -12,14 +13,19 @@ import (
 	"github.com/BwezB/Wikno-backend/internal/graph/api"
 	"github.com/BwezB/Wikno-backend/internal/graph/config"
 	"github.com/BwezB/Wikno-backend/internal/graph/db"
+	"github.com/BwezB/Wikno-backend/internal/graph/db/memory"
+	"github.com/BwezB/Wikno-backend/internal/graph/graphql"
 	"github.com/BwezB/Wikno-backend/internal/graph/service"
 
 	"github.com/go-playground/validator/v10"
 
 	h "github.com/BwezB/Wikno-backend/pkg/health"
 	l "github.com/BwezB/Wikno-backend/pkg/log"
+	mg "github.com/BwezB/Wikno-backend/pkg/migrate"
 	m "github.com/BwezB/Wikno-backend/pkg/metrics"
 	a "github.com/BwezB/Wikno-backend/pkg/auth"
+	ad "github.com/BwezB/Wikno-backend/pkg/admin"
+	au "github.com/BwezB/Wikno-backend/pkg/audit"
 )
 
 func main() {
@@ -38,29 +44,44 @@ func main() {
 	// Create the health checks service to add checks to
 	healthService := h.NewHealthService(config.Health)
 
-	// Create the database
-	database, err := db.New(config.Database)
-	if err != nil {
-		l.Fatal("Could not connect to database:", l.ErrField(err))
-	}
-	healthService.AddCheck(database) // Health check the database connection
-	// drop tables if needed
-	if config.Database.DropTables {
-		if config.Environment == "production" {
-			l.Error("Cannot drop tables in production!")
-		} else {
-			if err := database.DropTables(); err != nil {
-				l.Fatal("Could not drop tables:", l.ErrField(err))
+	// Create the storage of the graph
+	var repository db.GraphRepository
+	var auditor *au.Auditor
+	if config.Database.Storage == db.StorageMemory {
+		if len(flag.Args()) > 0 {
+			l.Fatal("Admin commands need the postgres storage")
+		}
+		l.Warn("Keeping the graph in memory, it is lost on shutdown")
+		store := memory.New()
+		healthService.AddCheck(store)
+		repository = store
+		auditor = au.NewMemory("graphservice")
+	} else {
+		database, err := db.New(config.Database)
+		if err != nil {
+			l.Fatal("Could not connect to database:", l.ErrField(err))
+		}
+		healthService.AddCheck(database) // Health check the database connection
+		// "graphservice [flags] <command>" runs an admin command instead of the service
+		if args := flag.Args(); len(args) > 0 {
+			runCommand(config.Environment, config.Database, database, validator, args)
+			return
+		}
+		// Apply pending migrations
+		if config.Database.MigrateOnStartup {
+			if err := database.Migrate(context.Background()); err != nil {
+				l.Fatal("Could not migrate database:", l.ErrField(err))
 			}
 		}
-	}
-	// Auto migrate the database
-	if err := database.AutoMigrate(); err != nil {
-		l.Fatal("Could not migrate database:", l.ErrField(err))
+		repository = database
+		auditor = au.New(database.DB, "graphservice")
 	}
 
+	// Create the audit log server
+	auditServer := au.NewServer(auditor, validator, config.Audit)
+
 	// Create the service
-	service := service.NewService(database)
+	service := service.NewService(repository, auditor, config.Service)
 
 	// Create the metrics
 	metrics := m.NewMetrics("graphservice")
@@ -72,15 +93,22 @@ func main() {
 	}
 
 	// Create the API server
-	server, err := api.NewServer(service, healthService, metrics, authService, validator, config.Server)
+	server, err := api.NewServer(service, healthService, metrics, authService, auditServer, validator, config.Server)
 	if err != nil {
 		l.Fatal("Could not create server:", l.ErrField(err))
 	}
 
+	// Create the GraphQL server
+	graphqlServer, err := graphql.NewServer(service, authService, validator, config.GraphQL)
+	if err != nil {
+		l.Fatal("Could not create GraphQL server:", l.ErrField(err))
+	}
+
 
 	// START
 	// Start server and metrics server
 	server.Serve()
+	graphqlServer.Serve()
 
 	// SHUTDOWN
 	// Create the stop channel
@@ -93,7 +121,63 @@ func main() {
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
+	if err := graphqlServer.Shutdown(ctx); err != nil {
+		l.Fatal("Could not shutdown GraphQL server:", l.ErrField(err))
+	}
 	if err := server.Shutdown(ctx); err != nil {
 		l.Fatal("Could not shutdown server:", l.ErrField(err))
 	}
-}
\ No newline at end of file
+}
+
+// runCommand runs an admin command: migrate, reset or seed
+func runCommand(environment string, dbConfig db.DatabaseConfig, database *db.Database, validator *validator.Validate, args []string) {
+	ctx := context.Background()
+	switch args[0] {
+	case "migrate":
+		migrator, err := database.SchemaMigrator()
+		if err != nil {
+			l.Fatal("Could not load migrations:", l.ErrField(err))
+		}
+		if err := mg.Run(ctx, migrator, args[1:], os.Stdout); err != nil {
+			l.Fatal("Could not migrate database:", l.ErrField(err))
+		}
+	case "reset":
+		// Drops all tables and migrates the empty database
+		options, err := ad.ParseOptions("reset", args[1:])
+		if err == nil {
+			err = ad.CheckTarget("reset", options, environment, dbConfig.Host)
+		}
+		if err != nil {
+			l.Fatal("Could not reset database:", l.ErrField(err))
+		}
+		if err := database.DropTables(); err != nil {
+			l.Fatal("Could not drop tables:", l.ErrField(err))
+		}
+		if err := database.Migrate(ctx); err != nil {
+			l.Fatal("Could not migrate database:", l.ErrField(err))
+		}
+	case "seed":
+		options, err := ad.ParseOptions("seed", args[1:])
+		if err == nil {
+			err = ad.CheckTarget("seed", options, environment, dbConfig.Host)
+		}
+		if err == nil {
+			err = ad.CheckDevEnvironment("seed", environment)
+		}
+		if err != nil {
+			l.Fatal("Could not seed database:", l.ErrField(err))
+		}
+		if len(options.Args) != 1 {
+			l.Fatal("Usage: seed -confirm [-force] <fixtures.yaml>")
+		}
+		fixtures, err := ad.LoadFixtures(options.Args[0], validator)
+		if err != nil {
+			l.Fatal("Could not load fixtures:", l.ErrField(err))
+		}
+		if err := database.Seed(ctx, fixtures); err != nil {
+			l.Fatal("Could not seed database:", l.ErrField(err))
+		}
+	default:
+		l.Fatal("Unknown command " + args[0] + ", commands are migrate, reset and seed")
+	}
+}
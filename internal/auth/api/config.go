@@ -1,15 +1,25 @@
 package api
 
 import (
+	ce "github.com/BwezB/Wikno-backend/pkg/certs"
 	c "github.com/BwezB/Wikno-backend/pkg/configs"
+	gw "github.com/BwezB/Wikno-backend/pkg/grpcweb"
 	m "github.com/BwezB/Wikno-backend/pkg/metrics"
+	rl "github.com/BwezB/Wikno-backend/pkg/ratelimit"
+	"net"
 	"strconv"
 )
 
 type ServerConfig struct {
 	Metrics m.MetricsServerConfig `yaml:"metrics"`
+	GrpcWeb gw.GrpcWebServerConfig `yaml:"grpc_web"`
+	TLS ce.ServerTLSConfig `yaml:"tls"`
+	RateLimit rl.RateLimitConfig `yaml:"rate_limit"`
 	Host string `yaml:"host" validate:"required,hostname|ip"`
 	Port int `yaml:"port" validate:"required,min=1,max=65535"`
+
+	// Listener serves gRPC on an existing listener instead of the host and port, like the in-process listeners of the test harness
+	Listener net.Listener `yaml:"-" validate:"-"`
 }
 
 
@@ -19,6 +29,10 @@ func (s *ServerConfig) SetDefaults() {
 	s.Host = "localhost"
 	s.Port = 50051
 	s.Metrics.SetDefaults()
+	s.GrpcWeb.SetDefaults()
+	s.TLS.SetDefaults()
+	s.RateLimit.SetDefaults()
+	s.RateLimit.Methods = "Login=60/30,Register=20/10,VerifyToken=0/0,Ping=0/0" // Logins are limited by IP, VerifyToken is called by the other services
 }
 
 
@@ -28,6 +42,9 @@ func (s *ServerConfig) AddFromEnv() {
 	c.SetEnvValue(&s.Host, "SERVER_HOST")
 	c.SetEnvValue(&s.Port, "SERVER_PORT")
 	s.Metrics.AddFromEnv()
+	s.GrpcWeb.AddFromEnv()
+	s.TLS.AddFromEnv()
+	s.RateLimit.AddFromEnv()
 }
 
 
@@ -41,6 +58,9 @@ func (s *ServerConfig) AddFromFlags() {
 	c.SetFlagValue(&s.Host, flagServerHost)
 	c.SetFlagValue(&s.Port, flagServerPort)
 	s.Metrics.AddFromFlags()
+	s.GrpcWeb.AddFromFlags()
+	s.TLS.AddFromFlags()
+	s.RateLimit.AddFromFlags()
 }
 
 
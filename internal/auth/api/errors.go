@@ -4,9 +4,9 @@ import (
 	"github.com/BwezB/Wikno-backend/internal/auth/db"
 	"github.com/BwezB/Wikno-backend/internal/auth/service"
 	e "github.com/BwezB/Wikno-backend/pkg/errors"
+	ge "github.com/BwezB/Wikno-backend/pkg/grpcerrors"
 
 	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
 )
 
 var (
@@ -16,8 +16,19 @@ var (
     ErrInternal = e.ErrInternal
 )
 
+// errorDomain is the domain of the ErrorInfo details of the errors
+const errorDomain = "authservice"
+
+// resourceUser is the resource type of the ResourceInfo details of not found users
+const resourceUser = "user"
+
 // translateIntoGrpcError translates an error into a gRPC error.
 func translateToGrpcError(err error) error {
+	return translateResourceError(err, nil)
+}
+
+// translateResourceError translates an error of a call about a resource into a gRPC error, with the resource if it was not found.
+func translateResourceError(err error, resource *ge.Resource) error {
 
 	if err == nil {
 		return nil
@@ -28,6 +39,11 @@ func translateToGrpcError(err error) error {
     var code codes.Code
 	var message string
     switch {
+    // Constraint errors, which wrap the database errors below
+    case e.Is(err, db.ErrDuplicateEmail):
+        code = codes.AlreadyExists
+		message = "Email already registered"
+
     // Database errors
     case e.Is(err, db.ErrRecordNotFound):
         code = codes.NotFound
@@ -35,6 +51,12 @@ func translateToGrpcError(err error) error {
     case e.Is(err, db.ErrDuplicateEntry):
         code = codes.AlreadyExists
 		message = "Resource already exists"
+    case e.Is(err, db.ErrInvalidReference):
+        code = codes.InvalidArgument
+		message = "Referenced resource does not exist"
+    case e.Is(err, db.ErrTransactionConflict):
+        code = codes.Aborted
+		message = "Transaction conflict, try again"
     case e.Is(err, db.ErrDatabaseConnection):
         code = codes.Unavailable
 		message = "Database connection error"
@@ -47,6 +69,9 @@ func translateToGrpcError(err error) error {
         code = codes.Unauthenticated
 
     // General errors
+    case e.Is(err, ErrInvalidRequest):
+        code = codes.InvalidArgument
+		message = "Invalid request"
     case e.Is(err, e.ErrInvalidFunctionArgument):
         code = codes.InvalidArgument
 		message = "Invalid function argument"
@@ -59,5 +84,5 @@ func translateToGrpcError(err error) error {
 		message = "Unknown error"
     }
 
-	return status.Error(code, message)
+	return ge.Error(err, code, message, errorDomain, resource)
 }
@@ -0,0 +1,59 @@
+package api
+
+import (
+	"context"
+	"strings"
+
+	"github.com/BwezB/Wikno-backend/internal/auth/model"
+
+	a "github.com/BwezB/Wikno-backend/pkg/auth"
+	l "github.com/BwezB/Wikno-backend/pkg/log"
+	r "github.com/BwezB/Wikno-backend/pkg/requestid"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// unaryAuthInterceptor verifies the authorization token of the protected methods.
+// The auth service can verify tokens itself, so unlike pkg/auth it does not call VerifyToken over gRPC.
+func (s *Server) unaryAuthInterceptor(protectedMethods []string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if !isProtected(info.FullMethod, protectedMethods) {
+			return handler(ctx, req)
+		}
+
+		token := a.GetAuthorizationToken(ctx)
+		if token == "" {
+			l.Warn("Missing authorization token", l.String("request_id", r.GetRequestID(ctx)))
+			return nil, status.Error(codes.Unauthenticated, "missing authorization token")
+		}
+
+		resp, err := s.service.VerifyToken(ctx, &model.VerifyTokenRequest{Token: token})
+		if err != nil {
+			l.Warn("Token verification failed",
+				l.String("request_id", r.GetRequestID(ctx)),
+				l.ErrField(err))
+			return nil, status.Error(codes.Unauthenticated, "invalid token")
+		}
+
+		// Add user info to context
+		ctx = a.WithUserID(ctx, resp.User.ID)
+		ctx = a.WithUserEmail(ctx, resp.User.Email)
+
+		return handler(ctx, req)
+	}
+}
+
+// Helpers
+
+func isProtected(fullMethod string, protectedMethods []string) bool {
+	parts := strings.Split(fullMethod, "/")
+	method := parts[len(parts)-1]
+	for _, protected := range protectedMethods {
+		if protected == method {
+			return true
+		}
+	}
+	return false
+}
@@ -3,16 +3,22 @@ package api
 import (
 	"context"
 	"net"
+	"net/http"
 
 	"github.com/BwezB/Wikno-backend/internal/auth/model"
 	"github.com/BwezB/Wikno-backend/internal/auth/service"
 	"github.com/go-playground/validator/v10"
 
+	au "github.com/BwezB/Wikno-backend/pkg/audit"
+	ce "github.com/BwezB/Wikno-backend/pkg/certs"
 	r "github.com/BwezB/Wikno-backend/pkg/requestid"
 	e "github.com/BwezB/Wikno-backend/pkg/errors"
+	ge "github.com/BwezB/Wikno-backend/pkg/grpcerrors"
+	gw "github.com/BwezB/Wikno-backend/pkg/grpcweb"
 	h "github.com/BwezB/Wikno-backend/pkg/health"
 	l "github.com/BwezB/Wikno-backend/pkg/log"
 	m "github.com/BwezB/Wikno-backend/pkg/metrics"
+	rl "github.com/BwezB/Wikno-backend/pkg/ratelimit"
 
 	pb "github.com/BwezB/Wikno-backend/api/proto/auth"
 	"google.golang.org/grpc"
@@ -27,12 +33,14 @@ type Server struct {
 	validator *validator.Validate
 
 	metricsServer *m.MetricsServer
+	grpcWebServer *gw.GrpcWebServer
 	healthServer  *h.GRPCHealthServer
 }
 
 func NewServer(service *service.AuthService,
 			   healthService *h.HealthService,
 			   metrics *m.MetricsService,
+			   auditServer *au.Server,
 			   validator *validator.Validate,
 			   config ServerConfig) (*Server, error) {
 
@@ -54,18 +62,44 @@ func NewServer(service *service.AuthService,
 	metricsServer := m.NewMetricsServer(metrics, config.Metrics)
 	server.metricsServer = metricsServer
 
+	// Load the TLS credentials
+	l.Debug("Loading TLS credentials", l.String("mode", config.TLS.Mode))
+	tlsConfig, err := ce.ServerTLS(config.TLS)
+	if err != nil {
+		return nil, e.Wrap("failed to load TLS credentials", err)
+	}
+
+	// Set up the rate limiter
+	l.Debug("Creating rate limiter")
+	limiter, err := rl.NewLimiter(config.RateLimit, metrics)
+	if err != nil {
+		return nil, e.Wrap("failed to create rate limiter", err)
+	}
+
 	// Set up the gRPC server
 	l.Debug("Creating gprc server")
 	server.GrpcServer = grpc.NewServer(
+		grpc.Creds(ce.ServerCredentials(tlsConfig)),
 		grpc.ChainUnaryInterceptor(
 			r.UnaryRequestIDInterceptor,
 			m.MetricsInterceptor(metricsServer.MetricsService),
+			server.unaryAuthInterceptor([]string{"GetProfile", "UpdateProfile", "BatchGetProfiles", "QueryAuditLog"}),
+			rl.UnaryRateLimitInterceptor(limiter),
 		),
 	)
 	pb.RegisterAuthServiceServer(server.GrpcServer, server) // Register auth service server
 	h.RegisterHealthServer(server.GrpcServer, healthServer) // Register the health server
+	au.RegisterServer(server.GrpcServer, auditServer)       // Register the audit log server
+
+	// Set up the gRPC-Web server for browsers
+	l.Debug("Creating gRPC-Web server")
+	server.grpcWebServer = gw.NewGrpcWebServer(server.GrpcServer, config.GrpcWeb, tlsConfig)
 
 	// Set up the listener
+	if config.Listener != nil {
+		server.netListener = config.Listener
+		return server, nil
+	}
 	l.Debug("Creating net listener", l.String("address", config.GetAddress()))
 
 	lis, err := net.Listen("tcp", config.GetAddress())
@@ -86,6 +120,9 @@ func (s *Server) Serve() {
 	l.Debug("Starting metrics server")
 	s.metricsServer.Serve()
 
+	// Start the gRPC-Web server
+	s.grpcWebServer.Serve()
+
 	// Start the gRPC server
 	l.Info("Starting gRPC server", l.String("address", s.netListener.Addr().String()))
 	go func() {
@@ -96,6 +133,11 @@ func (s *Server) Serve() {
 	}()
 }
 
+// GrpcWebHandler returns the handler of gRPC-Web calls, to serve them on a listener of the caller's
+func (s *Server) GrpcWebHandler() http.Handler {
+	return s.grpcWebServer.Handler()
+}
+
 func (s *Server) Shutdown(ctx context.Context) error {
 	l.Debug("Stopping health checks")
 	s.healthServer.Shutdown()
@@ -106,6 +148,12 @@ func (s *Server) Shutdown(ctx context.Context) error {
 		return e.Wrap("failed to shutdown metrics server", err)
 	}
 
+	l.Debug("Stopping gRPC-Web server")
+	err = s.grpcWebServer.Shutdown(ctx)
+	if err != nil {
+		return e.Wrap("failed to shutdown gRPC-Web server", err)
+	}
+
 	l.Info("Shutting down gRPC server")
 	s.GrpcServer.GracefulStop()
 	return nil
@@ -126,7 +174,7 @@ func (s *Server) Register(ctx context.Context, req *pb.AuthRequest) (*pb.AuthRes
 
 	// Validate the request
 	if err := s.validator.Struct(req); err != nil {
-		return nil, e.New("Request validation failed", ErrInvalidRequest, err)
+		return nil, translateToGrpcError(e.New("Request validation failed", ErrInvalidRequest, err))
 	}
 
 	// Register the user
@@ -138,7 +186,7 @@ func (s *Server) Register(ctx context.Context, req *pb.AuthRequest) (*pb.AuthRes
 
 	// Validate the response
 	if err := s.validator.Struct(response); err != nil {
-		return nil, e.New("Response validation failed", ErrInternal, err)
+		return nil, translateToGrpcError(e.New("Response validation failed", ErrInternal, err))
 	}
 
 	// Translate the response
@@ -169,7 +217,7 @@ func (s *Server) Login(ctx context.Context, req *pb.AuthRequest) (*pb.AuthRespon
 
 	// Validate the request
 	if err := s.validator.Struct(req); err != nil {
-		return nil, e.New("Request validation failed", ErrInvalidRequest, err)
+		return nil, translateToGrpcError(e.New("Request validation failed", ErrInvalidRequest, err))
 	}
 
 	// Login the user
@@ -181,7 +229,7 @@ func (s *Server) Login(ctx context.Context, req *pb.AuthRequest) (*pb.AuthRespon
 
 	// Validate the response
 	if err := s.validator.Struct(response); err != nil {
-		return nil, e.New("Response validation failed", ErrInternal, err)
+		return nil, translateToGrpcError(e.New("Response validation failed", ErrInternal, err))
 	}
 
 	// Translate the response
@@ -209,7 +257,7 @@ func (s *Server) VerifyToken(ctx context.Context, req *pb.VerifyTokenRequest) (*
 
 	// Validate the request
 	if err := s.validator.Struct(req); err != nil {
-		return nil, e.New("Request validation failed", ErrInvalidRequest, err)
+		return nil, translateToGrpcError(e.New("Request validation failed", ErrInvalidRequest, err))
 	}
 
 	// Verify the token
@@ -221,7 +269,7 @@ func (s *Server) VerifyToken(ctx context.Context, req *pb.VerifyTokenRequest) (*
 
 	// Validate the response
 	if err := s.validator.Struct(response); err != nil {
-		return nil, e.New("Response validation failed", ErrInternal, err)
+		return nil, translateToGrpcError(e.New("Response validation failed", ErrInternal, err))
 	}
 
 	// Translate the response
@@ -238,6 +286,98 @@ func (s *Server) VerifyToken(ctx context.Context, req *pb.VerifyTokenRequest) (*
 	return &res, nil
 }
 
+// Profiles
+
+func (s *Server) GetProfile(ctx context.Context, req *pb.GetProfileRequest) (*pb.Profile, error) {
+	l.Debug("Getting profile",
+		l.String("user_id", req.GetUserId()),
+		l.String("request_id", r.GetRequestID(ctx)))
+
+	// Translate the request
+	request := model.GetProfileRequest{
+		UserID: req.GetUserId(),
+	}
+
+	// Validate the request
+	if err := s.validator.Struct(request); err != nil {
+		return nil, translateToGrpcError(e.New("Request validation failed", ErrInvalidRequest, err))
+	}
+
+	// Get the profile
+	profile, err := s.service.GetProfile(ctx, &request)
+	if err != nil {
+		l.Warn("Failed to get profile:", l.ErrField(err))
+		return nil, translateResourceError(err, &ge.Resource{Type: resourceUser, Name: req.GetUserId()})
+	}
+
+	return translateProfileToProto(profile), nil
+}
+
+func (s *Server) UpdateProfile(ctx context.Context, req *pb.UpdateProfileRequest) (*pb.Profile, error) {
+	l.Debug("Updating profile",
+		l.String("display_name", req.GetDisplayName()),
+		l.String("request_id", r.GetRequestID(ctx)))
+
+	// Translate the request
+	request := model.UpdateProfileRequest{
+		DisplayName: req.GetDisplayName(),
+		AvatarURL:   req.GetAvatarUrl(),
+		Bio:         req.GetBio(),
+	}
+
+	// Validate the request
+	if err := s.validator.Struct(request); err != nil {
+		return nil, translateToGrpcError(e.New("Request validation failed", ErrInvalidRequest, err))
+	}
+
+	// Update the profile
+	profile, err := s.service.UpdateProfile(ctx, &request)
+	if err != nil {
+		l.Warn("Failed to update profile:", l.ErrField(err))
+		return nil, translateToGrpcError(err)
+	}
+
+	// Validate the response
+	if err := s.validator.Struct(profile); err != nil {
+		return nil, translateToGrpcError(e.New("Response validation failed", ErrInternal, err))
+	}
+
+	return translateProfileToProto(profile), nil
+}
+
+func (s *Server) BatchGetProfiles(ctx context.Context, req *pb.BatchGetProfilesRequest) (*pb.ProfilesList, error) {
+	l.Debug("Getting profiles",
+		l.Int("count", len(req.GetUserIds())),
+		l.String("request_id", r.GetRequestID(ctx)))
+
+	// Translate the request
+	request := model.BatchGetProfilesRequest{
+		UserIDs: req.GetUserIds(),
+	}
+
+	// Validate the request
+	if err := s.validator.Struct(request); err != nil {
+		return nil, translateToGrpcError(e.New("Request validation failed", ErrInvalidRequest, err))
+	}
+
+	// Get the profiles
+	profiles, err := s.service.BatchGetProfiles(ctx, &request)
+	if err != nil {
+		l.Warn("Failed to get profiles:", l.ErrField(err))
+		return nil, translateToGrpcError(err)
+	}
+
+	// Translate the response
+	res := &pb.ProfilesList{
+		Profiles: make([]*pb.Profile, len(profiles)),
+	}
+	for i := range profiles {
+		res.Profiles[i] = translateProfileToProto(&profiles[i])
+	}
+
+	return res, nil
+}
+
 // Ping
 
 func (s *Server) Ping(ctx context.Context, req *pb.PingRequest) (*pb.PingResponse, error) {
@@ -245,4 +385,15 @@ func (s *Server) Ping(ctx context.Context, req *pb.PingRequest) (*pb.PingRespons
 	return &pb.PingResponse{
 		ServiceName: "auth",
 	}, nil
-}
\ No newline at end of file
+}
+
+// HELPER FUNCTIONS
+
+func translateProfileToProto(profile *model.Profile) *pb.Profile {
+	return &pb.Profile{
+		UserId:      profile.UserID,
+		DisplayName: profile.DisplayName,
+		AvatarUrl:   profile.AvatarURL,
+		Bio:         profile.Bio,
+	}
+}
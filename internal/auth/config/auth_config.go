@@ -1,6 +1,7 @@
 package config
 
 import (
+	au "github.com/BwezB/Wikno-backend/pkg/audit"
 	c "github.com/BwezB/Wikno-backend/pkg/configs"
 	e "github.com/BwezB/Wikno-backend/pkg/errors"
 	g "github.com/BwezB/Wikno-backend/pkg/graph"
@@ -21,6 +22,7 @@ type AuthConfig struct {
 	Health   h.HealthServiceConfig
 	Service  service.ServiceConfig
 	Graph    g.GraphConfig
+	Audit    au.AuditConfig
 }
 
 func New(validator *validator.Validate) (*AuthConfig, error) {
@@ -39,6 +41,7 @@ func (a *AuthConfig) SetDefaults() {
 	a.Health.SetDefaults()
 	a.Service.SetDefaults()
 	a.Graph.SetDefaults()
+	a.Audit.SetDefaults()
 }
 
 func (a *AuthConfig) AddFromEnv() {
@@ -49,6 +52,7 @@ func (a *AuthConfig) AddFromEnv() {
 	a.Health.AddFromEnv()
 	a.Service.AddFromEnv()
 	a.Graph.AddFromEnv()
+	a.Audit.AddFromEnv()
 }
 
 func (a *AuthConfig) AddFromFlags() {
@@ -59,4 +63,5 @@ func (a *AuthConfig) AddFromFlags() {
 	a.Health.AddFromFlags()
 	a.Service.AddFromFlags()
 	a.Graph.AddFromFlags()
+	a.Audit.AddFromFlags()
 }
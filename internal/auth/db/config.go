@@ -1,10 +1,13 @@
 package db
 
 import (
+	"net"
 	"time"
 	"strconv"
+	"strings"
 
 	c "github.com/BwezB/Wikno-backend/pkg/configs"
+	rt "github.com/BwezB/Wikno-backend/pkg/retry"
 )
 
 type DatabaseConfig struct {
@@ -28,8 +31,25 @@ type DatabaseConfig struct {
 	// ConnMaxLifetime is the maximum lifetime of a connection to the database
 	ConnMaxLifetime time.Duration `yaml:"conn_max_lifetime" validate:"number,min=1"`
 
-	// DropTables is a flag to drop tables on startup (DO NOT USE IN PRODUCTION)
-	DropTables bool `yaml:"drop_tables" validate:"boolean"`
+	// TRANSACTION CONFIG
+	// TxMaxAttempts is how often a transaction is run at most when it fails with serialization failures, deadlocks or lost connections
+	TxMaxAttempts int `yaml:"tx_max_attempts" validate:"number,min=1"`
+	// TxRetryBackoff is the longest wait before retrying a transaction the first time. It doubles with every retry
+	TxRetryBackoff time.Duration `yaml:"tx_retry_backoff" validate:"number,min=1"`
+	// TxRetryBudget is how long a transaction can keep retrying
+	TxRetryBudget time.Duration `yaml:"tx_retry_budget" validate:"number,min=1"`
+
+	// REPLICA CONFIG
+	// Replicas are the comma separated host:port addresses of read replicas, with the user, password and database of the primary.
+	// Read-only queries go to the replicas, and to the primary if no replica is available.
+	Replicas string `yaml:"replicas"`
+	// ReplicaMaxLag is how far a replica can lag behind the primary before queries go to the other replicas or the primary
+	ReplicaMaxLag time.Duration `yaml:"replica_max_lag" validate:"number,min=1"`
+	// ReplicaCheckInterval is how often the replicas are checked for availability and lag
+	ReplicaCheckInterval time.Duration `yaml:"replica_check_interval" validate:"number,min=1"`
+
+	// MigrateOnStartup applies pending migrations on startup. Disable it to run the migrate command separately.
+	MigrateOnStartup bool `yaml:"migrate_on_startup" validate:"boolean"`
 }
 
 // DEFAULTS
@@ -45,7 +65,15 @@ func (d *DatabaseConfig) SetDefaults() {
 	d.MaxIdleConns = 5
 	d.ConnMaxLifetime = 5 * time.Minute
 
-	d.DropTables = false
+	d.TxMaxAttempts = 5
+	d.TxRetryBackoff = 20 * time.Millisecond
+	d.TxRetryBudget = 2 * time.Second
+
+	d.Replicas = "" // Everything is read from the primary unless configured
+	d.ReplicaMaxLag = 5 * time.Second
+	d.ReplicaCheckInterval = 5 * time.Second
+
+	d.MigrateOnStartup = true
 }
 
 // ENVIRONMENT VARIABLES
@@ -61,7 +89,15 @@ func (d *DatabaseConfig) AddFromEnv() {
 	c.SetEnvValue(&d.MaxIdleConns, "DB_MAX_IDLE_CONNS")
 	c.SetEnvValue(&d.ConnMaxLifetime, "DB_CONN_MAX_LIFETIME")
 
-	// DropTables should not be set from environment variables
+	c.SetEnvValue(&d.TxMaxAttempts, "DB_TX_MAX_ATTEMPTS")
+	c.SetEnvValue(&d.TxRetryBackoff, "DB_TX_RETRY_BACKOFF")
+	c.SetEnvValue(&d.TxRetryBudget, "DB_TX_RETRY_BUDGET")
+
+	c.SetEnvValue(&d.Replicas, "DB_REPLICAS")
+	c.SetEnvValue(&d.ReplicaMaxLag, "DB_REPLICA_MAX_LAG")
+	c.SetEnvValue(&d.ReplicaCheckInterval, "DB_REPLICA_CHECK_INTERVAL")
+
+	c.SetEnvValue(&d.MigrateOnStartup, "DB_MIGRATE_ON_STARTUP")
 }
 
 // FLAGS
@@ -77,7 +113,15 @@ var (
 	flagDatabaseMaxIdleConns     = c.NewFlag("db-max-idle-conns", "", "Database Max Idle Connections")
 	flagDatabaseConnMaxLifetime  = c.NewFlag("db-conn-max-lifetime", "", "Database Connection Max Lifetime")
 
-	flagDatabaseDropTables = c.NewFlag("db-drop-tables", "", "DROPS ALL TABLES! DO NOT USE IN PRODUCTION")
+	flagDatabaseTxMaxAttempts  = c.NewFlag("db-tx-max-attempts", "", "Maximum attempts of a database transaction")
+	flagDatabaseTxRetryBackoff = c.NewFlag("db-tx-retry-backoff", "", "Longest wait before the first retry of a database transaction")
+	flagDatabaseTxRetryBudget  = c.NewFlag("db-tx-retry-budget", "", "How long a database transaction can keep retrying")
+
+	flagDatabaseReplicas             = c.NewFlag("db-replicas", "", "Comma separated host:port addresses of database read replicas")
+	flagDatabaseReplicaMaxLag        = c.NewFlag("db-replica-max-lag", "", "Maximum lag of a database replica before reads go elsewhere")
+	flagDatabaseReplicaCheckInterval = c.NewFlag("db-replica-check-interval", "", "Database replica check interval")
+
+	flagDatabaseMigrateOnStartup = c.NewFlag("db-migrate-on-startup", "", "Apply pending migrations on startup")
 )
 
 func (d *DatabaseConfig) AddFromFlags() {
@@ -91,7 +135,15 @@ func (d *DatabaseConfig) AddFromFlags() {
 	c.SetFlagValue(&d.MaxIdleConns, flagDatabaseMaxIdleConns)
 	c.SetFlagValue(&d.ConnMaxLifetime, flagDatabaseConnMaxLifetime)
 
-	c.SetFlagValue(&d.DropTables, flagDatabaseDropTables)
+	c.SetFlagValue(&d.TxMaxAttempts, flagDatabaseTxMaxAttempts)
+	c.SetFlagValue(&d.TxRetryBackoff, flagDatabaseTxRetryBackoff)
+	c.SetFlagValue(&d.TxRetryBudget, flagDatabaseTxRetryBudget)
+
+	c.SetFlagValue(&d.Replicas, flagDatabaseReplicas)
+	c.SetFlagValue(&d.ReplicaMaxLag, flagDatabaseReplicaMaxLag)
+	c.SetFlagValue(&d.ReplicaCheckInterval, flagDatabaseReplicaCheckInterval)
+
+	c.SetFlagValue(&d.MigrateOnStartup, flagDatabaseMigrateOnStartup)
 }
 
 // HELPER FUNCTIONS
@@ -103,3 +155,34 @@ func (d *DatabaseConfig) GetAddress() string {
 func (d *DatabaseConfig) GetDSN() string {
 	return "host=" + d.Host + " port=" + strconv.Itoa(d.Port) + " user=" + d.User + " password=" + d.Password + " dbname=" + d.DBName + " sslmode=disable"
 }
+
+// GetRetryConfig returns the limits of transaction retries
+func (d *DatabaseConfig) GetRetryConfig() rt.Config {
+	return rt.Config{
+		MaxAttempts: d.TxMaxAttempts,
+		Backoff:     d.TxRetryBackoff,
+		Budget:      d.TxRetryBudget,
+	}
+}
+
+// GetReplicaAddresses returns the host:port addresses of the replicas. Replicas without a port use the port of the primary.
+func (d *DatabaseConfig) GetReplicaAddresses() []string {
+	var addresses []string
+	for _, address := range strings.Split(d.Replicas, ",") {
+		address = strings.TrimSpace(address)
+		if address == "" {
+			continue
+		}
+		if _, _, err := net.SplitHostPort(address); err != nil {
+			address = net.JoinHostPort(address, strconv.Itoa(d.Port))
+		}
+		addresses = append(addresses, address)
+	}
+	return addresses
+}
+
+// GetReplicaDSN returns the DSN of the replica at the host:port address
+func (d *DatabaseConfig) GetReplicaDSN(address string) string {
+	host, port, _ := net.SplitHostPort(address)
+	return "host=" + host + " port=" + port + " user=" + d.User + " password=" + d.Password + " dbname=" + d.DBName + " sslmode=disable"
+}
@@ -2,22 +2,32 @@ package db
 
 import (
 	"context"
+	"strings"
 	"time"
 
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 	"gorm.io/gorm/logger"
 
 	"github.com/BwezB/Wikno-backend/internal/auth/model"
 
+	au "github.com/BwezB/Wikno-backend/pkg/audit"
 	r "github.com/BwezB/Wikno-backend/pkg/requestid"
 	e "github.com/BwezB/Wikno-backend/pkg/errors"
 	h "github.com/BwezB/Wikno-backend/pkg/health"
 	l "github.com/BwezB/Wikno-backend/pkg/log"
+	rp "github.com/BwezB/Wikno-backend/pkg/replicas"
+	rt "github.com/BwezB/Wikno-backend/pkg/retry"
 )
 
 type Database struct {
 	*gorm.DB
+
+	// replicas serve the read-only queries, nil without replicas
+	replicas *rp.Set
+	// retry limits the retries of transactions
+	retry rt.Config
 }
 
 func New(config DatabaseConfig) (*Database, error) {
@@ -27,9 +37,56 @@ func New(config DatabaseConfig) (*Database, error) {
 		l.String("dbname", config.DBName))
 
 	// Connect to the database
-	dsn := config.GetDSN()
+	db, err := open(config.GetDSN(), config, false)
+	if err != nil {
+		return nil, err
+	}
+
+	l.Info("Connected to database",
+		l.String("address", config.GetAddress()),
+		l.String("user", config.User),
+		l.String("dbname", config.DBName))
+
+	// Connect to the read replicas. Replicas that are down are skipped until they come back.
+	addresses := config.GetReplicaAddresses()
+	if len(addresses) == 0 {
+		return &Database{DB: db, retry: config.GetRetryConfig()}, nil
+	}
+	replicas := make([]*rp.Replica, 0, len(addresses))
+	for _, address := range addresses {
+		replica, err := open(config.GetReplicaDSN(address), config, true)
+		if err != nil {
+			return nil, e.Wrap("Failed to connect to replica "+address, err)
+		}
+		replicas = append(replicas, &rp.Replica{Address: address, DB: replica})
+	}
+	l.Info("Routing reads to replicas", l.String("replicas", strings.Join(addresses, ",")))
+
+	return &Database{
+		DB:       db,
+		replicas: rp.New(replicas, config.ReplicaMaxLag, config.ReplicaCheckInterval),
+		retry:    config.GetRetryConfig(),
+	}, nil
+}
+
+// reader returns the database for read-only queries: an available replica, or the primary if there is none
+func (db *Database) reader() *Database {
+	if replica := db.replicas.Reader(); replica != nil {
+		return &Database{DB: replica, replicas: db.replicas, retry: db.retry}
+	}
+	return db
+}
+
+// transaction runs fn in a transaction, and again on serialization failures, deadlocks and lost connections
+func (db *Database) transaction(ctx context.Context, fn func(tx *gorm.DB) error) error {
+	return rt.Transaction(ctx, db.DB, db.retry, TranslateDatabaseError, fn)
+}
+
+// open opens a connection pool. Without the ping, connecting waits for the first query.
+func open(dsn string, config DatabaseConfig, skipPing bool) (*gorm.DB, error) {
 	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
-		Logger: logger.Default.LogMode(logger.Silent), // Disable gorm logging
+		Logger:               logger.Default.LogMode(logger.Silent), // Disable gorm logging
+		DisableAutomaticPing: skipPing,
 	})
 	if err != nil {
 		return nil, e.New("Failed to connect to database", ErrDatabaseConnection, err)
@@ -40,34 +97,18 @@ func New(config DatabaseConfig) (*Database, error) {
 	if err != nil {
 		return nil, e.New("Failed to get sql.DB from gorm.DB", ErrInternal, err)
 	}
-	
+
 	sqlDB.SetMaxOpenConns(config.MaxOpenConns)
 	sqlDB.SetMaxIdleConns(config.MaxIdleConns)
 	sqlDB.SetConnMaxLifetime(config.ConnMaxLifetime)
 
-	l.Info("Connected to database",
-		l.String("address", config.GetAddress()),
-		l.String("user", config.User),
-		l.String("dbname", config.DBName))
-
-	return &Database{db}, nil
-}
-
-func (db *Database) AutoMigrate() error {
-	l.Debug("Auto migrating database")
-
-	err := db.DB.AutoMigrate(&model.User{})
-	if err != nil {
-		return e.New("Auto migration failed", ErrInternal, err)
-	}
-
-	l.Info("Auto migration successful")
-	return nil
+	return db, nil
 }
 
+// DropTables drops all tables, including the record of applied migrations. Only the reset command uses it.
 func (db *Database) DropTables() error {
 	l.Debug("Resetting tables")
-	err := db.Migrator().DropTable(&model.User{})
+	err := db.Migrator().DropTable(&au.Event{}, &model.Profile{}, &model.User{}, "schema_migrations")
 	if err != nil {
 		return e.New("Failed to reset tables", ErrInternal, err)
 	}
@@ -79,20 +120,26 @@ func (db *Database) DropTables() error {
 // GET/SET METHODS
 
 // CreateUser needs to get a hashed password!
+// A default profile is created together with the user.
 func (db *Database) CreateUser(ctx context.Context, req *model.AuthRequest, hashedPassword string) (*model.User, error) {
 	l.Debug("Creating user",
 		l.String("email", req.Email),
 		l.String("request_id", r.GetRequestID(ctx)))
 	
-	// Create the user object that will be stored in the DB
-	user := &model.User{
-		Email:    req.Email,
-		Password: hashedPassword,
-	}
-	
-	res := db.WithContext(ctx).Create(user)
-	if res.Error != nil {
-		return nil, TranslateDatabaseError(res.Error)
+	var user *model.User
+	err := db.transaction(ctx, func(tx *gorm.DB) error {
+		// Create the user object that will be stored in the DB
+		user = &model.User{
+			Email:    req.Email,
+			Password: hashedPassword,
+			Profile: &model.Profile{
+				DisplayName: defaultDisplayName(req.Email),
+			},
+		}
+		return TranslateDatabaseError(tx.Create(user).Error)
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	l.Info("Created user", l.String("email", req.Email), l.String("id", user.ID))
@@ -104,8 +151,10 @@ func (db *Database) GetUserByEmail(ctx context.Context, email string) (*model.Us
 		l.String("email", email),
 		l.String("request_id", r.GetRequestID(ctx)))
 
+	reader := db.reader()
+
 	var user model.User
-	res := db.WithContext(ctx).First(&user, "email = ?", email)
+	res := reader.WithContext(ctx).First(&user, "email = ?", email)
 	if res.Error != nil {
 		return nil, TranslateDatabaseError(res.Error)
 	}
@@ -117,8 +166,10 @@ func (db *Database) GetUserByID(ctx context.Context, id string) (*model.User, er
 		l.String("id", id),
 		l.String("request_id", r.GetRequestID(ctx)))
 
+	reader := db.reader()
+
 	var user model.User
-	res := db.WithContext(ctx).First(&user, "id = ?", id)
+	res := reader.WithContext(ctx).First(&user, "id = ?", id)
 	if res.Error != nil {
 		return nil, TranslateDatabaseError(res.Error)
 	}
@@ -126,6 +177,73 @@ func (db *Database) GetUserByID(ctx context.Context, id string) (*model.User, er
 }
 
 
+// PROFILES
+
+// GetProfile gets the profile of the user with the given ID
+func (db *Database) GetProfile(ctx context.Context, userID string) (*model.Profile, error) {
+	l.Debug("Getting profile",
+		l.String("user_id", userID),
+		l.String("request_id", r.GetRequestID(ctx)))
+
+	reader := db.reader()
+
+	var profile model.Profile
+	res := reader.WithContext(ctx).First(&profile, "user_id = ?", userID)
+	if res.Error != nil {
+		return nil, TranslateDatabaseError(res.Error)
+	}
+	return &profile, nil
+}
+
+// GetProfiles gets the profiles of the users with the given IDs. Users without a profile are skipped.
+func (db *Database) GetProfiles(ctx context.Context, userIDs []string) ([]model.Profile, error) {
+	l.Debug("Getting profiles",
+		l.Int("count", len(userIDs)),
+		l.String("request_id", r.GetRequestID(ctx)))
+
+	reader := db.reader()
+
+	var profiles []model.Profile
+	res := reader.WithContext(ctx).Where("user_id IN ?", userIDs).Find(&profiles)
+	if res.Error != nil {
+		return nil, TranslateDatabaseError(res.Error)
+	}
+	return profiles, nil
+}
+
+// UpdateProfile overwrites the profile of the given user, creating it if it does not exist yet
+func (db *Database) UpdateProfile(ctx context.Context, userID string, req *model.UpdateProfileRequest) (*model.Profile, error) {
+	l.Debug("Updating profile",
+		l.String("user_id", userID),
+		l.String("display_name", req.DisplayName),
+		l.String("request_id", r.GetRequestID(ctx)))
+
+	profile := &model.Profile{
+		UserID:      userID,
+		DisplayName: req.DisplayName,
+		AvatarURL:   req.AvatarURL,
+		Bio:         req.Bio,
+	}
+	res := db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"display_name", "avatar_url", "bio", "updated_at"}),
+	}).Create(profile)
+	if res.Error != nil {
+		return nil, TranslateDatabaseError(res.Error)
+	}
+	return profile, nil
+}
+
+// defaultDisplayName derives a display name from the local part of an email address
+func defaultDisplayName(email string) string {
+	name, _, _ := strings.Cut(email, "@")
+	if len(name) > 64 {
+		name = name[:64]
+	}
+	return name
+}
+
+
 // HEALTH CHECK
 
 // HealthCheck checks the health of the database
@@ -0,0 +1,50 @@
+package db
+
+import (
+	"io/fs"
+	"strings"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+
+	e "github.com/BwezB/Wikno-backend/pkg/errors"
+	pe "github.com/BwezB/Wikno-backend/pkg/pgerrors"
+)
+
+// TestConstraints checks that the mapped constraints exist in the schema, and that their violations are translated
+// to their error types. Primary keys are named after their tables by postgres.
+func TestConstraints(t *testing.T) {
+	schema := ""
+	files, err := fs.Glob(migrations, "migrations/*.up.sql")
+	if err != nil || len(files) == 0 {
+		t.Fatalf("Could not find migrations: %v", err)
+	}
+	for _, file := range files {
+		data, err := fs.ReadFile(migrations, file)
+		if err != nil {
+			t.Fatalf("Could not read %s: %v", file, err)
+		}
+		schema += string(data)
+	}
+
+	for name, errorType := range constraints {
+		t.Run(name, func(t *testing.T) {
+			defined := `"` + name + `"`
+			if table, ok := strings.CutSuffix(name, "_pkey"); ok {
+				defined = `CREATE TABLE IF NOT EXISTS "` + table + `"`
+			}
+			if !strings.Contains(schema, defined) {
+				t.Errorf("Expected the migrations to define %s", name)
+			}
+
+			code, general := pe.CodeUniqueViolation, ErrDuplicateEntry
+			if strings.HasPrefix(name, "chk_") {
+				code, general = pe.CodeCheckViolation, pe.ErrCheckViolation
+			}
+			err := TranslateDatabaseError(&pgconn.PgError{Code: code, ConstraintName: name})
+			if e.CodeOf(err) != errorType.Code || !e.Is(err, general) {
+				t.Errorf("Expected %s wrapping %s, got: %v", errorType.Code, general.Code, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,164 @@
+// Package memory keeps the users of the auth service in memory.
+package memory
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/BwezB/Wikno-backend/internal/auth/db"
+	"github.com/BwezB/Wikno-backend/internal/auth/model"
+
+	e "github.com/BwezB/Wikno-backend/pkg/errors"
+	h "github.com/BwezB/Wikno-backend/pkg/health"
+)
+
+// Store is a db.UserStore that keeps users and profiles in memory, with the constraints of the database:
+// unique emails and one profile per user. Nothing is persisted, so it is meant for tests.
+type Store struct {
+	mu       sync.Mutex
+	users    map[string]model.User // By ID
+	emails   map[string]string     // User IDs by email
+	profiles map[string]model.Profile
+}
+
+var _ db.UserStore = (*Store)(nil)
+
+// New creates an empty store.
+func New() *Store {
+	return &Store{
+		users:    make(map[string]model.User),
+		emails:   make(map[string]string),
+		profiles: make(map[string]model.Profile),
+	}
+}
+
+// CreateUser needs to get a hashed password!
+// A default profile is created together with the user.
+func (s *Store) CreateUser(ctx context.Context, req *model.AuthRequest, hashedPassword string) (*model.User, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.emails[req.Email]; ok {
+		return nil, e.New("", db.ErrDuplicateEmail, e.New("Resource already exists", db.ErrDuplicateEntry, nil))
+	}
+
+	now := time.Now()
+	user := model.User{
+		ID:        uuid.New().String(),
+		Email:     req.Email,
+		Password:  hashedPassword,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	profile := model.Profile{
+		UserID:      user.ID,
+		DisplayName: defaultDisplayName(req.Email),
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	s.users[user.ID] = user
+	s.emails[user.Email] = user.ID
+	s.profiles[user.ID] = profile
+
+	user.Profile = &profile
+	return &user, nil
+}
+
+func (s *Store) GetUserByEmail(ctx context.Context, email string) (*model.User, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id, ok := s.emails[email]
+	if !ok {
+		return nil, e.New("", db.ErrRecordNotFound, nil)
+	}
+	user := s.users[id]
+	return &user, nil
+}
+
+func (s *Store) GetUserByID(ctx context.Context, id string) (*model.User, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	user, ok := s.users[id]
+	if !ok {
+		return nil, e.New("", db.ErrRecordNotFound, nil)
+	}
+	return &user, nil
+}
+
+// PROFILES
+
+// GetProfile gets the profile of the user with the given ID
+func (s *Store) GetProfile(ctx context.Context, userID string) (*model.Profile, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	profile, ok := s.profiles[userID]
+	if !ok {
+		return nil, e.New("", db.ErrRecordNotFound, nil)
+	}
+	return &profile, nil
+}
+
+// GetProfiles gets the profiles of the users with the given IDs. Users without a profile are skipped.
+func (s *Store) GetProfiles(ctx context.Context, userIDs []string) ([]model.Profile, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var profiles []model.Profile
+	seen := make(map[string]bool, len(userIDs))
+	for _, id := range userIDs {
+		if profile, ok := s.profiles[id]; ok && !seen[id] {
+			seen[id] = true
+			profiles = append(profiles, profile)
+		}
+	}
+	return profiles, nil
+}
+
+// UpdateProfile overwrites the profile of the given user, creating it if it does not exist yet
+func (s *Store) UpdateProfile(ctx context.Context, userID string, req *model.UpdateProfileRequest) (*model.Profile, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Like the foreign key of the profiles table
+	if _, ok := s.users[userID]; !ok {
+		return nil, e.New("Referenced resource does not exist", db.ErrInvalidReference, nil)
+	}
+
+	now := time.Now()
+	profile, ok := s.profiles[userID]
+	if !ok {
+		profile = model.Profile{UserID: userID, CreatedAt: now}
+	}
+	profile.DisplayName = req.DisplayName
+	profile.AvatarURL = req.AvatarURL
+	profile.Bio = req.Bio
+	profile.UpdatedAt = now
+	s.profiles[userID] = profile
+	return &profile, nil
+}
+
+// HEALTH CHECK
+
+// HealthCheck always reports the store as healthy, as there is no connection to lose
+func (s *Store) HealthCheck(ctx context.Context) *h.HealthStatus {
+	return &h.HealthStatus{
+		Healthy: true,
+		Time:    time.Now(),
+	}
+}
+
+// defaultDisplayName derives a display name from the local part of an email address
+func defaultDisplayName(email string) string {
+	name, _, _ := strings.Cut(email, "@")
+	if len(name) > 64 {
+		name = name[:64]
+	}
+	return name
+}
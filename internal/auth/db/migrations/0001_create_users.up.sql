@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS "users" (
+	"id" uuid DEFAULT gen_random_uuid(),
+	"email" text NOT NULL,
+	"password" text NOT NULL,
+	"created_at" timestamptz,
+	"updated_at" timestamptz,
+	PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_users_email" ON "users" ("email");
+
+CREATE TABLE IF NOT EXISTS "profiles" (
+	"user_id" uuid,
+	"display_name" varchar(64) NOT NULL,
+	"avatar_url" varchar(2048) NOT NULL DEFAULT '',
+	"bio" varchar(1024) NOT NULL DEFAULT '',
+	"created_at" timestamptz,
+	"updated_at" timestamptz,
+	PRIMARY KEY ("user_id"),
+	CONSTRAINT "fk_users_profile" FOREIGN KEY ("user_id") REFERENCES "users"("id") ON DELETE CASCADE
+);
@@ -0,0 +1 @@
+-- Backfilled profiles cannot be told apart from profiles created with their users, so they are kept
@@ -0,0 +1,8 @@
+-- Users created before profiles existed get the default profile new users get, named after their email
+
+INSERT INTO profiles (user_id, display_name, created_at, updated_at)
+SELECT u.id, left(split_part(u.email, '@', 1), 64), now(), now()
+FROM users u
+WHERE NOT EXISTS (
+	SELECT 1 FROM profiles p WHERE p.user_id = u.id
+);
@@ -0,0 +1,24 @@
+package db
+
+import (
+	"context"
+
+	"github.com/BwezB/Wikno-backend/internal/auth/model"
+)
+
+// UserStore is the storage the auth service keeps its users and profiles in.
+// Database stores them in postgres, and memory.Store keeps them in memory for tests.
+// Implementations return the error types of this package, so callers can tell missing and duplicate users apart.
+type UserStore interface {
+	// Users
+	CreateUser(ctx context.Context, req *model.AuthRequest, hashedPassword string) (*model.User, error)
+	GetUserByEmail(ctx context.Context, email string) (*model.User, error)
+	GetUserByID(ctx context.Context, id string) (*model.User, error)
+
+	// Profiles
+	GetProfile(ctx context.Context, userID string) (*model.Profile, error)
+	GetProfiles(ctx context.Context, userIDs []string) ([]model.Profile, error)
+	UpdateProfile(ctx context.Context, userID string, req *model.UpdateProfileRequest) (*model.Profile, error)
+}
+
+var _ UserStore = (*Database)(nil)
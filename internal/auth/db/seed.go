@@ -0,0 +1,50 @@
+package db
+
+import (
+	"context"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/BwezB/Wikno-backend/internal/auth/model"
+
+	ad "github.com/BwezB/Wikno-backend/pkg/admin"
+	e "github.com/BwezB/Wikno-backend/pkg/errors"
+	l "github.com/BwezB/Wikno-backend/pkg/log"
+)
+
+// Seed creates the users of the fixtures with their profiles.
+// Users whose ID or email already exists are skipped, so seeding twice is harmless.
+func (db *Database) Seed(ctx context.Context, fixtures *ad.Fixtures) error {
+	for _, fixture := range fixtures.Users {
+		var existing int64
+		res := db.WithContext(ctx).Model(&model.User{}).Where("id = ? OR email = ?", fixture.ID, fixture.Email).Count(&existing)
+		if res.Error != nil {
+			return e.Wrap("Could not check for seeded user", TranslateDatabaseError(res.Error))
+		}
+		if existing > 0 {
+			l.Info("Skipping existing user", l.String("user_id", fixture.ID), l.String("email", fixture.Email))
+			continue
+		}
+
+		hashedPassword, err := bcrypt.GenerateFromPassword([]byte(fixture.Password), bcrypt.DefaultCost)
+		if err != nil {
+			return e.New("Could not hash password of "+fixture.Email, ErrInternal, err)
+		}
+		displayName := fixture.DisplayName
+		if displayName == "" {
+			displayName = defaultDisplayName(fixture.Email)
+		}
+
+		user := &model.User{
+			ID:       fixture.ID,
+			Email:    fixture.Email,
+			Password: string(hashedPassword),
+			Profile:  &model.Profile{DisplayName: displayName},
+		}
+		if err := db.WithContext(ctx).Create(user).Error; err != nil {
+			return e.Wrap("Could not seed user "+fixture.Email, TranslateDatabaseError(err))
+		}
+		l.Info("Seeded user", l.String("user_id", user.ID), l.String("email", user.Email))
+	}
+	return nil
+}
@@ -11,6 +11,18 @@ type User struct {
 	Password  string    `gorm:"not null" json:"-"` // hide password from JSON
 	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
 	UpdatedAt time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+
+	Profile *Profile `gorm:"foreignKey:UserID;references:ID;constraint:OnDelete:CASCADE" json:"profile,omitempty"`
+}
+
+// Profile is the public, human-readable part of a user
+type Profile struct {
+	UserID      string    `gorm:"type:uuid;primary_key" json:"user_id" validate:"required,uuid"`
+	DisplayName string    `gorm:"type:varchar(64);not null" json:"display_name" validate:"required,max=64"`
+	AvatarURL   string    `gorm:"type:varchar(2048);not null;default:''" json:"avatar_url" validate:"omitempty,url,max=2048"`
+	Bio         string    `gorm:"type:varchar(1024);not null;default:''" json:"bio" validate:"max=1024"`
+	CreatedAt   time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt   time.Time `gorm:"autoUpdateTime" json:"updated_at"`
 }
 
 // DTOs
@@ -30,4 +42,18 @@ type VerifyTokenRequest struct {
 
 type VerifyTokenResponse struct {
 	User User `json:"user" validate:"required"`
+}
+
+type GetProfileRequest struct {
+	UserID string `json:"user_id" validate:"required,uuid"`
+}
+
+type UpdateProfileRequest struct {
+	DisplayName string `json:"display_name" validate:"required,max=64"`
+	AvatarURL   string `json:"avatar_url" validate:"omitempty,url,max=2048"`
+	Bio         string `json:"bio" validate:"max=1024"`
+}
+
+type BatchGetProfilesRequest struct {
+	UserIDs []string `json:"user_ids" validate:"required,min=1,max=100,dive,uuid"`
 }
\ No newline at end of file
@@ -0,0 +1,24 @@
+package service
+
+import (
+	"context"
+
+	a "github.com/BwezB/Wikno-backend/pkg/auth"
+)
+
+// Audited actions of the auth service
+const (
+	ActionRegister      = "auth.register"
+	ActionLogin         = "auth.login"
+	ActionVerifyToken   = "auth.verify_token" // Only failures are recorded, every authenticated request verifies a token
+	ActionUpdateProfile = "auth.update_profile"
+)
+
+// record records the outcome of an operation on a user's account in the audit log.
+// The user is the target, and also the actor when the request is not authenticated yet.
+func (s *AuthService) record(ctx context.Context, action, userID string, err error, details map[string]string) {
+	if a.GetUserID(ctx) == "" && userID != "" {
+		ctx = a.WithUserID(ctx, userID)
+	}
+	s.auditor.RecordResult(ctx, action, userID, err, details)
+}
@@ -6,6 +6,8 @@ import (
 	"github.com/BwezB/Wikno-backend/internal/auth/db"
 	"github.com/BwezB/Wikno-backend/internal/auth/model"
 
+	au "github.com/BwezB/Wikno-backend/pkg/audit"
+	a "github.com/BwezB/Wikno-backend/pkg/auth"
 	e "github.com/BwezB/Wikno-backend/pkg/errors"
 	g "github.com/BwezB/Wikno-backend/pkg/graph"
 	r "github.com/BwezB/Wikno-backend/pkg/requestid"
@@ -14,15 +16,18 @@ import (
 )
 
 type AuthService struct {
-	db     *db.Database
-	graph  *g.GraphService
-	config ServiceConfig
+	db      db.UserStore
+	graph   g.GraphProvisioner
+	auditor *au.Auditor
+	config  ServiceConfig
 	id     string // My id for calling other services
 	email  string // My email for calling other services
 	token  string // My token for calling other services
 }
 
-func NewAuthService(database *db.Database, graph *g.GraphService, config ServiceConfig) (*AuthService, error) {
+// NewAuthService creates the auth service on a user store, creating the graph users of new users with the provisioner.
+// The auth service signs in as its own user, which is created in the store if it does not exist yet.
+func NewAuthService(database db.UserStore, graph g.GraphProvisioner, auditor *au.Auditor, config ServiceConfig) (*AuthService, error) {
 	// Hash the password for auth user, so it is not stored in plain text
 	hashedPassword, err := hashPassword(config.password)
 	if err != nil {
@@ -51,17 +56,28 @@ func NewAuthService(database *db.Database, graph *g.GraphService, config Service
 	// JWT will be created with the first request
 
 	authService := &AuthService{
-		db:     database,
-		config: config,
-		graph:  graph,
-		id:     user.ID,
-		email:  user.Email,
+		db:      database,
+		config:  config,
+		graph:   graph,
+		auditor: auditor,
+		id:      user.ID,
+		email:   user.Email,
 	}
 
 	return authService, nil
 }
 
 func (s *AuthService) RegisterUser(ctx context.Context, req *model.AuthRequest) (*model.AuthResponse, error) {
+	response, err := s.registerUser(ctx, req)
+	if err != nil {
+		s.record(ctx, ActionRegister, "", err, map[string]string{"email": req.Email})
+		return nil, err
+	}
+	s.record(ctx, ActionRegister, response.User.ID, nil, map[string]string{"email": req.Email})
+	return response, nil
+}
+
+func (s *AuthService) registerUser(ctx context.Context, req *model.AuthRequest) (*model.AuthResponse, error) {
 	// Hash the password, so it is not stored in plain text
 	hashedPassword, err := hashPassword(req.Password)
 	if err != nil {
@@ -103,19 +119,23 @@ func (s *AuthService) LoginUser(ctx context.Context, req *model.AuthRequest) (*m
 	// Get the user from the DB
 	user, err := s.db.GetUserByEmail(ctx, req.Email)
 	if err != nil {
+		s.record(ctx, ActionLogin, "", err, map[string]string{"email": req.Email})
 		return nil, e.Wrap("LoginUser failed", err)
 	}
 
 	// Compare the passwords
 	if err := comparePasswords(user.Password, req.Password); err != nil {
+		s.record(ctx, ActionLogin, user.ID, err, map[string]string{"email": req.Email})
 		return nil, e.Wrap("LoginUser failed", err)
 	}
 
 	// Create the jwt token
 	token, err := generateJWT(user.ID, user.Email, s.config.jwtSecret, s.config.jwtExpiry)
 	if err != nil {
+		s.record(ctx, ActionLogin, user.ID, err, map[string]string{"email": req.Email})
 		return nil, e.Wrap("LoginUser failed", err)
 	}
+	s.record(ctx, ActionLogin, user.ID, nil, map[string]string{"email": req.Email})
 
 	response := model.AuthResponse{
 		User:  *user,
@@ -128,12 +148,14 @@ func (s *AuthService) VerifyToken(ctx context.Context, req *model.VerifyTokenReq
 	// Verify the token
 	claims, err := verifyJWT(req.Token, s.config.jwtSecret)
 	if err != nil {
+		s.record(ctx, ActionVerifyToken, "", err, nil)
 		return nil, e.Wrap("VerifyToken failed", err)
 	}
 
 	// Get the user from the DB
 	user, err := s.db.GetUserByID(ctx, claims.UserID)
 	if err != nil {
+		s.record(ctx, ActionVerifyToken, claims.UserID, err, nil)
 		return nil, e.Wrap("VerifyToken failed", err)
 	}
 
@@ -143,6 +165,41 @@ func (s *AuthService) VerifyToken(ctx context.Context, req *model.VerifyTokenReq
 	return &response, nil
 }
 
+// PROFILES
+
+// GetProfile returns the profile of the requested user
+func (s *AuthService) GetProfile(ctx context.Context, req *model.GetProfileRequest) (*model.Profile, error) {
+	profile, err := s.db.GetProfile(ctx, req.UserID)
+	if err != nil {
+		return nil, e.Wrap("GetProfile failed", err)
+	}
+	return profile, nil
+}
+
+// UpdateProfile changes the profile of the user in the context
+func (s *AuthService) UpdateProfile(ctx context.Context, req *model.UpdateProfileRequest) (*model.Profile, error) {
+	userID := a.GetUserID(ctx)
+	if userID == "" {
+		return nil, e.New("Failed to get user ID from context", ErrInternal, nil)
+	}
+
+	profile, err := s.db.UpdateProfile(ctx, userID, req)
+	s.record(ctx, ActionUpdateProfile, userID, err, nil)
+	if err != nil {
+		return nil, e.Wrap("UpdateProfile failed", err)
+	}
+	return profile, nil
+}
+
+// BatchGetProfiles returns the profiles of all requested users that have one
+func (s *AuthService) BatchGetProfiles(ctx context.Context, req *model.BatchGetProfilesRequest) ([]model.Profile, error) {
+	profiles, err := s.db.GetProfiles(ctx, req.UserIDs)
+	if err != nil {
+		return nil, e.Wrap("BatchGetProfiles failed", err)
+	}
+	return profiles, nil
+}
+
 // Helper functions
 
 func (s *AuthService) getJWToken() (string, error) {
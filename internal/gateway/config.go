@@ -0,0 +1,55 @@
+package gateway
+
+import (
+	"strconv"
+	"time"
+
+	c "github.com/BwezB/Wikno-backend/pkg/configs"
+)
+
+type ServerConfig struct {
+	Host string `yaml:"host" validate:"required,hostname|ip"`
+	Port int    `yaml:"port" validate:"required,min=1,max=65535"`
+	// RequestTimeout is how long a unary call may take. Streams are not limited
+	RequestTimeout time.Duration `yaml:"request_timeout" validate:"min=1s"`
+	// MaxBodyBytes is the largest accepted request body
+	MaxBodyBytes int `yaml:"max_body_bytes" validate:"min=1024"`
+}
+
+// DEFAULTS
+
+func (s *ServerConfig) SetDefaults() {
+	s.Host = "localhost"
+	s.Port = 8080
+	s.RequestTimeout = 30 * time.Second
+	s.MaxBodyBytes = 1 << 20
+}
+
+// ENV
+
+func (s *ServerConfig) AddFromEnv() {
+	c.SetEnvValue(&s.Host, "SERVER_HOST")
+	c.SetEnvValue(&s.Port, "SERVER_PORT")
+	c.SetEnvValue(&s.RequestTimeout, "GATEWAY_REQUEST_TIMEOUT")
+	c.SetEnvValue(&s.MaxBodyBytes, "GATEWAY_MAX_BODY_BYTES")
+}
+
+// FLAGS
+
+var (
+	flagServerHost     = c.NewFlag("server-host", "", "Server Host")
+	flagServerPort     = c.NewFlag("server-port", "", "Server Port")
+	flagRequestTimeout = c.NewFlag("gateway-request-timeout", "", "Longest duration of a unary call through the gateway")
+	flagMaxBodyBytes   = c.NewFlag("gateway-max-body-bytes", "", "Largest request body the gateway accepts")
+)
+
+func (s *ServerConfig) AddFromFlags() {
+	c.SetFlagValue(&s.Host, flagServerHost)
+	c.SetFlagValue(&s.Port, flagServerPort)
+	c.SetFlagValue(&s.RequestTimeout, flagRequestTimeout)
+	c.SetFlagValue(&s.MaxBodyBytes, flagMaxBodyBytes)
+}
+
+func (s *ServerConfig) GetAddress() string {
+	return s.Host + ":" + strconv.Itoa(s.Port)
+}
@@ -0,0 +1,52 @@
+package config
+
+import (
+	a "github.com/BwezB/Wikno-backend/pkg/auth"
+	c "github.com/BwezB/Wikno-backend/pkg/configs"
+	e "github.com/BwezB/Wikno-backend/pkg/errors"
+	g "github.com/BwezB/Wikno-backend/pkg/graph"
+	l "github.com/BwezB/Wikno-backend/pkg/log"
+
+	"github.com/BwezB/Wikno-backend/internal/gateway"
+	"github.com/go-playground/validator/v10"
+)
+
+type GatewayConfig struct {
+	c.Common `yaml:",inline"`
+	Server   gateway.ServerConfig
+	Logger   l.LoggerConfig
+	Auth     a.AuthConfig
+	Graph    g.GraphConfig
+}
+
+func New(validator *validator.Validate) (*GatewayConfig, error) {
+	gatewayConfig := &GatewayConfig{}
+	if err := c.LoadValidatedConfig(gatewayConfig, validator); err != nil {
+		return nil, e.Wrap("Failed to load config", err)
+	}
+	return gatewayConfig, nil
+}
+
+func (a *GatewayConfig) SetDefaults() {
+	a.Common.SetDefaults()
+	a.Server.SetDefaults()
+	a.Logger.SetDefaults()
+	a.Auth.SetDefaults()
+	a.Graph.SetDefaults()
+}
+
+func (a *GatewayConfig) AddFromEnv() {
+	a.Common.AddFromEnv()
+	a.Server.AddFromEnv()
+	a.Logger.AddFromEnv()
+	a.Auth.AddFromEnv()
+	a.Graph.AddFromEnv()
+}
+
+func (a *GatewayConfig) AddFromFlags() {
+	a.Common.AddFromFlags()
+	a.Server.AddFromFlags()
+	a.Logger.AddFromFlags()
+	a.Auth.AddFromFlags()
+	a.Graph.AddFromFlags()
+}
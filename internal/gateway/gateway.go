@@ -0,0 +1,282 @@
+// Package gateway serves the gRPC services as HTTP/JSON, for browser and script clients.
+// Every RPC is mapped to an HTTP route, and an OpenAPI document of the routes is served at /openapi.json.
+package gateway
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	e "github.com/BwezB/Wikno-backend/pkg/errors"
+	l "github.com/BwezB/Wikno-backend/pkg/log"
+	r "github.com/BwezB/Wikno-backend/pkg/requestid"
+)
+
+// forwardedHeaders are the HTTP headers passed to the gRPC services as metadata of the same (lower case) name
+var forwardedHeaders = []string{"authorization", "workspace-id"}
+
+// Route maps an HTTP method and path to a gRPC method
+type Route struct {
+	Method string // HTTP method
+	Path   string // URL path, where each {field} wildcard sets the field of the request message it is named after
+	RPC    string // Name of the gRPC method
+}
+
+// Service is a gRPC service served by the gateway, with a route for each of its methods
+type Service struct {
+	Name       string // Tag of the routes in the OpenAPI document
+	Descriptor protoreflect.ServiceDescriptor
+	Conn       grpc.ClientConnInterface
+	Routes     []Route
+}
+
+// binding is a route bound to the gRPC method it calls
+type binding struct {
+	service    string
+	route      Route
+	method     protoreflect.MethodDescriptor
+	pathFields []string
+	conn       grpc.ClientConnInterface
+}
+
+type Gateway struct {
+	server   *http.Server
+	bindings []*binding
+	config   ServerConfig
+}
+
+func New(config ServerConfig, services ...Service) (*Gateway, error) {
+	l.Debug("Creating gateway")
+	bindings, err := bind(services)
+	if err != nil {
+		return nil, e.Wrap("failed to bind routes", err)
+	}
+	openAPI, err := buildOpenAPI(bindings)
+	if err != nil {
+		return nil, e.Wrap("failed to build OpenAPI document", err)
+	}
+
+	gateway := &Gateway{
+		bindings: bindings,
+		config:   config,
+	}
+
+	mux := http.NewServeMux()
+	for _, b := range bindings {
+		mux.HandleFunc(b.route.Method+" "+b.route.Path, gateway.handle(b))
+	}
+	mux.HandleFunc("GET /openapi.json", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(openAPI)
+	})
+
+	gateway.server = &http.Server{
+		Addr:              config.GetAddress(),
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	return gateway, nil
+}
+
+func (g *Gateway) Serve() {
+	l.Info("Starting gateway", l.String("address", g.server.Addr), l.Int("routes", len(g.bindings)))
+	go func() {
+		if err := g.server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			l.Error("Gateway server error", l.ErrField(err))
+		}
+	}()
+}
+
+// Handler returns the handler of the gateway's routes, to serve them on a listener of the caller's
+func (g *Gateway) Handler() http.Handler {
+	return g.server.Handler
+}
+
+func (g *Gateway) Shutdown(ctx context.Context) error {
+	l.Info("Shutting down gateway")
+	return g.server.Shutdown(ctx)
+}
+
+// OpenAPI returns the OpenAPI document of the routes of the services
+func OpenAPI(services ...Service) ([]byte, error) {
+	bindings, err := bind(services)
+	if err != nil {
+		return nil, e.Wrap("failed to bind routes", err)
+	}
+	return buildOpenAPI(bindings)
+}
+
+// HANDLERS
+
+// handle calls the gRPC method of a binding with the request message decoded from the HTTP request
+func (g *Gateway) handle(b *binding) http.HandlerFunc {
+	fullMethod := "/" + string(b.method.Parent().FullName()) + "/" + string(b.method.Name())
+	return func(w http.ResponseWriter, req *http.Request) {
+		ctx := r.WithRequestID(req.Context(), uuid.New().String())
+		l.Debug("Gateway request",
+			l.String("method", req.Method),
+			l.String("path", req.URL.Path),
+			l.String("rpc", fullMethod),
+			l.String("request_id", r.GetRequestID(ctx)))
+
+		in, err := decodeRequest(req, b.method.Input(), b.pathFields, g.config.MaxBodyBytes)
+		if err != nil {
+			writeError(w, status.Error(codes.InvalidArgument, err.Error()))
+			return
+		}
+		ctx = forwardMetadata(ctx, req)
+
+		if b.method.IsStreamingServer() {
+			g.stream(ctx, w, b, fullMethod, in)
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(ctx, g.config.RequestTimeout)
+		defer cancel()
+		out := dynamicpb.NewMessage(b.method.Output())
+		if err := b.conn.Invoke(ctx, fullMethod, in, out); err != nil {
+			l.Debug("Gateway call failed", l.ErrField(err), l.String("request_id", r.GetRequestID(ctx)))
+			writeError(w, err)
+			return
+		}
+		writeMessage(w, http.StatusOK, out)
+	}
+}
+
+// stream calls a server streaming method, and writes the messages as newline delimited JSON as they arrive.
+// An error after the first message is written as a last line of the form {"error": <status>}.
+func (g *Gateway) stream(ctx context.Context, w http.ResponseWriter, b *binding, fullMethod string, in interface{}) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := b.conn.NewStream(ctx, &grpc.StreamDesc{ServerStreams: true}, fullMethod)
+	if err == nil {
+		err = stream.SendMsg(in)
+	}
+	if err == nil {
+		err = stream.CloseSend()
+	}
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	controller := http.NewResponseController(w)
+	for started := false; ; started = true {
+		out := dynamicpb.NewMessage(b.method.Output())
+		err := stream.RecvMsg(out)
+		if errors.Is(err, io.EOF) {
+			return
+		}
+		if err != nil {
+			if !started {
+				writeError(w, err)
+			} else if ctx.Err() == nil {
+				data, _ := marshalOptions.Marshal(status.Convert(err).Proto())
+				w.Write([]byte(`{"error":` + string(data) + "}\n"))
+			}
+			return
+		}
+
+		data, err := marshalOptions.Marshal(out)
+		if err != nil {
+			return
+		}
+		if !started {
+			w.Header().Set("Content-Type", "application/x-ndjson")
+			w.WriteHeader(http.StatusOK)
+		}
+		if _, err := w.Write(append(data, '\n')); err != nil {
+			return // The client went away
+		}
+		controller.Flush()
+	}
+}
+
+// HELPER FUNCTIONS
+
+// bind binds the routes of the services to their gRPC methods. Every method must have exactly one route,
+// so the gateway cannot silently fall behind the proto files.
+func bind(services []Service) ([]*binding, error) {
+	var bindings []*binding
+	for _, service := range services {
+		methods := service.Descriptor.Methods()
+		routed := make(map[protoreflect.Name]bool, methods.Len())
+
+		for _, route := range service.Routes {
+			method := methods.ByName(protoreflect.Name(route.RPC))
+			if method == nil {
+				return nil, e.New("No gRPC method "+route.RPC+" in "+string(service.Descriptor.FullName()), ErrRouteMissing, nil)
+			}
+			if routed[method.Name()] {
+				return nil, e.New("More than one route for "+route.RPC, ErrRouteMissing, nil)
+			}
+			if method.IsStreamingClient() {
+				return nil, e.New("Client streaming method "+route.RPC+" cannot be routed", ErrRouteMissing, nil)
+			}
+			routed[method.Name()] = true
+
+			pathFields := pathWildcards(route.Path)
+			for _, name := range pathFields {
+				if method.Input().Fields().ByName(protoreflect.Name(name)) == nil {
+					return nil, e.New("Path wildcard "+name+" of "+route.RPC+" is not a request field", ErrRouteMissing, nil)
+				}
+			}
+
+			bindings = append(bindings, &binding{
+				service:    service.Name,
+				route:      route,
+				method:     method,
+				pathFields: pathFields,
+				conn:       service.Conn,
+			})
+		}
+
+		for i := 0; i < methods.Len(); i++ {
+			if !routed[methods.Get(i).Name()] {
+				return nil, e.New("No route for "+string(methods.Get(i).FullName()), ErrRouteMissing, nil)
+			}
+		}
+	}
+	return bindings, nil
+}
+
+// pathWildcards returns the names of the {wildcards} of a path
+func pathWildcards(path string) []string {
+	var wildcards []string
+	for _, segment := range strings.Split(path, "/") {
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			wildcards = append(wildcards, strings.Trim(segment, "{}"))
+		}
+	}
+	return wildcards
+}
+
+// forwardMetadata passes the forwarded headers of an HTTP request as outgoing gRPC metadata
+func forwardMetadata(ctx context.Context, req *http.Request) context.Context {
+	md := metadata.MD{}
+	for _, header := range forwardedHeaders {
+		if value := req.Header.Get(header); value != "" {
+			md.Set(header, value)
+		}
+	}
+
+	// The services see the gateway as the peer, so it passes the client IP for the ones that trust it.
+	// The client's own X-Forwarded-For header is replaced, as any client can set it to any address.
+	if client, _, err := net.SplitHostPort(req.RemoteAddr); err == nil {
+		md.Set("x-forwarded-for", client)
+	}
+	return metadata.NewOutgoingContext(ctx, md)
+}
@@ -0,0 +1,197 @@
+package gateway
+
+//go:generate go run ../../cmd/gateway openapi ../../api/openapi/wikno.openapi.json
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// statusSchema is the schema of error responses, a google.rpc.Status
+const statusSchema = "google.rpc.Status"
+
+// buildOpenAPI builds an OpenAPI 3 document of the bound routes, with a schema for every message they use
+func buildOpenAPI(bindings []*binding) ([]byte, error) {
+	schemas := map[string]interface{}{
+		statusSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"code":    map[string]interface{}{"type": "integer", "format": "int32"},
+				"message": map[string]interface{}{"type": "string"},
+				"details": map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "object"}},
+			},
+		},
+	}
+
+	paths := map[string]map[string]interface{}{}
+	for _, b := range bindings {
+		if paths[b.route.Path] == nil {
+			paths[b.route.Path] = map[string]interface{}{}
+		}
+		paths[b.route.Path][strings.ToLower(b.route.Method)] = operation(b, schemas)
+	}
+
+	document := map[string]interface{}{
+		"openapi": "3.0.3",
+		"info": map[string]interface{}{
+			"title":   "Wikno API",
+			"version": "v1",
+			"description": "HTTP/JSON gateway to the Wikno gRPC services. " +
+				"Send the token from /v1/auth/login in the Authorization header, and scope graph requests " +
+				"to a workspace with the Workspace-Id header. Errors are google.rpc.Status objects.",
+		},
+		"paths": paths,
+		"components": map[string]interface{}{
+			"schemas": schemas,
+			"securitySchemes": map[string]interface{}{
+				"token": map[string]interface{}{"type": "apiKey", "in": "header", "name": "Authorization"},
+			},
+		},
+		"security": []interface{}{map[string]interface{}{"token": []string{}}},
+	}
+	return json.MarshalIndent(document, "", "  ")
+}
+
+// operation describes the route of a binding
+func operation(b *binding, schemas map[string]interface{}) map[string]interface{} {
+	input := b.method.Input()
+	op := map[string]interface{}{
+		"operationId": b.service + "." + b.route.RPC,
+		"tags":        []string{b.service},
+	}
+
+	parameters := []interface{}{}
+	inPath := make(map[protoreflect.Name]bool)
+	for _, name := range b.pathFields {
+		field := input.Fields().ByName(protoreflect.Name(name))
+		inPath[field.Name()] = true
+		parameters = append(parameters, map[string]interface{}{
+			"name":     name,
+			"in":       "path",
+			"required": true,
+			"schema":   fieldSchema(field, schemas),
+		})
+	}
+
+	switch b.route.Method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch:
+		op["requestBody"] = map[string]interface{}{
+			"content": map[string]interface{}{
+				"application/json": map[string]interface{}{"schema": messageSchema(input, schemas)},
+			},
+		}
+	default:
+		for i := 0; i < input.Fields().Len(); i++ {
+			field := input.Fields().Get(i)
+			if inPath[field.Name()] || field.IsMap() {
+				continue
+			}
+			parameters = append(parameters, map[string]interface{}{
+				"name":   string(field.Name()),
+				"in":     "query",
+				"schema": fieldSchema(field, schemas),
+			})
+		}
+	}
+	if len(parameters) > 0 {
+		op["parameters"] = parameters
+	}
+
+	contentType := "application/json"
+	description := "OK"
+	if b.method.IsStreamingServer() {
+		contentType = "application/x-ndjson"
+		description = "A stream of messages, one JSON object per line"
+	}
+	op["responses"] = map[string]interface{}{
+		"200": map[string]interface{}{
+			"description": description,
+			"content": map[string]interface{}{
+				contentType: map[string]interface{}{"schema": messageSchema(b.method.Output(), schemas)},
+			},
+		},
+		"default": map[string]interface{}{
+			"description": "Error",
+			"content": map[string]interface{}{
+				"application/json": map[string]interface{}{"schema": schemaRef(statusSchema)},
+			},
+		},
+	}
+	return op
+}
+
+// messageSchema returns a reference to the schema of a message, adding it and the messages it uses to the schemas
+func messageSchema(message protoreflect.MessageDescriptor, schemas map[string]interface{}) map[string]interface{} {
+	name := string(message.FullName())
+	if _, ok := schemas[name]; ok {
+		return schemaRef(name)
+	}
+
+	properties := map[string]interface{}{}
+	schema := map[string]interface{}{"type": "object", "properties": properties}
+	schemas[name] = schema // Before the fields, so recursive messages end
+	for i := 0; i < message.Fields().Len(); i++ {
+		field := message.Fields().Get(i)
+		properties[string(field.Name())] = fieldSchema(field, schemas)
+	}
+	return schemaRef(name)
+}
+
+// fieldSchema returns the schema of a field, as encoded by protojson
+func fieldSchema(field protoreflect.FieldDescriptor, schemas map[string]interface{}) map[string]interface{} {
+	if field.IsMap() {
+		return map[string]interface{}{
+			"type":                 "object",
+			"additionalProperties": fieldSchema(field.MapValue(), schemas),
+		}
+	}
+
+	var schema map[string]interface{}
+	switch field.Kind() {
+	case protoreflect.BoolKind:
+		schema = map[string]interface{}{"type": "boolean"}
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		schema = map[string]interface{}{"type": "integer", "format": "int32"}
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		schema = map[string]interface{}{"type": "integer", "format": "int64", "minimum": 0}
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind,
+		protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		schema = map[string]interface{}{"type": "string", "format": "int64"} // protojson encodes 64 bit integers as strings
+	case protoreflect.FloatKind:
+		schema = map[string]interface{}{"type": "number", "format": "float"}
+	case protoreflect.DoubleKind:
+		schema = map[string]interface{}{"type": "number", "format": "double"}
+	case protoreflect.BytesKind:
+		schema = map[string]interface{}{"type": "string", "format": "byte"}
+	case protoreflect.EnumKind:
+		values := field.Enum().Values()
+		names := make([]string, values.Len())
+		for i := range names {
+			names[i] = string(values.Get(i).Name())
+		}
+		schema = map[string]interface{}{"type": "string", "enum": names}
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		switch field.Message().FullName() {
+		case "google.protobuf.Timestamp":
+			schema = map[string]interface{}{"type": "string", "format": "date-time"}
+		case "google.protobuf.Duration":
+			schema = map[string]interface{}{"type": "string", "example": "1.5s"}
+		default:
+			schema = messageSchema(field.Message(), schemas)
+		}
+	default:
+		schema = map[string]interface{}{"type": "string"}
+	}
+
+	if field.IsList() {
+		return map[string]interface{}{"type": "array", "items": schema}
+	}
+	return schema
+}
+
+func schemaRef(name string) map[string]interface{} {
+	return map[string]interface{}{"$ref": "#/components/schemas/" + name}
+}
@@ -0,0 +1,120 @@
+package gateway
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// marshalOptions encode responses with the field names of the proto files, and with every field,
+// so clients do not have to know the proto3 defaults
+var marshalOptions = protojson.MarshalOptions{
+	UseProtoNames:   true,
+	EmitUnpopulated: true,
+}
+
+// decodeRequest builds the request message of a route from an HTTP request. The JSON body sets the fields
+// of POST, PUT and PATCH requests, the query parameters those of the other requests, and the path wildcards
+// always set the fields they are named after.
+func decodeRequest(req *http.Request, desc protoreflect.MessageDescriptor, pathFields []string, maxBodyBytes int) (proto.Message, error) {
+	msg := dynamicpb.NewMessage(desc)
+
+	params := make(map[string][]string)
+	switch req.Method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch:
+		body, err := io.ReadAll(http.MaxBytesReader(nil, req.Body, int64(maxBodyBytes)))
+		if err != nil {
+			return nil, fmt.Errorf("could not read body: %w", err)
+		}
+		if len(bytes.TrimSpace(body)) > 0 {
+			if err := protojson.Unmarshal(body, msg); err != nil {
+				return nil, fmt.Errorf("invalid body: %w", err)
+			}
+		}
+	default:
+		for name, values := range req.URL.Query() {
+			params[name] = values
+		}
+	}
+	for _, name := range pathFields {
+		params[name] = []string{req.PathValue(name)}
+	}
+
+	if err := setFields(msg, params); err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
+
+// setFields sets the fields named by parameters, by their proto or JSON name, to the parameter values.
+// The values are decoded like JSON string values, so any scalar, enum or well-known type like
+// google.protobuf.Timestamp can be set. Repeated fields take all values of a parameter.
+func setFields(msg *dynamicpb.Message, params map[string][]string) error {
+	if len(params) == 0 {
+		return nil
+	}
+
+	fields := msg.Descriptor().Fields()
+	object := make(map[string]interface{}, len(params))
+	for name, values := range params {
+		field := fields.ByName(protoreflect.Name(name))
+		if field == nil {
+			field = fields.ByJSONName(name)
+		}
+		if field == nil || field.IsMap() {
+			return fmt.Errorf("unknown parameter %q", name)
+		}
+
+		decoded := make([]interface{}, len(values))
+		for i, value := range values {
+			decoded[i] = value
+			if field.Kind() == protoreflect.BoolKind {
+				b, err := strconv.ParseBool(value)
+				if err != nil {
+					return fmt.Errorf("invalid boolean parameter %q", name)
+				}
+				decoded[i] = b
+			}
+		}
+
+		switch {
+		case field.IsList():
+			object[string(field.Name())] = decoded
+		case len(decoded) == 1:
+			object[string(field.Name())] = decoded[0]
+		default:
+			return fmt.Errorf("parameter %q given more than once", name)
+		}
+	}
+
+	data, err := json.Marshal(object)
+	if err != nil {
+		return err
+	}
+	fromParams := dynamicpb.NewMessage(msg.Descriptor())
+	if err := protojson.Unmarshal(data, fromParams); err != nil {
+		return fmt.Errorf("invalid parameters: %w", err)
+	}
+	proto.Merge(msg, fromParams)
+	return nil
+}
+
+// writeMessage writes a message as a JSON response
+func writeMessage(w http.ResponseWriter, httpStatus int, msg proto.Message) {
+	data, err := marshalOptions.Marshal(msg)
+	if err != nil {
+		http.Error(w, `{"code":13,"message":"Could not encode response"}`, http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(httpStatus)
+	w.Write(data)
+}
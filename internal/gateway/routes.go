@@ -0,0 +1,122 @@
+package gateway
+
+import (
+	"net/http"
+
+	"google.golang.org/grpc"
+
+	auditpb "github.com/BwezB/Wikno-backend/api/proto/audit"
+	authpb "github.com/BwezB/Wikno-backend/api/proto/auth"
+	graphpb "github.com/BwezB/Wikno-backend/api/proto/graph"
+)
+
+// authRoutes map the AuthService
+var authRoutes = []Route{
+	{http.MethodPost, "/v1/auth/register", "Register"},
+	{http.MethodPost, "/v1/auth/login", "Login"},
+	{http.MethodPost, "/v1/auth/verify", "VerifyToken"},
+	{http.MethodGet, "/v1/profiles/{user_id}", "GetProfile"},
+	{http.MethodPut, "/v1/profile", "UpdateProfile"},
+	{http.MethodGet, "/v1/profiles", "BatchGetProfiles"},
+	{http.MethodGet, "/v1/auth/ping", "Ping"},
+}
+
+// graphRoutes map the GraphService
+var graphRoutes = []Route{
+	// Users
+	{http.MethodPost, "/v1/graph/users", "CreateUser"},
+	{http.MethodGet, "/v1/graph", "GetUserData"},
+
+	// Entities
+	{http.MethodPost, "/v1/entities", "CreateEntity"},
+	{http.MethodPut, "/v1/entities/{id}", "UpdateEntity"},
+	{http.MethodGet, "/v1/entities", "FindEntities"},
+	{http.MethodGet, "/v1/entities/{id}/versions", "GetEntityVersions"},
+	{http.MethodGet, "/v1/entities/{entity_id}/connections", "GetConnections"},
+	{http.MethodGet, "/v1/entities/{id}/merges", "ListMerges"},
+
+	// Connection types
+	{http.MethodPost, "/v1/connection-types", "CreateConnectionType"},
+	{http.MethodGet, "/v1/connection-types", "FindConnectionTypes"},
+	{http.MethodGet, "/v1/connection-types/{id}/versions", "GetConnectionTypeVersions"},
+
+	// Connections
+	{http.MethodPost, "/v1/connections", "CreateConnection"},
+	{http.MethodDelete, "/v1/connections/{id}", "DeleteConnection"},
+
+	// Property types
+	{http.MethodPost, "/v1/property-types", "CreatePropertyType"},
+	{http.MethodGet, "/v1/property-types", "FindPropertyTypes"},
+	{http.MethodGet, "/v1/property-types/{id}/versions", "GetPropertyTypeVersions"},
+
+	// Entity classes
+	{http.MethodPost, "/v1/entity-classes", "CreateEntityClass"},
+	{http.MethodGet, "/v1/entity-classes", "FindEntityClasses"},
+	{http.MethodGet, "/v1/entity-classes/{id}/applicable-types", "GetApplicableTypes"},
+
+	// Shared nodes of any kind
+	{http.MethodPut, "/v1/nodes/{kind}/{id}/classes", "SetClasses"},
+	{http.MethodGet, "/v1/nodes/{kind}/{id}/classes", "GetClasses"},
+	{http.MethodPost, "/v1/nodes/{kind}/{id}/votes", "Vote"},
+	{http.MethodGet, "/v1/nodes/{kind}/{id}/revisions", "ListRevisions"},
+
+	// Merges
+	{http.MethodPost, "/v1/merges", "ProposeMerge"},
+	{http.MethodPost, "/v1/merges/{id}/accept", "AcceptMerge"},
+	{http.MethodPost, "/v1/splits", "SplitEntity"},
+
+	// Revisions
+	{http.MethodGet, "/v1/revisions/{id}", "GetRevision"},
+	{http.MethodPost, "/v1/revisions/{id}/revert", "RevertToRevision"},
+
+	// Workspaces
+	{http.MethodPost, "/v1/workspaces", "CreateWorkspace"},
+	{http.MethodGet, "/v1/workspaces", "ListWorkspaces"},
+	{http.MethodGet, "/v1/workspaces/{workspace_id}/members", "ListMembers"},
+	{http.MethodPost, "/v1/workspaces/{workspace_id}/invitations", "InviteMember"},
+	{http.MethodPut, "/v1/workspaces/{workspace_id}/members/{user_id}", "UpdateMemberRole"},
+	{http.MethodDelete, "/v1/workspaces/{workspace_id}/members/{user_id}", "RemoveMember"},
+	{http.MethodGet, "/v1/invitations", "ListInvitations"},
+	{http.MethodPost, "/v1/invitations/{id}/accept", "AcceptInvitation"},
+	{http.MethodPost, "/v1/invitations/{id}/decline", "DeclineInvitation"},
+
+	// Changes
+	{http.MethodGet, "/v1/graph/events", "WatchGraph"},
+	{http.MethodPost, "/v1/webhooks", "CreateWebhook"},
+	{http.MethodGet, "/v1/webhooks", "ListWebhooks"},
+	{http.MethodDelete, "/v1/webhooks/{webhook_id}", "DeleteWebhook"},
+	{http.MethodGet, "/v1/webhooks/{webhook_id}/deliveries", "ListWebhookDeliveries"},
+
+	{http.MethodGet, "/v1/graph/ping", "Ping"},
+}
+
+// Services returns the services served by the gateway, calling the auth and graph services over their connections
+func Services(authConn, graphConn grpc.ClientConnInterface) []Service {
+	auditService := auditpb.File_api_proto_audit_audit_proto.Services().ByName("AuditService")
+	return []Service{
+		{
+			Name:       "auth",
+			Descriptor: authpb.File_api_proto_auth_auth_proto.Services().ByName("AuthService"),
+			Conn:       authConn,
+			Routes:     authRoutes,
+		},
+		{
+			Name:       "graph",
+			Descriptor: graphpb.File_api_proto_graph_graph_proto.Services().ByName("GraphService"),
+			Conn:       graphConn,
+			Routes:     graphRoutes,
+		},
+		{
+			Name:       "auth-audit",
+			Descriptor: auditService,
+			Conn:       authConn,
+			Routes:     []Route{{http.MethodGet, "/v1/auth/audit-log", "QueryAuditLog"}},
+		},
+		{
+			Name:       "graph-audit",
+			Descriptor: auditService,
+			Conn:       graphConn,
+			Routes:     []Route{{http.MethodGet, "/v1/graph/audit-log", "QueryAuditLog"}},
+		},
+	}
+}
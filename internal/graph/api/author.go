@@ -0,0 +1,77 @@
+package api
+
+import (
+	"context"
+
+	l "github.com/BwezB/Wikno-backend/pkg/log"
+	r "github.com/BwezB/Wikno-backend/pkg/requestid"
+
+	pb "github.com/BwezB/Wikno-backend/api/proto/graph"
+)
+
+// AUTHORS
+
+// owned is a version or revision of the graph with the ID of the user it is by
+type owned interface {
+	*pb.UsersEntity | *pb.UsersConnectionType | *pb.UsersPropertyType | *pb.UsersEntityClass | *pb.Revision
+}
+
+// addAuthors sets the author of every message to the profile of its user, resolved by the auth service in batches.
+// Users without a profile, like workspaces, get no author.
+// The authors only decorate the response, so if they cannot be resolved the messages are returned without them.
+func addAuthors[T owned](ctx context.Context, s *Server, messages []T) {
+	if s.authService == nil || len(messages) == 0 {
+		return
+	}
+
+	seen := make(map[string]bool, len(messages))
+	var userIDs []string
+	for _, message := range messages {
+		userID := authorIDOf(message)
+		if userID != "" && !seen[userID] {
+			seen[userID] = true
+			userIDs = append(userIDs, userID)
+		}
+	}
+
+	profiles, err := s.authService.GetProfiles(ctx, userIDs)
+	if err != nil {
+		l.Warn("Failed to resolve authors, returning without them",
+			l.Int("users", len(userIDs)),
+			l.ErrField(err),
+			l.String("request_id", r.GetRequestID(ctx)))
+		return
+	}
+
+	for _, message := range messages {
+		profile, ok := profiles[authorIDOf(message)]
+		if !ok {
+			continue
+		}
+		author := &pb.Author{
+			UserId:      profile.GetUserId(),
+			DisplayName: profile.GetDisplayName(),
+			AvatarUrl:   profile.GetAvatarUrl(),
+		}
+		switch message := any(message).(type) {
+		case *pb.UsersEntity:
+			message.Author = author
+		case *pb.UsersConnectionType:
+			message.Author = author
+		case *pb.UsersPropertyType:
+			message.Author = author
+		case *pb.UsersEntityClass:
+			message.Author = author
+		case *pb.Revision:
+			message.Author = author
+		}
+	}
+}
+
+// authorIDOf returns the ID of the user a message is by: the owner of a version, the user that made a revision
+func authorIDOf[T owned](message T) string {
+	if revision, ok := any(message).(*pb.Revision); ok {
+		return revision.GetAuthorId()
+	}
+	return any(message).(interface{ GetUserId() string }).GetUserId()
+}
@@ -0,0 +1,194 @@
+package api
+
+import (
+	"context"
+
+	"github.com/BwezB/Wikno-backend/internal/graph/model"
+
+	e "github.com/BwezB/Wikno-backend/pkg/errors"
+	ge "github.com/BwezB/Wikno-backend/pkg/grpcerrors"
+	l "github.com/BwezB/Wikno-backend/pkg/log"
+	r "github.com/BwezB/Wikno-backend/pkg/requestid"
+
+	pb "github.com/BwezB/Wikno-backend/api/proto/graph"
+)
+
+// ENTITY CLASS METHODS
+
+func (s *Server) CreateEntityClass(ctx context.Context, req *pb.EntityClassRequest) (*pb.UsersEntityClass, error) {
+	l.Debug("Creating entity class",
+		l.String("name", req.GetName()),
+		l.String("request_id", r.GetRequestID(ctx)))
+
+	// Translate request
+	entityClassReq := &model.EntityClassRequest{
+		ID:         req.GetId(),
+		Name:       req.GetName(),
+		Definition: req.GetDefinition(),
+		ParentIDs:  req.GetParentIds(),
+	}
+
+	// Validate request
+	if err := s.validator.Struct(entityClassReq); err != nil {
+		return nil, translateToGrpcError(e.New("Request validation failed", ErrInvalidRequest, err))
+	}
+
+	// Create entity class
+	entityClass, err := s.service.CreateEntityClass(ctx, entityClassReq)
+	if err != nil {
+		l.Warn("Failed to create entity class:", l.ErrField(err))
+		return nil, translateToGrpcError(err)
+	}
+
+	return translateEntityClassToProto(entityClass), nil
+}
+
+func (s *Server) FindEntityClasses(ctx context.Context, req *pb.SearchRequest) (*pb.EntityClassesList, error) {
+	l.Debug("Finding entity classes",
+		l.String("name", req.GetName()),
+		l.String("request_id", r.GetRequestID(ctx)))
+
+	// Translate request
+	asOf, err := translateAsOf(req.GetAsOf())
+	if err != nil {
+		return nil, translateToGrpcError(err)
+	}
+	searchReq := &model.SearchRequest{
+		Name: req.GetName(),
+		AsOf: asOf,
+	}
+
+	// Validate request
+	if err := s.validator.Struct(searchReq); err != nil {
+		return nil, translateToGrpcError(e.New("Request validation failed", ErrInvalidRequest, err))
+	}
+
+	// Find entity classes
+	classes, err := s.service.FindEntityClasses(ctx, searchReq)
+	if err != nil {
+		l.Warn("Failed to find entity classes:", l.ErrField(err))
+		return nil, translateToGrpcError(err)
+	}
+
+	// Translate to protobuf response
+	response := &pb.EntityClassesList{
+		EntityClasses: translateEntityClassesToProto(classes),
+	}
+	addAuthors(ctx, s, response.EntityClasses)
+
+	return response, nil
+}
+
+func (s *Server) SetClasses(ctx context.Context, req *pb.ClassesRequest) (*pb.Empty, error) {
+	l.Debug("Setting classes",
+		l.String("kind", req.GetKind()),
+		l.String("id", req.GetId()),
+		l.String("request_id", r.GetRequestID(ctx)))
+
+	// Translate request
+	classesReq := &model.ClassesRequest{
+		NodeRequest: model.NodeRequest{
+			Kind: req.GetKind(),
+			ID:   req.GetId(),
+		},
+		ClassIDs: req.GetClassIds(),
+	}
+
+	// Validate request
+	if err := s.validator.Struct(classesReq); err != nil {
+		return nil, translateToGrpcError(e.New("Request validation failed", ErrInvalidRequest, err))
+	}
+
+	// Set classes
+	if err := s.service.SetClasses(ctx, classesReq); err != nil {
+		l.Warn("Failed to set classes:", l.ErrField(err))
+		return nil, translateToGrpcError(err)
+	}
+
+	return &pb.Empty{}, nil
+}
+
+func (s *Server) GetClasses(ctx context.Context, req *pb.NodeRequest) (*pb.EntityClassesList, error) {
+	l.Debug("Getting classes",
+		l.String("kind", req.GetKind()),
+		l.String("id", req.GetId()),
+		l.String("request_id", r.GetRequestID(ctx)))
+
+	// Translate request
+	nodeReq := &model.NodeRequest{
+		Kind: req.GetKind(),
+		ID:   req.GetId(),
+	}
+
+	// Validate request
+	if err := s.validator.Struct(nodeReq); err != nil {
+		return nil, translateToGrpcError(e.New("Request validation failed", ErrInvalidRequest, err))
+	}
+
+	// Get classes
+	classes, err := s.service.GetClasses(ctx, nodeReq)
+	if err != nil {
+		l.Warn("Failed to get classes:", l.ErrField(err))
+		return nil, translateToGrpcError(err)
+	}
+
+	// Translate to protobuf response
+	response := &pb.EntityClassesList{
+		EntityClasses: translateEntityClassesToProto(classes),
+	}
+	addAuthors(ctx, s, response.EntityClasses)
+
+	return response, nil
+}
+
+func (s *Server) GetApplicableTypes(ctx context.Context, req *pb.IdRequest) (*pb.ApplicableTypes, error) {
+	l.Debug("Getting applicable types",
+		l.String("id", req.GetId()),
+		l.String("request_id", r.GetRequestID(ctx)))
+
+	// Translate request
+	idReq := &model.IDRequest{
+		ID: req.GetId(),
+	}
+
+	// Validate request
+	if err := s.validator.Struct(idReq); err != nil {
+		return nil, translateToGrpcError(e.New("Request validation failed", ErrInvalidRequest, err))
+	}
+
+	// Get applicable types
+	types, err := s.service.GetApplicableTypes(ctx, idReq)
+	if err != nil {
+		l.Warn("Failed to get applicable types:", l.ErrField(err))
+		return nil, translateResourceError(err, &ge.Resource{Type: resourceEntityClass, Name: req.GetId()})
+	}
+
+	// Translate to protobuf response
+	response := &pb.ApplicableTypes{
+		ConnectionTypes: translateConnectionTypesToProto(types.ConnectionTypes),
+		PropertyTypes:   translatePropertyTypesToProto(types.PropertyTypes),
+	}
+
+	return response, nil
+}
+
+// HELPER FUNCTIONS
+
+func translateEntityClassToProto(entityClass *model.UsersEntityClass) *pb.UsersEntityClass {
+	return &pb.UsersEntityClass{
+		Name:          entityClass.Name,
+		Definition:    entityClass.Definition,
+		UserId:        entityClass.UserID,
+		EntityClassId: entityClass.EntityClassID,
+		Score:         int32(entityClass.Score),
+		UserCount:     int32(entityClass.UserCount),
+	}
+}
+
+func translateEntityClassesToProto(entityClasses []model.UsersEntityClass) []*pb.UsersEntityClass {
+	result := make([]*pb.UsersEntityClass, len(entityClasses))
+	for i := range entityClasses {
+		result[i] = translateEntityClassToProto(&entityClasses[i])
+	}
+	return result
+}
@@ -0,0 +1,127 @@
+package api
+
+import (
+	"context"
+
+	"github.com/BwezB/Wikno-backend/internal/graph/model"
+
+	e "github.com/BwezB/Wikno-backend/pkg/errors"
+	ge "github.com/BwezB/Wikno-backend/pkg/grpcerrors"
+	l "github.com/BwezB/Wikno-backend/pkg/log"
+	r "github.com/BwezB/Wikno-backend/pkg/requestid"
+
+	pb "github.com/BwezB/Wikno-backend/api/proto/graph"
+)
+
+// CONNECTION METHODS
+
+func (s *Server) CreateConnection(ctx context.Context, req *pb.ConnectionRequest) (*pb.Connection, error) {
+	l.Debug("Creating connection",
+		l.String("connection_type_id", req.GetConnectionTypeId()),
+		l.String("from_entity_id", req.GetFromEntityId()),
+		l.String("to_entity_id", req.GetToEntityId()),
+		l.String("request_id", r.GetRequestID(ctx)))
+
+	// Translate request
+	connectionReq := &model.ConnectionRequest{
+		ConnectionTypeID: req.GetConnectionTypeId(),
+		FromEntityID:     req.GetFromEntityId(),
+		ToEntityID:       req.GetToEntityId(),
+	}
+
+	// Validate request
+	if err := s.validator.Struct(connectionReq); err != nil {
+		return nil, translateToGrpcError(e.New("Request validation failed", ErrInvalidRequest, err))
+	}
+
+	// Create connection
+	connection, err := s.service.CreateConnection(ctx, connectionReq)
+	if err != nil {
+		l.Warn("Failed to create connection:", l.ErrField(err))
+		return nil, translateToGrpcError(err)
+	}
+
+	return translateConnectionToProto(connection), nil
+}
+
+func (s *Server) DeleteConnection(ctx context.Context, req *pb.IdRequest) (*pb.Empty, error) {
+	l.Debug("Deleting connection",
+		l.String("id", req.GetId()),
+		l.String("request_id", r.GetRequestID(ctx)))
+
+	// Translate request
+	idReq := &model.IDRequest{
+		ID: req.GetId(),
+	}
+
+	// Validate request
+	if err := s.validator.Struct(idReq); err != nil {
+		return nil, translateToGrpcError(e.New("Request validation failed", ErrInvalidRequest, err))
+	}
+
+	// Delete connection
+	if err := s.service.DeleteConnection(ctx, idReq); err != nil {
+		l.Warn("Failed to delete connection:", l.ErrField(err))
+		return nil, translateResourceError(err, &ge.Resource{Type: resourceConnection, Name: req.GetId()})
+	}
+
+	return &pb.Empty{}, nil
+}
+
+func (s *Server) GetConnections(ctx context.Context, req *pb.TraversalRequest) (*pb.ConnectionsList, error) {
+	l.Debug("Getting connections",
+		l.String("entity_id", req.GetEntityId()),
+		l.String("connection_type_id", req.GetConnectionTypeId()),
+		l.String("request_id", r.GetRequestID(ctx)))
+
+	// Translate request
+	asOf, err := translateAsOf(req.GetAsOf())
+	if err != nil {
+		return nil, translateToGrpcError(err)
+	}
+	traversalReq := &model.TraversalRequest{
+		EntityID:         req.GetEntityId(),
+		ConnectionTypeID: req.GetConnectionTypeId(),
+		AsOf:             asOf,
+	}
+
+	// Validate request
+	if err := s.validator.Struct(traversalReq); err != nil {
+		return nil, translateToGrpcError(e.New("Request validation failed", ErrInvalidRequest, err))
+	}
+
+	// Get connections
+	connections, err := s.service.GetConnections(ctx, traversalReq)
+	if err != nil {
+		l.Warn("Failed to get connections:", l.ErrField(err))
+		return nil, translateToGrpcError(err)
+	}
+
+	// Translate to protobuf response
+	response := &pb.ConnectionsList{
+		Connections: translateConnectionsToProto(connections),
+	}
+
+	return response, nil
+}
+
+// HELPER FUNCTIONS
+
+func translateConnectionToProto(connection *model.Connection) *pb.Connection {
+	return &pb.Connection{
+		Id:               connection.ID,
+		UserId:           connection.UserID,
+		ConnectionTypeId: connection.ConnectionTypeID,
+		FromEntityId:     connection.FromEntityID,
+		ToEntityId:       connection.ToEntityID,
+		Inferred:         connection.Inferred,
+	}
+}
+
+func translateConnectionsToProto(connections []model.Connection) []*pb.Connection {
+	result := make([]*pb.Connection, len(connections))
+	for i := range connections {
+		result[i] = translateConnectionToProto(&connections[i])
+	}
+	return result
+}
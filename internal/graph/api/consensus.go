@@ -0,0 +1,138 @@
+package api
+
+import (
+	"context"
+
+	"github.com/BwezB/Wikno-backend/internal/graph/model"
+
+	e "github.com/BwezB/Wikno-backend/pkg/errors"
+	ge "github.com/BwezB/Wikno-backend/pkg/grpcerrors"
+	l "github.com/BwezB/Wikno-backend/pkg/log"
+	r "github.com/BwezB/Wikno-backend/pkg/requestid"
+
+	pb "github.com/BwezB/Wikno-backend/api/proto/graph"
+)
+
+// CONSENSUS METHODS
+
+func (s *Server) Vote(ctx context.Context, req *pb.VoteRequest) (*pb.Empty, error) {
+	l.Debug("Voting on definition",
+		l.String("kind", req.GetKind()),
+		l.String("id", req.GetId()),
+		l.String("author_id", req.GetAuthorId()),
+		l.String("request_id", r.GetRequestID(ctx)))
+
+	// Translate request
+	voteReq := &model.VoteRequest{
+		Kind:     req.GetKind(),
+		ID:       req.GetId(),
+		AuthorID: req.GetAuthorId(),
+		Value:    int(req.GetValue()),
+	}
+
+	// Validate request
+	if err := s.validator.Struct(voteReq); err != nil {
+		return nil, translateToGrpcError(e.New("Request validation failed", ErrInvalidRequest, err))
+	}
+
+	// Vote
+	if err := s.service.Vote(ctx, voteReq); err != nil {
+		l.Warn("Failed to vote:", l.ErrField(err))
+		return nil, translateToGrpcError(err)
+	}
+
+	return &pb.Empty{}, nil
+}
+
+func (s *Server) GetEntityVersions(ctx context.Context, req *pb.IdRequest) (*pb.EntitiesList, error) {
+	l.Debug("Getting entity versions",
+		l.String("id", req.GetId()),
+		l.String("request_id", r.GetRequestID(ctx)))
+
+	// Translate request
+	idReq := &model.IDRequest{
+		ID: req.GetId(),
+	}
+
+	// Validate request
+	if err := s.validator.Struct(idReq); err != nil {
+		return nil, translateToGrpcError(e.New("Request validation failed", ErrInvalidRequest, err))
+	}
+
+	// Get versions
+	versions, err := s.service.GetEntityVersions(ctx, idReq)
+	if err != nil {
+		l.Warn("Failed to get entity versions:", l.ErrField(err))
+		return nil, translateResourceError(err, &ge.Resource{Type: resourceEntity, Name: req.GetId()})
+	}
+
+	// Translate to protobuf response
+	response := &pb.EntitiesList{
+		Entities: translateEntitiesToProto(versions),
+	}
+	addAuthors(ctx, s, response.Entities)
+
+	return response, nil
+}
+
+func (s *Server) GetConnectionTypeVersions(ctx context.Context, req *pb.IdRequest) (*pb.ConnectionTypesList, error) {
+	l.Debug("Getting connection type versions",
+		l.String("id", req.GetId()),
+		l.String("request_id", r.GetRequestID(ctx)))
+
+	// Translate request
+	idReq := &model.IDRequest{
+		ID: req.GetId(),
+	}
+
+	// Validate request
+	if err := s.validator.Struct(idReq); err != nil {
+		return nil, translateToGrpcError(e.New("Request validation failed", ErrInvalidRequest, err))
+	}
+
+	// Get versions
+	versions, err := s.service.GetConnectionTypeVersions(ctx, idReq)
+	if err != nil {
+		l.Warn("Failed to get connection type versions:", l.ErrField(err))
+		return nil, translateResourceError(err, &ge.Resource{Type: resourceConnectionType, Name: req.GetId()})
+	}
+
+	// Translate to protobuf response
+	response := &pb.ConnectionTypesList{
+		ConnectionTypes: translateConnectionTypesToProto(versions),
+	}
+	addAuthors(ctx, s, response.ConnectionTypes)
+
+	return response, nil
+}
+
+func (s *Server) GetPropertyTypeVersions(ctx context.Context, req *pb.IdRequest) (*pb.PropertyTypesList, error) {
+	l.Debug("Getting property type versions",
+		l.String("id", req.GetId()),
+		l.String("request_id", r.GetRequestID(ctx)))
+
+	// Translate request
+	idReq := &model.IDRequest{
+		ID: req.GetId(),
+	}
+
+	// Validate request
+	if err := s.validator.Struct(idReq); err != nil {
+		return nil, translateToGrpcError(e.New("Request validation failed", ErrInvalidRequest, err))
+	}
+
+	// Get versions
+	versions, err := s.service.GetPropertyTypeVersions(ctx, idReq)
+	if err != nil {
+		l.Warn("Failed to get property type versions:", l.ErrField(err))
+		return nil, translateResourceError(err, &ge.Resource{Type: resourcePropertyType, Name: req.GetId()})
+	}
+
+	// Translate to protobuf response
+	response := &pb.PropertyTypesList{
+		PropertyTypes: translatePropertyTypesToProto(versions),
+	}
+	addAuthors(ctx, s, response.PropertyTypes)
+
+	return response, nil
+}
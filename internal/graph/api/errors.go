@@ -2,10 +2,12 @@ package api
 
 import (
 	"github.com/BwezB/Wikno-backend/internal/graph/db"
+	"github.com/BwezB/Wikno-backend/internal/graph/model"
+	"github.com/BwezB/Wikno-backend/internal/graph/service"
 	e "github.com/BwezB/Wikno-backend/pkg/errors"
+	ge "github.com/BwezB/Wikno-backend/pkg/grpcerrors"
 
 	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
 )
 
 var (
@@ -15,8 +17,29 @@ var (
     ErrInternal = e.ErrInternal
 )
 
+// errorDomain is the domain of the ErrorInfo details of the errors
+const errorDomain = "graphservice"
+
+// Resource types of the ResourceInfo details of not found errors
+const (
+	resourceEntity         = model.KindEntity
+	resourceConnectionType = model.KindConnectionType
+	resourcePropertyType   = model.KindPropertyType
+	resourceEntityClass    = model.KindEntityClass
+	resourceConnection     = model.KindConnection
+	resourceMerge          = "merge"
+	resourceRevision       = "revision"
+	resourceWebhook        = "webhook"
+	resourceInvitation     = "invitation"
+)
+
 // translateIntoGrpcError translates an error into a gRPC error.
 func translateToGrpcError(err error) error {
+	return translateResourceError(err, nil)
+}
+
+// translateResourceError translates an error of a call about a resource into a gRPC error, with the resource if it was not found.
+func translateResourceError(err error, resource *ge.Resource) error {
 
 	if err == nil {
 		return nil
@@ -27,6 +50,31 @@ func translateToGrpcError(err error) error {
     var code codes.Code
 	var message string
     switch {
+    // Authorization errors, which can wrap the missing record that caused them
+    case e.Is(err, service.ErrPermissionDenied):
+        code = codes.PermissionDenied
+		message = "Permission denied"
+
+    // Constraint errors, which wrap the database errors below
+    case e.Is(err, db.ErrDuplicateVersion):
+        code = codes.AlreadyExists
+		message = "You already have a version of this node"
+    case e.Is(err, db.ErrDuplicateConnection):
+        code = codes.AlreadyExists
+		message = "Connection already exists"
+    case e.Is(err, db.ErrDuplicateMember):
+        code = codes.AlreadyExists
+		message = "User is already a member of the workspace"
+    case e.Is(err, db.ErrDuplicateInvitation):
+        code = codes.AlreadyExists
+		message = "User is already invited to the workspace"
+    case e.Is(err, db.ErrInvalidValueType):
+        code = codes.InvalidArgument
+		message = "Invalid property value type"
+    case e.Is(err, db.ErrInvalidRole):
+        code = codes.InvalidArgument
+		message = "Invalid workspace role"
+
     // Database errors
     case e.Is(err, db.ErrRecordNotFound):
         code = codes.NotFound
@@ -34,11 +82,31 @@ func translateToGrpcError(err error) error {
     case e.Is(err, db.ErrDuplicateEntry):
         code = codes.AlreadyExists
 		message = "Resource already exists"
+    case e.Is(err, db.ErrInvalidReference):
+        code = codes.InvalidArgument
+		message = "Referenced resource does not exist"
+    case e.Is(err, db.ErrCheckViolation):
+        code = codes.InvalidArgument
+		message = "Value is not allowed"
+    case e.Is(err, db.ErrTransactionConflict):
+        code = codes.Aborted
+		message = "Transaction conflict, try again"
     case e.Is(err, db.ErrDatabaseConnection):
         code = codes.Unavailable
 		message = "Database connection error"
 
+    // Watch errors
+    case e.Is(err, service.ErrResumeTokenExpired):
+        code = codes.FailedPrecondition
+		message = "Resume token expired"
+    case e.Is(err, service.ErrUnavailable):
+        code = codes.Unavailable
+		message = "Service unavailable"
+
     // General errors
+    case e.Is(err, e.ErrInvalidRequest):
+        code = codes.InvalidArgument
+		message = "Invalid request"
     case e.Is(err, e.ErrInvalidFunctionArgument):
         code = codes.InvalidArgument
 		message = "Invalid function argument"
@@ -51,5 +119,5 @@ func translateToGrpcError(err error) error {
 		message = "Unknown error"
     }
 
-	return status.Error(code, message)
+	return ge.Error(err, code, message, errorDomain, resource)
 }
@@ -0,0 +1,151 @@
+package api
+
+import (
+	"context"
+
+	"github.com/BwezB/Wikno-backend/internal/graph/model"
+
+	e "github.com/BwezB/Wikno-backend/pkg/errors"
+	ge "github.com/BwezB/Wikno-backend/pkg/grpcerrors"
+	l "github.com/BwezB/Wikno-backend/pkg/log"
+	r "github.com/BwezB/Wikno-backend/pkg/requestid"
+
+	pb "github.com/BwezB/Wikno-backend/api/proto/graph"
+)
+
+// MERGE METHODS
+
+func (s *Server) ProposeMerge(ctx context.Context, req *pb.MergeRequest) (*pb.Merge, error) {
+	l.Debug("Proposing entity merge",
+		l.String("source_id", req.GetSourceId()),
+		l.String("target_id", req.GetTargetId()),
+		l.String("request_id", r.GetRequestID(ctx)))
+
+	// Translate request
+	mergeReq := &model.MergeRequest{
+		SourceID: req.GetSourceId(),
+		TargetID: req.GetTargetId(),
+	}
+
+	// Validate request
+	if err := s.validator.Struct(mergeReq); err != nil {
+		return nil, translateToGrpcError(e.New("Request validation failed", ErrInvalidRequest, err))
+	}
+
+	// Propose merge
+	merge, err := s.service.ProposeMerge(ctx, mergeReq)
+	if err != nil {
+		l.Warn("Failed to propose merge:", l.ErrField(err))
+		return nil, translateToGrpcError(err)
+	}
+
+	return translateMergeToProto(merge), nil
+}
+
+func (s *Server) AcceptMerge(ctx context.Context, req *pb.MergeIdRequest) (*pb.Merge, error) {
+	l.Debug("Accepting entity merge",
+		l.String("merge_id", req.GetId()),
+		l.String("request_id", r.GetRequestID(ctx)))
+
+	// Translate request
+	idReq := &model.IDRequest{
+		ID: req.GetId(),
+	}
+
+	// Validate request
+	if err := s.validator.Struct(idReq); err != nil {
+		return nil, translateToGrpcError(e.New("Request validation failed", ErrInvalidRequest, err))
+	}
+
+	// Accept merge
+	merge, err := s.service.AcceptMerge(ctx, idReq)
+	if err != nil {
+		l.Warn("Failed to accept merge:", l.ErrField(err))
+		return nil, translateResourceError(err, &ge.Resource{Type: resourceMerge, Name: req.GetId()})
+	}
+
+	return translateMergeToProto(merge), nil
+}
+
+func (s *Server) SplitEntity(ctx context.Context, req *pb.SplitRequest) (*pb.Merge, error) {
+	l.Debug("Splitting entity",
+		l.String("merge_id", req.GetMergeId()),
+		l.String("entity_id", req.GetEntityId()),
+		l.String("request_id", r.GetRequestID(ctx)))
+
+	// Translate request
+	splitReq := &model.SplitRequest{
+		MergeID:  req.GetMergeId(),
+		EntityID: req.GetEntityId(),
+	}
+
+	// Validate request
+	if err := s.validator.Struct(splitReq); err != nil {
+		return nil, translateToGrpcError(e.New("Request validation failed", ErrInvalidRequest, err))
+	}
+
+	// Split entity
+	merge, err := s.service.SplitEntity(ctx, splitReq)
+	if err != nil {
+		l.Warn("Failed to split entity:", l.ErrField(err))
+		// Report the resource the split was requested by
+		resource := &ge.Resource{Type: resourceMerge, Name: req.GetMergeId()}
+		if req.GetEntityId() != "" {
+			resource = &ge.Resource{Type: resourceEntity, Name: req.GetEntityId()}
+		}
+		return nil, translateResourceError(err, resource)
+	}
+
+	return translateMergeToProto(merge), nil
+}
+
+func (s *Server) ListMerges(ctx context.Context, req *pb.IdRequest) (*pb.MergesList, error) {
+	l.Debug("Listing entity merges",
+		l.String("id", req.GetId()),
+		l.String("request_id", r.GetRequestID(ctx)))
+
+	// Translate request
+	idReq := &model.IDRequest{
+		ID: req.GetId(),
+	}
+
+	// Validate request
+	if err := s.validator.Struct(idReq); err != nil {
+		return nil, translateToGrpcError(e.New("Request validation failed", ErrInvalidRequest, err))
+	}
+
+	// List merges
+	merges, err := s.service.ListMerges(ctx, idReq)
+	if err != nil {
+		l.Warn("Failed to list merges:", l.ErrField(err))
+		return nil, translateResourceError(err, &ge.Resource{Type: resourceEntity, Name: req.GetId()})
+	}
+
+	// Translate to protobuf response
+	response := &pb.MergesList{
+		Merges: make([]*pb.Merge, len(merges)),
+	}
+	for i := range merges {
+		response.Merges[i] = translateMergeToProto(&merges[i])
+	}
+
+	return response, nil
+}
+
+// HELPER FUNCTIONS
+
+func translateMergeToProto(merge *model.EntityMerge) *pb.Merge {
+	response := &pb.Merge{
+		Id:              merge.ID,
+		SourceId:        merge.SourceID,
+		TargetId:        merge.TargetID,
+		ProposedBy:      merge.ProposedBy,
+		Status:          merge.Status,
+		MovedVersions:   int32(len(merge.Snapshot.Moved)),
+		DroppedVersions: int32(len(merge.Snapshot.Dropped)),
+	}
+	if merge.DecidedBy != nil {
+		response.DecidedBy = *merge.DecidedBy
+	}
+	return response
+}
@@ -0,0 +1,140 @@
+package api
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/BwezB/Wikno-backend/internal/graph/model"
+
+	e "github.com/BwezB/Wikno-backend/pkg/errors"
+	ge "github.com/BwezB/Wikno-backend/pkg/grpcerrors"
+	l "github.com/BwezB/Wikno-backend/pkg/log"
+	r "github.com/BwezB/Wikno-backend/pkg/requestid"
+
+	pb "github.com/BwezB/Wikno-backend/api/proto/graph"
+)
+
+// REVISION METHODS
+
+func (s *Server) ListRevisions(ctx context.Context, req *pb.RevisionsRequest) (*pb.RevisionsList, error) {
+	l.Debug("Listing revisions",
+		l.String("kind", req.GetKind()),
+		l.String("id", req.GetId()),
+		l.String("user_id", req.GetUserId()),
+		l.String("request_id", r.GetRequestID(ctx)))
+
+	// Translate request
+	revisionsReq := &model.RevisionsRequest{
+		NodeRequest: model.NodeRequest{
+			Kind: req.GetKind(),
+			ID:   req.GetId(),
+		},
+		UserID: req.GetUserId(),
+	}
+
+	// Validate request
+	if err := s.validator.Struct(revisionsReq); err != nil {
+		return nil, translateToGrpcError(e.New("Request validation failed", ErrInvalidRequest, err))
+	}
+
+	// List revisions
+	revisions, err := s.service.ListRevisions(ctx, revisionsReq)
+	if err != nil {
+		l.Warn("Failed to list revisions:", l.ErrField(err))
+		return nil, translateToGrpcError(err)
+	}
+
+	// Translate to protobuf response
+	response := &pb.RevisionsList{
+		Revisions: make([]*pb.Revision, len(revisions)),
+	}
+	for i := range revisions {
+		response.Revisions[i] = translateRevisionToProto(&revisions[i])
+	}
+	addAuthors(ctx, s, response.Revisions)
+
+	return response, nil
+}
+
+func (s *Server) GetRevision(ctx context.Context, req *pb.IdRequest) (*pb.Revision, error) {
+	l.Debug("Getting revision",
+		l.String("id", req.GetId()),
+		l.String("request_id", r.GetRequestID(ctx)))
+
+	// Translate request
+	idReq := &model.IDRequest{
+		ID: req.GetId(),
+	}
+
+	// Validate request
+	if err := s.validator.Struct(idReq); err != nil {
+		return nil, translateToGrpcError(e.New("Request validation failed", ErrInvalidRequest, err))
+	}
+
+	// Get revision
+	revision, err := s.service.GetRevision(ctx, idReq)
+	if err != nil {
+		l.Warn("Failed to get revision:", l.ErrField(err))
+		return nil, translateResourceError(err, &ge.Resource{Type: resourceRevision, Name: req.GetId()})
+	}
+
+	response := translateRevisionToProto(revision)
+	addAuthors(ctx, s, []*pb.Revision{response})
+
+	return response, nil
+}
+
+func (s *Server) RevertToRevision(ctx context.Context, req *pb.IdRequest) (*pb.Revision, error) {
+	l.Debug("Reverting to revision",
+		l.String("id", req.GetId()),
+		l.String("request_id", r.GetRequestID(ctx)))
+
+	// Translate request
+	idReq := &model.IDRequest{
+		ID: req.GetId(),
+	}
+
+	// Validate request
+	if err := s.validator.Struct(idReq); err != nil {
+		return nil, translateToGrpcError(e.New("Request validation failed", ErrInvalidRequest, err))
+	}
+
+	// Revert to revision
+	revision, err := s.service.RevertToRevision(ctx, idReq)
+	if err != nil {
+		l.Warn("Failed to revert to revision:", l.ErrField(err))
+		return nil, translateResourceError(err, &ge.Resource{Type: resourceRevision, Name: req.GetId()})
+	}
+
+	return translateRevisionToProto(revision), nil
+}
+
+// HELPER FUNCTIONS
+
+// translateAsOf translates the point in time of a read request, nil if it is not set
+func translateAsOf(asOf *timestamppb.Timestamp) (*time.Time, error) {
+	if asOf == nil {
+		return nil, nil
+	}
+	if err := asOf.CheckValid(); err != nil {
+		return nil, e.New("Invalid as_of timestamp", ErrInvalidRequest, err)
+	}
+	t := asOf.AsTime()
+	return &t, nil
+}
+
+func translateRevisionToProto(revision *model.Revision) *pb.Revision {
+	return &pb.Revision{
+		Id:         revision.ID,
+		Kind:       revision.Kind,
+		NodeId:     revision.NodeID,
+		UserId:     revision.UserID,
+		AuthorId:   revision.AuthorID,
+		Action:     revision.Action,
+		Name:       revision.Name,
+		Definition: revision.Definition,
+		CreatedAt:  timestamppb.New(revision.CreatedAt),
+	}
+}
@@ -3,17 +3,23 @@ package api
 import (
 	"context"
 	"net"
+	"net/http"
 
 	"github.com/BwezB/Wikno-backend/internal/graph/model"
 	"github.com/BwezB/Wikno-backend/internal/graph/service"
 	"github.com/go-playground/validator/v10"
 
 	a "github.com/BwezB/Wikno-backend/pkg/auth"
+	au "github.com/BwezB/Wikno-backend/pkg/audit"
+	ce "github.com/BwezB/Wikno-backend/pkg/certs"
 	r "github.com/BwezB/Wikno-backend/pkg/requestid"
 	e "github.com/BwezB/Wikno-backend/pkg/errors"
+	ge "github.com/BwezB/Wikno-backend/pkg/grpcerrors"
+	gw "github.com/BwezB/Wikno-backend/pkg/grpcweb"
 	h "github.com/BwezB/Wikno-backend/pkg/health"
 	l "github.com/BwezB/Wikno-backend/pkg/log"
 	m "github.com/BwezB/Wikno-backend/pkg/metrics"
+	rl "github.com/BwezB/Wikno-backend/pkg/ratelimit"
 
 	pb "github.com/BwezB/Wikno-backend/api/proto/graph"
 	"google.golang.org/grpc"
@@ -24,8 +30,10 @@ type Server struct {
 	GrpcServer    *grpc.Server
 	netListener   net.Listener
 	service       *service.GraphService
+	authService   *a.AuthService
 	validator     *validator.Validate
 	metricsServer *m.MetricsServer
+	grpcWebServer *gw.GrpcWebServer
 	healthServer  *h.GRPCHealthServer
 }
 
@@ -33,13 +41,15 @@ func NewServer(service *service.GraphService,
 	healthService *h.HealthService,
 	metrics *m.MetricsService,
 	authService *a.AuthService,
+	auditServer *au.Server,
 	validator *validator.Validate,
 	config ServerConfig) (*Server, error) {
 
 	l.Debug("Creating new server")
 	server := &Server{
-		service:   service,
-		validator: validator,
+		service:     service,
+		authService: authService,
+		validator:   validator,
 	}
 
 	l.Debug("Creating health server")
@@ -50,17 +60,46 @@ func NewServer(service *service.GraphService,
 	metricsServer := m.NewMetricsServer(metrics, config.Metrics)
 	server.metricsServer = metricsServer
 
+	l.Debug("Loading TLS credentials", l.String("mode", config.TLS.Mode))
+	tlsConfig, err := ce.ServerTLS(config.TLS)
+	if err != nil {
+		return nil, e.Wrap("failed to load TLS credentials", err)
+	}
+
+	l.Debug("Creating rate limiter")
+	limiter, err := rl.NewLimiter(config.RateLimit, metrics)
+	if err != nil {
+		return nil, e.Wrap("failed to create rate limiter", err)
+	}
+
 	l.Debug("Creating grpc server")
 	server.GrpcServer = grpc.NewServer(
+		grpc.Creds(ce.ServerCredentials(tlsConfig)),
 		grpc.ChainUnaryInterceptor(
 			r.UnaryRequestIDInterceptor,
 			m.MetricsInterceptor(metricsServer.MetricsService),
 			a.UnaryAuthInterceptor(authService, []string{"Ping"}),
+			rl.UnaryRateLimitInterceptor(limiter),
+			server.unaryWorkspaceInterceptor,
+		),
+		grpc.ChainStreamInterceptor(
+			r.StreamRequestIDInterceptor,
+			a.StreamAuthInterceptor(authService, []string{}),
+			rl.StreamRateLimitInterceptor(limiter),
+			server.streamWorkspaceInterceptor,
 		),
 	)
 	pb.RegisterGraphServiceServer(server.GrpcServer, server)
 	h.RegisterHealthServer(server.GrpcServer, healthServer)
+	au.RegisterServer(server.GrpcServer, auditServer)
 
+	l.Debug("Creating gRPC-Web server")
+	server.grpcWebServer = gw.NewGrpcWebServer(server.GrpcServer, config.GrpcWeb, tlsConfig)
+
+	if config.Listener != nil {
+		server.netListener = config.Listener
+		return server, nil
+	}
 	l.Debug("Creating net listener", l.String("address", config.GetAddress()))
 	lis, err := net.Listen("tcp", config.GetAddress())
 	if err != nil {
@@ -78,6 +117,9 @@ func (s *Server) Serve() {
 	l.Debug("Starting metrics server")
 	s.metricsServer.Serve()
 
+	l.Debug("Starting gRPC-Web server")
+	s.grpcWebServer.Serve()
+
 	l.Info("Starting gRPC server", l.String("address", s.netListener.Addr().String()))
 	go func() {
 		err := s.GrpcServer.Serve(s.netListener)
@@ -87,6 +129,11 @@ func (s *Server) Serve() {
 	}()
 }
 
+// GrpcWebHandler returns the handler of gRPC-Web calls, to serve them on a listener of the caller's
+func (s *Server) GrpcWebHandler() http.Handler {
+	return s.grpcWebServer.Handler()
+}
+
 func (s *Server) Shutdown(ctx context.Context) error {
 	l.Debug("Stopping health checks")
 	s.healthServer.Shutdown()
@@ -97,6 +144,15 @@ func (s *Server) Shutdown(ctx context.Context) error {
 		return e.Wrap("failed to shutdown metrics server", err)
 	}
 
+	l.Debug("Stopping gRPC-Web server")
+	err = s.grpcWebServer.Shutdown(ctx)
+	if err != nil {
+		return e.Wrap("failed to shutdown gRPC-Web server", err)
+	}
+
+	l.Debug("Ending watch streams")
+	s.service.Shutdown()
+
 	l.Info("Shutting down gRPC server")
 	s.GrpcServer.GracefulStop()
 	return nil
@@ -119,7 +175,7 @@ func (s *Server) CreateUser(ctx context.Context, req *pb.UserRequest) (*pb.Empty
 
 	// Validate request
 	if err := s.validator.Struct(userReq); err != nil {
-		return nil, e.New("Request validation failed", ErrInvalidRequest, err)
+		return nil, translateToGrpcError(e.New("Request validation failed", ErrInvalidRequest, err))
 	}
 
 	// Create user
@@ -132,11 +188,20 @@ func (s *Server) CreateUser(ctx context.Context, req *pb.UserRequest) (*pb.Empty
 	return &pb.Empty{}, nil
 }
 
-func (s *Server) GetUserData(ctx context.Context, _ *pb.Empty) (*pb.UserData, error) {
+func (s *Server) GetUserData(ctx context.Context, req *pb.UserDataRequest) (*pb.UserData, error) {
 	l.Debug("Getting user data", l.String("request_id", r.GetRequestID(ctx)))
 
+	// Translate request
+	asOf, err := translateAsOf(req.GetAsOf())
+	if err != nil {
+		return nil, translateToGrpcError(err)
+	}
+	userDataReq := &model.UserDataRequest{
+		AsOf: asOf,
+	}
+
 	// Get user data from service
-	userData, err := s.service.GetUserData(ctx)
+	userData, err := s.service.GetUserData(ctx, userDataReq)
 	if err != nil {
 		l.Warn("Failed to get user data:", l.ErrField(err))
 		return nil, translateToGrpcError(err)
@@ -144,7 +209,7 @@ func (s *Server) GetUserData(ctx context.Context, _ *pb.Empty) (*pb.UserData, er
 
 	// Validate the response
 	if err := s.validator.Struct(userData); err != nil {
-		return nil, e.New("Response validation failed", ErrInternal, err)
+		return nil, translateToGrpcError(e.New("Response validation failed", ErrInternal, err))
 	}
 
 	// Translate to protobuf response
@@ -152,6 +217,8 @@ func (s *Server) GetUserData(ctx context.Context, _ *pb.Empty) (*pb.UserData, er
 		Entities:        translateEntitiesToProto(userData.Entities),
 		ConnectionTypes: translateConnectionTypesToProto(userData.ConnectionTypes),
 		PropertyTypes:   translatePropertyTypesToProto(userData.PropertyTypes),
+		Connections:     translateConnectionsToProto(userData.Connections),
+		EntityClasses:   translateEntityClassesToProto(userData.EntityClasses),
 	}
 
 	return response, nil
@@ -173,7 +240,7 @@ func (s *Server) CreateEntity(ctx context.Context, req *pb.EntityRequest) (*pb.U
 
 	// Validate request
 	if err := s.validator.Struct(entityReq); err != nil {
-		return nil, e.New("Request validation failed", ErrInvalidRequest, err)
+		return nil, translateToGrpcError(e.New("Request validation failed", ErrInvalidRequest, err))
 	}
 
 	// Create entity
@@ -185,7 +252,7 @@ func (s *Server) CreateEntity(ctx context.Context, req *pb.EntityRequest) (*pb.U
 
 	// Validate response
 	if err := s.validator.Struct(entity); err != nil {
-		return nil, e.New("Response validation failed", ErrInternal, err)
+		return nil, translateToGrpcError(e.New("Response validation failed", ErrInternal, err))
 	}
 
 	// Translate to protobuf response
@@ -209,14 +276,14 @@ func (s *Server) UpdateEntity(ctx context.Context, req *pb.EntityRequest) (*pb.E
 
 	// Validate request
 	if err := s.validator.Struct(entityReq); err != nil {
-		return nil, e.New("Request validation failed", ErrInvalidRequest, err)
+		return nil, translateToGrpcError(e.New("Request validation failed", ErrInvalidRequest, err))
 	}
 
 	// Update entity
 	err := s.service.UpdateEntity(ctx, entityReq)
 	if err != nil {
 		l.Warn("Failed to update entity:", l.ErrField(err))
-		return nil, translateToGrpcError(err)
+		return nil, translateResourceError(err, &ge.Resource{Type: resourceEntity, Name: req.GetId()})
 	}
 
 	return &pb.Empty{}, nil
@@ -225,16 +292,23 @@ func (s *Server) UpdateEntity(ctx context.Context, req *pb.EntityRequest) (*pb.E
 func (s *Server) FindEntities(ctx context.Context, req *pb.SearchRequest) (*pb.EntitiesList, error) {
 	l.Debug("Finding entities",
 		l.String("name", req.GetName()),
+		l.String("class_id", req.GetClassId()),
 		l.String("request_id", r.GetRequestID(ctx)))
 
 	// Translate request
+	asOf, err := translateAsOf(req.GetAsOf())
+	if err != nil {
+		return nil, translateToGrpcError(err)
+	}
 	searchReq := &model.SearchRequest{
-		Name: req.GetName(),
+		Name:    req.GetName(),
+		ClassID: req.GetClassId(),
+		AsOf:    asOf,
 	}
 
 	// Validate request
 	if err := s.validator.Struct(searchReq); err != nil {
-		return nil, e.New("Request validation failed", ErrInvalidRequest, err)
+		return nil, translateToGrpcError(e.New("Request validation failed", ErrInvalidRequest, err))
 	}
 
 	// Find entities
@@ -248,6 +322,7 @@ func (s *Server) FindEntities(ctx context.Context, req *pb.SearchRequest) (*pb.E
 	response := &pb.EntitiesList{
 		Entities: translateEntitiesToProto(entities),
 	}
+	addAuthors(ctx, s, response.Entities)
 
 	return response, nil
 }
@@ -264,11 +339,16 @@ func (s *Server) CreateConnectionType(ctx context.Context, req *pb.ConnectionTyp
 		ID:         req.GetId(),
 		Name:       req.GetName(),
 		Definition: req.GetDefinition(),
+		InverseID:  req.GetInverseId(),
+		Symmetric:  req.GetSymmetric(),
+		Transitive: req.GetTransitive(),
+		DomainID:   req.GetDomainId(),
+		RangeID:    req.GetRangeId(),
 	}
 
 	// Validate request
 	if err := s.validator.Struct(connectionTypeReq); err != nil {
-		return nil, e.New("Request validation failed", ErrInvalidRequest, err)
+		return nil, translateToGrpcError(e.New("Request validation failed", ErrInvalidRequest, err))
 	}
 
 	// Create connection type
@@ -280,7 +360,7 @@ func (s *Server) CreateConnectionType(ctx context.Context, req *pb.ConnectionTyp
 
 	// Validate response
 	if err := s.validator.Struct(connectionType); err != nil {
-		return nil, e.New("Response validation failed", ErrInternal, err)
+		return nil, translateToGrpcError(e.New("Response validation failed", ErrInternal, err))
 	}
 
 	// Translate to protobuf response
@@ -295,13 +375,18 @@ func (s *Server) FindConnectionTypes(ctx context.Context, req *pb.SearchRequest)
 		l.String("request_id", r.GetRequestID(ctx)))
 
 	// Translate request
+	asOf, err := translateAsOf(req.GetAsOf())
+	if err != nil {
+		return nil, translateToGrpcError(err)
+	}
 	searchReq := &model.SearchRequest{
 		Name: req.GetName(),
+		AsOf: asOf,
 	}
 
 	// Validate request
 	if err := s.validator.Struct(searchReq); err != nil {
-		return nil, e.New("Request validation failed", ErrInvalidRequest, err)
+		return nil, translateToGrpcError(e.New("Request validation failed", ErrInvalidRequest, err))
 	}
 
 	// Find connection types
@@ -315,6 +400,7 @@ func (s *Server) FindConnectionTypes(ctx context.Context, req *pb.SearchRequest)
 	response := &pb.ConnectionTypesList{
 		ConnectionTypes: translateConnectionTypesToProto(connectionTypes),
 	}
+	addAuthors(ctx, s, response.ConnectionTypes)
 
 	return response, nil
 }
@@ -336,7 +422,7 @@ func (s *Server) CreatePropertyType(ctx context.Context, req *pb.PropertyTypeReq
 
 	// Validate request
 	if err := s.validator.Struct(propertyTypeReq); err != nil {
-		return nil, e.New("Request validation failed", ErrInvalidRequest, err)
+		return nil, translateToGrpcError(e.New("Request validation failed", ErrInvalidRequest, err))
 	}
 
 	// Create property type
@@ -348,7 +434,7 @@ func (s *Server) CreatePropertyType(ctx context.Context, req *pb.PropertyTypeReq
 
 	// Validate response
 	if err := s.validator.Struct(propertyType); err != nil {
-		return nil, e.New("Response validation failed", ErrInternal, err)
+		return nil, translateToGrpcError(e.New("Response validation failed", ErrInternal, err))
 	}
 
 	// Translate to protobuf response
@@ -363,13 +449,18 @@ func (s *Server) FindPropertyTypes(ctx context.Context, req *pb.SearchRequest) (
 		l.String("request_id", r.GetRequestID(ctx)))
 
 	// Translate request
+	asOf, err := translateAsOf(req.GetAsOf())
+	if err != nil {
+		return nil, translateToGrpcError(err)
+	}
 	searchReq := &model.SearchRequest{
 		Name: req.GetName(),
+		AsOf: asOf,
 	}
 
 	// Validate request
 	if err := s.validator.Struct(searchReq); err != nil {
-		return nil, e.New("Request validation failed", ErrInvalidRequest, err)
+		return nil, translateToGrpcError(e.New("Request validation failed", ErrInvalidRequest, err))
 	}
 
 	// Find property types
@@ -383,6 +474,7 @@ func (s *Server) FindPropertyTypes(ctx context.Context, req *pb.SearchRequest) (
 	response := &pb.PropertyTypesList{
 		PropertyTypes: translatePropertyTypesToProto(propertyTypes),
 	}
+	addAuthors(ctx, s, response.PropertyTypes)
 
 	return response, nil
 }
@@ -404,6 +496,8 @@ func translateEntityToProto(entity *model.UsersEntity) *pb.UsersEntity {
 		Definition: entity.Definition,
 		UserId:     entity.UserID,
 		EntityId:   entity.EntityID,
+		Score:      int32(entity.Score),
+		UserCount:  int32(entity.UserCount),
 	}
 }
 
@@ -416,12 +510,28 @@ func translateEntitiesToProto(entities []model.UsersEntity) []*pb.UsersEntity {
 }
 
 func translateConnectionTypeToProto(connectionType *model.UsersConnectionType) *pb.UsersConnectionType {
-	return &pb.UsersConnectionType{
+	response := &pb.UsersConnectionType{
 		Name:           connectionType.Name,
 		Definition:     connectionType.Definition,
 		UserId:         connectionType.UserID,
 		ConnectionTypeId: connectionType.ConnectionTypeID,
+		Score:          int32(connectionType.Score),
+		UserCount:      int32(connectionType.UserCount),
+	}
+	if semantics := connectionType.ConnectionType; semantics != nil {
+		response.Symmetric = semantics.Symmetric
+		response.Transitive = semantics.Transitive
+		if semantics.InverseID != nil {
+			response.InverseId = *semantics.InverseID
+		}
+		if semantics.DomainID != nil {
+			response.DomainId = *semantics.DomainID
+		}
+		if semantics.RangeID != nil {
+			response.RangeId = *semantics.RangeID
+		}
 	}
+	return response
 }
 
 func translateConnectionTypesToProto(connectionTypes []model.UsersConnectionType) []*pb.UsersConnectionType {
@@ -439,6 +549,8 @@ func translatePropertyTypeToProto(propertyType *model.PropertyTypeResponse) *pb.
 		UserId:       propertyType.UserID,
 		PropertyTypeId: propertyType.PropertyTypeID,
 		ValueType:    propertyType.ValueType,
+		Score:        int32(propertyType.Score),
+		UserCount:    int32(propertyType.UserCount),
 	}
 }
 
@@ -0,0 +1,67 @@
+package api
+
+import (
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/BwezB/Wikno-backend/internal/graph/model"
+
+	e "github.com/BwezB/Wikno-backend/pkg/errors"
+	l "github.com/BwezB/Wikno-backend/pkg/log"
+	r "github.com/BwezB/Wikno-backend/pkg/requestid"
+
+	pb "github.com/BwezB/Wikno-backend/api/proto/graph"
+)
+
+// WATCH METHODS
+
+func (s *Server) WatchGraph(req *pb.WatchRequest, stream pb.GraphService_WatchGraphServer) error {
+	ctx := stream.Context()
+	l.Debug("Watching graph",
+		l.Bool("resume", req.GetResumeToken() != ""),
+		l.Int("kinds", len(req.GetKinds())),
+		l.String("request_id", r.GetRequestID(ctx)))
+
+	// Translate request
+	watchReq := &model.WatchRequest{
+		ResumeToken: req.GetResumeToken(),
+		Kinds:       req.GetKinds(),
+	}
+
+	// Validate request
+	if err := s.validator.Struct(watchReq); err != nil {
+		return translateToGrpcError(e.New("Request validation failed", ErrInvalidRequest, err))
+	}
+
+	// Stream the changes
+	err := s.service.WatchGraph(ctx, watchReq, func(event *model.WatchEvent) error {
+		return stream.Send(translateWatchEventToProto(event))
+	})
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil // The client went away
+		}
+		l.Warn("Failed to watch graph:", l.ErrField(err))
+		return translateToGrpcError(err)
+	}
+
+	l.Debug("Graph watch ended", l.String("request_id", r.GetRequestID(ctx)))
+	return nil
+}
+
+// HELPER FUNCTIONS
+
+func translateWatchEventToProto(event *model.WatchEvent) *pb.GraphEvent {
+	return &pb.GraphEvent{
+		ResumeToken:      event.ResumeToken,
+		Action:           event.Action,
+		Kind:             event.Kind,
+		Id:               event.ID,
+		UserId:           event.UserID,
+		Name:             event.Name,
+		Definition:       event.Definition,
+		ConnectionTypeId: event.ConnectionTypeID,
+		FromEntityId:     event.FromEntityID,
+		ToEntityId:       event.ToEntityID,
+		CreatedAt:        timestamppb.New(event.CreatedAt),
+	}
+}
@@ -0,0 +1,147 @@
+package api
+
+import (
+	"context"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/BwezB/Wikno-backend/internal/graph/model"
+
+	e "github.com/BwezB/Wikno-backend/pkg/errors"
+	ge "github.com/BwezB/Wikno-backend/pkg/grpcerrors"
+	l "github.com/BwezB/Wikno-backend/pkg/log"
+	r "github.com/BwezB/Wikno-backend/pkg/requestid"
+
+	pb "github.com/BwezB/Wikno-backend/api/proto/graph"
+)
+
+// WEBHOOK METHODS
+
+func (s *Server) CreateWebhook(ctx context.Context, req *pb.WebhookRequest) (*pb.Webhook, error) {
+	l.Debug("Creating webhook",
+		l.String("url", req.GetUrl()),
+		l.String("request_id", r.GetRequestID(ctx)))
+
+	// Translate request
+	webhookReq := &model.WebhookRequest{
+		URL:   req.GetUrl(),
+		Kinds: req.GetKinds(),
+	}
+
+	// Validate request
+	if err := s.validator.Struct(webhookReq); err != nil {
+		return nil, translateToGrpcError(e.New("Request validation failed", ErrInvalidRequest, err))
+	}
+
+	// Create webhook
+	webhook, err := s.service.CreateWebhook(ctx, webhookReq)
+	if err != nil {
+		l.Warn("Failed to create webhook:", l.ErrField(err))
+		return nil, translateToGrpcError(err)
+	}
+
+	// The secret is only shown once
+	response := translateWebhookToProto(webhook)
+	response.Secret = webhook.Secret
+	return response, nil
+}
+
+func (s *Server) ListWebhooks(ctx context.Context, _ *pb.Empty) (*pb.WebhooksList, error) {
+	l.Debug("Listing webhooks", l.String("request_id", r.GetRequestID(ctx)))
+
+	// List webhooks
+	webhooks, err := s.service.ListWebhooks(ctx)
+	if err != nil {
+		l.Warn("Failed to list webhooks:", l.ErrField(err))
+		return nil, translateToGrpcError(err)
+	}
+
+	// Translate to protobuf response
+	response := &pb.WebhooksList{
+		Webhooks: make([]*pb.Webhook, len(webhooks)),
+	}
+	for i := range webhooks {
+		response.Webhooks[i] = translateWebhookToProto(&webhooks[i])
+	}
+
+	return response, nil
+}
+
+func (s *Server) DeleteWebhook(ctx context.Context, req *pb.WebhookIdRequest) (*pb.Empty, error) {
+	l.Debug("Deleting webhook",
+		l.String("webhook_id", req.GetWebhookId()),
+		l.String("request_id", r.GetRequestID(ctx)))
+
+	// Translate request
+	webhookReq := &model.WebhookIDRequest{
+		ID: req.GetWebhookId(),
+	}
+
+	// Validate request
+	if err := s.validator.Struct(webhookReq); err != nil {
+		return nil, translateToGrpcError(e.New("Request validation failed", ErrInvalidRequest, err))
+	}
+
+	// Delete webhook
+	if err := s.service.DeleteWebhook(ctx, webhookReq); err != nil {
+		l.Warn("Failed to delete webhook:", l.ErrField(err))
+		return nil, translateResourceError(err, &ge.Resource{Type: resourceWebhook, Name: req.GetWebhookId()})
+	}
+
+	return &pb.Empty{}, nil
+}
+
+func (s *Server) ListWebhookDeliveries(ctx context.Context, req *pb.WebhookDeliveriesRequest) (*pb.WebhookDeliveriesList, error) {
+	l.Debug("Listing webhook deliveries",
+		l.String("webhook_id", req.GetWebhookId()),
+		l.String("request_id", r.GetRequestID(ctx)))
+
+	// Translate request
+	deliveriesReq := &model.WebhookDeliveriesRequest{
+		WebhookID: req.GetWebhookId(),
+		Limit:     int(req.GetLimit()),
+	}
+
+	// Validate request
+	if err := s.validator.Struct(deliveriesReq); err != nil {
+		return nil, translateToGrpcError(e.New("Request validation failed", ErrInvalidRequest, err))
+	}
+
+	// List deliveries
+	deliveries, err := s.service.ListWebhookDeliveries(ctx, deliveriesReq)
+	if err != nil {
+		l.Warn("Failed to list webhook deliveries:", l.ErrField(err))
+		return nil, translateResourceError(err, &ge.Resource{Type: resourceWebhook, Name: req.GetWebhookId()})
+	}
+
+	// Translate to protobuf response
+	response := &pb.WebhookDeliveriesList{
+		Deliveries: make([]*pb.WebhookDelivery, len(deliveries)),
+	}
+	for i, delivery := range deliveries {
+		response.Deliveries[i] = &pb.WebhookDelivery{
+			Id:         delivery.ID,
+			EventId:    delivery.EventID,
+			Attempt:    int32(delivery.Attempt),
+			Outcome:    delivery.Outcome,
+			StatusCode: int32(delivery.StatusCode),
+			Error:      delivery.Error,
+			DurationMs: delivery.DurationMs,
+			CreatedAt:  timestamppb.New(delivery.CreatedAt),
+		}
+	}
+
+	return response, nil
+}
+
+// HELPER FUNCTIONS
+
+// translateWebhookToProto translates a webhook without its secret
+func translateWebhookToProto(webhook *model.Webhook) *pb.Webhook {
+	return &pb.Webhook{
+		Id:        webhook.ID,
+		Url:       webhook.URL,
+		Kinds:     webhook.Kinds,
+		CreatedAt: timestamppb.New(webhook.CreatedAt),
+	}
+}
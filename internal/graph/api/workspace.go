@@ -0,0 +1,362 @@
+package api
+
+import (
+	"context"
+
+	"github.com/BwezB/Wikno-backend/internal/graph/model"
+
+	a "github.com/BwezB/Wikno-backend/pkg/auth"
+	e "github.com/BwezB/Wikno-backend/pkg/errors"
+	ge "github.com/BwezB/Wikno-backend/pkg/grpcerrors"
+	l "github.com/BwezB/Wikno-backend/pkg/log"
+	r "github.com/BwezB/Wikno-backend/pkg/requestid"
+
+	pb "github.com/BwezB/Wikno-backend/api/proto/graph"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// workspaceRoles is the minimum workspace role needed for each method that can be scoped to a workspace.
+// Methods that are not listed ignore the "workspace-id" metadata.
+var workspaceRoles = map[string]string{
+	"/graph.GraphService/GetUserData":               model.RoleViewer,
+	"/graph.GraphService/FindEntities":              model.RoleViewer,
+	"/graph.GraphService/FindConnectionTypes":       model.RoleViewer,
+	"/graph.GraphService/FindPropertyTypes":         model.RoleViewer,
+	"/graph.GraphService/CreateEntity":              model.RoleEditor,
+	"/graph.GraphService/UpdateEntity":              model.RoleEditor,
+	"/graph.GraphService/CreateConnectionType":      model.RoleEditor,
+	"/graph.GraphService/CreatePropertyType":        model.RoleEditor,
+	"/graph.GraphService/GetConnections":            model.RoleViewer,
+	"/graph.GraphService/CreateConnection":          model.RoleEditor,
+	"/graph.GraphService/DeleteConnection":          model.RoleEditor,
+	"/graph.GraphService/Vote":                      model.RoleEditor,
+	"/graph.GraphService/GetEntityVersions":         model.RoleViewer,
+	"/graph.GraphService/GetConnectionTypeVersions": model.RoleViewer,
+	"/graph.GraphService/GetPropertyTypeVersions":   model.RoleViewer,
+	"/graph.GraphService/ProposeMerge":              model.RoleEditor,
+	"/graph.GraphService/AcceptMerge":               model.RoleEditor,
+	"/graph.GraphService/SplitEntity":               model.RoleEditor,
+	"/graph.GraphService/ListMerges":                model.RoleViewer,
+	"/graph.GraphService/CreateEntityClass":         model.RoleEditor,
+	"/graph.GraphService/FindEntityClasses":         model.RoleViewer,
+	"/graph.GraphService/SetClasses":                model.RoleEditor,
+	"/graph.GraphService/GetClasses":                model.RoleViewer,
+	"/graph.GraphService/GetApplicableTypes":        model.RoleViewer,
+	"/graph.GraphService/ListRevisions":             model.RoleViewer,
+	"/graph.GraphService/GetRevision":               model.RoleViewer,
+	"/graph.GraphService/RevertToRevision":          model.RoleEditor,
+	"/graph.GraphService/WatchGraph":                model.RoleViewer,
+	"/graph.GraphService/CreateWebhook":             model.RoleAdmin,
+	"/graph.GraphService/ListWebhooks":              model.RoleAdmin,
+	"/graph.GraphService/DeleteWebhook":             model.RoleAdmin,
+	"/graph.GraphService/ListWebhookDeliveries":     model.RoleAdmin,
+}
+
+// unaryWorkspaceInterceptor scopes requests to the workspace in the "workspace-id" metadata,
+// after checking that the authenticated user has the role the method needs.
+// It must run after the auth interceptor, as it needs the user ID in the context.
+func (s *Server) unaryWorkspaceInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	ctx, err := s.scopeToWorkspace(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// streamWorkspaceInterceptor is the stream version of unaryWorkspaceInterceptor
+func (s *Server) streamWorkspaceInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, err := s.scopeToWorkspace(ss.Context(), info.FullMethod)
+	if err != nil {
+		return err
+	}
+	return handler(srv, r.WrapServerStream(ss, ctx))
+}
+
+// scopeToWorkspace returns the context scoped to the workspace in the metadata, if the method can be scoped
+// and the user's role allows it
+func (s *Server) scopeToWorkspace(ctx context.Context, fullMethod string) (context.Context, error) {
+	required, scopable := workspaceRoles[fullMethod]
+	workspaceID := a.GetWorkspaceMetadata(ctx)
+	if !scopable || workspaceID == "" {
+		return ctx, nil
+	}
+
+	if err := s.validator.Var(workspaceID, "uuid"); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid workspace-id")
+	}
+
+	role, err := s.service.GetMemberRole(ctx, workspaceID)
+	if err != nil {
+		l.Warn("Workspace membership check failed",
+			l.String("workspace_id", workspaceID),
+			l.String("request_id", r.GetRequestID(ctx)),
+			l.ErrField(err))
+		return nil, translateToGrpcError(err)
+	}
+	if !model.HasRole(role, required) {
+		l.Warn("Workspace role too low",
+			l.String("workspace_id", workspaceID),
+			l.String("role", role),
+			l.String("required", required),
+			l.String("request_id", r.GetRequestID(ctx)))
+		return nil, status.Error(codes.PermissionDenied, "workspace role "+role+" cannot call this method")
+	}
+
+	return a.WithWorkspaceID(ctx, workspaceID), nil
+}
+
+// WORKSPACE METHODS
+
+func (s *Server) CreateWorkspace(ctx context.Context, req *pb.WorkspaceRequest) (*pb.Workspace, error) {
+	l.Debug("Creating workspace",
+		l.String("name", req.GetName()),
+		l.String("request_id", r.GetRequestID(ctx)))
+
+	// Translate request
+	workspaceReq := &model.WorkspaceRequest{
+		Name: req.GetName(),
+	}
+
+	// Validate request
+	if err := s.validator.Struct(workspaceReq); err != nil {
+		return nil, translateToGrpcError(e.New("Request validation failed", ErrInvalidRequest, err))
+	}
+
+	// Create workspace
+	workspace, err := s.service.CreateWorkspace(ctx, workspaceReq)
+	if err != nil {
+		l.Warn("Failed to create workspace:", l.ErrField(err))
+		return nil, translateToGrpcError(err)
+	}
+
+	return translateWorkspaceToProto(workspace), nil
+}
+
+func (s *Server) ListWorkspaces(ctx context.Context, _ *pb.Empty) (*pb.WorkspacesList, error) {
+	l.Debug("Listing workspaces", l.String("request_id", r.GetRequestID(ctx)))
+
+	// List workspaces
+	workspaces, err := s.service.ListWorkspaces(ctx)
+	if err != nil {
+		l.Warn("Failed to list workspaces:", l.ErrField(err))
+		return nil, translateToGrpcError(err)
+	}
+
+	// Translate to protobuf response
+	response := &pb.WorkspacesList{
+		Workspaces: make([]*pb.Workspace, len(workspaces)),
+	}
+	for i := range workspaces {
+		response.Workspaces[i] = translateWorkspaceToProto(&workspaces[i])
+	}
+
+	return response, nil
+}
+
+func (s *Server) ListMembers(ctx context.Context, req *pb.WorkspaceIdRequest) (*pb.MembersList, error) {
+	l.Debug("Listing workspace members",
+		l.String("workspace_id", req.GetWorkspaceId()),
+		l.String("request_id", r.GetRequestID(ctx)))
+
+	// Translate request
+	workspaceReq := &model.WorkspaceIDRequest{
+		WorkspaceID: req.GetWorkspaceId(),
+	}
+
+	// Validate request
+	if err := s.validator.Struct(workspaceReq); err != nil {
+		return nil, translateToGrpcError(e.New("Request validation failed", ErrInvalidRequest, err))
+	}
+
+	// List members
+	members, err := s.service.ListMembers(ctx, workspaceReq)
+	if err != nil {
+		l.Warn("Failed to list workspace members:", l.ErrField(err))
+		return nil, translateToGrpcError(err)
+	}
+
+	// Translate to protobuf response
+	response := &pb.MembersList{
+		Members: make([]*pb.Member, len(members)),
+	}
+	for i, member := range members {
+		response.Members[i] = &pb.Member{
+			UserId: member.UserID,
+			Role:   member.Role,
+		}
+	}
+
+	return response, nil
+}
+
+func (s *Server) InviteMember(ctx context.Context, req *pb.MemberRequest) (*pb.Invitation, error) {
+	l.Debug("Inviting workspace member",
+		l.String("workspace_id", req.GetWorkspaceId()),
+		l.String("invitee_id", req.GetUserId()),
+		l.String("request_id", r.GetRequestID(ctx)))
+
+	// Translate request
+	memberReq := translateMemberRequest(req)
+
+	// Validate request
+	if err := s.validator.Struct(memberReq); err != nil {
+		return nil, translateToGrpcError(e.New("Request validation failed", ErrInvalidRequest, err))
+	}
+
+	// Invite member
+	invitation, err := s.service.InviteMember(ctx, memberReq)
+	if err != nil {
+		l.Warn("Failed to invite workspace member:", l.ErrField(err))
+		return nil, translateToGrpcError(err)
+	}
+
+	return translateInvitationToProto(invitation), nil
+}
+
+func (s *Server) ListInvitations(ctx context.Context, _ *pb.Empty) (*pb.InvitationsList, error) {
+	l.Debug("Listing invitations", l.String("request_id", r.GetRequestID(ctx)))
+
+	// List invitations
+	invitations, err := s.service.ListInvitations(ctx)
+	if err != nil {
+		l.Warn("Failed to list invitations:", l.ErrField(err))
+		return nil, translateToGrpcError(err)
+	}
+
+	// Translate to protobuf response
+	response := &pb.InvitationsList{
+		Invitations: make([]*pb.Invitation, len(invitations)),
+	}
+	for i := range invitations {
+		response.Invitations[i] = translateInvitationToProto(&invitations[i])
+	}
+
+	return response, nil
+}
+
+func (s *Server) AcceptInvitation(ctx context.Context, req *pb.InvitationIdRequest) (*pb.Workspace, error) {
+	l.Debug("Accepting invitation",
+		l.String("invitation_id", req.GetId()),
+		l.String("request_id", r.GetRequestID(ctx)))
+
+	// Translate request
+	invitationReq := &model.InvitationIDRequest{
+		ID: req.GetId(),
+	}
+
+	// Validate request
+	if err := s.validator.Struct(invitationReq); err != nil {
+		return nil, translateToGrpcError(e.New("Request validation failed", ErrInvalidRequest, err))
+	}
+
+	// Accept invitation
+	workspace, err := s.service.AcceptInvitation(ctx, invitationReq)
+	if err != nil {
+		l.Warn("Failed to accept invitation:", l.ErrField(err))
+		return nil, translateResourceError(err, &ge.Resource{Type: resourceInvitation, Name: req.GetId()})
+	}
+
+	return translateWorkspaceToProto(workspace), nil
+}
+
+func (s *Server) DeclineInvitation(ctx context.Context, req *pb.InvitationIdRequest) (*pb.Empty, error) {
+	l.Debug("Declining invitation",
+		l.String("invitation_id", req.GetId()),
+		l.String("request_id", r.GetRequestID(ctx)))
+
+	// Translate request
+	invitationReq := &model.InvitationIDRequest{
+		ID: req.GetId(),
+	}
+
+	// Validate request
+	if err := s.validator.Struct(invitationReq); err != nil {
+		return nil, translateToGrpcError(e.New("Request validation failed", ErrInvalidRequest, err))
+	}
+
+	// Decline invitation
+	if err := s.service.DeclineInvitation(ctx, invitationReq); err != nil {
+		l.Warn("Failed to decline invitation:", l.ErrField(err))
+		return nil, translateResourceError(err, &ge.Resource{Type: resourceInvitation, Name: req.GetId()})
+	}
+
+	return &pb.Empty{}, nil
+}
+
+func (s *Server) UpdateMemberRole(ctx context.Context, req *pb.MemberRequest) (*pb.Empty, error) {
+	l.Debug("Updating workspace member role",
+		l.String("workspace_id", req.GetWorkspaceId()),
+		l.String("member_id", req.GetUserId()),
+		l.String("role", req.GetRole()),
+		l.String("request_id", r.GetRequestID(ctx)))
+
+	// Translate request
+	memberReq := translateMemberRequest(req)
+
+	// Validate request
+	if err := s.validator.Struct(memberReq); err != nil {
+		return nil, translateToGrpcError(e.New("Request validation failed", ErrInvalidRequest, err))
+	}
+
+	// Update member role
+	if err := s.service.UpdateMemberRole(ctx, memberReq); err != nil {
+		l.Warn("Failed to update workspace member role:", l.ErrField(err))
+		return nil, translateToGrpcError(err)
+	}
+
+	return &pb.Empty{}, nil
+}
+
+func (s *Server) RemoveMember(ctx context.Context, req *pb.MemberRequest) (*pb.Empty, error) {
+	l.Debug("Removing workspace member",
+		l.String("workspace_id", req.GetWorkspaceId()),
+		l.String("member_id", req.GetUserId()),
+		l.String("request_id", r.GetRequestID(ctx)))
+
+	// Translate request
+	memberReq := translateMemberRequest(req)
+
+	// Validate request
+	if err := s.validator.Struct(memberReq); err != nil {
+		return nil, translateToGrpcError(e.New("Request validation failed", ErrInvalidRequest, err))
+	}
+
+	// Remove member
+	if err := s.service.RemoveMember(ctx, memberReq); err != nil {
+		l.Warn("Failed to remove workspace member:", l.ErrField(err))
+		return nil, translateToGrpcError(err)
+	}
+
+	return &pb.Empty{}, nil
+}
+
+// HELPER FUNCTIONS
+
+func translateMemberRequest(req *pb.MemberRequest) *model.MemberRequest {
+	return &model.MemberRequest{
+		WorkspaceID: req.GetWorkspaceId(),
+		UserID:      req.GetUserId(),
+		Role:        req.GetRole(),
+	}
+}
+
+func translateWorkspaceToProto(workspace *model.WorkspaceResponse) *pb.Workspace {
+	return &pb.Workspace{
+		Id:   workspace.ID,
+		Name: workspace.Name,
+		Role: workspace.Role,
+	}
+}
+
+func translateInvitationToProto(invitation *model.InvitationResponse) *pb.Invitation {
+	return &pb.Invitation{
+		Id:            invitation.ID,
+		WorkspaceId:   invitation.WorkspaceID,
+		WorkspaceName: invitation.WorkspaceName,
+		InviterId:     invitation.InviterID,
+		InviteeId:     invitation.InviteeID,
+		Role:          invitation.Role,
+	}
+}
@@ -2,6 +2,7 @@ package config
 
 import (
 	a "github.com/BwezB/Wikno-backend/pkg/auth"
+	au "github.com/BwezB/Wikno-backend/pkg/audit"
 	c "github.com/BwezB/Wikno-backend/pkg/configs"
 	e "github.com/BwezB/Wikno-backend/pkg/errors"
 	h "github.com/BwezB/Wikno-backend/pkg/health"
@@ -9,6 +10,8 @@ import (
 
 	"github.com/BwezB/Wikno-backend/internal/graph/api"
 	"github.com/BwezB/Wikno-backend/internal/graph/db"
+	"github.com/BwezB/Wikno-backend/internal/graph/graphql"
+	"github.com/BwezB/Wikno-backend/internal/graph/service"
 	"github.com/go-playground/validator/v10"
 )
 
@@ -19,6 +22,9 @@ type GraphConfig struct {
 	Logger   l.LoggerConfig
 	Health   h.HealthServiceConfig
 	Auth     a.AuthConfig
+	Audit    au.AuditConfig
+	Service  service.ServiceConfig
+	GraphQL  graphql.ServerConfig
 }
 
 func New(validator *validator.Validate) (*GraphConfig, error) {
@@ -36,6 +42,9 @@ func (a *GraphConfig) SetDefaults() {
 	a.Logger.SetDefaults()
 	a.Health.SetDefaults()
 	a.Auth.SetDefaults()
+	a.Audit.SetDefaults()
+	a.Service.SetDefaults()
+	a.GraphQL.SetDefaults()
 }
 
 func (a *GraphConfig) AddFromEnv() {
@@ -45,6 +54,9 @@ func (a *GraphConfig) AddFromEnv() {
 	a.Logger.AddFromEnv()
 	a.Health.AddFromEnv()
 	a.Auth.AddFromEnv()
+	a.Audit.AddFromEnv()
+	a.Service.AddFromEnv()
+	a.GraphQL.AddFromEnv()
 }
 
 func (a *GraphConfig) AddFromFlags() {
@@ -54,4 +66,7 @@ func (a *GraphConfig) AddFromFlags() {
 	a.Logger.AddFromFlags()
 	a.Health.AddFromFlags()
 	a.Auth.AddFromFlags()
+	a.Audit.AddFromFlags()
+	a.Service.AddFromFlags()
+	a.GraphQL.AddFromFlags()
 }
@@ -0,0 +1,342 @@
+package db
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	"github.com/BwezB/Wikno-backend/internal/graph/model"
+
+	e "github.com/BwezB/Wikno-backend/pkg/errors"
+	l "github.com/BwezB/Wikno-backend/pkg/log"
+	r "github.com/BwezB/Wikno-backend/pkg/requestid"
+)
+
+// classLink describes the join table that links a kind of shared node to entity classes
+type classLink struct {
+	table       string // Join table
+	idColumn    string // Column referencing the shared node
+	classColumn string // Column referencing the class
+}
+
+var classLinks = map[string]classLink{
+	model.KindEntity:         {table: "entity_instances", idColumn: "entity_id", classColumn: "entity_class_id"},
+	model.KindEntityClass:    {table: "entity_class_parents", idColumn: "entity_class_id", classColumn: "parent_id"},
+	model.KindConnectionType: {table: "connection_type_classes", idColumn: "connection_type_id", classColumn: "entity_class_id"},
+	model.KindPropertyType:   {table: "property_type_classes", idColumn: "property_type_id", classColumn: "entity_class_id"},
+}
+
+// subclassesQuery selects the ID of a class and of every class below it in the hierarchy
+const subclassesQuery = `WITH RECURSIVE subclasses AS (
+		SELECT CAST(? AS uuid) AS id
+		UNION
+		SELECT p.entity_class_id FROM entity_class_parents p JOIN subclasses s ON p.parent_id = s.id
+	)
+	SELECT id FROM subclasses`
+
+// superclassesQuery selects the ID of a class and of every class above it in the hierarchy
+const superclassesQuery = `WITH RECURSIVE superclasses AS (
+		SELECT CAST(? AS uuid) AS id
+		UNION
+		SELECT p.parent_id FROM entity_class_parents p JOIN superclasses s ON p.entity_class_id = s.id
+	)
+	SELECT id FROM superclasses`
+
+// instancesOfClassQuery selects the IDs of the entities that are instances of a class, directly or through a subclass
+const instancesOfClassQuery = `SELECT entity_id FROM entity_instances WHERE entity_class_id IN (` + subclassesQuery + `)`
+
+// applicableQuery selects whether a connection type applies to an entity: types without classes apply to every entity,
+// the others to instances of their classes and of the subclasses of those
+const applicableQuery = `WITH RECURSIVE applicable AS (
+		SELECT entity_class_id AS id FROM connection_type_classes WHERE connection_type_id = @type
+		UNION
+		SELECT p.entity_class_id FROM entity_class_parents p JOIN applicable a ON p.parent_id = a.id
+	)
+	SELECT NOT EXISTS (SELECT 1 FROM connection_type_classes WHERE connection_type_id = @type)
+		OR EXISTS (SELECT 1 FROM entity_instances WHERE entity_id = @entity AND entity_class_id IN (SELECT id FROM applicable))`
+
+// CreateEntityClass creates a UsersEntityClass and creates an EntityClass with the requested parents if one does not already exist.
+func (db *Database) CreateEntityClass(ctx context.Context, req *model.EntityClassRequest) (*model.UsersEntityClass, error) {
+	// Get ID from context
+	ownerID := getOwnerID(ctx)
+	if ownerID == "" {
+		return nil, e.New("Failed to get owner ID from context", ErrInternal, nil)
+	}
+
+	l.Debug("Creating entity class",
+		l.String("owner_id", ownerID),
+		l.String("name", req.Name),
+		l.String("definition", req.Definition),
+		l.String("entity_class_id", req.ID),
+		l.String("request_id", r.GetRequestID(ctx)))
+
+	// Start transaction
+	tx := db.WithContext(ctx).Begin()
+	if tx.Error != nil {
+		return nil, e.Wrap("Could not start transaction", TranslateDatabaseError(tx.Error))
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	var entityClass model.EntityClass
+	if req.ID != "" {
+		// Check if entity class exists
+		if err := tx.First(&entityClass, "id = ?", req.ID).Error; err != nil {
+			tx.Rollback() // Even if ErrRecordNotFound, rollback (entity class must exist)
+			return nil, e.Wrap("Could not find entity class", TranslateDatabaseError(err))
+		}
+	} else {
+		// Create new entity class
+		entityClass = model.EntityClass{}
+		if err := tx.Create(&entityClass).Error; err != nil {
+			tx.Rollback()
+			return nil, e.Wrap("Could not create entity class", TranslateDatabaseError(err))
+		}
+		if err := setClasses(tx, model.KindEntityClass, entityClass.ID, req.ParentIDs); err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+	}
+
+	// Create user-entity class relationship
+	userEntityClass := model.UsersEntityClass{
+		UserID:        ownerID,
+		EntityClassID: entityClass.ID,
+		Name:          req.Name,
+		Definition:    req.Definition,
+	}
+	if err := tx.Create(&userEntityClass).Error; err != nil {
+		tx.Rollback()
+		return nil, e.Wrap("Could not create userEntityClass", TranslateDatabaseError(err))
+	}
+	if _, err := recordRevision(tx, model.KindEntityClass, model.RevisionCreate, ownerID, entityClass.ID, req.Name, req.Definition); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	// Commit transaction
+	if err := tx.Commit().Error; err != nil {
+		return nil, e.Wrap("Could not commit", TranslateDatabaseError(err))
+	}
+
+	return &userEntityClass, nil
+}
+
+// FindEntityClassesWithName finds the EntityClasses that have the given name written in the UsersEntityClass table.
+// Each entity class is returned in its canonical version, which may have a different name.
+// If a point in time is given, the versions are searched as they were then.
+func (db *Database) FindEntityClassesWithName(ctx context.Context, req *model.SearchRequest) ([]model.UsersEntityClass, error) {
+	l.Debug("Finding entity classes with name",
+		l.String("name", req.Name),
+		l.Bool("as_of", req.AsOf != nil),
+		l.String("request_id", r.GetRequestID(ctx)))
+
+	reader := db.reader()
+
+	query, args := canonicalVersionsWithNameQuery(ctx, model.KindEntityClass, req.AsOf, req.Name, "")
+	var userEntityClasses []model.UsersEntityClass
+	res := reader.WithContext(ctx).
+		Raw(query, args...).
+		Scan(&userEntityClasses)
+	if res.Error != nil {
+		return nil, e.Wrap("Failed to find entity classes with name", TranslateDatabaseError(res.Error))
+	}
+
+	return userEntityClasses, nil
+}
+
+// SetClasses replaces the classes of a shared node: the classes an entity is an instance of,
+// the parents of a class, or the classes a connection or property type applies to.
+func (db *Database) SetClasses(ctx context.Context, req *model.ClassesRequest) error {
+	l.Debug("Setting classes",
+		l.String("kind", req.Kind),
+		l.String("id", req.ID),
+		l.Int("classes", len(req.ClassIDs)),
+		l.String("request_id", r.GetRequestID(ctx)))
+
+	// Start transaction
+	tx := db.WithContext(ctx).Begin()
+	if tx.Error != nil {
+		return e.Wrap("Could not start transaction", TranslateDatabaseError(tx.Error))
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	var count int64
+	if err := tx.Table(sharedKinds[req.Kind].nodeTable).Where("id = ?", req.ID).Count(&count).Error; err != nil {
+		tx.Rollback()
+		return e.Wrap("Could not find node", TranslateDatabaseError(err))
+	}
+	if count == 0 {
+		tx.Rollback()
+		return e.New("Node not found", ErrRecordNotFound, nil)
+	}
+
+	if err := setClasses(tx, req.Kind, req.ID, req.ClassIDs); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	// Commit transaction
+	if err := tx.Commit().Error; err != nil {
+		return e.Wrap("Could not commit", TranslateDatabaseError(err))
+	}
+	return nil
+}
+
+// GetClasses gets the canonical versions of the classes directly linked to a shared node.
+func (db *Database) GetClasses(ctx context.Context, req *model.NodeRequest) ([]model.UsersEntityClass, error) {
+	l.Debug("Getting classes",
+		l.String("kind", req.Kind),
+		l.String("id", req.ID),
+		l.String("request_id", r.GetRequestID(ctx)))
+
+	link := classLinks[req.Kind]
+	query, args := canonicalVersionsQuery(ctx, model.KindEntityClass, nil,
+		`SELECT `+link.classColumn+` FROM `+link.table+` WHERE `+link.idColumn+` = ?`, req.ID)
+	var classes []model.UsersEntityClass
+	res := db.WithContext(ctx).
+		Raw(query, args...).
+		Scan(&classes)
+	if res.Error != nil {
+		return nil, e.Wrap("Failed to get classes", TranslateDatabaseError(res.Error))
+	}
+	return classes, nil
+}
+
+// GetClassesOfNodes gets the canonical versions of the classes directly linked to many shared nodes of a kind in one query.
+// Each class is returned once for every node it is linked to.
+func (db *Database) GetClassesOfNodes(ctx context.Context, req *model.BatchNodeRequest) ([]model.NodeClass, error) {
+	l.Debug("Getting classes of nodes",
+		l.String("kind", req.Kind),
+		l.Int("ids", len(req.IDs)),
+		l.String("request_id", r.GetRequestID(ctx)))
+
+	if len(req.IDs) == 0 {
+		return nil, nil
+	}
+	link := classLinks[req.Kind]
+	query, args := canonicalVersionsQuery(ctx, model.KindEntityClass, nil,
+		`SELECT `+link.classColumn+` FROM `+link.table+` WHERE `+link.idColumn+` IN (?)`, req.IDs)
+	var classes []model.NodeClass
+	res := db.WithContext(ctx).
+		Raw(`SELECT links.`+link.idColumn+` AS node_id, canonical.*
+			FROM `+link.table+` links
+			JOIN (`+query+`) canonical ON canonical.entity_class_id = links.`+link.classColumn+`
+			WHERE links.`+link.idColumn+` IN (?)`, append(args, req.IDs)...).
+		Scan(&classes)
+	if res.Error != nil {
+		return nil, e.Wrap("Failed to get classes of nodes", TranslateDatabaseError(res.Error))
+	}
+	return classes, nil
+}
+
+// GetApplicableTypes gets the canonical versions of the connection and property types that apply to
+// instances of a class, including the ones declared on its superclasses.
+func (db *Database) GetApplicableTypes(ctx context.Context, req *model.IDRequest) (*model.ApplicableTypesResponse, error) {
+	l.Debug("Getting applicable types",
+		l.String("entity_class_id", req.ID),
+		l.String("request_id", r.GetRequestID(ctx)))
+
+	var response model.ApplicableTypesResponse
+	query, args := canonicalVersionsQuery(ctx, model.KindConnectionType, nil,
+		`SELECT connection_type_id FROM connection_type_classes WHERE entity_class_id IN (`+superclassesQuery+`)`, req.ID)
+	res := db.WithContext(ctx).
+		Raw(query, args...).
+		Scan(&response.ConnectionTypes)
+	if res.Error != nil {
+		return nil, e.Wrap("Failed to get applicable connection types", TranslateDatabaseError(res.Error))
+	}
+	if err := db.loadConnectionTypes(ctx, response.ConnectionTypes); err != nil {
+		return nil, err
+	}
+
+	query, args = canonicalVersionsQuery(ctx, model.KindPropertyType, nil,
+		`SELECT property_type_id FROM property_type_classes WHERE entity_class_id IN (`+superclassesQuery+`)`, req.ID)
+	res = db.WithContext(ctx).
+		Raw(query, args...).
+		Scan(&response.PropertyTypes)
+	if res.Error != nil {
+		return nil, e.Wrap("Failed to get applicable property types", TranslateDatabaseError(res.Error))
+	}
+
+	return &response, nil
+}
+
+// HELPER FUNCTIONS
+
+// setClasses replaces the classes linked to a shared node.
+// A class cannot become a parent of itself or of one of its superclasses.
+func setClasses(tx *gorm.DB, kind, id string, classIDs []string) error {
+	link := classLinks[kind]
+
+	unique := make(map[string]bool, len(classIDs))
+	for _, classID := range classIDs {
+		unique[classID] = true
+	}
+	if len(unique) > 0 {
+		var count int64
+		if err := tx.Model(&model.EntityClass{}).Where("id IN ?", classIDs).Count(&count).Error; err != nil {
+			return e.Wrap("Could not find entity classes", TranslateDatabaseError(err))
+		}
+		if int(count) != len(unique) {
+			return e.New("Entity class not found", ErrRecordNotFound, nil)
+		}
+	}
+
+	if kind == model.KindEntityClass && len(unique) > 0 {
+		var cycles int64
+		if err := tx.Raw(`SELECT COUNT(*) FROM (`+subclassesQuery+`) subclasses WHERE id IN ?`, id, classIDs).Scan(&cycles).Error; err != nil {
+			return e.Wrap("Could not check class hierarchy", TranslateDatabaseError(err))
+		}
+		if cycles > 0 {
+			return e.New("A class cannot be its own ancestor", ErrInvalidRequest, nil)
+		}
+	}
+
+	if err := tx.Exec(`DELETE FROM `+link.table+` WHERE `+link.idColumn+` = ?`, id).Error; err != nil {
+		return e.Wrap("Could not remove classes", TranslateDatabaseError(err))
+	}
+	for classID := range unique {
+		if err := tx.Exec(`INSERT INTO `+link.table+` (`+link.idColumn+`, `+link.classColumn+`) VALUES (?, ?)`, id, classID).Error; err != nil {
+			return e.Wrap("Could not add class", TranslateDatabaseError(err))
+		}
+	}
+	return nil
+}
+
+// requireApplicable checks that a connection type applies to an entity
+func requireApplicable(tx *gorm.DB, connectionTypeID, entityID string) error {
+	params := map[string]interface{}{
+		"type":   connectionTypeID,
+		"entity": entityID,
+	}
+	var applicable bool
+	if err := tx.Raw(applicableQuery, params).Scan(&applicable).Error; err != nil {
+		return e.Wrap("Could not check connection type classes", TranslateDatabaseError(err))
+	}
+	if !applicable {
+		return e.New("Entity is not an instance of a class the connection type applies to", ErrInvalidRequest, nil)
+	}
+	return nil
+}
+
+// copyClasses makes an entity an instance of the classes of another entity, and returns those classes.
+func copyClasses(tx *gorm.DB, fromID, toID string) ([]string, error) {
+	var classIDs []string
+	if err := tx.Table("entity_instances").Where("entity_id = ?", fromID).Pluck("entity_class_id", &classIDs).Error; err != nil {
+		return nil, e.Wrap("Could not get entity classes", TranslateDatabaseError(err))
+	}
+	if err := tx.Exec(`INSERT INTO entity_instances (entity_id, entity_class_id)
+		SELECT ?, entity_class_id FROM entity_instances WHERE entity_id = ?
+		ON CONFLICT DO NOTHING`, toID, fromID).Error; err != nil {
+		return nil, e.Wrap("Could not copy entity classes", TranslateDatabaseError(err))
+	}
+	return classIDs, nil
+}
@@ -0,0 +1,392 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/BwezB/Wikno-backend/internal/graph/model"
+
+	e "github.com/BwezB/Wikno-backend/pkg/errors"
+	l "github.com/BwezB/Wikno-backend/pkg/log"
+	r "github.com/BwezB/Wikno-backend/pkg/requestid"
+)
+
+// liveConnectionFilter selects the connections c that exist at the @as_of time, or now if it is NULL
+const liveConnectionFilter = `(CAST(@as_of AS timestamptz) IS NULL AND c.deleted_at IS NULL
+		OR c.created_at <= @as_of AND (c.deleted_at IS NULL OR c.deleted_at > @as_of))`
+
+// directConnectionsCTE selects the owner's stored connections, and the connections their inverse and symmetric types imply.
+// It needs the @owner and @as_of parameters and is aliased as "direct".
+const directConnectionsCTE = `direct AS (
+		SELECT c.id::text AS id, c.user_id, c.connection_type_id, c.from_entity_id, c.to_entity_id, false AS inferred
+		FROM connections c
+		WHERE c.user_id = @owner AND ` + liveConnectionFilter + `
+		UNION ALL
+		SELECT c.id::text, c.user_id, ct.inverse_id, c.to_entity_id, c.from_entity_id, true
+		FROM connections c JOIN connection_types ct ON ct.id = c.connection_type_id
+		WHERE c.user_id = @owner AND ` + liveConnectionFilter + ` AND ct.inverse_id IS NOT NULL
+		UNION ALL
+		SELECT c.id::text, c.user_id, c.connection_type_id, c.to_entity_id, c.from_entity_id, true
+		FROM connections c JOIN connection_types ct ON ct.id = c.connection_type_id
+		WHERE c.user_id = @owner AND ` + liveConnectionFilter + ` AND ct.symmetric
+	)`
+
+// traversalQuery selects the connections going out of the @entities in the owner's graph, inferred ones included.
+// Connections implied by transitive types chain connections of the same type and have no ID.
+// If filterType is set, only connections of the @type are selected.
+func traversalQuery(filterType bool) string {
+	typeFilter := ""
+	if filterType {
+		typeFilter = " AND connection_type_id = @type"
+	}
+	return `WITH RECURSIVE ` + directConnectionsCTE + `,
+	reach AS (
+		SELECT d.connection_type_id, d.from_entity_id, d.to_entity_id
+		FROM direct d JOIN connection_types ct ON ct.id = d.connection_type_id
+		WHERE ct.transitive AND d.from_entity_id IN (@entities)` + typeFilter + `
+		UNION
+		SELECT r.connection_type_id, r.from_entity_id, d.to_entity_id
+		FROM reach r JOIN direct d ON d.connection_type_id = r.connection_type_id AND d.from_entity_id = r.to_entity_id
+	),
+	outgoing AS (
+		SELECT * FROM direct WHERE from_entity_id IN (@entities)` + typeFilter + `
+		UNION ALL
+		SELECT '', @owner, connection_type_id, from_entity_id, to_entity_id, true
+		FROM reach
+		WHERE to_entity_id <> from_entity_id
+	)
+	SELECT DISTINCT ON (from_entity_id, connection_type_id, to_entity_id) *
+	FROM outgoing
+	ORDER BY from_entity_id, connection_type_id, to_entity_id, inferred, id`
+}
+
+// reachesQuery selects whether the @entity is the @target or reaches it through transitive connections in the owner's graph.
+const reachesQuery = `WITH RECURSIVE ` + directConnectionsCTE + `,
+	reach AS (
+		SELECT CAST(@entity AS uuid) AS entity_id
+		UNION
+		SELECT d.to_entity_id
+		FROM reach r
+		JOIN direct d ON d.from_entity_id = r.entity_id
+		JOIN connection_types ct ON ct.id = d.connection_type_id
+		WHERE ct.transitive
+	)
+	SELECT EXISTS (SELECT 1 FROM reach WHERE entity_id = @target)`
+
+// impliedQuery selects whether a connection is already stored or implied in the owner's graph.
+const impliedQuery = `WITH ` + directConnectionsCTE + `
+	SELECT EXISTS (
+		SELECT 1 FROM direct
+		WHERE connection_type_id = @type AND from_entity_id = @from AND to_entity_id = @to
+	)`
+
+// CreateConnection creates a connection in the owner's graph.
+// Connections that are already stored or implied by an inverse or symmetric type are rejected,
+// as are connections whose entities do not satisfy the domain and range of the connection type,
+// and connections from entities that are not instances of the classes the connection type applies to.
+func (db *Database) CreateConnection(ctx context.Context, req *model.ConnectionRequest) (*model.Connection, error) {
+	// Get ID from context
+	ownerID := getOwnerID(ctx)
+	if ownerID == "" {
+		return nil, e.New("Failed to get owner ID from context", ErrInternal, nil)
+	}
+
+	l.Debug("Creating connection",
+		l.String("owner_id", ownerID),
+		l.String("connection_type_id", req.ConnectionTypeID),
+		l.String("from_entity_id", req.FromEntityID),
+		l.String("to_entity_id", req.ToEntityID),
+		l.String("request_id", r.GetRequestID(ctx)))
+
+	// Start transaction
+	tx := db.WithContext(ctx).Begin()
+	if tx.Error != nil {
+		return nil, e.Wrap("Could not start transaction", TranslateDatabaseError(tx.Error))
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	var connectionType model.ConnectionType
+	if err := tx.First(&connectionType, "id = ?", req.ConnectionTypeID).Error; err != nil {
+		tx.Rollback()
+		return nil, e.Wrap("Could not find connection type", TranslateDatabaseError(err))
+	}
+	if err := requireEntities(tx, req.FromEntityID, req.ToEntityID); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	params := map[string]interface{}{
+		"owner": ownerID,
+		"type":  req.ConnectionTypeID,
+		"from":  req.FromEntityID,
+		"to":    req.ToEntityID,
+		"as_of": nil,
+	}
+	var implied bool
+	if err := tx.Raw(impliedQuery, params).Scan(&implied).Error; err != nil {
+		tx.Rollback()
+		return nil, e.Wrap("Could not check existing connections", TranslateDatabaseError(err))
+	}
+	if implied {
+		tx.Rollback()
+		return nil, e.New("Connection already exists or is implied by another connection", ErrDuplicateEntry, nil)
+	}
+
+	if err := requireApplicable(tx, req.ConnectionTypeID, req.FromEntityID); err != nil {
+		tx.Rollback()
+		return nil, e.Wrap("From entity is outside the classes of the connection type", err)
+	}
+	if connectionType.DomainID != nil {
+		if err := requireReaches(tx, ownerID, req.FromEntityID, *connectionType.DomainID); err != nil {
+			tx.Rollback()
+			return nil, e.Wrap("From entity is outside the domain of the connection type", err)
+		}
+	}
+	if connectionType.RangeID != nil {
+		if err := requireReaches(tx, ownerID, req.ToEntityID, *connectionType.RangeID); err != nil {
+			tx.Rollback()
+			return nil, e.Wrap("To entity is outside the range of the connection type", err)
+		}
+	}
+
+	connection := model.Connection{
+		UserID:           ownerID,
+		ConnectionTypeID: req.ConnectionTypeID,
+		FromEntityID:     req.FromEntityID,
+		ToEntityID:       req.ToEntityID,
+	}
+	if err := tx.Create(&connection).Error; err != nil {
+		tx.Rollback()
+		return nil, e.Wrap("Could not create connection", TranslateDatabaseError(err))
+	}
+
+	// Commit transaction
+	if err := tx.Commit().Error; err != nil {
+		return nil, e.Wrap("Could not commit", TranslateDatabaseError(err))
+	}
+
+	return &connection, nil
+}
+
+// DeleteConnection deletes a stored connection of the owner's graph. The connections it implied disappear with it.
+// The connection is kept as deleted, so it can still be read as of earlier times.
+func (db *Database) DeleteConnection(ctx context.Context, req *model.IDRequest) error {
+	// Get ID from context
+	ownerID := getOwnerID(ctx)
+	if ownerID == "" {
+		return e.New("Failed to get owner ID from context", ErrInternal, nil)
+	}
+
+	l.Debug("Deleting connection",
+		l.String("owner_id", ownerID),
+		l.String("connection_id", req.ID),
+		l.String("request_id", r.GetRequestID(ctx)))
+
+	res := db.WithContext(ctx).
+		Where("id = ? AND user_id = ?", req.ID, ownerID).
+		Delete(&model.Connection{})
+	if res.Error != nil {
+		return e.Wrap("Failed to delete connection", TranslateDatabaseError(res.Error))
+	}
+	if res.RowsAffected == 0 {
+		return ErrRecordNotFound
+	}
+	return nil
+}
+
+// GetConnections gets the connections going out of an entity in the owner's graph, including inferred ones.
+// If a point in time is given, the connections are traversed as they were then.
+func (db *Database) GetConnections(ctx context.Context, req *model.TraversalRequest) ([]model.Connection, error) {
+	// Get ID from context
+	ownerID := getOwnerID(ctx)
+	if ownerID == "" {
+		return nil, e.New("Failed to get owner ID from context", ErrInternal, nil)
+	}
+
+	l.Debug("Getting connections",
+		l.String("owner_id", ownerID),
+		l.String("entity_id", req.EntityID),
+		l.String("connection_type_id", req.ConnectionTypeID),
+		l.Bool("as_of", req.AsOf != nil),
+		l.String("request_id", r.GetRequestID(ctx)))
+
+	return db.traverse(ctx, ownerID, []string{req.EntityID}, req.ConnectionTypeID, req.AsOf)
+}
+
+// GetConnectionsOfEntities gets the connections going out of many entities in the owner's graph in one query,
+// including inferred ones. If a point in time is given, the connections are traversed as they were then.
+func (db *Database) GetConnectionsOfEntities(ctx context.Context, req *model.BatchTraversalRequest) ([]model.Connection, error) {
+	// Get ID from context
+	ownerID := getOwnerID(ctx)
+	if ownerID == "" {
+		return nil, e.New("Failed to get owner ID from context", ErrInternal, nil)
+	}
+
+	l.Debug("Getting connections of entities",
+		l.String("owner_id", ownerID),
+		l.Int("entities", len(req.EntityIDs)),
+		l.String("connection_type_id", req.ConnectionTypeID),
+		l.Bool("as_of", req.AsOf != nil),
+		l.String("request_id", r.GetRequestID(ctx)))
+
+	if len(req.EntityIDs) == 0 {
+		return nil, nil
+	}
+	return db.traverse(ctx, ownerID, req.EntityIDs, req.ConnectionTypeID, req.AsOf)
+}
+
+// HELPER FUNCTIONS
+
+// createConnectionType creates a shared connection type with the requested semantics.
+// A new inverse is linked back to the new type, so inverses always point at each other.
+func createConnectionType(tx *gorm.DB, req *model.ConnectionTypeRequest) (*model.ConnectionType, error) {
+	connectionType := model.ConnectionType{
+		ConnectionSemantics: model.ConnectionSemantics{
+			Symmetric:  req.Symmetric,
+			Transitive: req.Transitive,
+			InverseID:  optionalID(req.InverseID),
+			DomainID:   optionalID(req.DomainID),
+			RangeID:    optionalID(req.RangeID),
+		},
+	}
+
+	var inverse model.ConnectionType
+	if req.InverseID != "" {
+		if err := tx.First(&inverse, "id = ?", req.InverseID).Error; err != nil {
+			return nil, e.Wrap("Could not find inverse connection type", TranslateDatabaseError(err))
+		}
+		if inverse.InverseID != nil || inverse.Symmetric {
+			return nil, e.New("Inverse connection type already has an inverse or is symmetric", ErrInvalidRequest, nil)
+		}
+		if inverse.Transitive != req.Transitive {
+			return nil, e.New("Inverse connection types must both be transitive or not", ErrInvalidRequest, nil)
+		}
+		// The domain of a type is the range of its inverse
+		if !sameID(inverse.DomainID, connectionType.RangeID) || !sameID(inverse.RangeID, connectionType.DomainID) {
+			return nil, e.New("Domain and range must be the range and domain of the inverse connection type", ErrInvalidRequest, nil)
+		}
+	}
+
+	var entityIDs []string
+	for _, id := range []*string{connectionType.DomainID, connectionType.RangeID} {
+		if id != nil {
+			entityIDs = append(entityIDs, *id)
+		}
+	}
+	if err := requireEntities(tx, entityIDs...); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Create(&connectionType).Error; err != nil {
+		return nil, e.Wrap("Could not create connection type", TranslateDatabaseError(err))
+	}
+
+	if req.InverseID != "" {
+		if err := tx.Model(&inverse).Update("inverse_id", connectionType.ID).Error; err != nil {
+			return nil, e.Wrap("Could not link inverse connection type", TranslateDatabaseError(err))
+		}
+	}
+
+	return &connectionType, nil
+}
+
+// traverse selects the connections going out of the entities in the owner's graph, inferred ones included
+func (db *Database) traverse(ctx context.Context, ownerID string, entityIDs []string, connectionTypeID string, asOf *time.Time) ([]model.Connection, error) {
+	params := map[string]interface{}{
+		"owner":    ownerID,
+		"entities": entityIDs,
+		"type":     connectionTypeID,
+		"as_of":    asOf,
+	}
+	var connections []model.Connection
+	res := db.WithContext(ctx).
+		Raw(traversalQuery(connectionTypeID != ""), params).
+		Scan(&connections)
+	if res.Error != nil {
+		return nil, e.Wrap("Failed to get connections", TranslateDatabaseError(res.Error))
+	}
+	return connections, nil
+}
+
+// requireEntities checks that all the entities exist
+func requireEntities(tx *gorm.DB, entityIDs ...string) error {
+	unique := make(map[string]bool, len(entityIDs))
+	for _, id := range entityIDs {
+		unique[id] = true
+	}
+	if len(unique) == 0 {
+		return nil
+	}
+
+	var count int64
+	if err := tx.Model(&model.Entity{}).Where("id IN ?", entityIDs).Count(&count).Error; err != nil {
+		return e.Wrap("Could not find entities", TranslateDatabaseError(err))
+	}
+	if int(count) != len(unique) {
+		return e.New("Entity not found", ErrRecordNotFound, nil)
+	}
+	return nil
+}
+
+// requireReaches checks that the entity is the target or reaches it through transitive connections of the owner
+func requireReaches(tx *gorm.DB, ownerID, entityID, targetID string) error {
+	params := map[string]interface{}{
+		"owner":  ownerID,
+		"entity": entityID,
+		"target": targetID,
+		"as_of":  nil,
+	}
+	var reaches bool
+	if err := tx.Raw(reachesQuery, params).Scan(&reaches).Error; err != nil {
+		return e.Wrap("Could not check connection constraints", TranslateDatabaseError(err))
+	}
+	if !reaches {
+		return e.New("Entity does not satisfy the connection type constraints", ErrInvalidRequest, nil)
+	}
+	return nil
+}
+
+func optionalID(id string) *string {
+	if id == "" {
+		return nil
+	}
+	return &id
+}
+
+func sameID(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// loadConnectionTypes sets the shared connection type of versions read by raw queries
+func (db *Database) loadConnectionTypes(ctx context.Context, versions []model.UsersConnectionType) error {
+	if len(versions) == 0 {
+		return nil
+	}
+
+	ids := make([]string, len(versions))
+	for i, version := range versions {
+		ids[i] = version.ConnectionTypeID
+	}
+	var connectionTypes []model.ConnectionType
+	if err := db.WithContext(ctx).Where("id IN ?", ids).Find(&connectionTypes).Error; err != nil {
+		return e.Wrap("Could not load connection types", TranslateDatabaseError(err))
+	}
+
+	byID := make(map[string]*model.ConnectionType, len(connectionTypes))
+	for i := range connectionTypes {
+		byID[connectionTypes[i].ID] = &connectionTypes[i]
+	}
+	for i := range versions {
+		versions[i].ConnectionType = byID[versions[i].ConnectionTypeID]
+	}
+	return nil
+}
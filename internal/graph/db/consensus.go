@@ -0,0 +1,253 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm/clause"
+
+	"github.com/BwezB/Wikno-backend/internal/graph/model"
+
+	a "github.com/BwezB/Wikno-backend/pkg/auth"
+	e "github.com/BwezB/Wikno-backend/pkg/errors"
+	l "github.com/BwezB/Wikno-backend/pkg/log"
+	r "github.com/BwezB/Wikno-backend/pkg/requestid"
+)
+
+// sharedKind describes where the users' versions of a kind of shared node are stored
+type sharedKind struct {
+	table     string // Table with the users' versions
+	idColumn  string // Column referencing the shared node
+	nodeTable string // Table of the shared nodes
+	columns   string // Extra columns needed by the response type
+	join      string // Joins for the extra columns
+}
+
+var sharedKinds = map[string]sharedKind{
+	model.KindEntity:         {table: "users_entities", idColumn: "entity_id", nodeTable: "entities"},
+	model.KindConnectionType: {table: "users_connection_types", idColumn: "connection_type_id", nodeTable: "connection_types"},
+	model.KindEntityClass:    {table: "users_entity_classes", idColumn: "entity_class_id", nodeTable: "entity_classes"},
+	model.KindPropertyType: {
+		table:     "users_property_types",
+		idColumn:  "property_type_id",
+		nodeTable: "property_types",
+		columns:   ", pt.value_type",
+		join:      " JOIN property_types pt ON pt.id = uv.property_type_id",
+	},
+}
+
+// versionsTable returns what the versions of a kind are read from, and the arguments it needs:
+// the table with the current versions, or a subquery that rebuilds the versions as they were at asOf from their revisions.
+func versionsTable(kind string, asOf *time.Time) (string, []interface{}) {
+	k := sharedKinds[kind]
+	if asOf == nil {
+		return k.table, nil
+	}
+	return `(SELECT node_id AS ` + k.idColumn + `, user_id, name, definition
+		FROM (SELECT DISTINCT ON (node_id, user_id) *
+		      FROM revisions
+		      WHERE kind = '` + kind + `' AND created_at <= ?
+		      ORDER BY node_id, user_id, created_at DESC) latest
+		WHERE action <> '` + model.RevisionDelete + `')`, []interface{}{*asOf}
+}
+
+// visibleOwnerCondition is the condition that the owner of a version in the column is visible to the request:
+// every user, but of the workspaces only the one the request is scoped to.
+// Versions of other workspaces are private, so they are left out of results and of the consensus of shared nodes.
+func visibleOwnerCondition(ctx context.Context, column string) (string, []interface{}) {
+	var scope interface{} // NULL, so no workspace is visible
+	if workspaceID := a.GetWorkspaceID(ctx); workspaceID != "" {
+		scope = workspaceID
+	}
+	return `NOT EXISTS (SELECT 1 FROM workspaces w WHERE w.id = ` + column + ` AND w.id IS DISTINCT FROM ?)`, []interface{}{scope}
+}
+
+// rankedVersionsQuery selects the versions of a kind that are visible to the request from the versions table,
+// with their consensus (score and user_count) and since, when their owner's first revision of the node was recorded.
+// Callers filter and order the result, which is aliased as "ranked". Scores always count the current votes.
+func rankedVersionsQuery(ctx context.Context, kind, table string, tableArgs []interface{}) (string, []interface{}) {
+	k := sharedKinds[kind]
+	visibleVersion, visibleVersionArgs := visibleOwnerCondition(ctx, "uv.user_id")
+	visibleCounted, visibleCountedArgs := visibleOwnerCondition(ctx, "counted.user_id")
+	query := `(SELECT uv.*` + k.columns + `, COALESCE(votes.score, 0) AS score, counts.user_count, firsts.since
+		FROM ` + table + ` uv` + k.join + `
+		LEFT JOIN (SELECT target_id, author_id, SUM(value) AS score
+		           FROM definition_votes
+		           WHERE kind = '` + kind + `'
+		           GROUP BY target_id, author_id) votes
+			ON votes.target_id = uv.` + k.idColumn + ` AND votes.author_id = uv.user_id
+		JOIN (SELECT ` + k.idColumn + `, COUNT(*) AS user_count
+		      FROM ` + table + ` counted
+		      WHERE ` + visibleCounted + `
+		      GROUP BY ` + k.idColumn + `) counts
+			ON counts.` + k.idColumn + ` = uv.` + k.idColumn + `
+		LEFT JOIN (SELECT node_id, user_id, MIN(created_at) AS since
+		           FROM revisions
+		           WHERE kind = '` + kind + `'
+		           GROUP BY node_id, user_id) firsts
+			ON firsts.node_id = uv.` + k.idColumn + ` AND firsts.user_id = uv.user_id
+		WHERE ` + visibleVersion + `) ranked`
+	return query, concatArgs(tableArgs, tableArgs, visibleCountedArgs, visibleVersionArgs)
+}
+
+// rankedOrder orders ranked versions from the canonical version down: the highest scoring one,
+// and of equally scoring ones the one that has been on the node the longest. The owner ID only keeps the order stable.
+const rankedOrder = `ranked.score DESC, ranked.since ASC NULLS LAST, ranked.user_id`
+
+// canonicalVersionsQuery selects the canonical version, at asOf or now if it is nil, of every shared node of a kind
+// whose ID is in the ids subquery, and returns it with its arguments.
+func canonicalVersionsQuery(ctx context.Context, kind string, asOf *time.Time, ids string, idsArgs ...interface{}) (string, []interface{}) {
+	k := sharedKinds[kind]
+	table, tableArgs := versionsTable(kind, asOf)
+	ranked, rankedArgs := rankedVersionsQuery(ctx, kind, table, tableArgs)
+	query := `SELECT DISTINCT ON (ranked.` + k.idColumn + `) *
+		FROM ` + ranked + `
+		WHERE ranked.` + k.idColumn + ` IN (` + ids + `)
+		ORDER BY ranked.` + k.idColumn + `, ` + rankedOrder
+	return query, concatArgs(rankedArgs, idsArgs)
+}
+
+// canonicalVersionsWithNameQuery selects the canonical version of every shared node of a kind
+// that had at least one visible version with the given name at asOf, or has one now if it is nil.
+// Extra conditions on the shared node IDs can be added with filter, which starts with AND.
+func canonicalVersionsWithNameQuery(ctx context.Context, kind string, asOf *time.Time, name string, filter string, filterArgs ...interface{}) (string, []interface{}) {
+	k := sharedKinds[kind]
+	table, tableArgs := versionsTable(kind, asOf)
+	visible, visibleArgs := visibleOwnerCondition(ctx, "named.user_id")
+	ids := `SELECT ` + k.idColumn + ` FROM ` + table + ` named WHERE name = ? AND ` + visible + filter
+	return canonicalVersionsQuery(ctx, kind, asOf, ids, concatArgs(tableArgs, []interface{}{name}, visibleArgs, filterArgs)...)
+}
+
+// versionsQuery selects all current versions of one shared node that are visible to the request, the canonical version first.
+func versionsQuery(ctx context.Context, kind, id string) (string, []interface{}) {
+	k := sharedKinds[kind]
+	ranked, rankedArgs := rankedVersionsQuery(ctx, kind, k.table, nil)
+	query := `SELECT *
+		FROM ` + ranked + `
+		WHERE ranked.` + k.idColumn + ` = ?
+		ORDER BY ` + rankedOrder
+	return query, concatArgs(rankedArgs, []interface{}{id})
+}
+
+func concatArgs(lists ...[]interface{}) []interface{} {
+	var args []interface{}
+	for _, list := range lists {
+		args = append(args, list...)
+	}
+	return args
+}
+
+// Vote stores the vote of the user in the context on another owner's version of a shared node.
+// A value of 0 removes the vote.
+func (db *Database) Vote(ctx context.Context, req *model.VoteRequest) error {
+	// Get ID from context
+	userID := a.GetUserID(ctx)
+	if userID == "" {
+		return e.New("Failed to get user ID from context", ErrInternal, nil)
+	}
+
+	l.Debug("Voting on definition",
+		l.String("user_id", userID),
+		l.String("kind", req.Kind),
+		l.String("id", req.ID),
+		l.String("author_id", req.AuthorID),
+		l.Int("value", req.Value),
+		l.String("request_id", r.GetRequestID(ctx)))
+
+	if req.AuthorID == userID {
+		return e.New("Users cannot vote on their own definitions", ErrInvalidRequest, nil)
+	}
+
+	// The version voted on must exist and be visible to the voter
+	k := sharedKinds[req.Kind]
+	visible, visibleArgs := visibleOwnerCondition(ctx, "user_id")
+	var count int64
+	res := db.WithContext(ctx).Table(k.table).
+		Where(k.idColumn+" = ? AND user_id = ? AND "+visible, append([]interface{}{req.ID, req.AuthorID}, visibleArgs...)...).
+		Count(&count)
+	if res.Error != nil {
+		return e.Wrap("Failed to find definition", TranslateDatabaseError(res.Error))
+	}
+	if count == 0 {
+		return e.New("Definition not found", ErrRecordNotFound, nil)
+	}
+
+	vote := model.DefinitionVote{
+		VoterID:  userID,
+		Kind:     req.Kind,
+		TargetID: req.ID,
+		AuthorID: req.AuthorID,
+		Value:    req.Value,
+	}
+
+	if req.Value == 0 {
+		res = db.WithContext(ctx).Delete(&vote)
+	} else {
+		res = db.WithContext(ctx).Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "voter_id"}, {Name: "kind"}, {Name: "target_id"}, {Name: "author_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"value", "updated_at"}),
+		}).Create(&vote)
+	}
+	if res.Error != nil {
+		return e.Wrap("Failed to store vote", TranslateDatabaseError(res.Error))
+	}
+
+	return nil
+}
+
+// GetEntityVersions gets all users' versions of an entity, the canonical version first.
+func (db *Database) GetEntityVersions(ctx context.Context, req *model.IDRequest) ([]model.UsersEntity, error) {
+	l.Debug("Getting entity versions",
+		l.String("entity_id", req.ID),
+		l.String("request_id", r.GetRequestID(ctx)))
+
+	var versions []model.UsersEntity
+	query, args := versionsQuery(ctx, model.KindEntity, req.ID)
+	res := db.WithContext(ctx).Raw(query, args...).Scan(&versions)
+	if res.Error != nil {
+		return nil, e.Wrap("Failed to get entity versions", TranslateDatabaseError(res.Error))
+	}
+	if len(versions) == 0 {
+		return nil, e.New("Entity not found", ErrRecordNotFound, nil)
+	}
+	return versions, nil
+}
+
+// GetConnectionTypeVersions gets all users' versions of a connection type, the canonical version first.
+func (db *Database) GetConnectionTypeVersions(ctx context.Context, req *model.IDRequest) ([]model.UsersConnectionType, error) {
+	l.Debug("Getting connection type versions",
+		l.String("connection_type_id", req.ID),
+		l.String("request_id", r.GetRequestID(ctx)))
+
+	var versions []model.UsersConnectionType
+	query, args := versionsQuery(ctx, model.KindConnectionType, req.ID)
+	res := db.WithContext(ctx).Raw(query, args...).Scan(&versions)
+	if res.Error != nil {
+		return nil, e.Wrap("Failed to get connection type versions", TranslateDatabaseError(res.Error))
+	}
+	if len(versions) == 0 {
+		return nil, e.New("Connection type not found", ErrRecordNotFound, nil)
+	}
+	if err := db.loadConnectionTypes(ctx, versions); err != nil {
+		return nil, err
+	}
+	return versions, nil
+}
+
+// GetPropertyTypeVersions gets all users' versions of a property type, the canonical version first.
+func (db *Database) GetPropertyTypeVersions(ctx context.Context, req *model.IDRequest) ([]model.PropertyTypeResponse, error) {
+	l.Debug("Getting property type versions",
+		l.String("property_type_id", req.ID),
+		l.String("request_id", r.GetRequestID(ctx)))
+
+	var versions []model.PropertyTypeResponse
+	query, args := versionsQuery(ctx, model.KindPropertyType, req.ID)
+	res := db.WithContext(ctx).Raw(query, args...).Scan(&versions)
+	if res.Error != nil {
+		return nil, e.Wrap("Failed to get property type versions", TranslateDatabaseError(res.Error))
+	}
+	if len(versions) == 0 {
+		return nil, e.New("Property type not found", ErrRecordNotFound, nil)
+	}
+	return versions, nil
+}
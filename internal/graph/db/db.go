@@ -2,6 +2,7 @@ package db
 
 import (
 	"context"
+	"strings"
 	"time"
 
 	"gorm.io/driver/postgres"
@@ -10,15 +11,23 @@ import (
 
 	"github.com/BwezB/Wikno-backend/internal/graph/model"
 
+	au "github.com/BwezB/Wikno-backend/pkg/audit"
 	a "github.com/BwezB/Wikno-backend/pkg/auth"
 	r "github.com/BwezB/Wikno-backend/pkg/requestid"
 	e "github.com/BwezB/Wikno-backend/pkg/errors"
 	l "github.com/BwezB/Wikno-backend/pkg/log"
+	rp "github.com/BwezB/Wikno-backend/pkg/replicas"
+	rt "github.com/BwezB/Wikno-backend/pkg/retry"
 	h "github.com/BwezB/Wikno-backend/pkg/health"
 )
 
 type Database struct {
 	*gorm.DB
+
+	// replicas serve the read-only queries, nil without replicas
+	replicas *rp.Set
+	// retry limits the retries of transactions
+	retry rt.Config
 }
 
 func New(config DatabaseConfig) (*Database, error) {
@@ -28,9 +37,56 @@ func New(config DatabaseConfig) (*Database, error) {
 		l.String("dbname", config.DBName))
 
 	// Connect to the database
-	dsn := config.GetDSN()
+	db, err := open(config.GetDSN(), config, false)
+	if err != nil {
+		return nil, err
+	}
+
+	l.Info("Connected to database",
+		l.String("address", config.GetAddress()),
+		l.String("user", config.User),
+		l.String("dbname", config.DBName))
+
+	// Connect to the read replicas. Replicas that are down are skipped until they come back.
+	addresses := config.GetReplicaAddresses()
+	if len(addresses) == 0 {
+		return &Database{DB: db, retry: config.GetRetryConfig()}, nil
+	}
+	replicas := make([]*rp.Replica, 0, len(addresses))
+	for _, address := range addresses {
+		replica, err := open(config.GetReplicaDSN(address), config, true)
+		if err != nil {
+			return nil, e.Wrap("Failed to connect to replica "+address, err)
+		}
+		replicas = append(replicas, &rp.Replica{Address: address, DB: replica})
+	}
+	l.Info("Routing reads to replicas", l.String("replicas", strings.Join(addresses, ",")))
+
+	return &Database{
+		DB:       db,
+		replicas: rp.New(replicas, config.ReplicaMaxLag, config.ReplicaCheckInterval),
+		retry:    config.GetRetryConfig(),
+	}, nil
+}
+
+// reader returns the database for read-only queries: an available replica, or the primary if there is none
+func (db *Database) reader() *Database {
+	if replica := db.replicas.Reader(); replica != nil {
+		return &Database{DB: replica, replicas: db.replicas, retry: db.retry}
+	}
+	return db
+}
+
+// transaction runs fn in a transaction, and again on serialization failures, deadlocks and lost connections
+func (db *Database) transaction(ctx context.Context, fn func(tx *gorm.DB) error) error {
+	return rt.Transaction(ctx, db.DB, db.retry, TranslateDatabaseError, fn)
+}
+
+// open opens a connection pool. Without the ping, connecting waits for the first query.
+func open(dsn string, config DatabaseConfig, skipPing bool) (*gorm.DB, error) {
 	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
-		Logger: logger.Default.LogMode(logger.Silent), // Disable gorm logging
+		Logger:               logger.Default.LogMode(logger.Silent), // Disable gorm logging
+		DisableAutomaticPing: skipPing,
 	})
 	if err != nil {
 		return nil, e.New("Failed to connect to database", ErrDatabaseConnection, err)
@@ -46,39 +102,27 @@ func New(config DatabaseConfig) (*Database, error) {
 	sqlDB.SetMaxIdleConns(config.MaxIdleConns)
 	sqlDB.SetConnMaxLifetime(config.ConnMaxLifetime)
 
-	l.Info("Connected to database",
-		l.String("address", config.GetAddress()),
-		l.String("user", config.User),
-		l.String("dbname", config.DBName))
-
-	return &Database{db}, nil
+	return db, nil
 }
 
 // Other database setup functions
 
-func (db *Database) AutoMigrate() error {
-	l.Debug("Auto migrating database")
-
-	err := db.DB.AutoMigrate(
-		&model.GraphUser{},
-		&model.Entity{},
-		&model.ConnectionType{},
-		&model.PropertyType{},
-		&model.UsersEntity{},
-		&model.UsersConnectionType{},
-		&model.UsersPropertyType{},
-	)
-	if err != nil {
-		return e.New("Auto migration failed", ErrInternal, err)
-	}
-
-	l.Info("Auto migration successful")
-	return nil
-}
-
+// DropTables drops all tables, including the record of applied migrations. Only the reset command uses it.
 func (db *Database) DropTables() error {
 	l.Debug("Resetting tables")
 	err := db.Migrator().DropTable(
+		&au.Event{},
+		&model.WebhookDeadLetter{},
+		&model.WebhookDelivery{},
+		&model.Webhook{},
+		&model.GraphEvent{},
+		&model.Revision{},
+		&model.EntityMerge{},
+		&model.DefinitionVote{},
+		&model.Connection{},
+		&model.WorkspaceInvitation{},
+		&model.WorkspaceMember{},
+		&model.Workspace{},
 		&model.GraphUser{},
 		&model.Entity{},
 		&model.ConnectionType{},
@@ -86,6 +130,13 @@ func (db *Database) DropTables() error {
 		&model.UsersEntity{},
 		&model.UsersConnectionType{},
 		&model.UsersPropertyType{},
+		"entity_instances",
+		"entity_class_parents",
+		"connection_type_classes",
+		"property_type_classes",
+		&model.UsersEntityClass{},
+		&model.EntityClass{},
+		"schema_migrations",
 	)
 	if err != nil {
 		return e.New("Failed to reset tables", ErrInternal, err)
@@ -104,9 +155,14 @@ func (db *Database) CreateUser(ctx context.Context, req *model.UserRequest) erro
 
 	// Create the user object that will be stored in the DB
 	user := model.GraphUser{ID: req.ID}
-	res := db.WithContext(ctx).Create(&user)
-	if res.Error != nil {
-		return e.Wrap("Failed to create user", TranslateDatabaseError(res.Error))
+	err := db.transaction(ctx, func(tx *gorm.DB) error {
+		if err := tx.Create(&user).Error; err != nil {
+			return e.Wrap("Failed to create user", TranslateDatabaseError(err))
+		}
+		return nil
+	})
+	if err != nil {
+		return err
 	}
 
 	l.Info("Created user", l.String("user_id", user.ID), l.String("request_id", r.GetRequestID(ctx)))
@@ -114,30 +170,40 @@ func (db *Database) CreateUser(ctx context.Context, req *model.UserRequest) erro
 	return nil
 }
 
-// GetUserData gets the user by ID (from the context) and preloads the user's entities, connection types, and property types.
-func (db *Database) GetUserData(ctx context.Context) (*model.UserDataResponse, error) {
+// GetUserData gets the owner (user or workspace) from the context and preloads the user's entities, connection types, property types, connections and entity classes.
+// If a point in time is given, the graph is rebuilt as it was then.
+func (db *Database) GetUserData(ctx context.Context, req *model.UserDataRequest) (*model.UserDataResponse, error) {
 	// Get ID from context
-	userID := a.GetUserID(ctx)
-	if userID == "" {
-		return nil, e.New("Failed to get user ID from context", ErrInternal, nil)
+	ownerID := getOwnerID(ctx)
+	if ownerID == "" {
+		return nil, e.New("Failed to get owner ID from context", ErrInternal, nil)
 	}
 
 	l.Debug("Getting user",
-		l.String("user_id", userID),
+		l.String("owner_id", ownerID),
+		l.Bool("as_of", req.AsOf != nil),
 		l.String("request_id", r.GetRequestID(ctx)))
 
+	reader := db.reader()
+
+	if req.AsOf != nil {
+		return reader.getUserDataAsOf(ctx, ownerID, *req.AsOf)
+	}
+
 	var user model.GraphUser
-	res := db.WithContext(ctx).
+	res := reader.WithContext(ctx).
 		Preload("UsersEntities").
-		Preload("UsersConnectionTypes").
+		Preload("UsersConnectionTypes.ConnectionType").
 		Preload("UsersPropertyTypes").
-		First(&user, "id = ?", userID)
+		Preload("Connections").
+		Preload("UsersEntityClasses").
+		First(&user, "id = ?", ownerID)
 	if res.Error != nil {
 		return nil, e.Wrap("Failed to get user", TranslateDatabaseError(res.Error))
 	}
 
 	// Translate to response
-	userData, err := db.translateUserToResponse(ctx, &user)
+	userData, err := reader.translateUserToResponse(ctx, &user)
 	if err != nil {
 		return nil, e.Wrap("Failed to translate user to response", err)
 	}
@@ -148,107 +214,128 @@ func (db *Database) GetUserData(ctx context.Context) (*model.UserDataResponse, e
 // CreateEntity creates a UserEntity and creates an Entity if one does not already exist.
 func (db *Database) CreateEntity(ctx context.Context, req *model.EntityRequest) (*model.UsersEntity, error) {
 	// Get ID from context
-	userID := a.GetUserID(ctx)
-	if userID == "" {
-		return nil, e.New("Failed to get user ID from context", ErrInternal, nil)
+	ownerID := getOwnerID(ctx)
+	if ownerID == "" {
+		return nil, e.New("Failed to get owner ID from context", ErrInternal, nil)
 	}
 
 	l.Debug("Creating entity",
-		l.String("user_id", userID),
+		l.String("owner_id", ownerID),
 		l.String("name", req.Name),
 		l.String("definition", req.Definition),
 		l.String("entity_id", req.ID),
 		l.String("request_id", r.GetRequestID(ctx)))
 
-	// Start transaction
-	tx := db.WithContext(ctx).Begin()
-	if tx.Error != nil {
-		return nil, e.Wrap("Could not start transaction", TranslateDatabaseError(tx.Error))
-	}
-	defer func() {
-		if r := recover(); r != nil {
-			tx.Rollback()
+	var userEntity model.UsersEntity
+	err := db.transaction(ctx, func(tx *gorm.DB) error {
+		var entity model.Entity
+		if req.ID != "" {
+			// Check if entity exists (it must)
+			if err := tx.First(&entity, "id = ?", req.ID).Error; err != nil {
+				return e.Wrap("Could not find entity", TranslateDatabaseError(err))
+			}
+		} else {
+			// Create new entity
+			if err := tx.Create(&entity).Error; err != nil {
+				return e.Wrap("Could not create entity", TranslateDatabaseError(err))
+			}
 		}
-	}()
 
-	var entity model.Entity
-	if req.ID != "" {
-		// Check if entity exists
-		if err := tx.First(&entity, "id = ?", req.ID).Error; err != nil {
-			tx.Rollback() // Even if ErrRecordNotFound, rollback (entity must exist)
-			return nil, e.Wrap("Could not find entity", TranslateDatabaseError(err))
+		// Create user-entity relationship
+		userEntity = model.UsersEntity{
+			UserID:     ownerID,
+			EntityID:   entity.ID,
+			Name:       req.Name,
+			Definition: req.Definition,
 		}
-	} else {
-		// Create new entity
-		entity = model.Entity{}
-		if err := tx.Create(&entity).Error; err != nil {
-			tx.Rollback()
-			return nil, e.Wrap("Could not create entity", TranslateDatabaseError(err))
+		if err := tx.Create(&userEntity).Error; err != nil {
+			return e.Wrap("Could not create userEntity", TranslateDatabaseError(err))
 		}
-	}
-
-	// Create user-entity relationship
-	userEntity := model.UsersEntity{
-		UserID:     userID,
-		EntityID:   entity.ID,
-		Name:       req.Name,
-		Definition: req.Definition,
-	}
-	if err := tx.Create(&userEntity).Error; err != nil {
-		tx.Rollback()
-		return nil, e.Wrap("Could not create userEntity", TranslateDatabaseError(err))
-	}
-
-	// Commit transaction
-	if err := tx.Commit().Error; err != nil {
-		return nil, e.Wrap("Could not commit", TranslateDatabaseError(err))
+		_, err := recordRevision(tx, model.KindEntity, model.RevisionCreate, ownerID, entity.ID, req.Name, req.Definition)
+		return err
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return &userEntity, nil
 }
 
-// UpdateEntity updates the UserEntity
+// UpdateEntity updates the UserEntity and records the new revision
 func (db *Database) UpdateEntity(ctx context.Context, req *model.EntityRequest) error {
 	// Get ID from context
-	userID := a.GetUserID(ctx)
-	if userID == "" {
-		return e.New("Failed to get user ID from context", ErrInternal, nil)
+	ownerID := getOwnerID(ctx)
+	if ownerID == "" {
+		return e.New("Failed to get owner ID from context", ErrInternal, nil)
 	}
 
 	l.Debug("Updating entity",
-		l.String("user_id", userID),
+		l.String("owner_id", ownerID),
 		l.String("name", req.Name),
 		l.String("definition", req.Definition),
 		l.String("entity_id", req.ID),
 		l.String("request_id", r.GetRequestID(ctx)))
 
-	res := db.WithContext(ctx).Model(&model.UsersEntity{}).
-		Where("user_id = ? AND entity_id = ?", userID, req.ID).
+	// Start transaction
+	tx := db.WithContext(ctx).Begin()
+	if tx.Error != nil {
+		return e.Wrap("Could not start transaction", TranslateDatabaseError(tx.Error))
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	res := tx.Model(&model.UsersEntity{}).
+		Where("user_id = ? AND entity_id = ?", ownerID, req.ID).
 		Updates(map[string]interface{}{
 			"name":       req.Name,
 			"definition": req.Definition,
 		})
 
 	if res.Error != nil {
+		tx.Rollback()
 		return e.Wrap("Failed to update entity", TranslateDatabaseError(res.Error))
 	}
 	if res.RowsAffected == 0 {
+		tx.Rollback()
 		return ErrRecordNotFound
 	}
+	if _, err := recordRevision(tx, model.KindEntity, model.RevisionUpdate, ownerID, req.ID, req.Name, req.Definition); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	// Commit transaction
+	if err := tx.Commit().Error; err != nil {
+		return e.Wrap("Could not commit", TranslateDatabaseError(err))
+	}
 	return nil
 }
 
-// FindEntitiesWithName finds the Entity that has the given name written in the UserEntity table.
+// FindEntitiesWithName finds the Entities that have the given name written in the UserEntity table.
+// Each entity is returned in its canonical version, which may have a different name.
+// If a class is given, only instances of the class and its subclasses are found.
+// If a point in time is given, the versions are searched as they were then. Classes are always the current ones.
 func (db *Database) FindEntitiesWithName(ctx context.Context, req *model.SearchRequest) ([]model.UsersEntity, error) {
 	l.Debug("Finding entities with name",
 		l.String("name", req.Name),
+		l.String("class_id", req.ClassID),
+		l.Bool("as_of", req.AsOf != nil),
 		l.String("request_id", r.GetRequestID(ctx)))
 
+	reader := db.reader()
+
+	query, args := canonicalVersionsWithNameQuery(ctx, model.KindEntity, req.AsOf, req.Name, "")
+	if req.ClassID != "" {
+		query, args = canonicalVersionsWithNameQuery(ctx, model.KindEntity, req.AsOf, req.Name,
+			` AND entity_id IN (`+instancesOfClassQuery+`)`, req.ClassID)
+	}
+
 	var userEntities []model.UsersEntity
-	res := db.WithContext(ctx).
-		Raw(`SELECT DISTINCT ON (entity_id) *
-             FROM users_entities
-             WHERE name = ?`, req.Name).
+	res := reader.WithContext(ctx).
+		Raw(query, args...).
 		Scan(&userEntities)
 
 	if res.Error != nil {
@@ -258,152 +345,193 @@ func (db *Database) FindEntitiesWithName(ctx context.Context, req *model.SearchR
 	return userEntities, nil
 }
 
+// GetEntities gets the canonical versions of the entities with the given IDs. Unknown IDs are skipped.
+// If a point in time is given, the versions are read as they were then.
+func (db *Database) GetEntities(ctx context.Context, req *model.BatchRequest) ([]model.UsersEntity, error) {
+	l.Debug("Getting entities",
+		l.Int("ids", len(req.IDs)),
+		l.Bool("as_of", req.AsOf != nil),
+		l.String("request_id", r.GetRequestID(ctx)))
+
+	if len(req.IDs) == 0 {
+		return nil, nil
+	}
+	reader := db.reader()
+
+	query, args := canonicalVersionsQuery(ctx, model.KindEntity, req.AsOf, "?", req.IDs)
+	var userEntities []model.UsersEntity
+	res := reader.WithContext(ctx).
+		Raw(query, args...).
+		Scan(&userEntities)
+	if res.Error != nil {
+		return nil, e.Wrap("Failed to get entities", TranslateDatabaseError(res.Error))
+	}
+	return userEntities, nil
+}
+
 // CreateConnectionType creates a UserConnectionType and creates a ConnectionType if one does not already exist.
 func (db *Database) CreateConnectionType(ctx context.Context, req *model.ConnectionTypeRequest) (*model.UsersConnectionType, error) {
 	// Get ID from context
-	userID := a.GetUserID(ctx)
-	if userID == "" {
-		return nil, e.New("Failed to get user ID from context", ErrInternal, nil)
+	ownerID := getOwnerID(ctx)
+	if ownerID == "" {
+		return nil, e.New("Failed to get owner ID from context", ErrInternal, nil)
 	}
 
 	l.Debug("Creating connection type",
-		l.String("user_id", userID),
+		l.String("owner_id", ownerID),
 		l.String("name", req.Name),
 		l.String("definition", req.Definition),
 		l.String("connection_type_id", req.ID),
 		l.String("request_id", r.GetRequestID(ctx)))
 
-	// Start transaction
-	tx := db.WithContext(ctx).Begin()
-	if tx.Error != nil {
-		return nil, e.Wrap("Could not start transaction", TranslateDatabaseError(tx.Error))
-	}
-	defer func() {
-		if r := recover(); r != nil {
-			tx.Rollback()
+	var userConnectionType model.UsersConnectionType
+	err := db.transaction(ctx, func(tx *gorm.DB) error {
+		var connectionType model.ConnectionType
+		if req.ID != "" {
+			// Check if connection type exists (it must)
+			if err := tx.First(&connectionType, "id = ?", req.ID).Error; err != nil {
+				return e.Wrap("Could not find connection type", TranslateDatabaseError(err))
+			}
+		} else {
+			// Create new connection type
+			created, err := createConnectionType(tx, req)
+			if err != nil {
+				return err
+			}
+			connectionType = *created
 		}
-	}()
 
-	var connectionType model.ConnectionType
-	if req.ID != "" {
-		// Check if connection type exists
-		if err := tx.First(&connectionType, "id = ?", req.ID).Error; err != nil {
-			tx.Rollback() // Even if ErrRecordNotFound, rollback (connection type must exist)
-			return nil, e.Wrap("Could not find connection type", TranslateDatabaseError(err))
+		// Create user-connection type relationship
+		userConnectionType = model.UsersConnectionType{
+			UserID:           ownerID,
+			ConnectionTypeID: connectionType.ID,
+			Name:             req.Name,
+			Definition:       req.Definition,
 		}
-	} else {
-		// Create new connection type
-		connectionType = model.ConnectionType{}
-		if err := tx.Create(&connectionType).Error; err != nil {
-			tx.Rollback()
-			return nil, e.Wrap("Could not create connection type", TranslateDatabaseError(err))
+		if err := tx.Create(&userConnectionType).Error; err != nil {
+			return e.Wrap("Could not create userConnectionType", TranslateDatabaseError(err))
 		}
-	}
-
-	// Create user-connection type relationship
-	userConnectionType := model.UsersConnectionType{
-		UserID:           userID,
-		ConnectionTypeID: connectionType.ID,
-		Name:             req.Name,
-		Definition:       req.Definition,
-	}
-	if err := tx.Create(&userConnectionType).Error; err != nil {
-		tx.Rollback()
-		return nil, e.Wrap("Could not create userConnectionType", TranslateDatabaseError(err))
-	}
-
-	// Commit transaction
-	if err := tx.Commit().Error; err != nil {
-		return nil, e.Wrap("Could not commit", TranslateDatabaseError(err))
+		if _, err := recordRevision(tx, model.KindConnectionType, model.RevisionCreate, ownerID, connectionType.ID, req.Name, req.Definition); err != nil {
+			return err
+		}
+		userConnectionType.ConnectionType = &connectionType
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return &userConnectionType, nil
 }
 
-// FindConnectionTypesWithName finds the ConnectionType that has the given name written in the UserConnectionType table.
+// FindConnectionTypesWithName finds the ConnectionTypes that have the given name written in the UserConnectionType table.
+// Each connection type is returned in its canonical version, which may have a different name.
+// If a point in time is given, the versions are searched as they were then.
 func (db *Database) FindConnectionTypesWithName(ctx context.Context, req *model.SearchRequest) ([]model.UsersConnectionType, error) {
 	l.Debug("Finding connection types with name",
 		l.String("name", req.Name),
+		l.Bool("as_of", req.AsOf != nil),
 		l.String("request_id", r.GetRequestID(ctx)))
 
+	reader := db.reader()
+
+	query, args := canonicalVersionsWithNameQuery(ctx, model.KindConnectionType, req.AsOf, req.Name, "")
 	var userConnectionTypes []model.UsersConnectionType
-	res := db.WithContext(ctx).
-		Raw(`SELECT DISTINCT ON (connection_type_id) *
-			 FROM users_connection_types
-			 WHERE name = ?`, req.Name).
+	res := reader.WithContext(ctx).
+		Raw(query, args...).
 		Scan(&userConnectionTypes)
 
 	if res.Error != nil {
 		return nil, e.Wrap("Failed to find connection types with name", TranslateDatabaseError(res.Error))
 	}
+	if err := reader.loadConnectionTypes(ctx, userConnectionTypes); err != nil {
+		return nil, err
+	}
+
+	return userConnectionTypes, nil
+}
+
+// GetConnectionTypes gets the canonical versions of the connection types with the given IDs. Unknown IDs are skipped.
+// If a point in time is given, the versions are read as they were then.
+func (db *Database) GetConnectionTypes(ctx context.Context, req *model.BatchRequest) ([]model.UsersConnectionType, error) {
+	l.Debug("Getting connection types",
+		l.Int("ids", len(req.IDs)),
+		l.Bool("as_of", req.AsOf != nil),
+		l.String("request_id", r.GetRequestID(ctx)))
+
+	if len(req.IDs) == 0 {
+		return nil, nil
+	}
+	reader := db.reader()
+
+	query, args := canonicalVersionsQuery(ctx, model.KindConnectionType, req.AsOf, "?", req.IDs)
+	var userConnectionTypes []model.UsersConnectionType
+	res := reader.WithContext(ctx).
+		Raw(query, args...).
+		Scan(&userConnectionTypes)
+	if res.Error != nil {
+		return nil, e.Wrap("Failed to get connection types", TranslateDatabaseError(res.Error))
+	}
+	if err := reader.loadConnectionTypes(ctx, userConnectionTypes); err != nil {
+		return nil, err
+	}
 	return userConnectionTypes, nil
 }
 
 // CreatePropertyType creates a UserPropertyType and creates a PropertyType if one does not already exist.
 func (db *Database) CreatePropertyType(ctx context.Context, req *model.PropertyTypeRequest) (*model.PropertyTypeResponse, error) {
 	// Get ID from context
-	userID := a.GetUserID(ctx)
-	if userID == "" {
-		return nil, e.New("Failed to get user ID from context", ErrInternal, nil)
+	ownerID := getOwnerID(ctx)
+	if ownerID == "" {
+		return nil, e.New("Failed to get owner ID from context", ErrInternal, nil)
 	}
 
 	l.Debug("Creating property type",
-		l.String("user_id", userID),
+		l.String("owner_id", ownerID),
 		l.String("name", req.Name),
 		l.String("definition", req.Definition),
 		l.String("property_type_id", req.ID),
 		l.String("request_id", r.GetRequestID(ctx)))
 
-	// Start transaction
-	tx := db.WithContext(ctx).Begin()
-	if tx.Error != nil {
-		return nil, e.Wrap("Could not start transaction", TranslateDatabaseError(tx.Error))
-	}
-	defer func() {
-		if r := recover(); r != nil {
-			tx.Rollback()
-		}
-	}()
-
 	var propertyType model.PropertyType
-	if req.ID != "" {
-		// Check if property type exists
-		if err := tx.First(&propertyType, "id = ?", req.ID).Error; err != nil {
-			tx.Rollback() // Even if ErrRecordNotFound, rollback (property type must exist)
-			return nil, e.Wrap("Could not find property type", TranslateDatabaseError(err))
+	var userPropertyType model.UsersPropertyType
+	err := db.transaction(ctx, func(tx *gorm.DB) error {
+		if req.ID != "" {
+			// Check if property type exists (it must)
+			propertyType = model.PropertyType{}
+			if err := tx.First(&propertyType, "id = ?", req.ID).Error; err != nil {
+				return e.Wrap("Could not find property type", TranslateDatabaseError(err))
+			}
+
+			// Check if property type value type is the same
+			if propertyType.ValueType != req.ValueType {
+				return e.New("Property type value type does not match", ErrInvalidRequest, nil)
+			}
+		} else {
+			// Create new property type
+			propertyType = model.PropertyType{
+				ValueType: req.ValueType,
+			}
+			if err := tx.Create(&propertyType).Error; err != nil {
+				return e.Wrap("Could not create property type", TranslateDatabaseError(err))
+			}
 		}
 
-		// Check if property type value type is the same
-		if propertyType.ValueType != req.ValueType {
-			tx.Rollback()
-			return nil, e.New("Property type value type does not match", ErrInvalidRequest, nil)
+		// Create user-property type relationship
+		userPropertyType = model.UsersPropertyType{
+			UserID:         ownerID,
+			PropertyTypeID: propertyType.ID,
+			Name:           req.Name,
+			Definition:     req.Definition,
 		}
-	} else {
-		// Create new property type
-		propertyType = model.PropertyType{
-			ValueType: req.ValueType,
+		if err := tx.Create(&userPropertyType).Error; err != nil {
+			return e.Wrap("Could not create userPropertyType", TranslateDatabaseError(err))
 		}
-		if err := tx.Create(&propertyType).Error; err != nil {
-			tx.Rollback()
-			return nil, e.Wrap("Could not create property type", TranslateDatabaseError(err))
-		}
-	}
-
-	// Create user-property type relationship
-	userPropertyType := model.UsersPropertyType{
-		UserID:         userID,
-		PropertyTypeID: propertyType.ID,
-		Name:           req.Name,
-		Definition:     req.Definition,
-	}
-	if err := tx.Create(&userPropertyType).Error; err != nil {
-		tx.Rollback()
-		return nil, e.Wrap("Could not create userPropertyType", TranslateDatabaseError(err))
-	}
-
-	// Commit transaction
-	if err := tx.Commit().Error; err != nil {
-		return nil, e.Wrap("Could not commit", TranslateDatabaseError(err))
+		_, err := recordRevision(tx, model.KindPropertyType, model.RevisionCreate, ownerID, propertyType.ID, req.Name, req.Definition)
+		return err
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	// Create response
@@ -412,36 +540,43 @@ func (db *Database) CreatePropertyType(ctx context.Context, req *model.PropertyT
 	return propertyTypeResponse, nil
 }
 
-// FindPropertyTypesWithName finds the PropertyType that has the given name written in the UserPropertyType table.
+// FindPropertyTypesWithName finds the PropertyTypes that have the given name written in the UserPropertyType table.
+// Each property type is returned in its canonical version, which may have a different name.
+// If a point in time is given, the versions are searched as they were then.
 func (db *Database) FindPropertyTypesWithName(ctx context.Context, req *model.SearchRequest) ([]model.PropertyTypeResponse, error) {
 	l.Debug("Finding property types with name",
 		l.String("name", req.Name),
+		l.Bool("as_of", req.AsOf != nil),
 		l.String("request_id", r.GetRequestID(ctx)))
 
-	var userPropertyTypes []model.UsersPropertyType
-	res := db.WithContext(ctx).
-		Raw(`SELECT DISTINCT ON (property_type_id) *
-			 FROM users_property_types
-			 WHERE name = ?`, req.Name).
-		Scan(&userPropertyTypes)
+	reader := db.reader()
+
+	query, args := canonicalVersionsWithNameQuery(ctx, model.KindPropertyType, req.AsOf, req.Name, "")
+	var propertyTypes []model.PropertyTypeResponse
+	res := reader.WithContext(ctx).
+		Raw(query, args...).
+		Scan(&propertyTypes)
 	if res.Error != nil {
 		return nil, e.Wrap("Failed to find property types with name", TranslateDatabaseError(res.Error))
 	}
 
-	// Translate to response
-	propertyTypes, err := db.translatePropertyTypesToResponse(ctx, userPropertyTypes)
-	if err != nil {
-		return nil, e.Wrap("Failed to translate property types to response", err)
-	}
-
 	return propertyTypes, nil
 }
 
 
 // HELPER FUNCTIONS
 
+// getOwnerID returns the graph user that owns the versions a request reads and writes:
+// the workspace for workspace-scoped requests, the authenticated user otherwise.
+func getOwnerID(ctx context.Context) string {
+	if workspaceID := a.GetWorkspaceID(ctx); workspaceID != "" {
+		return workspaceID
+	}
+	return a.GetUserID(ctx)
+}
+
 func (db *Database) translatePropertyTypesToResponse(ctx context.Context, usersPropertyTypes []model.UsersPropertyType) ([]model.PropertyTypeResponse, error) {
-	propertyTypes := make([]model.PropertyTypeResponse, len(usersPropertyTypes))
+	propertyTypes := make([]model.PropertyTypeResponse, 0, len(usersPropertyTypes))
 	for _, userPropertyType := range usersPropertyTypes {
 		// Get the property type so we can get the value type
 		propertyType := model.PropertyType{}
@@ -475,6 +610,8 @@ func (db *Database) translateUserToResponse(ctx context.Context, user *model.Gra
 		Entities:        user.UsersEntities,
 		ConnectionTypes: user.UsersConnectionTypes,
 		PropertyTypes:   propertyTypes,
+		Connections:     user.Connections,
+		EntityClasses:   user.UsersEntityClasses,
 	}, nil
 }
 
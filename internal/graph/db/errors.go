@@ -1,52 +1,55 @@
 package db
 
 import (
-	"strings"
-
 	e "github.com/BwezB/Wikno-backend/pkg/errors"
-	"gorm.io/gorm"
+	pe "github.com/BwezB/Wikno-backend/pkg/pgerrors"
 )
 
 var (
-    // Common errors
-    ErrInternal           = e.ErrInternal
+	// Common errors
+	ErrInternal = e.ErrInternal
 	// Common database error types
-	ErrDatabaseConnection = e.NewErrorType("DB_CONNECTION_ERROR", "database connection error")
-	ErrDuplicateEntry     = e.NewErrorType("DB_DUPLICATE_ENTRY", "resource already exists")
-	ErrRecordNotFound     = e.NewErrorType("DB_NOT_FOUND", "resource not found")
-	ErrInvalidRequest     = e.ErrInvalidRequest
+	ErrDatabaseConnection  = pe.ErrDatabaseConnection
+	ErrDuplicateEntry      = pe.ErrDuplicateEntry
+	ErrRecordNotFound      = pe.ErrRecordNotFound
+	ErrInvalidReference    = pe.ErrInvalidReference
+	ErrCheckViolation      = pe.ErrCheckViolation
+	ErrTransactionConflict = pe.ErrTransactionConflict
+	ErrInvalidRequest      = e.ErrInvalidRequest
+
+	// Constraint error types, which wrap the common ones
+	// ErrDuplicateVersion is returned when an owner creates a second version of the same node
+	ErrDuplicateVersion = e.NewErrorType("DB_DUPLICATE_VERSION", "owner already has a version of the node")
+	// ErrDuplicateConnection is returned when an owner creates the same connection twice
+	ErrDuplicateConnection = e.NewErrorType("DB_DUPLICATE_CONNECTION", "connection already exists")
+	// ErrDuplicateMember is returned when a user joins a workspace twice
+	ErrDuplicateMember = e.NewErrorType("DB_DUPLICATE_MEMBER", "user is already a member of the workspace")
+	// ErrDuplicateInvitation is returned when a user is invited to a workspace twice
+	ErrDuplicateInvitation = e.NewErrorType("DB_DUPLICATE_INVITATION", "user is already invited to the workspace")
+	// ErrInvalidValueType is returned for property types with an unknown value type
+	ErrInvalidValueType = e.NewErrorType("DB_INVALID_VALUE_TYPE", "invalid property value type")
+	// ErrInvalidRole is returned for workspace members and invitations with an unknown role
+	ErrInvalidRole = e.NewErrorType("DB_INVALID_ROLE", "invalid workspace role")
+
+	// ErrLastAdmin is returned when the last admin of a workspace would be demoted or removed
+	ErrLastAdmin = e.NewErrorType("DB_LAST_ADMIN", "a workspace must keep at least one admin")
 )
 
+// constraints are the constraints of the graph schema with their own error types
+var constraints = pe.Constraints{
+	"users_entities_pkey":            ErrDuplicateVersion,
+	"users_connection_types_pkey":    ErrDuplicateVersion,
+	"users_property_types_pkey":      ErrDuplicateVersion,
+	"users_entity_classes_pkey":      ErrDuplicateVersion,
+	"idx_connection":                 ErrDuplicateConnection,
+	"workspace_members_pkey":         ErrDuplicateMember,
+	"idx_workspace_invitee":          ErrDuplicateInvitation,
+	"chk_property_types_value_type":  ErrInvalidValueType,
+	"chk_workspace_members_role":     ErrInvalidRole,
+	"chk_workspace_invitations_role": ErrInvalidRole,
+}
+
 // TranslateDatabaseError converts GORM and postgres errors into internal application errors
 func TranslateDatabaseError(err error) error {
-	if err == nil {
-		return nil
-	}
-
-	// Check for GORM-specific errors
-	if e.Is(err, gorm.ErrRecordNotFound) {
-		return e.New("", ErrRecordNotFound, err)
-	} else if e.Is(err, gorm.ErrInvalidDB) {
-		return e.New("Invalid database connection", ErrDatabaseConnection, err)
-	} else if e.Is(err, gorm.ErrDuplicatedKey) {
-		return e.New("Resource already exists", ErrDuplicateEntry, err)
-	}
-	// Check for Postgres-specific errors
-	errMsg := err.Error()
-	switch {
-	case strings.Contains(errMsg, "connection refused"):
-		return e.New("Database connection failed", ErrDatabaseConnection, err)
-
-	case strings.Contains(errMsg, "duplicate key"):
-		return e.New("Resource already exists", ErrDuplicateEntry, err)
-
-	case strings.Contains(errMsg, "deadlock"):
-		return e.New("Database transaction conflict", ErrInternal, err)
-
-	case strings.Contains(errMsg, "foreign key"):
-		return e.New("Database constraint violation", ErrInternal, err)
-	}
-
-	// Default case - wrap as internal error
-	return e.New("Unexpected database error", ErrInternal, err)
+	return pe.Translate(err, constraints)
 }
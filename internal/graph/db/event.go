@@ -0,0 +1,90 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/BwezB/Wikno-backend/internal/graph/model"
+
+	e "github.com/BwezB/Wikno-backend/pkg/errors"
+	l "github.com/BwezB/Wikno-backend/pkg/log"
+	r "github.com/BwezB/Wikno-backend/pkg/requestid"
+)
+
+// stableEventsFilter only selects the events of transactions that have ended. No event can be committed
+// before them in (tx_id, seq) order anymore, so a reader that has seen them never misses one.
+const stableEventsFilter = `tx_id < pg_snapshot_xmin(pg_current_snapshot())::text::bigint`
+
+// afterPositionFilter selects the events after a position
+const afterPositionFilter = `(tx_id, seq) > (?, ?)`
+
+// GetEventsHead gets the position of the last stable graph event, or the zero position if there are none.
+func (db *Database) GetEventsHead(ctx context.Context) (model.EventPosition, error) {
+	var event model.GraphEvent
+	res := db.WithContext(ctx).
+		Select("tx_id", "seq").
+		Where(stableEventsFilter).
+		Order("tx_id DESC, seq DESC").
+		Limit(1).
+		Find(&event)
+	if res.Error != nil {
+		return model.EventPosition{}, e.Wrap("Failed to get head of graph events", TranslateDatabaseError(res.Error))
+	}
+	return model.EventPosition{TxID: event.TxID, Seq: event.Seq}, nil
+}
+
+// ListGraphEvents lists the stable graph events of the owner in the context after a position, in order.
+// If kinds are given, only events of those kinds are listed.
+func (db *Database) ListGraphEvents(ctx context.Context, after model.EventPosition, kinds []string, limit int) ([]model.GraphEvent, error) {
+	ownerID := getOwnerID(ctx)
+	if ownerID == "" {
+		return nil, e.New("Failed to get owner ID from context", ErrInternal, nil)
+	}
+	return db.ListOwnerGraphEvents(ctx, ownerID, after, kinds, limit)
+}
+
+// ListOwnerGraphEvents is ListGraphEvents for the given owner, for callers without an authenticated context.
+func (db *Database) ListOwnerGraphEvents(ctx context.Context, ownerID string, after model.EventPosition, kinds []string, limit int) ([]model.GraphEvent, error) {
+	query := db.WithContext(ctx).
+		Where("user_id = ?", ownerID).
+		Where(afterPositionFilter, after.TxID, after.Seq).
+		Where(stableEventsFilter)
+	if len(kinds) > 0 {
+		query = query.Where("kind IN ?", kinds)
+	}
+
+	var events []model.GraphEvent
+	if err := query.Order("tx_id, seq").Limit(limit).Find(&events).Error; err != nil {
+		return nil, e.Wrap("Failed to list graph events", TranslateDatabaseError(err))
+	}
+	return events, nil
+}
+
+// ListEventOwners lists the owner and position of the stable graph events of all owners after a position, in order.
+func (db *Database) ListEventOwners(ctx context.Context, after model.EventPosition, limit int) ([]model.GraphEvent, error) {
+	var events []model.GraphEvent
+	res := db.WithContext(ctx).
+		Select("user_id", "tx_id", "seq").
+		Where(afterPositionFilter, after.TxID, after.Seq).
+		Where(stableEventsFilter).
+		Order("tx_id, seq").
+		Limit(limit).
+		Find(&events)
+	if res.Error != nil {
+		return nil, e.Wrap("Failed to list event owners", TranslateDatabaseError(res.Error))
+	}
+	return events, nil
+}
+
+// PruneGraphEvents deletes the graph events created before a point in time.
+func (db *Database) PruneGraphEvents(ctx context.Context, before time.Time) (int64, error) {
+	l.Debug("Pruning graph events",
+		l.String("before", before.Format(time.RFC3339)),
+		l.String("request_id", r.GetRequestID(ctx)))
+
+	res := db.WithContext(ctx).Where("created_at < ?", before).Delete(&model.GraphEvent{})
+	if res.Error != nil {
+		return 0, e.Wrap("Failed to prune graph events", TranslateDatabaseError(res.Error))
+	}
+	return res.RowsAffected, nil
+}
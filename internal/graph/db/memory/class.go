@@ -0,0 +1,244 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/BwezB/Wikno-backend/internal/graph/db"
+	"github.com/BwezB/Wikno-backend/internal/graph/model"
+
+	e "github.com/BwezB/Wikno-backend/pkg/errors"
+)
+
+// CreateEntityClass creates a users version of an entity class, and the class with the requested parents if no ID is given.
+func (s *Store) CreateEntityClass(ctx context.Context, req *model.EntityClassRequest) (*model.UsersEntityClass, error) {
+	ownerID := getOwnerID(ctx)
+	if ownerID == "" {
+		return nil, e.New("Failed to get owner ID from context", db.ErrInternal, nil)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.begin()
+
+	entityClassID, err := s.sharedNode(model.KindEntityClass, req.ID, ownerID, "entity class")
+	if err != nil {
+		return nil, err
+	}
+	if req.ID == "" {
+		if err := s.checkClasses(model.KindEntityClass, entityClassID, req.ParentIDs); err != nil {
+			return nil, err
+		}
+		s.nodes[model.KindEntityClass][entityClassID] = true
+		s.setClasses(model.KindEntityClass, entityClassID, req.ParentIDs)
+	}
+	v := s.createVersion(ctx, model.KindEntityClass, ownerID, entityClassID, req.Name, req.Definition)
+
+	entityClass := s.entityClass(ranked{version: v})
+	return &entityClass, nil
+}
+
+// FindEntityClassesWithName finds the entity classes with a version with the given name, each in its canonical version.
+// If a point in time is given, the versions are searched as they were then.
+func (s *Store) FindEntityClassesWithName(ctx context.Context, req *model.SearchRequest) ([]model.UsersEntityClass, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var entityClasses []model.UsersEntityClass
+	for _, v := range s.canonical(ctx, model.KindEntityClass, req.AsOf, s.namedNodes(ctx, model.KindEntityClass, req.AsOf, req.Name)) {
+		entityClasses = append(entityClasses, s.entityClass(v))
+	}
+	return entityClasses, nil
+}
+
+// SetClasses replaces the classes of a shared node: the classes an entity is an instance of,
+// the parents of a class, or the classes a connection or property type applies to.
+func (s *Store) SetClasses(ctx context.Context, req *model.ClassesRequest) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.nodes[req.Kind][req.ID] {
+		return e.New("Node not found", db.ErrRecordNotFound, nil)
+	}
+	if err := s.checkClasses(req.Kind, req.ID, req.ClassIDs); err != nil {
+		return err
+	}
+	s.setClasses(req.Kind, req.ID, req.ClassIDs)
+	return nil
+}
+
+// GetClasses gets the canonical versions of the classes directly linked to a shared node.
+func (s *Store) GetClasses(ctx context.Context, req *model.NodeRequest) ([]model.UsersEntityClass, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var classes []model.UsersEntityClass
+	for _, v := range s.canonical(ctx, model.KindEntityClass, nil, s.classLinks[req.Kind][req.ID]) {
+		classes = append(classes, s.entityClass(v))
+	}
+	return classes, nil
+}
+
+// GetClassesOfNodes gets the canonical versions of the classes directly linked to many shared nodes of a kind.
+// Each class is returned once for every node it is linked to.
+func (s *Store) GetClassesOfNodes(ctx context.Context, req *model.BatchNodeRequest) ([]model.NodeClass, error) {
+	if len(req.IDs) == 0 {
+		return nil, nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	classIDs := make(map[string]bool)
+	for _, id := range req.IDs {
+		for classID := range s.classLinks[req.Kind][id] {
+			classIDs[classID] = true
+		}
+	}
+	canonical := make(map[string]model.UsersEntityClass)
+	for _, v := range s.canonical(ctx, model.KindEntityClass, nil, classIDs) {
+		canonical[v.nodeID] = s.entityClass(v)
+	}
+
+	var classes []model.NodeClass
+	for _, id := range sortedIDs(idSet(req.IDs)) {
+		for _, classID := range sortedIDs(s.classLinks[req.Kind][id]) {
+			if class, ok := canonical[classID]; ok {
+				classes = append(classes, model.NodeClass{NodeID: id, UsersEntityClass: class})
+			}
+		}
+	}
+	return classes, nil
+}
+
+// GetApplicableTypes gets the canonical versions of the connection and property types that apply to
+// instances of a class, including the ones declared on its superclasses.
+func (s *Store) GetApplicableTypes(ctx context.Context, req *model.IDRequest) (*model.ApplicableTypesResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	superclasses := s.superclasses(req.ID)
+	applicable := func(kind string) map[string]bool {
+		ids := make(map[string]bool)
+		for id, classIDs := range s.classLinks[kind] {
+			for classID := range classIDs {
+				if superclasses[classID] {
+					ids[id] = true
+				}
+			}
+		}
+		return ids
+	}
+
+	var response model.ApplicableTypesResponse
+	for _, v := range s.canonical(ctx, model.KindConnectionType, nil, applicable(model.KindConnectionType)) {
+		response.ConnectionTypes = append(response.ConnectionTypes, s.connectionType(v))
+	}
+	for _, v := range s.canonical(ctx, model.KindPropertyType, nil, applicable(model.KindPropertyType)) {
+		response.PropertyTypes = append(response.PropertyTypes, s.propertyType(v))
+	}
+	return &response, nil
+}
+
+// HELPER FUNCTIONS
+
+// checkClasses checks that the classes exist and, for the parents of a class,
+// that the class does not become a parent of itself or of one of its superclasses
+func (s *Store) checkClasses(kind, id string, classIDs []string) error {
+	for _, classID := range classIDs {
+		if !s.nodes[model.KindEntityClass][classID] {
+			return e.New("Entity class not found", db.ErrRecordNotFound, nil)
+		}
+	}
+	if kind == model.KindEntityClass {
+		subclasses := s.subclasses(id)
+		for _, classID := range classIDs {
+			if subclasses[classID] {
+				return e.New("A class cannot be its own ancestor", db.ErrInvalidRequest, nil)
+			}
+		}
+	}
+	return nil
+}
+
+// setClasses replaces the classes linked to a shared node
+func (s *Store) setClasses(kind, id string, classIDs []string) {
+	delete(s.classLinks[kind], id)
+	if len(classIDs) > 0 {
+		s.classLinks[kind][id] = idSet(classIDs)
+	}
+}
+
+// subclasses returns the ID of a class and of every class below it in the hierarchy
+func (s *Store) subclasses(classID string) map[string]bool {
+	return s.hierarchy(classID, func(child, parent string) (string, string) { return parent, child })
+}
+
+// superclasses returns the ID of a class and of every class above it in the hierarchy
+func (s *Store) superclasses(classID string) map[string]bool {
+	return s.hierarchy(classID, func(child, parent string) (string, string) { return child, parent })
+}
+
+// hierarchy walks the parent links from a class, in the direction given by step,
+// which turns a link into the class it is followed from and the class it leads to
+func (s *Store) hierarchy(classID string, step func(child, parent string) (string, string)) map[string]bool {
+	found := map[string]bool{classID: true}
+	queue := []string{classID}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for child, parents := range s.classLinks[model.KindEntityClass] {
+			for parent := range parents {
+				from, to := step(child, parent)
+				if from == current && !found[to] {
+					found[to] = true
+					queue = append(queue, to)
+				}
+			}
+		}
+	}
+	return found
+}
+
+// instancesOfClass returns the IDs of the entities that are instances of a class, directly or through a subclass
+func (s *Store) instancesOfClass(classID string) map[string]bool {
+	subclasses := s.subclasses(classID)
+	instances := make(map[string]bool)
+	for entityID, classIDs := range s.classLinks[model.KindEntity] {
+		for id := range classIDs {
+			if subclasses[id] {
+				instances[entityID] = true
+			}
+		}
+	}
+	return instances
+}
+
+// copyClasses makes an entity an instance of the classes of another entity, and returns those classes.
+func (s *Store) copyClasses(fromID, toID string) []string {
+	classIDs := sortedIDs(s.classLinks[model.KindEntity][fromID])
+	if len(classIDs) == 0 {
+		return nil
+	}
+	if s.classLinks[model.KindEntity][toID] == nil {
+		s.classLinks[model.KindEntity][toID] = make(map[string]bool)
+	}
+	for _, classID := range classIDs {
+		s.classLinks[model.KindEntity][toID][classID] = true
+	}
+	return classIDs
+}
+
+// applicable returns whether a connection type applies to an entity: types without classes apply to every entity,
+// the others to instances of their classes and of the subclasses of those
+func (s *Store) applicable(connectionTypeID, entityID string) bool {
+	classIDs := s.classLinks[model.KindConnectionType][connectionTypeID]
+	if len(classIDs) == 0 {
+		return true
+	}
+	for classID := range classIDs {
+		if s.instancesOfClass(classID)[entityID] {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,359 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/BwezB/Wikno-backend/internal/graph/db"
+	"github.com/BwezB/Wikno-backend/internal/graph/model"
+
+	e "github.com/BwezB/Wikno-backend/pkg/errors"
+)
+
+// edge is a connection of the owner's graph, stored or implied by an inverse or symmetric type
+type edge struct {
+	id               string
+	connectionTypeID string
+	fromEntityID     string
+	toEntityID       string
+	inferred         bool
+}
+
+// CreateConnection creates a connection in the owner's graph.
+// Connections that are already stored or implied by an inverse or symmetric type are rejected,
+// as are connections whose entities do not satisfy the domain and range of the connection type,
+// and connections from entities that are not instances of the classes the connection type applies to.
+func (s *Store) CreateConnection(ctx context.Context, req *model.ConnectionRequest) (*model.Connection, error) {
+	ownerID := getOwnerID(ctx)
+	if ownerID == "" {
+		return nil, e.New("Failed to get owner ID from context", db.ErrInternal, nil)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.begin()
+
+	connectionType, ok := s.connectionTypes[req.ConnectionTypeID]
+	if !ok {
+		return nil, notFound("Could not find connection type")
+	}
+	if err := s.requireEntities(req.FromEntityID, req.ToEntityID); err != nil {
+		return nil, err
+	}
+
+	for _, d := range s.direct(ownerID, nil) {
+		if d.connectionTypeID == req.ConnectionTypeID && d.fromEntityID == req.FromEntityID && d.toEntityID == req.ToEntityID {
+			return nil, e.New("Connection already exists or is implied by another connection", db.ErrDuplicateEntry, nil)
+		}
+	}
+
+	if !s.applicable(req.ConnectionTypeID, req.FromEntityID) {
+		return nil, e.Wrap("From entity is outside the classes of the connection type",
+			e.New("Entity is not an instance of a class the connection type applies to", db.ErrInvalidRequest, nil))
+	}
+	if connectionType.DomainID != nil && !s.reaches(ownerID, req.FromEntityID, *connectionType.DomainID) {
+		return nil, e.Wrap("From entity is outside the domain of the connection type",
+			e.New("Entity does not satisfy the connection type constraints", db.ErrInvalidRequest, nil))
+	}
+	if connectionType.RangeID != nil && !s.reaches(ownerID, req.ToEntityID, *connectionType.RangeID) {
+		return nil, e.Wrap("To entity is outside the range of the connection type",
+			e.New("Entity does not satisfy the connection type constraints", db.ErrInvalidRequest, nil))
+	}
+
+	connection := &model.Connection{
+		ID:               uuid.New().String(),
+		UserID:           ownerID,
+		ConnectionTypeID: req.ConnectionTypeID,
+		FromEntityID:     req.FromEntityID,
+		ToEntityID:       req.ToEntityID,
+		CreatedAt:        s.now,
+	}
+	s.connections = append(s.connections, connection)
+	s.capture(model.KindConnection, nil, connectionChange(connection))
+
+	created := *connection
+	return &created, nil
+}
+
+// DeleteConnection deletes a stored connection of the owner's graph. The connections it implied disappear with it.
+// The connection is kept as deleted, so it can still be read as of earlier times.
+func (s *Store) DeleteConnection(ctx context.Context, req *model.IDRequest) error {
+	ownerID := getOwnerID(ctx)
+	if ownerID == "" {
+		return e.New("Failed to get owner ID from context", db.ErrInternal, nil)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.begin()
+
+	for _, connection := range s.connections {
+		if connection.ID == req.ID && connection.UserID == ownerID && !connection.DeletedAt.Valid {
+			old := connectionChange(connection)
+			connection.DeletedAt = gorm.DeletedAt{Time: s.now, Valid: true}
+			s.capture(model.KindConnection, old, nil)
+			return nil
+		}
+	}
+	return db.ErrRecordNotFound
+}
+
+// GetConnections gets the connections going out of an entity in the owner's graph, including inferred ones.
+// If a point in time is given, the connections are traversed as they were then.
+func (s *Store) GetConnections(ctx context.Context, req *model.TraversalRequest) ([]model.Connection, error) {
+	ownerID := getOwnerID(ctx)
+	if ownerID == "" {
+		return nil, e.New("Failed to get owner ID from context", db.ErrInternal, nil)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.traverse(ownerID, []string{req.EntityID}, req.ConnectionTypeID, req.AsOf), nil
+}
+
+// GetConnectionsOfEntities gets the connections going out of many entities in the owner's graph, including inferred ones.
+// If a point in time is given, the connections are traversed as they were then.
+func (s *Store) GetConnectionsOfEntities(ctx context.Context, req *model.BatchTraversalRequest) ([]model.Connection, error) {
+	ownerID := getOwnerID(ctx)
+	if ownerID == "" {
+		return nil, e.New("Failed to get owner ID from context", db.ErrInternal, nil)
+	}
+	if len(req.EntityIDs) == 0 {
+		return nil, nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.traverse(ownerID, req.EntityIDs, req.ConnectionTypeID, req.AsOf), nil
+}
+
+// HELPER FUNCTIONS
+
+// createConnectionType stores a shared connection type with the requested semantics.
+// A new inverse is linked back to the new type, so inverses always point at each other.
+func (s *Store) createConnectionType(id string, req *model.ConnectionTypeRequest) error {
+	connectionType := &model.ConnectionType{
+		ID: id,
+		ConnectionSemantics: model.ConnectionSemantics{
+			Symmetric:  req.Symmetric,
+			Transitive: req.Transitive,
+			InverseID:  optionalID(req.InverseID),
+			DomainID:   optionalID(req.DomainID),
+			RangeID:    optionalID(req.RangeID),
+		},
+	}
+
+	var inverse *model.ConnectionType
+	if req.InverseID != "" {
+		var ok bool
+		if inverse, ok = s.connectionTypes[req.InverseID]; !ok {
+			return notFound("Could not find inverse connection type")
+		}
+		if inverse.InverseID != nil || inverse.Symmetric {
+			return e.New("Inverse connection type already has an inverse or is symmetric", db.ErrInvalidRequest, nil)
+		}
+		if inverse.Transitive != req.Transitive {
+			return e.New("Inverse connection types must both be transitive or not", db.ErrInvalidRequest, nil)
+		}
+		// The domain of a type is the range of its inverse
+		if !sameID(inverse.DomainID, connectionType.RangeID) || !sameID(inverse.RangeID, connectionType.DomainID) {
+			return e.New("Domain and range must be the range and domain of the inverse connection type", db.ErrInvalidRequest, nil)
+		}
+	}
+
+	var entityIDs []string
+	for _, entityID := range []*string{connectionType.DomainID, connectionType.RangeID} {
+		if entityID != nil {
+			entityIDs = append(entityIDs, *entityID)
+		}
+	}
+	if err := s.requireEntities(entityIDs...); err != nil {
+		return err
+	}
+
+	s.nodes[model.KindConnectionType][id] = true
+	s.connectionTypes[id] = connectionType
+	if inverse != nil {
+		inverse.InverseID = &connectionType.ID
+	}
+	return nil
+}
+
+// direct returns the owner's connections that exist at asOf, or now if it is nil,
+// and the connections their inverse and symmetric types imply
+func (s *Store) direct(ownerID string, asOf *time.Time) []edge {
+	var edges []edge
+	for _, connection := range s.connections {
+		if connection.UserID != ownerID || !isLive(connection, asOf) {
+			continue
+		}
+		edges = append(edges, edge{
+			id:               connection.ID,
+			connectionTypeID: connection.ConnectionTypeID,
+			fromEntityID:     connection.FromEntityID,
+			toEntityID:       connection.ToEntityID,
+		})
+
+		connectionType := s.connectionTypes[connection.ConnectionTypeID]
+		if connectionType.InverseID != nil {
+			edges = append(edges, edge{
+				id:               connection.ID,
+				connectionTypeID: *connectionType.InverseID,
+				fromEntityID:     connection.ToEntityID,
+				toEntityID:       connection.FromEntityID,
+				inferred:         true,
+			})
+		}
+		if connectionType.Symmetric {
+			edges = append(edges, edge{
+				id:               connection.ID,
+				connectionTypeID: connection.ConnectionTypeID,
+				fromEntityID:     connection.ToEntityID,
+				toEntityID:       connection.FromEntityID,
+				inferred:         true,
+			})
+		}
+	}
+	return edges
+}
+
+// traverse returns the connections going out of the entities in the owner's graph, inferred ones included.
+// Connections implied by transitive types chain connections of the same type and have no ID.
+// If a connection type is given, only connections of that type are returned.
+func (s *Store) traverse(ownerID string, entityIDs []string, connectionTypeID string, asOf *time.Time) []model.Connection {
+	entities := idSet(entityIDs)
+	direct := s.direct(ownerID, asOf)
+	selected := func(d edge) bool {
+		return entities[d.fromEntityID] && (connectionTypeID == "" || d.connectionTypeID == connectionTypeID)
+	}
+
+	var outgoing []edge
+	for _, d := range direct {
+		if selected(d) {
+			outgoing = append(outgoing, d)
+		}
+	}
+
+	// Chain the connections of transitive types until no new ones are reached
+	var reach []edge
+	reached := make(map[edge]bool)
+	for _, d := range direct {
+		if selected(d) && s.connectionTypes[d.connectionTypeID].Transitive {
+			r := edge{connectionTypeID: d.connectionTypeID, fromEntityID: d.fromEntityID, toEntityID: d.toEntityID, inferred: true}
+			if !reached[r] {
+				reached[r] = true
+				reach = append(reach, r)
+			}
+		}
+	}
+	for i := 0; i < len(reach); i++ {
+		for _, d := range direct {
+			if d.connectionTypeID != reach[i].connectionTypeID || d.fromEntityID != reach[i].toEntityID {
+				continue
+			}
+			r := edge{connectionTypeID: d.connectionTypeID, fromEntityID: reach[i].fromEntityID, toEntityID: d.toEntityID, inferred: true}
+			if !reached[r] {
+				reached[r] = true
+				reach = append(reach, r)
+			}
+		}
+	}
+	for _, r := range reach {
+		if r.toEntityID != r.fromEntityID {
+			outgoing = append(outgoing, r)
+		}
+	}
+
+	// Every connection is returned once, stored ones before inferred ones
+	sort.Slice(outgoing, func(i, j int) bool {
+		x, y := outgoing[i], outgoing[j]
+		if x.fromEntityID != y.fromEntityID {
+			return x.fromEntityID < y.fromEntityID
+		}
+		if x.connectionTypeID != y.connectionTypeID {
+			return x.connectionTypeID < y.connectionTypeID
+		}
+		if x.toEntityID != y.toEntityID {
+			return x.toEntityID < y.toEntityID
+		}
+		if x.inferred != y.inferred {
+			return !x.inferred
+		}
+		return x.id < y.id
+	})
+	var connections []model.Connection
+	for i, d := range outgoing {
+		if i > 0 && d.fromEntityID == outgoing[i-1].fromEntityID && d.connectionTypeID == outgoing[i-1].connectionTypeID &&
+			d.toEntityID == outgoing[i-1].toEntityID {
+			continue
+		}
+		connections = append(connections, model.Connection{
+			ID:               d.id,
+			UserID:           ownerID,
+			ConnectionTypeID: d.connectionTypeID,
+			FromEntityID:     d.fromEntityID,
+			ToEntityID:       d.toEntityID,
+			Inferred:         d.inferred,
+		})
+	}
+	return connections
+}
+
+// reaches reports whether the entity is the target or reaches it through transitive connections in the owner's graph
+func (s *Store) reaches(ownerID, entityID, targetID string) bool {
+	direct := s.direct(ownerID, nil)
+	reached := map[string]bool{entityID: true}
+	queue := []string{entityID}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		if current == targetID {
+			return true
+		}
+		for _, d := range direct {
+			if d.fromEntityID == current && s.connectionTypes[d.connectionTypeID].Transitive && !reached[d.toEntityID] {
+				reached[d.toEntityID] = true
+				queue = append(queue, d.toEntityID)
+			}
+		}
+	}
+	return false
+}
+
+// requireEntities checks that all the entities exist
+func (s *Store) requireEntities(entityIDs ...string) error {
+	for _, id := range entityIDs {
+		if !s.nodes[model.KindEntity][id] {
+			return e.New("Entity not found", db.ErrRecordNotFound, nil)
+		}
+	}
+	return nil
+}
+
+// isLive reports whether a connection exists at asOf, or now if it is nil
+func isLive(connection *model.Connection, asOf *time.Time) bool {
+	if asOf == nil {
+		return !connection.DeletedAt.Valid
+	}
+	return !connection.CreatedAt.After(*asOf) && (!connection.DeletedAt.Valid || connection.DeletedAt.Time.After(*asOf))
+}
+
+func optionalID(id string) *string {
+	if id == "" {
+		return nil
+	}
+	return &id
+}
+
+func sameID(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
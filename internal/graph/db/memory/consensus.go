@@ -0,0 +1,84 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/BwezB/Wikno-backend/internal/graph/db"
+	"github.com/BwezB/Wikno-backend/internal/graph/model"
+
+	a "github.com/BwezB/Wikno-backend/pkg/auth"
+	e "github.com/BwezB/Wikno-backend/pkg/errors"
+)
+
+// Vote stores the vote of the user in the context on another owner's version of a shared node.
+// A value of 0 removes the vote.
+func (s *Store) Vote(ctx context.Context, req *model.VoteRequest) error {
+	userID := a.GetUserID(ctx)
+	if userID == "" {
+		return e.New("Failed to get user ID from context", db.ErrInternal, nil)
+	}
+	if req.AuthorID == userID {
+		return e.New("Users cannot vote on their own definitions", db.ErrInvalidRequest, nil)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// The version voted on must exist and be visible to the voter
+	if s.versions[req.Kind][versionKey{userID: req.AuthorID, nodeID: req.ID}] == nil || !s.visibleTo(ctx)(req.AuthorID) {
+		return e.New("Definition not found", db.ErrRecordNotFound, nil)
+	}
+
+	key := voteKey{voterID: userID, kind: req.Kind, targetID: req.ID, authorID: req.AuthorID}
+	if req.Value == 0 {
+		delete(s.votes, key)
+	} else {
+		s.votes[key] = req.Value
+	}
+	return nil
+}
+
+// GetEntityVersions gets all users' versions of an entity, the canonical version first.
+func (s *Store) GetEntityVersions(ctx context.Context, req *model.IDRequest) ([]model.UsersEntity, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var versions []model.UsersEntity
+	for _, v := range s.visibleVersionsOf(ctx, model.KindEntity, req.ID) {
+		versions = append(versions, s.entity(v))
+	}
+	if len(versions) == 0 {
+		return nil, e.New("Entity not found", db.ErrRecordNotFound, nil)
+	}
+	return versions, nil
+}
+
+// GetConnectionTypeVersions gets all users' versions of a connection type, the canonical version first.
+func (s *Store) GetConnectionTypeVersions(ctx context.Context, req *model.IDRequest) ([]model.UsersConnectionType, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var versions []model.UsersConnectionType
+	for _, v := range s.visibleVersionsOf(ctx, model.KindConnectionType, req.ID) {
+		versions = append(versions, s.connectionType(v))
+	}
+	if len(versions) == 0 {
+		return nil, e.New("Connection type not found", db.ErrRecordNotFound, nil)
+	}
+	return versions, nil
+}
+
+// GetPropertyTypeVersions gets all users' versions of a property type, the canonical version first.
+func (s *Store) GetPropertyTypeVersions(ctx context.Context, req *model.IDRequest) ([]model.PropertyTypeResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var versions []model.PropertyTypeResponse
+	for _, v := range s.visibleVersionsOf(ctx, model.KindPropertyType, req.ID) {
+		versions = append(versions, s.propertyType(v))
+	}
+	if len(versions) == 0 {
+		return nil, e.New("Property type not found", db.ErrRecordNotFound, nil)
+	}
+	return versions, nil
+}
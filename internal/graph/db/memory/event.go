@@ -0,0 +1,178 @@
+package memory
+
+import (
+	"context"
+	"reflect"
+	"time"
+
+	"github.com/BwezB/Wikno-backend/internal/graph/db"
+	"github.com/BwezB/Wikno-backend/internal/graph/model"
+
+	e "github.com/BwezB/Wikno-backend/pkg/errors"
+)
+
+// change is a live row of an event table, as the database trigger sees it
+type change struct {
+	userID string
+	nodeID string
+	data   map[string]interface{}
+}
+
+// GetEventsHead gets the position of the last graph event, or the zero position if there are none.
+func (s *Store) GetEventsHead(ctx context.Context) (model.EventPosition, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.events) == 0 {
+		return model.EventPosition{}, nil
+	}
+	last := s.events[len(s.events)-1]
+	return model.EventPosition{TxID: last.TxID, Seq: last.Seq}, nil
+}
+
+// ListGraphEvents lists the graph events of the owner in the context after a position, in order.
+// If kinds are given, only events of those kinds are listed.
+func (s *Store) ListGraphEvents(ctx context.Context, after model.EventPosition, kinds []string, limit int) ([]model.GraphEvent, error) {
+	ownerID := getOwnerID(ctx)
+	if ownerID == "" {
+		return nil, e.New("Failed to get owner ID from context", db.ErrInternal, nil)
+	}
+	return s.ListOwnerGraphEvents(ctx, ownerID, after, kinds, limit)
+}
+
+// ListOwnerGraphEvents is ListGraphEvents for the given owner, for callers without an authenticated context.
+func (s *Store) ListOwnerGraphEvents(ctx context.Context, ownerID string, after model.EventPosition, kinds []string, limit int) ([]model.GraphEvent, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	wanted := idSet(kinds)
+	var events []model.GraphEvent
+	for _, event := range s.events {
+		if limit >= 0 && len(events) == limit {
+			break
+		}
+		if event.UserID == ownerID && isAfter(event, after) && (len(kinds) == 0 || wanted[event.Kind]) {
+			events = append(events, copyEvent(event))
+		}
+	}
+	return events, nil
+}
+
+// ListEventOwners lists the owner and position of the graph events of all owners after a position, in order.
+func (s *Store) ListEventOwners(ctx context.Context, after model.EventPosition, limit int) ([]model.GraphEvent, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var events []model.GraphEvent
+	for _, event := range s.events {
+		if limit >= 0 && len(events) == limit {
+			break
+		}
+		if isAfter(event, after) {
+			events = append(events, model.GraphEvent{UserID: event.UserID, TxID: event.TxID, Seq: event.Seq})
+		}
+	}
+	return events, nil
+}
+
+// PruneGraphEvents deletes the graph events created before a point in time.
+func (s *Store) PruneGraphEvents(ctx context.Context, before time.Time) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	kept := s.events[:0]
+	for _, event := range s.events {
+		if !event.CreatedAt.Before(before) {
+			kept = append(kept, event)
+		}
+	}
+	pruned := int64(len(s.events) - len(kept))
+	s.events = kept
+	return pruned, nil
+}
+
+// HELPER FUNCTIONS
+
+// capture appends the graph events of a changed row, like the graph_events_capture trigger: rows that are created,
+// updated or deleted, where a row that moves to another node or owner is deleted and created.
+// A nil change is a row that does not exist or is soft deleted.
+func (s *Store) capture(kind string, old, new *change) {
+	if old != nil && new != nil && old.userID == new.userID && old.nodeID == new.nodeID {
+		if !reflect.DeepEqual(old.data, new.data) {
+			s.appendEvent(kind, model.EventUpdate, new)
+		}
+		return
+	}
+	if old != nil {
+		s.appendEvent(kind, model.EventDelete, old)
+	}
+	if new != nil {
+		s.appendEvent(kind, model.EventCreate, new)
+	}
+}
+
+// appendEvent appends a graph event in the change being made
+func (s *Store) appendEvent(kind, action string, row *change) {
+	s.seq++
+	s.events = append(s.events, model.GraphEvent{
+		Seq:       s.seq,
+		TxID:      s.txID,
+		UserID:    row.userID,
+		Kind:      kind,
+		NodeID:    row.nodeID,
+		Action:    action,
+		Data:      row.data,
+		CreatedAt: s.now,
+	})
+}
+
+// versionChange is the row of a version in the event data
+func versionChange(kind string, v *version) *change {
+	if v == nil {
+		return nil
+	}
+	return &change{
+		userID: v.userID,
+		nodeID: v.nodeID,
+		data: map[string]interface{}{
+			"user_id":       v.userID,
+			idColumns[kind]: v.nodeID,
+			"name":          v.name,
+			"definition":    v.definition,
+		},
+	}
+}
+
+// connectionChange is the row of a connection in the event data, or nil if it is deleted
+func connectionChange(connection *model.Connection) *change {
+	if connection == nil || connection.DeletedAt.Valid {
+		return nil
+	}
+	return &change{
+		userID: connection.UserID,
+		nodeID: connection.ID,
+		data: map[string]interface{}{
+			"id":                 connection.ID,
+			"user_id":            connection.UserID,
+			"connection_type_id": connection.ConnectionTypeID,
+			"from_entity_id":     connection.FromEntityID,
+			"to_entity_id":       connection.ToEntityID,
+			"created_at":         connection.CreatedAt.Format(time.RFC3339Nano),
+			"deleted_at":         nil,
+		},
+	}
+}
+
+func isAfter(event model.GraphEvent, position model.EventPosition) bool {
+	return event.TxID > position.TxID || event.TxID == position.TxID && event.Seq > position.Seq
+}
+
+// copyEvent copies the data of an event, so callers cannot change the stored one
+func copyEvent(event model.GraphEvent) model.GraphEvent {
+	data := make(map[string]interface{}, len(event.Data))
+	for key, value := range event.Data {
+		data[key] = value
+	}
+	event.Data = data
+	return event
+}
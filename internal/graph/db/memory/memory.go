@@ -0,0 +1,668 @@
+// Package memory keeps the graph of the graph service in memory.
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/BwezB/Wikno-backend/internal/graph/db"
+	"github.com/BwezB/Wikno-backend/internal/graph/model"
+
+	a "github.com/BwezB/Wikno-backend/pkg/auth"
+	e "github.com/BwezB/Wikno-backend/pkg/errors"
+	h "github.com/BwezB/Wikno-backend/pkg/health"
+)
+
+// Store is a db.GraphRepository that keeps everything in memory, with the semantics of the postgres database:
+// shared nodes with per-user versions, consensus, revisions, inferred connections, merges, workspaces,
+// webhooks and the graph events the database triggers write. Nothing is persisted, so it is meant for tests and local demos.
+type Store struct {
+	mu sync.Mutex
+
+	// txID and now identify the change being made, like the transaction of the database
+	txID int64
+	now  time.Time
+
+	users           map[string]bool
+	nodes           map[string]map[string]bool // Shared nodes of each kind
+	connectionTypes map[string]*model.ConnectionType
+	valueTypes      map[string]string                     // Value types of the property types
+	versions        map[string]map[versionKey]*version    // Users' versions of the shared nodes of each kind
+	classLinks      map[string]map[string]map[string]bool // Classes linked to the shared nodes of each kind
+	votes           map[voteKey]int
+	revisions       []model.Revision
+	connections     []*model.Connection // Deleted connections are kept, like in the database
+	merges          []*model.EntityMerge
+
+	workspaces  map[string]*model.Workspace
+	members     []*model.WorkspaceMember
+	invitations []*model.WorkspaceInvitation
+
+	events      []model.GraphEvent
+	seq         int64
+	webhooks    []*model.Webhook
+	deliveries  []model.WebhookDelivery
+	deadLetters []model.WebhookDeadLetter
+}
+
+var _ db.GraphRepository = (*Store)(nil)
+
+// versionKey identifies a users version of a shared node
+type versionKey struct {
+	userID string
+	nodeID string
+}
+
+// version is a users version of a shared node of any kind
+type version struct {
+	userID     string
+	nodeID     string
+	name       string
+	definition string
+}
+
+// voteKey identifies a vote, like the primary key of the definition_votes table
+type voteKey struct {
+	voterID  string
+	kind     string
+	targetID string
+	authorID string
+}
+
+// ranked is a version with its consensus
+type ranked struct {
+	version
+	score     int
+	userCount int
+}
+
+// idColumns are the columns referencing the shared node in the versions of each kind, used in graph event data
+var idColumns = map[string]string{
+	model.KindEntity:         "entity_id",
+	model.KindConnectionType: "connection_type_id",
+	model.KindPropertyType:   "property_type_id",
+	model.KindEntityClass:    "entity_class_id",
+}
+
+// New creates an empty store.
+func New() *Store {
+	s := &Store{
+		users:           make(map[string]bool),
+		nodes:           make(map[string]map[string]bool),
+		connectionTypes: make(map[string]*model.ConnectionType),
+		valueTypes:      make(map[string]string),
+		versions:        make(map[string]map[versionKey]*version),
+		classLinks:      make(map[string]map[string]map[string]bool),
+		votes:           make(map[voteKey]int),
+		workspaces:      make(map[string]*model.Workspace),
+	}
+	for kind := range idColumns {
+		s.nodes[kind] = make(map[string]bool)
+		s.versions[kind] = make(map[versionKey]*version)
+		s.classLinks[kind] = make(map[string]map[string]bool)
+	}
+	return s
+}
+
+// CRUD
+
+// CreateUser creates a new user
+func (s *Store) CreateUser(ctx context.Context, req *model.UserRequest) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.users[req.ID] {
+		return duplicate("Failed to create user", nil)
+	}
+	s.users[req.ID] = true
+	return nil
+}
+
+// GetUserData gets the versions and connections of the owner (user or workspace) in the context.
+// If a point in time is given, the graph is rebuilt as it was then.
+func (s *Store) GetUserData(ctx context.Context, req *model.UserDataRequest) (*model.UserDataResponse, error) {
+	ownerID := getOwnerID(ctx)
+	if ownerID == "" {
+		return nil, e.New("Failed to get owner ID from context", db.ErrInternal, nil)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.users[ownerID] {
+		return nil, notFound("Failed to get user")
+	}
+
+	userData := model.UserDataResponse{ID: ownerID}
+	owned := func(kind string) []ranked {
+		var versions []ranked
+		for _, v := range s.versionsAt(kind, req.AsOf) {
+			if v.userID == ownerID {
+				versions = append(versions, ranked{version: v})
+			}
+		}
+		return versions
+	}
+	for _, v := range owned(model.KindEntity) {
+		userData.Entities = append(userData.Entities, s.entity(v))
+	}
+	for _, v := range owned(model.KindConnectionType) {
+		userData.ConnectionTypes = append(userData.ConnectionTypes, s.connectionType(v))
+	}
+	for _, v := range owned(model.KindPropertyType) {
+		userData.PropertyTypes = append(userData.PropertyTypes, s.propertyType(v))
+	}
+	for _, v := range owned(model.KindEntityClass) {
+		userData.EntityClasses = append(userData.EntityClasses, s.entityClass(v))
+	}
+
+	for _, connection := range s.connections {
+		if connection.UserID == ownerID && isLive(connection, req.AsOf) {
+			userData.Connections = append(userData.Connections, *connection)
+		}
+	}
+	return &userData, nil
+}
+
+// CreateEntity creates a users version of an entity, and the entity if no ID is given.
+func (s *Store) CreateEntity(ctx context.Context, req *model.EntityRequest) (*model.UsersEntity, error) {
+	ownerID := getOwnerID(ctx)
+	if ownerID == "" {
+		return nil, e.New("Failed to get owner ID from context", db.ErrInternal, nil)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.begin()
+
+	entityID, err := s.sharedNode(model.KindEntity, req.ID, ownerID, "entity")
+	if err != nil {
+		return nil, err
+	}
+	s.nodes[model.KindEntity][entityID] = true
+	v := s.createVersion(ctx, model.KindEntity, ownerID, entityID, req.Name, req.Definition)
+
+	entity := s.entity(ranked{version: v})
+	return &entity, nil
+}
+
+// UpdateEntity updates the owner's version of an entity and records the new revision
+func (s *Store) UpdateEntity(ctx context.Context, req *model.EntityRequest) error {
+	ownerID := getOwnerID(ctx)
+	if ownerID == "" {
+		return e.New("Failed to get owner ID from context", db.ErrInternal, nil)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.begin()
+
+	key := versionKey{userID: ownerID, nodeID: req.ID}
+	if s.versions[model.KindEntity][key] == nil {
+		return db.ErrRecordNotFound
+	}
+	s.putVersion(model.KindEntity, version{userID: ownerID, nodeID: req.ID, name: req.Name, definition: req.Definition})
+	s.recordRevision(ctx, model.KindEntity, model.RevisionUpdate, ownerID, req.ID, req.Name, req.Definition)
+	return nil
+}
+
+// FindEntitiesWithName finds the entities with a version with the given name, each in its canonical version.
+// If a class is given, only instances of the class and its subclasses are found.
+// If a point in time is given, the versions are searched as they were then. Classes are always the current ones.
+func (s *Store) FindEntitiesWithName(ctx context.Context, req *model.SearchRequest) ([]model.UsersEntity, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ids := s.namedNodes(ctx, model.KindEntity, req.AsOf, req.Name)
+	if req.ClassID != "" {
+		instances := s.instancesOfClass(req.ClassID)
+		for id := range ids {
+			if !instances[id] {
+				delete(ids, id)
+			}
+		}
+	}
+
+	var entities []model.UsersEntity
+	for _, v := range s.canonical(ctx, model.KindEntity, req.AsOf, ids) {
+		entities = append(entities, s.entity(v))
+	}
+	return entities, nil
+}
+
+// GetEntities gets the canonical versions of the entities with the given IDs. Unknown IDs are skipped.
+// If a point in time is given, the versions are read as they were then.
+func (s *Store) GetEntities(ctx context.Context, req *model.BatchRequest) ([]model.UsersEntity, error) {
+	if len(req.IDs) == 0 {
+		return nil, nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var entities []model.UsersEntity
+	for _, v := range s.canonical(ctx, model.KindEntity, req.AsOf, idSet(req.IDs)) {
+		entities = append(entities, s.entity(v))
+	}
+	return entities, nil
+}
+
+// CreateConnectionType creates a users version of a connection type, and the connection type with the requested
+// semantics if no ID is given.
+func (s *Store) CreateConnectionType(ctx context.Context, req *model.ConnectionTypeRequest) (*model.UsersConnectionType, error) {
+	ownerID := getOwnerID(ctx)
+	if ownerID == "" {
+		return nil, e.New("Failed to get owner ID from context", db.ErrInternal, nil)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.begin()
+
+	connectionTypeID, err := s.sharedNode(model.KindConnectionType, req.ID, ownerID, "connection type")
+	if err != nil {
+		return nil, err
+	}
+	if req.ID == "" {
+		if err := s.createConnectionType(connectionTypeID, req); err != nil {
+			return nil, err
+		}
+	}
+	v := s.createVersion(ctx, model.KindConnectionType, ownerID, connectionTypeID, req.Name, req.Definition)
+
+	connectionType := s.connectionType(ranked{version: v})
+	return &connectionType, nil
+}
+
+// FindConnectionTypesWithName finds the connection types with a version with the given name, each in its canonical version.
+// If a point in time is given, the versions are searched as they were then.
+func (s *Store) FindConnectionTypesWithName(ctx context.Context, req *model.SearchRequest) ([]model.UsersConnectionType, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var connectionTypes []model.UsersConnectionType
+	for _, v := range s.canonical(ctx, model.KindConnectionType, req.AsOf, s.namedNodes(ctx, model.KindConnectionType, req.AsOf, req.Name)) {
+		connectionTypes = append(connectionTypes, s.connectionType(v))
+	}
+	return connectionTypes, nil
+}
+
+// GetConnectionTypes gets the canonical versions of the connection types with the given IDs. Unknown IDs are skipped.
+// If a point in time is given, the versions are read as they were then.
+func (s *Store) GetConnectionTypes(ctx context.Context, req *model.BatchRequest) ([]model.UsersConnectionType, error) {
+	if len(req.IDs) == 0 {
+		return nil, nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var connectionTypes []model.UsersConnectionType
+	for _, v := range s.canonical(ctx, model.KindConnectionType, req.AsOf, idSet(req.IDs)) {
+		connectionTypes = append(connectionTypes, s.connectionType(v))
+	}
+	return connectionTypes, nil
+}
+
+// CreatePropertyType creates a users version of a property type, and the property type if no ID is given.
+// The value type must match the one of an existing property type.
+func (s *Store) CreatePropertyType(ctx context.Context, req *model.PropertyTypeRequest) (*model.PropertyTypeResponse, error) {
+	ownerID := getOwnerID(ctx)
+	if ownerID == "" {
+		return nil, e.New("Failed to get owner ID from context", db.ErrInternal, nil)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.begin()
+
+	if req.ID != "" && s.nodes[model.KindPropertyType][req.ID] && s.valueTypes[req.ID] != req.ValueType {
+		return nil, e.New("Property type value type does not match", db.ErrInvalidRequest, nil)
+	}
+	propertyTypeID, err := s.sharedNode(model.KindPropertyType, req.ID, ownerID, "property type")
+	if err != nil {
+		return nil, err
+	}
+	s.nodes[model.KindPropertyType][propertyTypeID] = true
+	if req.ID == "" {
+		s.valueTypes[propertyTypeID] = req.ValueType
+	}
+	v := s.createVersion(ctx, model.KindPropertyType, ownerID, propertyTypeID, req.Name, req.Definition)
+
+	propertyType := s.propertyType(ranked{version: v})
+	return &propertyType, nil
+}
+
+// FindPropertyTypesWithName finds the property types with a version with the given name, each in its canonical version.
+// If a point in time is given, the versions are searched as they were then.
+func (s *Store) FindPropertyTypesWithName(ctx context.Context, req *model.SearchRequest) ([]model.PropertyTypeResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var propertyTypes []model.PropertyTypeResponse
+	for _, v := range s.canonical(ctx, model.KindPropertyType, req.AsOf, s.namedNodes(ctx, model.KindPropertyType, req.AsOf, req.Name)) {
+		propertyTypes = append(propertyTypes, s.propertyType(v))
+	}
+	return propertyTypes, nil
+}
+
+// HEALTH CHECK
+
+func (s *Store) HealthCheck(ctx context.Context) *h.HealthStatus {
+	return &h.HealthStatus{
+		Healthy: true,
+		Time:    time.Now(),
+	}
+}
+
+// HELPER FUNCTIONS
+
+// getOwnerID returns the graph user that owns the versions a request reads and writes:
+// the workspace for workspace-scoped requests, the authenticated user otherwise.
+func getOwnerID(ctx context.Context) string {
+	if workspaceID := a.GetWorkspaceID(ctx); workspaceID != "" {
+		return workspaceID
+	}
+	return a.GetUserID(ctx)
+}
+
+// notFound and duplicate are the errors the database returns for missing and conflicting rows
+func notFound(msg string) error {
+	return e.Wrap(msg, e.New("", db.ErrRecordNotFound, nil))
+}
+
+// duplicate is the error of a unique constraint, wrapped in the error type of the constraint if it has one, like in postgres
+func duplicate(msg string, constraintType *e.AppError) error {
+	err := e.New("Resource already exists", db.ErrDuplicateEntry, nil)
+	if constraintType != nil {
+		err = e.New("", constraintType, err)
+	}
+	return e.Wrap(msg, err)
+}
+
+// begin starts a change. Its revisions and graph events share the time and transaction ID.
+func (s *Store) begin() {
+	s.txID++
+	s.now = time.Now()
+}
+
+// sharedNode returns the ID of the shared node a new version is created for: the requested one, which must exist,
+// or a new one. The owner must not have a version of the node yet. The caller stores a new node.
+func (s *Store) sharedNode(kind, id, ownerID, name string) (string, error) {
+	if id == "" {
+		return uuid.New().String(), nil
+	}
+	if !s.nodes[kind][id] {
+		return "", notFound("Could not find " + name)
+	}
+	if s.versions[kind][versionKey{userID: ownerID, nodeID: id}] != nil {
+		return "", duplicate("Could not create version of "+name, db.ErrDuplicateVersion)
+	}
+	return id, nil
+}
+
+// createVersion stores a new version and records its creation
+func (s *Store) createVersion(ctx context.Context, kind, ownerID, nodeID, name, definition string) version {
+	v := version{userID: ownerID, nodeID: nodeID, name: name, definition: definition}
+	s.putVersion(kind, v)
+	s.recordRevision(ctx, kind, model.RevisionCreate, ownerID, nodeID, name, definition)
+	return v
+}
+
+// putVersion creates or updates a version
+func (s *Store) putVersion(kind string, v version) {
+	key := versionKey{userID: v.userID, nodeID: v.nodeID}
+	old := s.versions[kind][key]
+	s.versions[kind][key] = &v
+	s.capture(kind, versionChange(kind, old), versionChange(kind, &v))
+}
+
+// deleteVersion deletes a version if it exists
+func (s *Store) deleteVersion(kind string, key versionKey) {
+	old := s.versions[kind][key]
+	if old == nil {
+		return
+	}
+	delete(s.versions[kind], key)
+	s.capture(kind, versionChange(kind, old), nil)
+}
+
+// versionsAt returns the versions of a kind, as they are now if asOf is nil,
+// or as they were at asOf, rebuilt from the latest revision of every version that is not a delete
+func (s *Store) versionsAt(kind string, asOf *time.Time) []version {
+	var versions []version
+	if asOf == nil {
+		for _, v := range s.versions[kind] {
+			versions = append(versions, *v)
+		}
+	} else {
+		latest := make(map[versionKey]model.Revision)
+		for _, revision := range s.revisions {
+			// Revisions are kept in the order they were recorded, so later ones replace earlier ones
+			if revision.Kind == kind && !revision.CreatedAt.After(*asOf) {
+				latest[versionKey{userID: revision.UserID, nodeID: revision.NodeID}] = revision
+			}
+		}
+		for _, revision := range latest {
+			if revision.Action != model.RevisionDelete {
+				versions = append(versions, version{userID: revision.UserID, nodeID: revision.NodeID, name: revision.Name, definition: revision.Definition})
+			}
+		}
+	}
+
+	sort.Slice(versions, func(i, j int) bool {
+		if versions[i].nodeID != versions[j].nodeID {
+			return versions[i].nodeID < versions[j].nodeID
+		}
+		return versions[i].userID < versions[j].userID
+	})
+	return versions
+}
+
+// visibleVersionsAt returns the versions of a kind at asOf, or now if it is nil, that are visible to the request
+func (s *Store) visibleVersionsAt(ctx context.Context, kind string, asOf *time.Time) []version {
+	visible := s.visibleTo(ctx)
+	var versions []version
+	for _, v := range s.versionsAt(kind, asOf) {
+		if visible(v.userID) {
+			versions = append(versions, v)
+		}
+	}
+	return versions
+}
+
+// visibleTo returns whether the versions of an owner are visible to the request: every user's,
+// but of the workspaces only the one the request is scoped to, like in the database
+func (s *Store) visibleTo(ctx context.Context) func(ownerID string) bool {
+	scope := a.GetWorkspaceID(ctx)
+	return func(ownerID string) bool {
+		_, workspace := s.workspaces[ownerID]
+		return !workspace || ownerID == scope
+	}
+}
+
+// rank adds the consensus to versions: the sum of the current votes on each, and the number of versions of its node.
+// The versions are ordered by node, then from the canonical version down: by score, then by how long they have been
+// on the node, and the owner ID only keeps the order stable.
+func (s *Store) rank(kind string, versions []version) []ranked {
+	since := make(map[versionKey]time.Time)
+	for _, revision := range s.revisions {
+		key := versionKey{userID: revision.UserID, nodeID: revision.NodeID}
+		if _, ok := since[key]; !ok && revision.Kind == kind {
+			since[key] = revision.CreatedAt
+		}
+	}
+
+	userCounts := make(map[string]int)
+	for _, v := range versions {
+		userCounts[v.nodeID]++
+	}
+	scores := make(map[versionKey]int)
+	for key, value := range s.votes {
+		if key.kind == kind {
+			scores[versionKey{userID: key.authorID, nodeID: key.targetID}] += value
+		}
+	}
+
+	rankedVersions := make([]ranked, len(versions))
+	for i, v := range versions {
+		rankedVersions[i] = ranked{
+			version:   v,
+			score:     scores[versionKey{userID: v.userID, nodeID: v.nodeID}],
+			userCount: userCounts[v.nodeID],
+		}
+	}
+	sort.SliceStable(rankedVersions, func(i, j int) bool {
+		if rankedVersions[i].nodeID != rankedVersions[j].nodeID {
+			return rankedVersions[i].nodeID < rankedVersions[j].nodeID
+		}
+		if rankedVersions[i].score != rankedVersions[j].score {
+			return rankedVersions[i].score > rankedVersions[j].score
+		}
+		sinceI, okI := since[versionKey{userID: rankedVersions[i].userID, nodeID: rankedVersions[i].nodeID}]
+		sinceJ, okJ := since[versionKey{userID: rankedVersions[j].userID, nodeID: rankedVersions[j].nodeID}]
+		if okI != okJ {
+			return okI // Versions without revisions go last
+		}
+		if !sinceI.Equal(sinceJ) {
+			return sinceI.Before(sinceJ)
+		}
+		return rankedVersions[i].userID < rankedVersions[j].userID
+	})
+	return rankedVersions
+}
+
+// canonical returns the canonical version, at asOf or now if it is nil, of the shared nodes of a kind with the given IDs,
+// among the versions visible to the request.
+func (s *Store) canonical(ctx context.Context, kind string, asOf *time.Time, ids map[string]bool) []ranked {
+	var canonical []ranked
+	for _, v := range s.rank(kind, s.visibleVersionsAt(ctx, kind, asOf)) {
+		if ids[v.nodeID] && (len(canonical) == 0 || canonical[len(canonical)-1].nodeID != v.nodeID) {
+			canonical = append(canonical, v)
+		}
+	}
+	return canonical
+}
+
+// visibleVersionsOf returns the current versions of a shared node that are visible to the request, the canonical version first
+func (s *Store) visibleVersionsOf(ctx context.Context, kind, nodeID string) []ranked {
+	var versions []ranked
+	for _, v := range s.rank(kind, s.visibleVersionsAt(ctx, kind, nil)) {
+		if v.nodeID == nodeID {
+			versions = append(versions, v)
+		}
+	}
+	return versions
+}
+
+// versionsOf returns all current versions of a shared node, including the private ones of workspaces, the canonical version first
+func (s *Store) versionsOf(kind, nodeID string) []ranked {
+	var versions []ranked
+	for _, v := range s.rank(kind, s.versionsAt(kind, nil)) {
+		if v.nodeID == nodeID {
+			versions = append(versions, v)
+		}
+	}
+	return versions
+}
+
+// namedNodes returns the IDs of the shared nodes of a kind that had a visible version with the name at asOf, or have one now
+func (s *Store) namedNodes(ctx context.Context, kind string, asOf *time.Time, name string) map[string]bool {
+	ids := make(map[string]bool)
+	for _, v := range s.visibleVersionsAt(ctx, kind, asOf) {
+		if v.name == name {
+			ids[v.nodeID] = true
+		}
+	}
+	return ids
+}
+
+func (s *Store) entity(v ranked) model.UsersEntity {
+	return model.UsersEntity{
+		UserID:     v.userID,
+		EntityID:   v.nodeID,
+		Name:       v.name,
+		Definition: v.definition,
+		Consensus:  model.Consensus{Score: v.score, UserCount: v.userCount},
+	}
+}
+
+func (s *Store) connectionType(v ranked) model.UsersConnectionType {
+	return model.UsersConnectionType{
+		UserID:           v.userID,
+		ConnectionTypeID: v.nodeID,
+		Name:             v.name,
+		Definition:       v.definition,
+		Consensus:        model.Consensus{Score: v.score, UserCount: v.userCount},
+		ConnectionType:   s.loadConnectionType(v.nodeID),
+	}
+}
+
+func (s *Store) propertyType(v ranked) model.PropertyTypeResponse {
+	return model.PropertyTypeResponse{
+		UserID:         v.userID,
+		PropertyTypeID: v.nodeID,
+		Name:           v.name,
+		Definition:     v.definition,
+		ValueType:      s.valueTypes[v.nodeID],
+		Consensus:      model.Consensus{Score: v.score, UserCount: v.userCount},
+	}
+}
+
+func (s *Store) entityClass(v ranked) model.UsersEntityClass {
+	return model.UsersEntityClass{
+		UserID:        v.userID,
+		EntityClassID: v.nodeID,
+		Name:          v.name,
+		Definition:    v.definition,
+		Consensus:     model.Consensus{Score: v.score, UserCount: v.userCount},
+	}
+}
+
+// loadConnectionType returns a copy of a shared connection type, so callers cannot change the stored one
+func (s *Store) loadConnectionType(id string) *model.ConnectionType {
+	stored, ok := s.connectionTypes[id]
+	if !ok {
+		return nil
+	}
+	connectionType := model.ConnectionType{
+		ID: stored.ID,
+		ConnectionSemantics: model.ConnectionSemantics{
+			InverseID:  copyID(stored.InverseID),
+			Symmetric:  stored.Symmetric,
+			Transitive: stored.Transitive,
+			DomainID:   copyID(stored.DomainID),
+			RangeID:    copyID(stored.RangeID),
+		},
+	}
+	return &connectionType
+}
+
+func copyID(id *string) *string {
+	if id == nil {
+		return nil
+	}
+	copied := *id
+	return &copied
+}
+
+func idSet(ids []string) map[string]bool {
+	set := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		set[id] = true
+	}
+	return set
+}
+
+func sortedIDs(set map[string]bool) []string {
+	ids := make([]string, 0, len(set))
+	for id := range set {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
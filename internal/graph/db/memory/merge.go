@@ -0,0 +1,453 @@
+package memory
+
+import (
+	"context"
+	"sort"
+
+	"github.com/google/uuid"
+
+	"github.com/BwezB/Wikno-backend/internal/graph/db"
+	"github.com/BwezB/Wikno-backend/internal/graph/model"
+
+	a "github.com/BwezB/Wikno-backend/pkg/auth"
+	e "github.com/BwezB/Wikno-backend/pkg/errors"
+)
+
+// HasVersion reports whether the owner in the context has a version of any of the shared nodes of a kind.
+func (s *Store) HasVersion(ctx context.Context, kind string, ids ...string) (bool, error) {
+	ownerID := getOwnerID(ctx)
+	if ownerID == "" {
+		return false, e.New("Failed to get owner ID from context", db.ErrInternal, nil)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, id := range ids {
+		if s.versions[kind][versionKey{userID: ownerID, nodeID: id}] != nil {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// GetMerge gets a merge record by ID.
+func (s *Store) GetMerge(ctx context.Context, req *model.IDRequest) (*model.EntityMerge, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	merge := s.findMerge(req.ID, "")
+	if merge == nil {
+		return nil, notFound("Failed to get merge")
+	}
+	found := *merge
+	return &found, nil
+}
+
+// ListMerges lists the merges and splits an entity was part of, newest first.
+func (s *Store) ListMerges(ctx context.Context, req *model.IDRequest) ([]model.EntityMerge, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var merges []model.EntityMerge
+	for i := len(s.merges) - 1; i >= 0; i-- {
+		if s.merges[i].SourceID == req.ID || s.merges[i].TargetID == req.ID {
+			merges = append(merges, *s.merges[i])
+		}
+	}
+	return merges, nil
+}
+
+// ProposeMerge records a proposal of the owner in the context to merge the source entity into the target entity.
+func (s *Store) ProposeMerge(ctx context.Context, req *model.MergeRequest) (*model.EntityMerge, error) {
+	ownerID := getOwnerID(ctx)
+	if ownerID == "" {
+		return nil, e.New("Failed to get owner ID from context", db.ErrInternal, nil)
+	}
+	userID := a.GetUserID(ctx)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.begin()
+
+	// Both entities must exist
+	if req.SourceID == req.TargetID || s.requireEntities(req.SourceID, req.TargetID) != nil {
+		return nil, e.New("Entity not found", db.ErrRecordNotFound, nil)
+	}
+
+	merge := &model.EntityMerge{
+		ID:             uuid.New().String(),
+		SourceID:       req.SourceID,
+		TargetID:       req.TargetID,
+		ProposedBy:     ownerID,
+		ProposerUserID: &userID,
+		Status:         model.MergeProposed,
+		CreatedAt:      s.now,
+		UpdatedAt:      s.now,
+	}
+	s.merges = append(s.merges, merge)
+
+	proposed := *merge
+	return &proposed, nil
+}
+
+// AcceptMerge merges the source entity of a proposed merge into its target entity, on behalf of the owner in the context.
+// Source versions move to the target entity, unless their owner already has a version of it, in which case they are dropped.
+// Votes follow the versions they were cast on, and connections and connection type constraints move to the target entity.
+// Everything that changed is stored in the merge snapshot.
+func (s *Store) AcceptMerge(ctx context.Context, req *model.IDRequest) (*model.EntityMerge, error) {
+	ownerID := getOwnerID(ctx)
+	if ownerID == "" {
+		return nil, e.New("Failed to get owner ID from context", db.ErrInternal, nil)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.begin()
+
+	merge := s.findMerge(req.ID, model.MergeProposed)
+	if merge == nil {
+		return nil, notFound("Could not find proposed merge")
+	}
+	if merge.IsProposer(ownerID, a.GetUserID(ctx)) {
+		return nil, e.New("Merges must be accepted by another user", db.ErrInvalidRequest, nil)
+	}
+
+	// Sort the source versions into moved and dropped ones
+	snapshot := model.MergeSnapshot{}
+	for _, v := range s.versionsOf(model.KindEntity, merge.SourceID) {
+		mv := model.MergedVersion{UserID: v.userID, Name: v.name, Definition: v.definition}
+		if s.versions[model.KindEntity][versionKey{userID: v.userID, nodeID: merge.TargetID}] != nil {
+			snapshot.Dropped = append(snapshot.Dropped, mv)
+		} else {
+			snapshot.Moved = append(snapshot.Moved, mv)
+		}
+	}
+	snapshot.Votes = s.entityVotes(merge.SourceID, "")
+
+	s.moveEntityVersions(ctx, merge.SourceID, merge.TargetID, versionOwners(snapshot.Moved))
+	snapshot.Connections = s.moveConnections(merge.SourceID, merge.TargetID, "")
+	snapshot.DomainOf, snapshot.RangeOf = s.moveConstraints(merge.SourceID, merge.TargetID)
+	snapshot.Classes = s.copyClasses(merge.SourceID, merge.TargetID)
+
+	// Whatever is left on the source entity was dropped
+	for _, v := range snapshot.Dropped {
+		s.recordRevision(ctx, model.KindEntity, model.RevisionDelete, v.UserID, merge.SourceID, v.Name, v.Definition)
+	}
+	for key := range s.votes {
+		if key.kind == model.KindEntity && key.targetID == merge.SourceID {
+			delete(s.votes, key)
+		}
+	}
+	for _, v := range snapshot.Dropped {
+		s.deleteVersion(model.KindEntity, versionKey{userID: v.UserID, nodeID: merge.SourceID})
+	}
+	delete(s.classLinks[model.KindEntity], merge.SourceID)
+	delete(s.nodes[model.KindEntity], merge.SourceID)
+
+	merge.Status = model.MergeMerged
+	merge.DecidedBy = &ownerID
+	merge.Snapshot = snapshot
+	merge.UpdatedAt = s.now
+
+	merged := *merge
+	return &merged, nil
+}
+
+// RevertMerge restores the source entity of an accepted merge from the merge snapshot, on behalf of the owner in the context.
+// Moved versions that were deleted since the merge stay deleted, edits made since the merge are kept.
+func (s *Store) RevertMerge(ctx context.Context, req *model.IDRequest) (*model.EntityMerge, error) {
+	ownerID := getOwnerID(ctx)
+	if ownerID == "" {
+		return nil, e.New("Failed to get owner ID from context", db.ErrInternal, nil)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.begin()
+
+	merge := s.findMerge(req.ID, model.MergeMerged)
+	if merge == nil {
+		return nil, notFound("Could not find accepted merge")
+	}
+	if s.nodes[model.KindEntity][merge.SourceID] {
+		return nil, duplicate("Could not restore source entity", db.ErrDuplicateVersion)
+	}
+	s.nodes[model.KindEntity][merge.SourceID] = true
+
+	// Move versions back, with the votes that followed them
+	s.moveEntityVersions(ctx, merge.TargetID, merge.SourceID, versionOwners(merge.Snapshot.Moved))
+
+	// Restore dropped versions with the votes they had before the merge
+	dropped := make(map[string]bool, len(merge.Snapshot.Dropped))
+	for _, v := range merge.Snapshot.Dropped {
+		dropped[v.UserID] = true
+		s.createVersion(ctx, model.KindEntity, v.UserID, merge.SourceID, v.Name, v.Definition)
+	}
+	for _, vote := range merge.Snapshot.Votes {
+		if dropped[vote.AuthorID] {
+			s.votes[voteKey{voterID: vote.VoterID, kind: model.KindEntity, targetID: merge.SourceID, authorID: vote.AuthorID}] = vote.Value
+		}
+	}
+
+	// Restore connections and constraints. Moved connections that were deleted since the merge stay deleted.
+	for _, snapshot := range merge.Snapshot.Connections {
+		if snapshot.Dropped {
+			s.restoreConnection(snapshot)
+			continue
+		}
+		if connection := s.findConnection(snapshot.ID); connection != nil {
+			s.setEndpoints(connection, snapshot.FromEntityID, snapshot.ToEntityID)
+		}
+	}
+	for _, id := range merge.Snapshot.DomainOf {
+		if connectionType, ok := s.connectionTypes[id]; ok {
+			connectionType.DomainID = &merge.SourceID
+		}
+	}
+	for _, id := range merge.Snapshot.RangeOf {
+		if connectionType, ok := s.connectionTypes[id]; ok {
+			connectionType.RangeID = &merge.SourceID
+		}
+	}
+
+	// Restore the classes of the source entity, the target entity keeps the ones it got from it
+	if len(merge.Snapshot.Classes) > 0 {
+		s.classLinks[model.KindEntity][merge.SourceID] = idSet(merge.Snapshot.Classes)
+	}
+
+	merge.Status = model.MergeReverted
+	merge.DecidedBy = &ownerID
+	merge.UpdatedAt = s.now
+
+	reverted := *merge
+	return &reverted, nil
+}
+
+// SplitEntity moves the version of the owner in the context off an entity into a new entity, with its votes and connections.
+// The split is recorded as a merge of the new entity (source) with the old one (target).
+func (s *Store) SplitEntity(ctx context.Context, req *model.IDRequest) (*model.EntityMerge, error) {
+	ownerID := getOwnerID(ctx)
+	if ownerID == "" {
+		return nil, e.New("Failed to get owner ID from context", db.ErrInternal, nil)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.begin()
+
+	v := s.versions[model.KindEntity][versionKey{userID: ownerID, nodeID: req.ID}]
+	if v == nil {
+		return nil, notFound("Could not find entity version")
+	}
+	if len(s.versionsOf(model.KindEntity, req.ID)) == 1 {
+		return nil, e.New("The only version of an entity cannot be split off", db.ErrInvalidRequest, nil)
+	}
+
+	entityID := uuid.New().String()
+	s.nodes[model.KindEntity][entityID] = true
+
+	snapshot := model.MergeSnapshot{
+		Moved: []model.MergedVersion{{UserID: v.userID, Name: v.name, Definition: v.definition}},
+		Votes: s.entityVotes(req.ID, ownerID),
+	}
+	s.moveEntityVersions(ctx, req.ID, entityID, []string{ownerID})
+	snapshot.Connections = s.moveConnections(req.ID, entityID, ownerID)
+	// The new entity starts as an instance of the same classes
+	snapshot.Classes = s.copyClasses(req.ID, entityID)
+
+	merge := &model.EntityMerge{
+		ID:         uuid.New().String(),
+		SourceID:   entityID,
+		TargetID:   req.ID,
+		ProposedBy: ownerID,
+		DecidedBy:  &ownerID,
+		Status:     model.MergeSplit,
+		Snapshot:   snapshot,
+		CreatedAt:  s.now,
+		UpdatedAt:  s.now,
+	}
+	s.merges = append(s.merges, merge)
+
+	split := *merge
+	return &split, nil
+}
+
+// HELPER FUNCTIONS
+
+// findMerge returns the stored merge with an ID, and the status if one is given
+func (s *Store) findMerge(id, status string) *model.EntityMerge {
+	for _, merge := range s.merges {
+		if merge.ID == id && (status == "" || merge.Status == status) {
+			return merge
+		}
+	}
+	return nil
+}
+
+// entityVotes returns the votes on the versions of an entity, only those on the author's version if one is given
+func (s *Store) entityVotes(entityID, authorID string) []model.MergedVote {
+	var votes []model.MergedVote
+	for key, value := range s.votes {
+		if key.kind == model.KindEntity && key.targetID == entityID && (authorID == "" || key.authorID == authorID) {
+			votes = append(votes, model.MergedVote{VoterID: key.voterID, AuthorID: key.authorID, Value: value})
+		}
+	}
+	sort.Slice(votes, func(i, j int) bool {
+		if votes[i].AuthorID != votes[j].AuthorID {
+			return votes[i].AuthorID < votes[j].AuthorID
+		}
+		return votes[i].VoterID < votes[j].VoterID
+	})
+	return votes
+}
+
+// moveEntityVersions re-points the owners' versions of an entity, and the votes on them, to another entity,
+// and records the move in the revisions of both entities.
+// The owners must not have a version of the other entity. Stale votes left on the other entity are removed first.
+func (s *Store) moveEntityVersions(ctx context.Context, fromID, toID string, owners []string) {
+	movedOwners := idSet(owners)
+	for _, ownerID := range owners {
+		from := versionKey{userID: ownerID, nodeID: fromID}
+		v := s.versions[model.KindEntity][from]
+		if v == nil {
+			continue
+		}
+		delete(s.versions[model.KindEntity], from)
+		moved := version{userID: ownerID, nodeID: toID, name: v.name, definition: v.definition}
+		s.versions[model.KindEntity][versionKey{userID: ownerID, nodeID: toID}] = &moved
+		s.capture(model.KindEntity, versionChange(model.KindEntity, v), versionChange(model.KindEntity, &moved))
+
+		s.recordRevision(ctx, model.KindEntity, model.RevisionDelete, ownerID, fromID, v.name, v.definition)
+		s.recordRevision(ctx, model.KindEntity, model.RevisionMove, ownerID, toID, v.name, v.definition)
+	}
+
+	for key := range s.votes {
+		if key.kind == model.KindEntity && key.targetID == toID && movedOwners[key.authorID] {
+			delete(s.votes, key)
+		}
+	}
+	for key, value := range s.votes {
+		if key.kind == model.KindEntity && key.targetID == fromID && movedOwners[key.authorID] {
+			delete(s.votes, key)
+			key.targetID = toID
+			s.votes[key] = value
+		}
+	}
+}
+
+// moveConnections re-points the connections of an entity to another entity, only those of the owner if one is given.
+// Deleted connections move too, so the entity can be removed. Connections the owner already has on the other entity
+// are dropped. It returns the connections as they were.
+func (s *Store) moveConnections(fromID, toID, ownerID string) []model.MergedConnection {
+	var snapshot []model.MergedConnection
+	for _, connection := range append([]*model.Connection(nil), s.connections...) {
+		if connection.FromEntityID != fromID && connection.ToEntityID != fromID || ownerID != "" && connection.UserID != ownerID {
+			continue
+		}
+		merged := model.MergedConnection{
+			ID:               connection.ID,
+			UserID:           connection.UserID,
+			ConnectionTypeID: connection.ConnectionTypeID,
+			FromEntityID:     connection.FromEntityID,
+			ToEntityID:       connection.ToEntityID,
+		}
+
+		newFrom, newTo := connection.FromEntityID, connection.ToEntityID
+		if newFrom == fromID {
+			newFrom = toID
+		}
+		if newTo == fromID {
+			newTo = toID
+		}
+
+		// Only live connections can be duplicates. Dropped connections are restored from the snapshot,
+		// so they are removed for good.
+		if !connection.DeletedAt.Valid && s.hasLiveConnection(connection, newFrom, newTo) {
+			merged.Dropped = true
+			s.removeConnection(connection)
+		} else {
+			s.setEndpoints(connection, newFrom, newTo)
+		}
+		snapshot = append(snapshot, merged)
+	}
+	return snapshot
+}
+
+// moveConstraints re-points the domains and ranges of connection types from an entity to another entity.
+// It returns the IDs of the connection types whose domain and range moved.
+func (s *Store) moveConstraints(fromID, toID string) (domainOf, rangeOf []string) {
+	for _, id := range sortedIDs(s.nodes[model.KindConnectionType]) {
+		connectionType := s.connectionTypes[id]
+		if connectionType.DomainID != nil && *connectionType.DomainID == fromID {
+			connectionType.DomainID = &toID
+			domainOf = append(domainOf, id)
+		}
+		if connectionType.RangeID != nil && *connectionType.RangeID == fromID {
+			connectionType.RangeID = &toID
+			rangeOf = append(rangeOf, id)
+		}
+	}
+	return domainOf, rangeOf
+}
+
+// hasLiveConnection reports whether the owner of a connection has another live connection of its type between the entities
+func (s *Store) hasLiveConnection(connection *model.Connection, fromID, toID string) bool {
+	for _, other := range s.connections {
+		if other.ID != connection.ID && !other.DeletedAt.Valid && other.UserID == connection.UserID &&
+			other.ConnectionTypeID == connection.ConnectionTypeID && other.FromEntityID == fromID && other.ToEntityID == toID {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Store) findConnection(id string) *model.Connection {
+	for _, connection := range s.connections {
+		if connection.ID == id {
+			return connection
+		}
+	}
+	return nil
+}
+
+// setEndpoints re-points a stored connection
+func (s *Store) setEndpoints(connection *model.Connection, fromID, toID string) {
+	old := connectionChange(connection)
+	connection.FromEntityID = fromID
+	connection.ToEntityID = toID
+	s.capture(model.KindConnection, old, connectionChange(connection))
+}
+
+// removeConnection deletes a stored connection for good
+func (s *Store) removeConnection(connection *model.Connection) {
+	for i, stored := range s.connections {
+		if stored == connection {
+			s.connections = append(s.connections[:i], s.connections[i+1:]...)
+			break
+		}
+	}
+	s.capture(model.KindConnection, connectionChange(connection), nil)
+}
+
+// restoreConnection stores a connection that a merge dropped again, as created now
+func (s *Store) restoreConnection(snapshot model.MergedConnection) {
+	connection := &model.Connection{
+		ID:               snapshot.ID,
+		UserID:           snapshot.UserID,
+		ConnectionTypeID: snapshot.ConnectionTypeID,
+		FromEntityID:     snapshot.FromEntityID,
+		ToEntityID:       snapshot.ToEntityID,
+		CreatedAt:        s.now,
+	}
+	s.connections = append(s.connections, connection)
+	s.capture(model.KindConnection, nil, connectionChange(connection))
+}
+
+func versionOwners(versions []model.MergedVersion) []string {
+	owners := make([]string, len(versions))
+	for i, v := range versions {
+		owners[i] = v.UserID
+	}
+	return owners
+}
@@ -0,0 +1,131 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/BwezB/Wikno-backend/internal/graph/db"
+	"github.com/BwezB/Wikno-backend/internal/graph/model"
+
+	a "github.com/BwezB/Wikno-backend/pkg/auth"
+	e "github.com/BwezB/Wikno-backend/pkg/errors"
+)
+
+// ListRevisions lists the revisions of a users version of a shared node, newest first.
+// The version is the one of the owner in the context, unless another owner is requested.
+// Only the user's own revisions and those of their workspaces are listed.
+func (s *Store) ListRevisions(ctx context.Context, req *model.RevisionsRequest) ([]model.Revision, error) {
+	ownerID := req.UserID
+	if ownerID == "" {
+		ownerID = getOwnerID(ctx)
+	}
+	if ownerID == "" {
+		return nil, e.New("Failed to get owner ID from context", db.ErrInternal, nil)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.readableBy(ctx, ownerID) {
+		return nil, nil
+	}
+	var revisions []model.Revision
+	for i := len(s.revisions) - 1; i >= 0; i-- {
+		revision := s.revisions[i]
+		if revision.UserID == ownerID && revision.Kind == req.Kind && revision.NodeID == req.ID {
+			revisions = append(revisions, revision)
+		}
+	}
+	return revisions, nil
+}
+
+// GetRevision gets a revision by ID, if it is one of the user's own or of their workspaces.
+func (s *Store) GetRevision(ctx context.Context, req *model.IDRequest) (*model.Revision, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, revision := range s.revisions {
+		if revision.ID == req.ID && s.readableBy(ctx, revision.UserID) {
+			return &revision, nil
+		}
+	}
+	return nil, notFound("Failed to get revision")
+}
+
+// RevertToRevision sets the name and definition of a version of the owner in the context back to one of its revisions.
+// A version that was moved off its node is restored if the node still exists. The revert is recorded as a new revision.
+func (s *Store) RevertToRevision(ctx context.Context, req *model.IDRequest) (*model.Revision, error) {
+	ownerID := getOwnerID(ctx)
+	if ownerID == "" {
+		return nil, e.New("Failed to get owner ID from context", db.ErrInternal, nil)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.begin()
+
+	// Only the owner's own revisions can be reverted to
+	var revision *model.Revision
+	for i := range s.revisions {
+		if s.revisions[i].ID == req.ID && s.revisions[i].UserID == ownerID {
+			revision = &s.revisions[i]
+		}
+	}
+	if revision == nil {
+		return nil, notFound("Could not find revision")
+	}
+	if revision.Action == model.RevisionDelete {
+		return nil, e.New("Cannot revert to a revision that removed the version", db.ErrInvalidRequest, nil)
+	}
+
+	// A version that was moved off the node is restored
+	key := versionKey{userID: ownerID, nodeID: revision.NodeID}
+	if s.versions[revision.Kind][key] == nil && !s.nodes[revision.Kind][revision.NodeID] {
+		return nil, e.New("The node of the revision no longer exists", db.ErrRecordNotFound, nil)
+	}
+	kind, nodeID, name, definition := revision.Kind, revision.NodeID, revision.Name, revision.Definition
+	s.putVersion(kind, version{userID: ownerID, nodeID: nodeID, name: name, definition: definition})
+
+	reverted := s.recordRevision(ctx, kind, model.RevisionRevert, ownerID, nodeID, name, definition)
+	return &reverted, nil
+}
+
+// HELPER FUNCTIONS
+
+// recordRevision appends a revision of a users version, authored by the user in the context.
+func (s *Store) recordRevision(ctx context.Context, kind, action, ownerID, nodeID, name, definition string) model.Revision {
+	authorID := a.GetUserID(ctx)
+	if authorID == "" {
+		authorID = ownerID
+	}
+
+	revision := model.Revision{
+		ID:         uuid.New().String(),
+		Kind:       kind,
+		NodeID:     nodeID,
+		UserID:     ownerID,
+		AuthorID:   authorID,
+		Action:     action,
+		Name:       name,
+		Definition: definition,
+		CreatedAt:  s.now,
+	}
+	s.revisions = append(s.revisions, revision)
+	return revision
+}
+
+// readableBy reports whether the user in the context can read the revisions of an owner:
+// the owner is the user or a workspace they are a member of
+func (s *Store) readableBy(ctx context.Context, ownerID string) bool {
+	userID := a.GetUserID(ctx)
+	if userID != "" && ownerID == userID {
+		return true
+	}
+	for _, member := range s.members {
+		if member.WorkspaceID == ownerID && member.UserID == userID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,277 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/BwezB/Wikno-backend/internal/graph/db"
+	"github.com/BwezB/Wikno-backend/internal/graph/model"
+
+	a "github.com/BwezB/Wikno-backend/pkg/auth"
+	e "github.com/BwezB/Wikno-backend/pkg/errors"
+)
+
+// CreateWebhook subscribes a URL to the graph events of the owner in the context, starting after the current ones.
+func (s *Store) CreateWebhook(ctx context.Context, req *model.WebhookRequest, secret string) (*model.Webhook, error) {
+	userID := a.GetUserID(ctx)
+	ownerID := getOwnerID(ctx)
+	if userID == "" || ownerID == "" {
+		return nil, e.New("Failed to get user ID from context", db.ErrInternal, nil)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	webhook := &model.Webhook{
+		ID:        uuid.New().String(),
+		UserID:    ownerID,
+		URL:       req.URL,
+		Kinds:     append([]string(nil), req.Kinds...),
+		Secret:    secret,
+		CreatedBy: userID,
+		CreatedAt: time.Now(),
+	}
+	if len(s.events) > 0 {
+		last := s.events[len(s.events)-1]
+		webhook.CursorTxID, webhook.CursorSeq = last.TxID, last.Seq
+	}
+	s.webhooks = append(s.webhooks, webhook)
+
+	created := copyWebhook(webhook)
+	return &created, nil
+}
+
+// ListWebhooks lists the webhooks of the owner in the context.
+func (s *Store) ListWebhooks(ctx context.Context) ([]model.Webhook, error) {
+	ownerID := getOwnerID(ctx)
+	if ownerID == "" {
+		return nil, e.New("Failed to get owner ID from context", db.ErrInternal, nil)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var webhooks []model.Webhook
+	for _, webhook := range s.webhooks {
+		if webhook.UserID == ownerID {
+			webhooks = append(webhooks, copyWebhook(webhook))
+		}
+	}
+	return webhooks, nil
+}
+
+// DeleteWebhook deletes a webhook of the owner in the context, with its deliveries and dead letters.
+// It returns ErrRecordNotFound if the owner has no such webhook.
+func (s *Store) DeleteWebhook(ctx context.Context, req *model.WebhookIDRequest) error {
+	ownerID := getOwnerID(ctx)
+	if ownerID == "" {
+		return e.New("Failed to get owner ID from context", db.ErrInternal, nil)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	webhook := s.findWebhook(req.ID)
+	if webhook == nil || webhook.UserID != ownerID {
+		return db.ErrRecordNotFound
+	}
+
+	webhooks := s.webhooks[:0]
+	for _, other := range s.webhooks {
+		if other != webhook {
+			webhooks = append(webhooks, other)
+		}
+	}
+	s.webhooks = webhooks
+
+	deliveries := s.deliveries[:0]
+	for _, delivery := range s.deliveries {
+		if delivery.WebhookID != req.ID {
+			deliveries = append(deliveries, delivery)
+		}
+	}
+	s.deliveries = deliveries
+
+	deadLetters := s.deadLetters[:0]
+	for _, deadLetter := range s.deadLetters {
+		if deadLetter.WebhookID != req.ID {
+			deadLetters = append(deadLetters, deadLetter)
+		}
+	}
+	s.deadLetters = deadLetters
+	return nil
+}
+
+// ListWebhookDeliveries lists the delivery log of a webhook of the owner in the context, newest first.
+// It returns ErrRecordNotFound if the owner has no such webhook.
+func (s *Store) ListWebhookDeliveries(ctx context.Context, req *model.WebhookDeliveriesRequest) ([]model.WebhookDelivery, error) {
+	ownerID := getOwnerID(ctx)
+	if ownerID == "" {
+		return nil, e.New("Failed to get owner ID from context", db.ErrInternal, nil)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	webhook := s.findWebhook(req.WebhookID)
+	if webhook == nil || webhook.UserID != ownerID {
+		return nil, notFound("Could not find webhook")
+	}
+
+	var deliveries []model.WebhookDelivery
+	for i := len(s.deliveries) - 1; i >= 0; i-- {
+		if req.Limit >= 0 && len(deliveries) == req.Limit {
+			break
+		}
+		if s.deliveries[i].WebhookID == req.WebhookID {
+			deliveries = append(deliveries, s.deliveries[i])
+		}
+	}
+	return deliveries, nil
+}
+
+// DISPATCHING
+
+// ListLeasableWebhooks lists the webhooks of all owners that are not leased by another holder.
+func (s *Store) ListLeasableWebhooks(ctx context.Context, holder string) ([]model.Webhook, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	var webhooks []model.Webhook
+	for _, webhook := range s.webhooks {
+		if leaseAvailable(webhook, holder, now) {
+			webhooks = append(webhooks, copyWebhook(webhook))
+		}
+	}
+	return webhooks, nil
+}
+
+// LeaseWebhook leases a webhook to a holder for a duration, or extends the holder's lease.
+// It reports whether the holder has the lease, which it does not if the webhook was deleted
+// or is leased by another holder.
+func (s *Store) LeaseWebhook(ctx context.Context, webhookID, holder string, duration time.Duration) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	webhook := s.findWebhook(webhookID)
+	if webhook == nil || !leaseAvailable(webhook, holder, now) {
+		return false, nil
+	}
+	leasedUntil := now.Add(duration)
+	webhook.LeaseHolder = holder
+	webhook.LeasedUntil = &leasedUntil
+	return true, nil
+}
+
+// ReleaseWebhooks ends all leases of a holder
+func (s *Store) ReleaseWebhooks(ctx context.Context, holder string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, webhook := range s.webhooks {
+		if webhook.LeaseHolder == holder {
+			webhook.LeaseHolder = ""
+			webhook.LeasedUntil = nil
+		}
+	}
+	return nil
+}
+
+// AdvanceWebhookCursor moves the cursor of a webhook to the position of the last handled event.
+func (s *Store) AdvanceWebhookCursor(ctx context.Context, webhookID string, position model.EventPosition) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if webhook := s.findWebhook(webhookID); webhook != nil {
+		webhook.CursorTxID, webhook.CursorSeq = position.TxID, position.Seq
+	}
+	return nil
+}
+
+// CreateWebhookDelivery adds an attempt to the delivery log of a webhook.
+func (s *Store) CreateWebhookDelivery(ctx context.Context, delivery *model.WebhookDelivery) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Like the foreign key of the delivery log
+	if s.findWebhook(delivery.WebhookID) == nil {
+		return e.Wrap("Failed to log webhook delivery", e.New("Referenced resource does not exist", db.ErrInvalidReference, nil))
+	}
+	if delivery.ID == "" {
+		delivery.ID = uuid.New().String()
+	}
+	if delivery.CreatedAt.IsZero() {
+		delivery.CreatedAt = time.Now()
+	}
+	stored := *delivery
+	stored.Webhook = model.Webhook{}
+	s.deliveries = append(s.deliveries, stored)
+	return nil
+}
+
+// CreateWebhookDeadLetter keeps an event that could not be delivered to a webhook.
+func (s *Store) CreateWebhookDeadLetter(ctx context.Context, deadLetter *model.WebhookDeadLetter) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.findWebhook(deadLetter.WebhookID) == nil {
+		return e.Wrap("Failed to dead-letter webhook event", e.New("Referenced resource does not exist", db.ErrInvalidReference, nil))
+	}
+	if deadLetter.ID == "" {
+		deadLetter.ID = uuid.New().String()
+	}
+	if deadLetter.CreatedAt.IsZero() {
+		deadLetter.CreatedAt = time.Now()
+	}
+	stored := *deadLetter
+	stored.Webhook = model.Webhook{}
+	s.deadLetters = append(s.deadLetters, stored)
+	return nil
+}
+
+// PruneWebhookDeliveries deletes the delivery log entries created before a point in time.
+func (s *Store) PruneWebhookDeliveries(ctx context.Context, before time.Time) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	kept := s.deliveries[:0]
+	for _, delivery := range s.deliveries {
+		if !delivery.CreatedAt.Before(before) {
+			kept = append(kept, delivery)
+		}
+	}
+	pruned := int64(len(s.deliveries) - len(kept))
+	s.deliveries = kept
+	return pruned, nil
+}
+
+// HELPER FUNCTIONS
+
+func (s *Store) findWebhook(id string) *model.Webhook {
+	for _, webhook := range s.webhooks {
+		if webhook.ID == id {
+			return webhook
+		}
+	}
+	return nil
+}
+
+// leaseAvailable reports whether a webhook is not leased by another holder
+func leaseAvailable(webhook *model.Webhook, holder string, now time.Time) bool {
+	return webhook.LeasedUntil == nil || webhook.LeasedUntil.Before(now) || webhook.LeaseHolder == holder
+}
+
+// copyWebhook copies a stored webhook, so callers cannot change it
+func copyWebhook(webhook *model.Webhook) model.Webhook {
+	copied := *webhook
+	copied.Kinds = append([]string(nil), webhook.Kinds...)
+	if webhook.LeasedUntil != nil {
+		leasedUntil := *webhook.LeasedUntil
+		copied.LeasedUntil = &leasedUntil
+	}
+	return copied
+}
@@ -0,0 +1,272 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/BwezB/Wikno-backend/internal/graph/db"
+	"github.com/BwezB/Wikno-backend/internal/graph/model"
+
+	a "github.com/BwezB/Wikno-backend/pkg/auth"
+	e "github.com/BwezB/Wikno-backend/pkg/errors"
+)
+
+// CreateWorkspace creates a workspace, the graph user owning its versions, and makes the creator its admin.
+func (s *Store) CreateWorkspace(ctx context.Context, req *model.WorkspaceRequest) (*model.WorkspaceResponse, error) {
+	userID := a.GetUserID(ctx)
+	if userID == "" {
+		return nil, e.New("Failed to get user ID from context", db.ErrInternal, nil)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// The workspace owns its versions through a graph user with the same ID
+	workspaceID := uuid.New().String()
+	s.users[workspaceID] = true
+
+	now := time.Now()
+	s.workspaces[workspaceID] = &model.Workspace{
+		ID:        workspaceID,
+		Name:      req.Name,
+		CreatedBy: userID,
+		CreatedAt: now,
+	}
+	s.members = append(s.members, &model.WorkspaceMember{
+		WorkspaceID: workspaceID,
+		UserID:      userID,
+		Role:        model.RoleAdmin,
+		CreatedAt:   now,
+	})
+
+	return &model.WorkspaceResponse{
+		ID:   workspaceID,
+		Name: req.Name,
+		Role: model.RoleAdmin,
+	}, nil
+}
+
+// ListWorkspaces lists the workspaces the user in the context is a member of, with the user's role.
+func (s *Store) ListWorkspaces(ctx context.Context) ([]model.WorkspaceResponse, error) {
+	userID := a.GetUserID(ctx)
+	if userID == "" {
+		return nil, e.New("Failed to get user ID from context", db.ErrInternal, nil)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var workspaces []model.WorkspaceResponse
+	for _, member := range s.members {
+		if member.UserID == userID {
+			workspace := s.workspaces[member.WorkspaceID]
+			workspaces = append(workspaces, model.WorkspaceResponse{ID: workspace.ID, Name: workspace.Name, Role: member.Role})
+		}
+	}
+	sort.SliceStable(workspaces, func(i, j int) bool { return workspaces[i].Name < workspaces[j].Name })
+	return workspaces, nil
+}
+
+// GetMemberRole returns the role of a user in a workspace, or ErrRecordNotFound if the user is not a member.
+func (s *Store) GetMemberRole(ctx context.Context, workspaceID, userID string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	member := s.findMember(workspaceID, userID)
+	if member == nil {
+		return "", notFound("Failed to get workspace member")
+	}
+	return member.Role, nil
+}
+
+// ListMembers lists all members of a workspace.
+func (s *Store) ListMembers(ctx context.Context, workspaceID string) ([]model.WorkspaceMember, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var members []model.WorkspaceMember
+	for _, member := range s.members {
+		if member.WorkspaceID == workspaceID {
+			members = append(members, *member)
+		}
+	}
+	return members, nil
+}
+
+// UpdateMemberRole changes the role of an existing member.
+// The last admin of a workspace cannot be demoted, which returns ErrLastAdmin.
+func (s *Store) UpdateMemberRole(ctx context.Context, req *model.MemberRequest) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if req.Role != model.RoleAdmin && s.lastAdmin(req.WorkspaceID, req.UserID) {
+		return e.New("", db.ErrLastAdmin, nil)
+	}
+	member := s.findMember(req.WorkspaceID, req.UserID)
+	if member == nil {
+		return db.ErrRecordNotFound
+	}
+	member.Role = req.Role
+	return nil
+}
+
+// RemoveMember removes a user from a workspace.
+// The last admin of a workspace cannot be removed, which returns ErrLastAdmin.
+func (s *Store) RemoveMember(ctx context.Context, req *model.MemberRequest) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.lastAdmin(req.WorkspaceID, req.UserID) {
+		return e.New("", db.ErrLastAdmin, nil)
+	}
+	for i, member := range s.members {
+		if member.WorkspaceID == req.WorkspaceID && member.UserID == req.UserID {
+			s.members = append(s.members[:i], s.members[i+1:]...)
+			return nil
+		}
+	}
+	return db.ErrRecordNotFound
+}
+
+// CreateInvitation invites a user to a workspace on behalf of the user in the context.
+func (s *Store) CreateInvitation(ctx context.Context, req *model.MemberRequest) (*model.InvitationResponse, error) {
+	userID := a.GetUserID(ctx)
+	if userID == "" {
+		return nil, e.New("Failed to get user ID from context", db.ErrInternal, nil)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// The invitee must be a graph user, and not the graph user of a workspace
+	if !s.users[req.UserID] {
+		return nil, notFound("Could not find invitee")
+	}
+	if _, ok := s.workspaces[req.UserID]; ok {
+		return nil, e.New("Workspaces cannot be invited to workspaces", db.ErrInvalidRequest, nil)
+	}
+
+	// Members cannot be invited again
+	if s.findMember(req.WorkspaceID, req.UserID) != nil {
+		return nil, e.New("User is already a member of the workspace", db.ErrDuplicateEntry, nil)
+	}
+
+	// Like the foreign key and the unique index of the invitations table
+	workspace, ok := s.workspaces[req.WorkspaceID]
+	if !ok {
+		return nil, notFound("Could not create invitation")
+	}
+	for _, invitation := range s.invitations {
+		if invitation.WorkspaceID == req.WorkspaceID && invitation.InviteeID == req.UserID {
+			return nil, duplicate("Could not create invitation", db.ErrDuplicateInvitation)
+		}
+	}
+
+	invitation := &model.WorkspaceInvitation{
+		ID:          uuid.New().String(),
+		WorkspaceID: req.WorkspaceID,
+		InviterID:   userID,
+		InviteeID:   req.UserID,
+		Role:        req.Role,
+		CreatedAt:   time.Now(),
+		Workspace:   *workspace,
+	}
+	s.invitations = append(s.invitations, invitation)
+
+	return invitationResponse(invitation), nil
+}
+
+// ListInvitations lists the pending invitations of the user in the context.
+func (s *Store) ListInvitations(ctx context.Context) ([]model.InvitationResponse, error) {
+	userID := a.GetUserID(ctx)
+	if userID == "" {
+		return nil, e.New("Failed to get user ID from context", db.ErrInternal, nil)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	responses := []model.InvitationResponse{}
+	for _, invitation := range s.invitations {
+		if invitation.InviteeID == userID {
+			responses = append(responses, *invitationResponse(invitation))
+		}
+	}
+	return responses, nil
+}
+
+// AnswerInvitation deletes an invitation of the user in the context, adding the user to the workspace if accepted.
+func (s *Store) AnswerInvitation(ctx context.Context, req *model.InvitationIDRequest, accept bool) (*model.WorkspaceResponse, error) {
+	userID := a.GetUserID(ctx)
+	if userID == "" {
+		return nil, e.New("Failed to get user ID from context", db.ErrInternal, nil)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Only the invitee can answer the invitation
+	for i, invitation := range s.invitations {
+		if invitation.ID != req.ID || invitation.InviteeID != userID {
+			continue
+		}
+
+		if accept {
+			if s.findMember(invitation.WorkspaceID, userID) != nil {
+				return nil, duplicate("Could not add workspace member", db.ErrDuplicateMember)
+			}
+			s.members = append(s.members, &model.WorkspaceMember{
+				WorkspaceID: invitation.WorkspaceID,
+				UserID:      userID,
+				Role:        invitation.Role,
+				CreatedAt:   time.Now(),
+			})
+		}
+		s.invitations = append(s.invitations[:i], s.invitations[i+1:]...)
+
+		return &model.WorkspaceResponse{
+			ID:   invitation.Workspace.ID,
+			Name: invitation.Workspace.Name,
+			Role: invitation.Role,
+		}, nil
+	}
+	return nil, notFound("Could not find invitation")
+}
+
+// HELPER FUNCTIONS
+
+func (s *Store) findMember(workspaceID, userID string) *model.WorkspaceMember {
+	for _, member := range s.members {
+		if member.WorkspaceID == workspaceID && member.UserID == userID {
+			return member
+		}
+	}
+	return nil
+}
+
+// lastAdmin reports whether the user is the only admin of the workspace
+func (s *Store) lastAdmin(workspaceID, userID string) bool {
+	admins := 0
+	isAdmin := false
+	for _, member := range s.members {
+		if member.WorkspaceID == workspaceID && member.Role == model.RoleAdmin {
+			admins++
+			isAdmin = isAdmin || member.UserID == userID
+		}
+	}
+	return isAdmin && admins == 1
+}
+
+func invitationResponse(invitation *model.WorkspaceInvitation) *model.InvitationResponse {
+	return &model.InvitationResponse{
+		ID:            invitation.ID,
+		WorkspaceID:   invitation.WorkspaceID,
+		WorkspaceName: invitation.Workspace.Name,
+		InviterID:     invitation.InviterID,
+		InviteeID:     invitation.InviteeID,
+		Role:          invitation.Role,
+	}
+}
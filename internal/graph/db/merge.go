@@ -0,0 +1,624 @@
+package db
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/BwezB/Wikno-backend/internal/graph/model"
+
+	a "github.com/BwezB/Wikno-backend/pkg/auth"
+	e "github.com/BwezB/Wikno-backend/pkg/errors"
+	l "github.com/BwezB/Wikno-backend/pkg/log"
+	r "github.com/BwezB/Wikno-backend/pkg/requestid"
+)
+
+// HasVersion reports whether the owner in the context has a version of any of the shared nodes of a kind.
+func (db *Database) HasVersion(ctx context.Context, kind string, ids ...string) (bool, error) {
+	// Get ID from context
+	ownerID := getOwnerID(ctx)
+	if ownerID == "" {
+		return false, e.New("Failed to get owner ID from context", ErrInternal, nil)
+	}
+
+	k := sharedKinds[kind]
+	var count int64
+	res := db.WithContext(ctx).Table(k.table).
+		Where("user_id = ? AND "+k.idColumn+" IN ?", ownerID, ids).
+		Count(&count)
+	if res.Error != nil {
+		return false, e.Wrap("Failed to find versions", TranslateDatabaseError(res.Error))
+	}
+	return count > 0, nil
+}
+
+// GetMerge gets a merge record by ID.
+func (db *Database) GetMerge(ctx context.Context, req *model.IDRequest) (*model.EntityMerge, error) {
+	var merge model.EntityMerge
+	if err := db.WithContext(ctx).First(&merge, "id = ?", req.ID).Error; err != nil {
+		return nil, e.Wrap("Failed to get merge", TranslateDatabaseError(err))
+	}
+	return &merge, nil
+}
+
+// ListMerges lists the merges and splits an entity was part of, newest first.
+func (db *Database) ListMerges(ctx context.Context, req *model.IDRequest) ([]model.EntityMerge, error) {
+	l.Debug("Listing entity merges",
+		l.String("entity_id", req.ID),
+		l.String("request_id", r.GetRequestID(ctx)))
+
+	var merges []model.EntityMerge
+	res := db.WithContext(ctx).
+		Where("source_id = ? OR target_id = ?", req.ID, req.ID).
+		Order("created_at DESC").
+		Find(&merges)
+	if res.Error != nil {
+		return nil, e.Wrap("Failed to list merges", TranslateDatabaseError(res.Error))
+	}
+	return merges, nil
+}
+
+// ProposeMerge records a proposal of the owner in the context to merge the source entity into the target entity.
+func (db *Database) ProposeMerge(ctx context.Context, req *model.MergeRequest) (*model.EntityMerge, error) {
+	// Get ID from context
+	ownerID := getOwnerID(ctx)
+	if ownerID == "" {
+		return nil, e.New("Failed to get owner ID from context", ErrInternal, nil)
+	}
+	userID := a.GetUserID(ctx)
+
+	l.Debug("Proposing entity merge",
+		l.String("owner_id", ownerID),
+		l.String("source_id", req.SourceID),
+		l.String("target_id", req.TargetID),
+		l.String("request_id", r.GetRequestID(ctx)))
+
+	// Both entities must exist
+	var count int64
+	res := db.WithContext(ctx).Model(&model.Entity{}).
+		Where("id IN ?", []string{req.SourceID, req.TargetID}).
+		Count(&count)
+	if res.Error != nil {
+		return nil, e.Wrap("Failed to find entities", TranslateDatabaseError(res.Error))
+	}
+	if count != 2 {
+		return nil, e.New("Entity not found", ErrRecordNotFound, nil)
+	}
+
+	merge := model.EntityMerge{
+		SourceID:       req.SourceID,
+		TargetID:       req.TargetID,
+		ProposedBy:     ownerID,
+		ProposerUserID: &userID,
+		Status:         model.MergeProposed,
+	}
+	if err := db.WithContext(ctx).Create(&merge).Error; err != nil {
+		return nil, e.Wrap("Could not create merge", TranslateDatabaseError(err))
+	}
+
+	return &merge, nil
+}
+
+// AcceptMerge merges the source entity of a proposed merge into its target entity, on behalf of the owner in the context.
+// Source versions move to the target entity, unless their owner already has a version of it, in which case they are dropped.
+// Votes follow the versions they were cast on, and connections and connection type constraints move to the target entity.
+// Everything that changed is stored in the merge snapshot.
+func (db *Database) AcceptMerge(ctx context.Context, req *model.IDRequest) (*model.EntityMerge, error) {
+	// Get ID from context
+	ownerID := getOwnerID(ctx)
+	if ownerID == "" {
+		return nil, e.New("Failed to get owner ID from context", ErrInternal, nil)
+	}
+
+	l.Debug("Accepting entity merge",
+		l.String("owner_id", ownerID),
+		l.String("merge_id", req.ID),
+		l.String("request_id", r.GetRequestID(ctx)))
+
+	// Start transaction
+	tx := db.WithContext(ctx).Begin()
+	if tx.Error != nil {
+		return nil, e.Wrap("Could not start transaction", TranslateDatabaseError(tx.Error))
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	// Lock the merge so it cannot be accepted twice
+	var merge model.EntityMerge
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		First(&merge, "id = ? AND status = ?", req.ID, model.MergeProposed).Error; err != nil {
+		tx.Rollback()
+		return nil, e.Wrap("Could not find proposed merge", TranslateDatabaseError(err))
+	}
+	if merge.IsProposer(ownerID, a.GetUserID(ctx)) {
+		tx.Rollback()
+		return nil, e.New("Merges must be accepted by another user", ErrInvalidRequest, nil)
+	}
+
+	// Sort the source versions into moved and dropped ones
+	var sourceVersions []model.UsersEntity
+	if err := tx.Where("entity_id = ?", merge.SourceID).Find(&sourceVersions).Error; err != nil {
+		tx.Rollback()
+		return nil, e.Wrap("Could not get source versions", TranslateDatabaseError(err))
+	}
+	var targetOwners []string
+	if err := tx.Model(&model.UsersEntity{}).Where("entity_id = ?", merge.TargetID).Pluck("user_id", &targetOwners).Error; err != nil {
+		tx.Rollback()
+		return nil, e.Wrap("Could not get target versions", TranslateDatabaseError(err))
+	}
+	hasTarget := make(map[string]bool, len(targetOwners))
+	for _, owner := range targetOwners {
+		hasTarget[owner] = true
+	}
+
+	snapshot := model.MergeSnapshot{}
+	for _, version := range sourceVersions {
+		mv := model.MergedVersion{UserID: version.UserID, Name: version.Name, Definition: version.Definition}
+		if hasTarget[version.UserID] {
+			snapshot.Dropped = append(snapshot.Dropped, mv)
+		} else {
+			snapshot.Moved = append(snapshot.Moved, mv)
+		}
+	}
+
+	var votes []model.DefinitionVote
+	if err := tx.Where("kind = ? AND target_id = ?", model.KindEntity, merge.SourceID).Find(&votes).Error; err != nil {
+		tx.Rollback()
+		return nil, e.Wrap("Could not get source votes", TranslateDatabaseError(err))
+	}
+	for _, vote := range votes {
+		snapshot.Votes = append(snapshot.Votes, model.MergedVote{VoterID: vote.VoterID, AuthorID: vote.AuthorID, Value: vote.Value})
+	}
+
+	moved := versionOwners(snapshot.Moved)
+	err := moveEntityVersions(tx, merge.SourceID, merge.TargetID, moved)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	snapshot.Connections, err = moveConnections(tx, merge.SourceID, merge.TargetID, "")
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	snapshot.DomainOf, snapshot.RangeOf, err = moveConstraints(tx, merge.SourceID, merge.TargetID)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	snapshot.Classes, err = copyClasses(tx, merge.SourceID, merge.TargetID)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	// Whatever is left on the source entity was dropped
+	for _, version := range snapshot.Dropped {
+		if _, err := recordRevision(tx, model.KindEntity, model.RevisionDelete, version.UserID, merge.SourceID, version.Name, version.Definition); err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+	}
+	if err := tx.Where("kind = ? AND target_id = ?", model.KindEntity, merge.SourceID).Delete(&model.DefinitionVote{}).Error; err != nil {
+		tx.Rollback()
+		return nil, e.Wrap("Could not delete dropped votes", TranslateDatabaseError(err))
+	}
+	if err := tx.Where("entity_id = ?", merge.SourceID).Delete(&model.UsersEntity{}).Error; err != nil {
+		tx.Rollback()
+		return nil, e.Wrap("Could not delete dropped versions", TranslateDatabaseError(err))
+	}
+	if err := tx.Exec("DELETE FROM entity_instances WHERE entity_id = ?", merge.SourceID).Error; err != nil {
+		tx.Rollback()
+		return nil, e.Wrap("Could not delete source classes", TranslateDatabaseError(err))
+	}
+	if err := tx.Delete(&model.Entity{ID: merge.SourceID}).Error; err != nil {
+		tx.Rollback()
+		return nil, e.Wrap("Could not delete source entity", TranslateDatabaseError(err))
+	}
+
+	merge.Status = model.MergeMerged
+	merge.DecidedBy = &ownerID
+	merge.Snapshot = snapshot
+	if err := tx.Save(&merge).Error; err != nil {
+		tx.Rollback()
+		return nil, e.Wrap("Could not update merge", TranslateDatabaseError(err))
+	}
+
+	// Commit transaction
+	if err := tx.Commit().Error; err != nil {
+		return nil, e.Wrap("Could not commit", TranslateDatabaseError(err))
+	}
+
+	l.Info("Merged entities",
+		l.String("merge_id", merge.ID),
+		l.String("source_id", merge.SourceID),
+		l.String("target_id", merge.TargetID),
+		l.Int("moved", len(snapshot.Moved)),
+		l.Int("dropped", len(snapshot.Dropped)),
+		l.String("request_id", r.GetRequestID(ctx)))
+
+	return &merge, nil
+}
+
+// RevertMerge restores the source entity of an accepted merge from the merge snapshot, on behalf of the owner in the context.
+// Moved versions that were deleted since the merge stay deleted, edits made since the merge are kept.
+func (db *Database) RevertMerge(ctx context.Context, req *model.IDRequest) (*model.EntityMerge, error) {
+	// Get ID from context
+	ownerID := getOwnerID(ctx)
+	if ownerID == "" {
+		return nil, e.New("Failed to get owner ID from context", ErrInternal, nil)
+	}
+
+	l.Debug("Reverting entity merge",
+		l.String("owner_id", ownerID),
+		l.String("merge_id", req.ID),
+		l.String("request_id", r.GetRequestID(ctx)))
+
+	// Start transaction
+	tx := db.WithContext(ctx).Begin()
+	if tx.Error != nil {
+		return nil, e.Wrap("Could not start transaction", TranslateDatabaseError(tx.Error))
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	// Lock the merge so it cannot be reverted twice
+	var merge model.EntityMerge
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		First(&merge, "id = ? AND status = ?", req.ID, model.MergeMerged).Error; err != nil {
+		tx.Rollback()
+		return nil, e.Wrap("Could not find accepted merge", TranslateDatabaseError(err))
+	}
+
+	if err := tx.Create(&model.Entity{ID: merge.SourceID}).Error; err != nil {
+		tx.Rollback()
+		return nil, e.Wrap("Could not restore source entity", TranslateDatabaseError(err))
+	}
+
+	// Move versions back, with the votes that followed them
+	if err := moveEntityVersions(tx, merge.TargetID, merge.SourceID, versionOwners(merge.Snapshot.Moved)); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	// Restore dropped versions with the votes they had before the merge
+	dropped := make(map[string]bool, len(merge.Snapshot.Dropped))
+	for _, version := range merge.Snapshot.Dropped {
+		dropped[version.UserID] = true
+		userEntity := model.UsersEntity{
+			UserID:     version.UserID,
+			EntityID:   merge.SourceID,
+			Name:       version.Name,
+			Definition: version.Definition,
+		}
+		if err := tx.Create(&userEntity).Error; err != nil {
+			tx.Rollback()
+			return nil, e.Wrap("Could not restore dropped version", TranslateDatabaseError(err))
+		}
+		if _, err := recordRevision(tx, model.KindEntity, model.RevisionCreate, version.UserID, merge.SourceID, version.Name, version.Definition); err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+	}
+	for _, vote := range merge.Snapshot.Votes {
+		if !dropped[vote.AuthorID] {
+			continue // Votes on moved versions were moved back with them
+		}
+		definitionVote := model.DefinitionVote{
+			VoterID:  vote.VoterID,
+			Kind:     model.KindEntity,
+			TargetID: merge.SourceID,
+			AuthorID: vote.AuthorID,
+			Value:    vote.Value,
+		}
+		if err := tx.Create(&definitionVote).Error; err != nil {
+			tx.Rollback()
+			return nil, e.Wrap("Could not restore vote", TranslateDatabaseError(err))
+		}
+	}
+
+	// Restore connections and constraints
+	for _, connection := range merge.Snapshot.Connections {
+		original := model.Connection{
+			ID:               connection.ID,
+			UserID:           connection.UserID,
+			ConnectionTypeID: connection.ConnectionTypeID,
+			FromEntityID:     connection.FromEntityID,
+			ToEntityID:       connection.ToEntityID,
+		}
+		var err error
+		if connection.Dropped {
+			err = tx.Create(&original).Error
+		} else {
+			// Moved connections that were deleted since the merge stay deleted
+			err = tx.Unscoped().Model(&model.Connection{}).Where("id = ?", connection.ID).Updates(map[string]interface{}{
+				"from_entity_id": connection.FromEntityID,
+				"to_entity_id":   connection.ToEntityID,
+			}).Error
+		}
+		if err != nil {
+			tx.Rollback()
+			return nil, e.Wrap("Could not restore connection", TranslateDatabaseError(err))
+		}
+	}
+	if len(merge.Snapshot.DomainOf) > 0 {
+		if err := tx.Model(&model.ConnectionType{}).Where("id IN ?", merge.Snapshot.DomainOf).Update("domain_id", merge.SourceID).Error; err != nil {
+			tx.Rollback()
+			return nil, e.Wrap("Could not restore connection type domains", TranslateDatabaseError(err))
+		}
+	}
+	if len(merge.Snapshot.RangeOf) > 0 {
+		if err := tx.Model(&model.ConnectionType{}).Where("id IN ?", merge.Snapshot.RangeOf).Update("range_id", merge.SourceID).Error; err != nil {
+			tx.Rollback()
+			return nil, e.Wrap("Could not restore connection type ranges", TranslateDatabaseError(err))
+		}
+	}
+
+	// Restore the classes of the source entity, the target entity keeps the ones it got from it
+	for _, classID := range merge.Snapshot.Classes {
+		if err := tx.Exec("INSERT INTO entity_instances (entity_id, entity_class_id) VALUES (?, ?)", merge.SourceID, classID).Error; err != nil {
+			tx.Rollback()
+			return nil, e.Wrap("Could not restore source classes", TranslateDatabaseError(err))
+		}
+	}
+
+	merge.Status = model.MergeReverted
+	merge.DecidedBy = &ownerID
+	if err := tx.Save(&merge).Error; err != nil {
+		tx.Rollback()
+		return nil, e.Wrap("Could not update merge", TranslateDatabaseError(err))
+	}
+
+	// Commit transaction
+	if err := tx.Commit().Error; err != nil {
+		return nil, e.Wrap("Could not commit", TranslateDatabaseError(err))
+	}
+
+	l.Info("Reverted entity merge",
+		l.String("merge_id", merge.ID),
+		l.String("source_id", merge.SourceID),
+		l.String("target_id", merge.TargetID),
+		l.String("request_id", r.GetRequestID(ctx)))
+
+	return &merge, nil
+}
+
+// SplitEntity moves the version of the owner in the context off an entity into a new entity, with its votes and connections.
+// The split is recorded as a merge of the new entity (source) with the old one (target).
+func (db *Database) SplitEntity(ctx context.Context, req *model.IDRequest) (*model.EntityMerge, error) {
+	// Get ID from context
+	ownerID := getOwnerID(ctx)
+	if ownerID == "" {
+		return nil, e.New("Failed to get owner ID from context", ErrInternal, nil)
+	}
+
+	l.Debug("Splitting entity",
+		l.String("owner_id", ownerID),
+		l.String("entity_id", req.ID),
+		l.String("request_id", r.GetRequestID(ctx)))
+
+	// Start transaction
+	tx := db.WithContext(ctx).Begin()
+	if tx.Error != nil {
+		return nil, e.Wrap("Could not start transaction", TranslateDatabaseError(tx.Error))
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	var version model.UsersEntity
+	if err := tx.First(&version, "user_id = ? AND entity_id = ?", ownerID, req.ID).Error; err != nil {
+		tx.Rollback()
+		return nil, e.Wrap("Could not find entity version", TranslateDatabaseError(err))
+	}
+
+	var count int64
+	if err := tx.Model(&model.UsersEntity{}).Where("entity_id = ?", req.ID).Count(&count).Error; err != nil {
+		tx.Rollback()
+		return nil, e.Wrap("Could not count entity versions", TranslateDatabaseError(err))
+	}
+	if count == 1 {
+		tx.Rollback()
+		return nil, e.New("The only version of an entity cannot be split off", ErrInvalidRequest, nil)
+	}
+
+	entity := model.Entity{}
+	if err := tx.Create(&entity).Error; err != nil {
+		tx.Rollback()
+		return nil, e.Wrap("Could not create entity", TranslateDatabaseError(err))
+	}
+
+	var votes []model.DefinitionVote
+	if err := tx.Where("kind = ? AND target_id = ? AND author_id = ?", model.KindEntity, req.ID, ownerID).Find(&votes).Error; err != nil {
+		tx.Rollback()
+		return nil, e.Wrap("Could not get votes", TranslateDatabaseError(err))
+	}
+
+	if err := moveEntityVersions(tx, req.ID, entity.ID, []string{ownerID}); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	connections, err := moveConnections(tx, req.ID, entity.ID, ownerID)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	// The new entity starts as an instance of the same classes
+	classes, err := copyClasses(tx, req.ID, entity.ID)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	snapshot := model.MergeSnapshot{
+		Moved:       []model.MergedVersion{{UserID: version.UserID, Name: version.Name, Definition: version.Definition}},
+		Connections: connections,
+		Classes:     classes,
+	}
+	for _, vote := range votes {
+		snapshot.Votes = append(snapshot.Votes, model.MergedVote{VoterID: vote.VoterID, AuthorID: vote.AuthorID, Value: vote.Value})
+	}
+
+	merge := model.EntityMerge{
+		SourceID:   entity.ID,
+		TargetID:   req.ID,
+		ProposedBy: ownerID,
+		DecidedBy:  &ownerID,
+		Status:     model.MergeSplit,
+		Snapshot:   snapshot,
+	}
+	if err := tx.Create(&merge).Error; err != nil {
+		tx.Rollback()
+		return nil, e.Wrap("Could not create merge", TranslateDatabaseError(err))
+	}
+
+	// Commit transaction
+	if err := tx.Commit().Error; err != nil {
+		return nil, e.Wrap("Could not commit", TranslateDatabaseError(err))
+	}
+
+	l.Info("Split entity",
+		l.String("entity_id", req.ID),
+		l.String("new_entity_id", entity.ID),
+		l.String("owner_id", ownerID),
+		l.String("request_id", r.GetRequestID(ctx)))
+
+	return &merge, nil
+}
+
+// HELPER FUNCTIONS
+
+// moveEntityVersions re-points the owners' versions of an entity, and the votes on them, to another entity,
+// and records the move in the revisions of both entities.
+// The owners must not have a version of the other entity. Stale votes left on the other entity are removed first.
+func moveEntityVersions(tx *gorm.DB, fromID, toID string, owners []string) error {
+	if len(owners) == 0 {
+		return nil
+	}
+
+	if err := tx.Model(&model.UsersEntity{}).
+		Where("entity_id = ? AND user_id IN ?", fromID, owners).
+		Update("entity_id", toID).Error; err != nil {
+		return e.Wrap("Could not move versions", TranslateDatabaseError(err))
+	}
+
+	var moved []model.UsersEntity
+	if err := tx.Where("entity_id = ? AND user_id IN ?", toID, owners).Find(&moved).Error; err != nil {
+		return e.Wrap("Could not get moved versions", TranslateDatabaseError(err))
+	}
+	for _, version := range moved {
+		if _, err := recordRevision(tx, model.KindEntity, model.RevisionDelete, version.UserID, fromID, version.Name, version.Definition); err != nil {
+			return err
+		}
+		if _, err := recordRevision(tx, model.KindEntity, model.RevisionMove, version.UserID, toID, version.Name, version.Definition); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Where("kind = ? AND target_id = ? AND author_id IN ?", model.KindEntity, toID, owners).
+		Delete(&model.DefinitionVote{}).Error; err != nil {
+		return e.Wrap("Could not delete stale votes", TranslateDatabaseError(err))
+	}
+	if err := tx.Model(&model.DefinitionVote{}).
+		Where("kind = ? AND target_id = ? AND author_id IN ?", model.KindEntity, fromID, owners).
+		Update("target_id", toID).Error; err != nil {
+		return e.Wrap("Could not move votes", TranslateDatabaseError(err))
+	}
+	return nil
+}
+
+// moveConnections re-points the connections of an entity to another entity, only those of the owner if one is given.
+// Deleted connections move too, so the entity can be removed. Connections the owner already has on the other entity
+// are dropped. It returns the connections as they were.
+func moveConnections(tx *gorm.DB, fromID, toID, ownerID string) ([]model.MergedConnection, error) {
+	query := tx.Unscoped().Where("from_entity_id = ? OR to_entity_id = ?", fromID, fromID)
+	if ownerID != "" {
+		query = query.Where("user_id = ?", ownerID)
+	}
+	var connections []model.Connection
+	if err := query.Find(&connections).Error; err != nil {
+		return nil, e.Wrap("Could not get connections", TranslateDatabaseError(err))
+	}
+
+	snapshot := make([]model.MergedConnection, len(connections))
+	for i, connection := range connections {
+		snapshot[i] = model.MergedConnection{
+			ID:               connection.ID,
+			UserID:           connection.UserID,
+			ConnectionTypeID: connection.ConnectionTypeID,
+			FromEntityID:     connection.FromEntityID,
+			ToEntityID:       connection.ToEntityID,
+		}
+
+		if connection.FromEntityID == fromID {
+			connection.FromEntityID = toID
+		}
+		if connection.ToEntityID == fromID {
+			connection.ToEntityID = toID
+		}
+
+		// Only live connections can be duplicates
+		var count int64
+		if !connection.DeletedAt.Valid {
+			if err := tx.Model(&model.Connection{}).
+				Where("user_id = ? AND connection_type_id = ? AND from_entity_id = ? AND to_entity_id = ? AND id <> ?",
+					connection.UserID, connection.ConnectionTypeID, connection.FromEntityID, connection.ToEntityID, connection.ID).
+				Count(&count).Error; err != nil {
+				return nil, e.Wrap("Could not find duplicate connections", TranslateDatabaseError(err))
+			}
+		}
+
+		var err error
+		if count > 0 {
+			// Dropped connections are restored from the snapshot, so they are removed for good
+			snapshot[i].Dropped = true
+			err = tx.Unscoped().Delete(&model.Connection{}, "id = ?", connection.ID).Error
+		} else {
+			err = tx.Unscoped().Model(&model.Connection{}).Where("id = ?", connection.ID).Updates(map[string]interface{}{
+				"from_entity_id": connection.FromEntityID,
+				"to_entity_id":   connection.ToEntityID,
+			}).Error
+		}
+		if err != nil {
+			return nil, e.Wrap("Could not move connection", TranslateDatabaseError(err))
+		}
+	}
+	return snapshot, nil
+}
+
+// moveConstraints re-points the domains and ranges of connection types from an entity to another entity.
+// It returns the IDs of the connection types whose domain and range moved.
+func moveConstraints(tx *gorm.DB, fromID, toID string) (domainOf, rangeOf []string, err error) {
+	for column, moved := range map[string]*[]string{"domain_id": &domainOf, "range_id": &rangeOf} {
+		if err := tx.Model(&model.ConnectionType{}).Where(column+" = ?", fromID).Pluck("id", moved).Error; err != nil {
+			return nil, nil, e.Wrap("Could not get connection type constraints", TranslateDatabaseError(err))
+		}
+		if len(*moved) == 0 {
+			continue
+		}
+		if err := tx.Model(&model.ConnectionType{}).Where("id IN ?", *moved).Update(column, toID).Error; err != nil {
+			return nil, nil, e.Wrap("Could not move connection type constraints", TranslateDatabaseError(err))
+		}
+	}
+	return domainOf, rangeOf, nil
+}
+
+func versionOwners(versions []model.MergedVersion) []string {
+	owners := make([]string, len(versions))
+	for i, version := range versions {
+		owners[i] = version.UserID
+	}
+	return owners
+}
@@ -0,0 +1,41 @@
+package db
+
+import (
+	"context"
+	"embed"
+	"io/fs"
+
+	e "github.com/BwezB/Wikno-backend/pkg/errors"
+	l "github.com/BwezB/Wikno-backend/pkg/log"
+	mg "github.com/BwezB/Wikno-backend/pkg/migrate"
+)
+
+// migrations are the numbered up and down SQL files of the graph schema
+//
+//go:embed migrations/*.sql
+var migrations embed.FS
+
+// SchemaMigrator returns the migrator of the graph schema
+func (db *Database) SchemaMigrator() (*mg.Migrator, error) {
+	files, err := fs.Sub(migrations, "migrations")
+	if err != nil {
+		return nil, e.New("Could not read migrations", ErrInternal, err)
+	}
+	return mg.New(db.DB, files, "graphservice")
+}
+
+// Migrate applies the migrations that have not been applied yet
+func (db *Database) Migrate(ctx context.Context) error {
+	l.Debug("Migrating database")
+
+	migrator, err := db.SchemaMigrator()
+	if err != nil {
+		return e.Wrap("Migration failed", err)
+	}
+	if err := migrator.Up(ctx); err != nil {
+		return e.Wrap("Migration failed", err)
+	}
+
+	l.Info("Database schema is at version", l.Int("version", int(migrator.Latest())))
+	return nil
+}
@@ -0,0 +1,24 @@
+DROP TABLE IF EXISTS "webhook_dead_letters";
+DROP TABLE IF EXISTS "webhook_deliveries";
+DROP TABLE IF EXISTS "webhooks";
+DROP TABLE IF EXISTS "graph_events";
+DROP TABLE IF EXISTS "revisions";
+DROP TABLE IF EXISTS "entity_merges";
+DROP TABLE IF EXISTS "definition_votes";
+DROP TABLE IF EXISTS "connections";
+DROP TABLE IF EXISTS "workspace_invitations";
+DROP TABLE IF EXISTS "workspace_members";
+DROP TABLE IF EXISTS "workspaces";
+DROP TABLE IF EXISTS "users_entity_classes";
+DROP TABLE IF EXISTS "users_property_types";
+DROP TABLE IF EXISTS "users_connection_types";
+DROP TABLE IF EXISTS "users_entities";
+DROP TABLE IF EXISTS "property_type_classes";
+DROP TABLE IF EXISTS "property_types";
+DROP TABLE IF EXISTS "connection_type_classes";
+DROP TABLE IF EXISTS "connection_types";
+DROP TABLE IF EXISTS "entity_instances";
+DROP TABLE IF EXISTS "entities";
+DROP TABLE IF EXISTS "entity_class_parents";
+DROP TABLE IF EXISTS "entity_classes";
+DROP TABLE IF EXISTS "users";
@@ -0,0 +1,270 @@
+CREATE TABLE IF NOT EXISTS "users" (
+	"id" uuid DEFAULT gen_random_uuid(),
+	PRIMARY KEY ("id")
+);
+
+CREATE TABLE IF NOT EXISTS "entity_classes" (
+	"id" uuid DEFAULT gen_random_uuid(),
+	PRIMARY KEY ("id")
+);
+
+CREATE TABLE IF NOT EXISTS "entity_class_parents" (
+	"entity_class_id" uuid DEFAULT gen_random_uuid(),
+	"parent_id" uuid DEFAULT gen_random_uuid(),
+	PRIMARY KEY ("entity_class_id","parent_id"),
+	CONSTRAINT "fk_entity_class_parents_parents" FOREIGN KEY ("parent_id") REFERENCES "entity_classes"("id"),
+	CONSTRAINT "fk_entity_class_parents_entity_class" FOREIGN KEY ("entity_class_id") REFERENCES "entity_classes"("id")
+);
+
+CREATE TABLE IF NOT EXISTS "entities" (
+	"id" uuid DEFAULT gen_random_uuid(),
+	PRIMARY KEY ("id")
+);
+
+CREATE TABLE IF NOT EXISTS "entity_instances" (
+	"entity_id" uuid DEFAULT gen_random_uuid(),
+	"entity_class_id" uuid DEFAULT gen_random_uuid(),
+	PRIMARY KEY ("entity_id","entity_class_id"),
+	CONSTRAINT "fk_entity_instances_entity" FOREIGN KEY ("entity_id") REFERENCES "entities"("id"),
+	CONSTRAINT "fk_entity_instances_entity_class" FOREIGN KEY ("entity_class_id") REFERENCES "entity_classes"("id")
+);
+
+CREATE TABLE IF NOT EXISTS "connection_types" (
+	"id" uuid DEFAULT gen_random_uuid(),
+	"inverse_id" uuid,
+	"symmetric" boolean NOT NULL DEFAULT false,
+	"transitive" boolean NOT NULL DEFAULT false,
+	"domain_id" uuid,
+	"range_id" uuid,
+	PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_connection_types_inverse_id" ON "connection_types" ("inverse_id");
+
+CREATE TABLE IF NOT EXISTS "connection_type_classes" (
+	"connection_type_id" uuid DEFAULT gen_random_uuid(),
+	"entity_class_id" uuid DEFAULT gen_random_uuid(),
+	PRIMARY KEY ("connection_type_id","entity_class_id"),
+	CONSTRAINT "fk_connection_type_classes_connection_type" FOREIGN KEY ("connection_type_id") REFERENCES "connection_types"("id"),
+	CONSTRAINT "fk_connection_type_classes_entity_class" FOREIGN KEY ("entity_class_id") REFERENCES "entity_classes"("id")
+);
+
+CREATE TABLE IF NOT EXISTS "property_types" (
+	"id" uuid DEFAULT gen_random_uuid(),
+	"value_type" varchar(10),
+	PRIMARY KEY ("id"),
+	CONSTRAINT "chk_property_types_value_type" CHECK (value_type in ('string','int','float','boolean'))
+);
+
+CREATE TABLE IF NOT EXISTS "property_type_classes" (
+	"property_type_id" uuid DEFAULT gen_random_uuid(),
+	"entity_class_id" uuid DEFAULT gen_random_uuid(),
+	PRIMARY KEY ("property_type_id","entity_class_id"),
+	CONSTRAINT "fk_property_type_classes_property_type" FOREIGN KEY ("property_type_id") REFERENCES "property_types"("id"),
+	CONSTRAINT "fk_property_type_classes_entity_class" FOREIGN KEY ("entity_class_id") REFERENCES "entity_classes"("id")
+);
+
+CREATE TABLE IF NOT EXISTS "users_entities" (
+	"name" varchar(255) NOT NULL,
+	"definition" varchar(4096) NOT NULL,
+	"user_id" uuid,
+	"entity_id" uuid,
+	PRIMARY KEY ("user_id","entity_id"),
+	CONSTRAINT "fk_users_users_entities" FOREIGN KEY ("user_id") REFERENCES "users"("id"),
+	CONSTRAINT "fk_entities_users_entities" FOREIGN KEY ("entity_id") REFERENCES "entities"("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_users_entity_name" ON "users_entities" ("name");
+
+CREATE TABLE IF NOT EXISTS "users_connection_types" (
+	"name" varchar(255) NOT NULL,
+	"definition" varchar(4096) NOT NULL,
+	"user_id" uuid,
+	"connection_type_id" uuid,
+	PRIMARY KEY ("user_id","connection_type_id"),
+	CONSTRAINT "fk_users_users_connection_types" FOREIGN KEY ("user_id") REFERENCES "users"("id"),
+	CONSTRAINT "fk_connection_types_users_connection_types" FOREIGN KEY ("connection_type_id") REFERENCES "connection_types"("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_users_connection_type" ON "users_connection_types" ("name");
+
+CREATE TABLE IF NOT EXISTS "users_property_types" (
+	"name" varchar(255) NOT NULL,
+	"definition" varchar(4096) NOT NULL,
+	"user_id" uuid,
+	"property_type_id" uuid,
+	PRIMARY KEY ("user_id","property_type_id"),
+	CONSTRAINT "fk_property_types_users_property_types" FOREIGN KEY ("property_type_id") REFERENCES "property_types"("id"),
+	CONSTRAINT "fk_users_users_property_types" FOREIGN KEY ("user_id") REFERENCES "users"("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_users_property_type" ON "users_property_types" ("name");
+
+CREATE TABLE IF NOT EXISTS "users_entity_classes" (
+	"name" varchar(255) NOT NULL,
+	"definition" varchar(4096) NOT NULL,
+	"user_id" uuid,
+	"entity_class_id" uuid,
+	PRIMARY KEY ("user_id","entity_class_id"),
+	CONSTRAINT "fk_entity_classes_users_entity_classes" FOREIGN KEY ("entity_class_id") REFERENCES "entity_classes"("id"),
+	CONSTRAINT "fk_users_users_entity_classes" FOREIGN KEY ("user_id") REFERENCES "users"("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_users_entity_class_name" ON "users_entity_classes" ("name");
+
+CREATE TABLE IF NOT EXISTS "workspaces" (
+	"id" uuid,
+	"name" varchar(255) NOT NULL,
+	"created_by" uuid NOT NULL,
+	"created_at" timestamptz,
+	PRIMARY KEY ("id")
+);
+
+CREATE TABLE IF NOT EXISTS "workspace_members" (
+	"workspace_id" uuid,
+	"user_id" uuid,
+	"role" varchar(10) NOT NULL,
+	"created_at" timestamptz,
+	PRIMARY KEY ("workspace_id","user_id"),
+	CONSTRAINT "fk_workspaces_members" FOREIGN KEY ("workspace_id") REFERENCES "workspaces"("id") ON DELETE CASCADE,
+	CONSTRAINT "chk_workspace_members_role" CHECK (role in ('viewer','editor','admin'))
+);
+CREATE INDEX IF NOT EXISTS "idx_workspace_members_user_id" ON "workspace_members" ("user_id");
+
+CREATE TABLE IF NOT EXISTS "workspace_invitations" (
+	"id" uuid DEFAULT gen_random_uuid(),
+	"workspace_id" uuid NOT NULL,
+	"inviter_id" uuid NOT NULL,
+	"invitee_id" uuid NOT NULL,
+	"role" varchar(10) NOT NULL,
+	"created_at" timestamptz,
+	PRIMARY KEY ("id"),
+	CONSTRAINT "fk_workspace_invitations_workspace" FOREIGN KEY ("workspace_id") REFERENCES "workspaces"("id") ON DELETE CASCADE,
+	CONSTRAINT "chk_workspace_invitations_role" CHECK (role in ('viewer','editor','admin'))
+);
+CREATE INDEX IF NOT EXISTS "idx_workspace_invitations_invitee_id" ON "workspace_invitations" ("invitee_id");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_workspace_invitee" ON "workspace_invitations" ("workspace_id","invitee_id");
+
+CREATE TABLE IF NOT EXISTS "connections" (
+	"id" uuid DEFAULT gen_random_uuid(),
+	"user_id" uuid NOT NULL,
+	"connection_type_id" uuid NOT NULL,
+	"from_entity_id" uuid NOT NULL,
+	"to_entity_id" uuid NOT NULL,
+	"created_at" timestamptz,
+	"deleted_at" timestamptz,
+	PRIMARY KEY ("id"),
+	CONSTRAINT "fk_entities_incoming_connections" FOREIGN KEY ("to_entity_id") REFERENCES "entities"("id"),
+	CONSTRAINT "fk_connection_types_connections" FOREIGN KEY ("connection_type_id") REFERENCES "connection_types"("id"),
+	CONSTRAINT "fk_users_connections" FOREIGN KEY ("user_id") REFERENCES "users"("id"),
+	CONSTRAINT "fk_entities_outgoing_connections" FOREIGN KEY ("from_entity_id") REFERENCES "entities"("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_connections_deleted_at" ON "connections" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_connections_to_entity_id" ON "connections" ("to_entity_id");
+CREATE INDEX IF NOT EXISTS "idx_connections_from_entity_id" ON "connections" ("from_entity_id");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_connection" ON "connections" ("user_id","connection_type_id","from_entity_id","to_entity_id") WHERE deleted_at IS NULL;
+
+CREATE TABLE IF NOT EXISTS "definition_votes" (
+	"voter_id" uuid,
+	"kind" varchar(20),
+	"target_id" uuid,
+	"author_id" uuid,
+	"value" smallint NOT NULL,
+	"created_at" timestamptz,
+	"updated_at" timestamptz,
+	PRIMARY KEY ("voter_id","kind","target_id","author_id"),
+	CONSTRAINT "chk_definition_votes_value" CHECK (value in (-1,1)),
+	CONSTRAINT "chk_definition_votes_kind" CHECK (kind in ('entity','connection_type','property_type','entity_class'))
+);
+CREATE INDEX IF NOT EXISTS "idx_definition_votes_target" ON "definition_votes" ("target_id","author_id");
+
+CREATE TABLE IF NOT EXISTS "entity_merges" (
+	"id" uuid DEFAULT gen_random_uuid(),
+	"source_id" uuid NOT NULL,
+	"target_id" uuid NOT NULL,
+	"proposed_by" uuid NOT NULL,
+	"decided_by" uuid,
+	"status" varchar(10) NOT NULL,
+	"snapshot" jsonb,
+	"created_at" timestamptz,
+	"updated_at" timestamptz,
+	PRIMARY KEY ("id"),
+	CONSTRAINT "chk_entity_merges_status" CHECK (status in ('proposed','merged','reverted','split'))
+);
+CREATE INDEX IF NOT EXISTS "idx_entity_merges_source_id" ON "entity_merges" ("source_id");
+CREATE INDEX IF NOT EXISTS "idx_entity_merges_target_id" ON "entity_merges" ("target_id");
+
+CREATE TABLE IF NOT EXISTS "revisions" (
+	"id" uuid DEFAULT gen_random_uuid(),
+	"kind" varchar(20) NOT NULL,
+	"node_id" uuid NOT NULL,
+	"user_id" uuid NOT NULL,
+	"author_id" uuid NOT NULL,
+	"action" varchar(10) NOT NULL,
+	"name" varchar(255) NOT NULL,
+	"definition" varchar(4096) NOT NULL,
+	"created_at" timestamptz,
+	PRIMARY KEY ("id"),
+	CONSTRAINT "chk_revisions_kind" CHECK (kind in ('entity','connection_type','property_type','entity_class')),
+	CONSTRAINT "chk_revisions_action" CHECK (action in ('create','update','revert','move','delete'))
+);
+CREATE INDEX IF NOT EXISTS "idx_revisions_time" ON "revisions" ("kind","created_at");
+CREATE INDEX IF NOT EXISTS "idx_revisions_version" ON "revisions" ("user_id","kind","node_id","created_at");
+
+CREATE TABLE IF NOT EXISTS "graph_events" (
+	"seq" bigserial,
+	"tx_id" bigint NOT NULL,
+	"user_id" uuid NOT NULL,
+	"kind" varchar(20) NOT NULL,
+	"node_id" uuid NOT NULL,
+	"action" varchar(10) NOT NULL,
+	"data" jsonb,
+	"created_at" timestamptz NOT NULL,
+	PRIMARY KEY ("seq"),
+	CONSTRAINT "chk_graph_events_kind" CHECK (kind in ('entity','connection_type','property_type','entity_class','connection')),
+	CONSTRAINT "chk_graph_events_action" CHECK (action in ('create','update','delete'))
+);
+CREATE INDEX IF NOT EXISTS "idx_graph_events_created_at" ON "graph_events" ("created_at");
+CREATE INDEX IF NOT EXISTS "idx_graph_events_owner" ON "graph_events" ("user_id","tx_id","seq");
+CREATE INDEX IF NOT EXISTS "idx_graph_events_position" ON "graph_events" ("tx_id","seq");
+
+CREATE TABLE IF NOT EXISTS "webhooks" (
+	"id" uuid DEFAULT gen_random_uuid(),
+	"user_id" uuid NOT NULL,
+	"url" varchar(2048) NOT NULL,
+	"kinds" jsonb,
+	"secret" varchar(64) NOT NULL,
+	"cursor_tx_id" bigint NOT NULL DEFAULT 0,
+	"cursor_seq" bigint NOT NULL DEFAULT 0,
+	"lease_holder" varchar(36),
+	"leased_until" timestamptz,
+	"created_by" uuid NOT NULL,
+	"created_at" timestamptz,
+	PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_webhooks_user_id" ON "webhooks" ("user_id");
+
+CREATE TABLE IF NOT EXISTS "webhook_deliveries" (
+	"id" uuid DEFAULT gen_random_uuid(),
+	"webhook_id" uuid NOT NULL,
+	"event_id" varchar(41) NOT NULL,
+	"attempt" bigint NOT NULL,
+	"outcome" varchar(12) NOT NULL,
+	"status_code" bigint NOT NULL DEFAULT 0,
+	"error" varchar(1024),
+	"duration_ms" bigint NOT NULL DEFAULT 0,
+	"created_at" timestamptz NOT NULL,
+	PRIMARY KEY ("id"),
+	CONSTRAINT "fk_webhook_deliveries_webhook" FOREIGN KEY ("webhook_id") REFERENCES "webhooks"("id") ON DELETE CASCADE,
+	CONSTRAINT "chk_webhook_deliveries_outcome" CHECK (outcome in ('success','retry','dead_letter'))
+);
+CREATE INDEX IF NOT EXISTS "idx_webhook_deliveries_created_at" ON "webhook_deliveries" ("created_at");
+CREATE INDEX IF NOT EXISTS "idx_webhook_deliveries" ON "webhook_deliveries" ("webhook_id","created_at");
+
+CREATE TABLE IF NOT EXISTS "webhook_dead_letters" (
+	"id" uuid DEFAULT gen_random_uuid(),
+	"webhook_id" uuid NOT NULL,
+	"event_id" varchar(41) NOT NULL,
+	"payload" jsonb NOT NULL,
+	"attempts" bigint NOT NULL,
+	"last_error" varchar(1024),
+	"created_at" timestamptz,
+	PRIMARY KEY ("id"),
+	CONSTRAINT "fk_webhook_dead_letters_webhook" FOREIGN KEY ("webhook_id") REFERENCES "webhooks"("id") ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS "idx_webhook_dead_letters_webhook_id" ON "webhook_dead_letters" ("webhook_id");
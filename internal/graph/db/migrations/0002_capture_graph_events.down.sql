@@ -0,0 +1,8 @@
+DROP TRIGGER IF EXISTS graph_events_capture ON users_entities;
+DROP TRIGGER IF EXISTS graph_events_capture ON users_connection_types;
+DROP TRIGGER IF EXISTS graph_events_capture ON users_property_types;
+DROP TRIGGER IF EXISTS graph_events_capture ON users_entity_classes;
+DROP TRIGGER IF EXISTS graph_events_capture ON connections;
+
+DROP FUNCTION IF EXISTS graph_events_capture();
+DROP FUNCTION IF EXISTS graph_events_append(text, text, text, jsonb);
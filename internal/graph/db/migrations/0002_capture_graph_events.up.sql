@@ -0,0 +1,60 @@
+-- Graph events are appended for the changed rows of the event tables.
+-- A soft deleted row counts as deleted, and a row that moves to another node or owner as a delete and a create.
+CREATE OR REPLACE FUNCTION graph_events_append(p_kind text, p_id_column text, p_action text, p_data jsonb) RETURNS void AS $$
+	INSERT INTO graph_events (tx_id, user_id, kind, node_id, action, data, created_at)
+	VALUES (pg_current_xact_id()::text::bigint, (p_data->>'user_id')::uuid, p_kind, (p_data->>p_id_column)::uuid, p_action, p_data, now());
+$$ LANGUAGE sql;
+
+CREATE OR REPLACE FUNCTION graph_events_capture() RETURNS trigger AS $$
+DECLARE
+	old_row jsonb;
+	new_row jsonb;
+	old_live boolean := false;
+	new_live boolean := false;
+BEGIN
+	IF TG_OP <> 'INSERT' THEN
+		old_row := to_jsonb(OLD);
+		old_live := old_row->>'deleted_at' IS NULL;
+	END IF;
+	IF TG_OP <> 'DELETE' THEN
+		new_row := to_jsonb(NEW);
+		new_live := new_row->>'deleted_at' IS NULL;
+	END IF;
+
+	IF old_live AND new_live
+		AND old_row->>'user_id' = new_row->>'user_id'
+		AND old_row->>TG_ARGV[1] = new_row->>TG_ARGV[1] THEN
+		IF old_row <> new_row THEN
+			PERFORM graph_events_append(TG_ARGV[0], TG_ARGV[1], 'update', new_row);
+		END IF;
+		RETURN NULL;
+	END IF;
+	IF old_live THEN
+		PERFORM graph_events_append(TG_ARGV[0], TG_ARGV[1], 'delete', old_row);
+	END IF;
+	IF new_live THEN
+		PERFORM graph_events_append(TG_ARGV[0], TG_ARGV[1], 'create', new_row);
+	END IF;
+	RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS graph_events_capture ON users_entities;
+CREATE TRIGGER graph_events_capture AFTER INSERT OR UPDATE OR DELETE ON users_entities
+	FOR EACH ROW EXECUTE FUNCTION graph_events_capture('entity', 'entity_id');
+
+DROP TRIGGER IF EXISTS graph_events_capture ON users_connection_types;
+CREATE TRIGGER graph_events_capture AFTER INSERT OR UPDATE OR DELETE ON users_connection_types
+	FOR EACH ROW EXECUTE FUNCTION graph_events_capture('connection_type', 'connection_type_id');
+
+DROP TRIGGER IF EXISTS graph_events_capture ON users_property_types;
+CREATE TRIGGER graph_events_capture AFTER INSERT OR UPDATE OR DELETE ON users_property_types
+	FOR EACH ROW EXECUTE FUNCTION graph_events_capture('property_type', 'property_type_id');
+
+DROP TRIGGER IF EXISTS graph_events_capture ON users_entity_classes;
+CREATE TRIGGER graph_events_capture AFTER INSERT OR UPDATE OR DELETE ON users_entity_classes
+	FOR EACH ROW EXECUTE FUNCTION graph_events_capture('entity_class', 'entity_class_id');
+
+DROP TRIGGER IF EXISTS graph_events_capture ON connections;
+CREATE TRIGGER graph_events_capture AFTER INSERT OR UPDATE OR DELETE ON connections
+	FOR EACH ROW EXECUTE FUNCTION graph_events_capture('connection', 'id');
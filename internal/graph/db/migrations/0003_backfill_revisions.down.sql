@@ -0,0 +1 @@
+DELETE FROM revisions WHERE action = 'create' AND created_at = to_timestamp(0);
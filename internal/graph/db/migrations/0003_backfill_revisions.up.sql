@@ -0,0 +1,33 @@
+-- Versions created before revisions were recorded get an initial revision dated at the epoch
+
+INSERT INTO revisions (kind, node_id, user_id, author_id, action, name, definition, created_at)
+SELECT 'entity', v.entity_id, v.user_id, v.user_id, 'create', v.name, v.definition, to_timestamp(0)
+FROM users_entities v
+WHERE NOT EXISTS (
+	SELECT 1 FROM revisions rv
+	WHERE rv.kind = 'entity' AND rv.node_id = v.entity_id AND rv.user_id = v.user_id
+);
+
+INSERT INTO revisions (kind, node_id, user_id, author_id, action, name, definition, created_at)
+SELECT 'connection_type', v.connection_type_id, v.user_id, v.user_id, 'create', v.name, v.definition, to_timestamp(0)
+FROM users_connection_types v
+WHERE NOT EXISTS (
+	SELECT 1 FROM revisions rv
+	WHERE rv.kind = 'connection_type' AND rv.node_id = v.connection_type_id AND rv.user_id = v.user_id
+);
+
+INSERT INTO revisions (kind, node_id, user_id, author_id, action, name, definition, created_at)
+SELECT 'property_type', v.property_type_id, v.user_id, v.user_id, 'create', v.name, v.definition, to_timestamp(0)
+FROM users_property_types v
+WHERE NOT EXISTS (
+	SELECT 1 FROM revisions rv
+	WHERE rv.kind = 'property_type' AND rv.node_id = v.property_type_id AND rv.user_id = v.user_id
+);
+
+INSERT INTO revisions (kind, node_id, user_id, author_id, action, name, definition, created_at)
+SELECT 'entity_class', v.entity_class_id, v.user_id, v.user_id, 'create', v.name, v.definition, to_timestamp(0)
+FROM users_entity_classes v
+WHERE NOT EXISTS (
+	SELECT 1 FROM revisions rv
+	WHERE rv.kind = 'entity_class' AND rv.node_id = v.entity_class_id AND rv.user_id = v.user_id
+);
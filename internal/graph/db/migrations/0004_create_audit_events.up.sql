@@ -0,0 +1,34 @@
+CREATE TABLE IF NOT EXISTS "audit_events" (
+	"seq" bigserial,
+	"service" varchar(32) NOT NULL,
+	"action" varchar(64) NOT NULL,
+	"user_id" varchar(36) NOT NULL DEFAULT '',
+	"target_id" varchar(255) NOT NULL DEFAULT '',
+	"outcome" varchar(10) NOT NULL,
+	"request_id" varchar(64) NOT NULL DEFAULT '',
+	"details" jsonb,
+	"created_at" timestamptz NOT NULL,
+	"prev_hash" varchar(64) NOT NULL DEFAULT '',
+	"hash" varchar(64) NOT NULL,
+	PRIMARY KEY ("seq"),
+	CONSTRAINT "chk_audit_events_outcome" CHECK (outcome IN ('success','failure'))
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_audit_events_hash" ON "audit_events" ("hash");
+CREATE INDEX IF NOT EXISTS "idx_audit_events_created_at" ON "audit_events" ("created_at");
+CREATE INDEX IF NOT EXISTS "idx_audit_events_user" ON "audit_events" ("user_id");
+CREATE INDEX IF NOT EXISTS "idx_audit_events_action" ON "audit_events" ("action");
+
+-- The audit log is append-only
+CREATE OR REPLACE FUNCTION audit_events_append_only() RETURNS trigger AS $$
+BEGIN
+	RAISE EXCEPTION 'audit_events is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS audit_events_no_change ON audit_events;
+CREATE TRIGGER audit_events_no_change BEFORE UPDATE OR DELETE ON audit_events
+	FOR EACH ROW EXECUTE FUNCTION audit_events_append_only();
+
+DROP TRIGGER IF EXISTS audit_events_no_truncate ON audit_events;
+CREATE TRIGGER audit_events_no_truncate BEFORE TRUNCATE ON audit_events
+	FOR EACH STATEMENT EXECUTE FUNCTION audit_events_append_only();
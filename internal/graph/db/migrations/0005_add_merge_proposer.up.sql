@@ -0,0 +1,6 @@
+-- The user behind the owner that proposed a merge, so they cannot accept it as another owner
+ALTER TABLE "entity_merges" ADD COLUMN IF NOT EXISTS "proposer_user_id" uuid;
+
+-- Proposals of users were made by the users themselves, those of workspaces cannot be attributed
+UPDATE entity_merges SET proposer_user_id = proposed_by
+WHERE NOT EXISTS (SELECT 1 FROM workspaces w WHERE w.id = entity_merges.proposed_by);
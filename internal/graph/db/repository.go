@@ -0,0 +1,97 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/BwezB/Wikno-backend/internal/graph/model"
+)
+
+// GraphRepository is the storage the graph service reads and writes through.
+// Database stores the graph in postgres, and memory.Store keeps it in memory for tests and local demos.
+// Implementations return the error types of this package, so callers can tell the cases apart.
+type GraphRepository interface {
+	// Users and graphs
+	CreateUser(ctx context.Context, req *model.UserRequest) error
+	GetUserData(ctx context.Context, req *model.UserDataRequest) (*model.UserDataResponse, error)
+
+	// Entities
+	CreateEntity(ctx context.Context, req *model.EntityRequest) (*model.UsersEntity, error)
+	UpdateEntity(ctx context.Context, req *model.EntityRequest) error
+	FindEntitiesWithName(ctx context.Context, req *model.SearchRequest) ([]model.UsersEntity, error)
+	GetEntities(ctx context.Context, req *model.BatchRequest) ([]model.UsersEntity, error)
+
+	// Connection and property types
+	CreateConnectionType(ctx context.Context, req *model.ConnectionTypeRequest) (*model.UsersConnectionType, error)
+	FindConnectionTypesWithName(ctx context.Context, req *model.SearchRequest) ([]model.UsersConnectionType, error)
+	GetConnectionTypes(ctx context.Context, req *model.BatchRequest) ([]model.UsersConnectionType, error)
+	CreatePropertyType(ctx context.Context, req *model.PropertyTypeRequest) (*model.PropertyTypeResponse, error)
+	FindPropertyTypesWithName(ctx context.Context, req *model.SearchRequest) ([]model.PropertyTypeResponse, error)
+
+	// Entity classes
+	CreateEntityClass(ctx context.Context, req *model.EntityClassRequest) (*model.UsersEntityClass, error)
+	FindEntityClassesWithName(ctx context.Context, req *model.SearchRequest) ([]model.UsersEntityClass, error)
+	SetClasses(ctx context.Context, req *model.ClassesRequest) error
+	GetClasses(ctx context.Context, req *model.NodeRequest) ([]model.UsersEntityClass, error)
+	GetClassesOfNodes(ctx context.Context, req *model.BatchNodeRequest) ([]model.NodeClass, error)
+	GetApplicableTypes(ctx context.Context, req *model.IDRequest) (*model.ApplicableTypesResponse, error)
+
+	// Connections
+	CreateConnection(ctx context.Context, req *model.ConnectionRequest) (*model.Connection, error)
+	DeleteConnection(ctx context.Context, req *model.IDRequest) error
+	GetConnections(ctx context.Context, req *model.TraversalRequest) ([]model.Connection, error)
+	GetConnectionsOfEntities(ctx context.Context, req *model.BatchTraversalRequest) ([]model.Connection, error)
+
+	// Consensus
+	Vote(ctx context.Context, req *model.VoteRequest) error
+	GetEntityVersions(ctx context.Context, req *model.IDRequest) ([]model.UsersEntity, error)
+	GetConnectionTypeVersions(ctx context.Context, req *model.IDRequest) ([]model.UsersConnectionType, error)
+	GetPropertyTypeVersions(ctx context.Context, req *model.IDRequest) ([]model.PropertyTypeResponse, error)
+
+	// Revisions
+	ListRevisions(ctx context.Context, req *model.RevisionsRequest) ([]model.Revision, error)
+	GetRevision(ctx context.Context, req *model.IDRequest) (*model.Revision, error)
+	RevertToRevision(ctx context.Context, req *model.IDRequest) (*model.Revision, error)
+
+	// Merges
+	HasVersion(ctx context.Context, kind string, ids ...string) (bool, error)
+	GetMerge(ctx context.Context, req *model.IDRequest) (*model.EntityMerge, error)
+	ListMerges(ctx context.Context, req *model.IDRequest) ([]model.EntityMerge, error)
+	ProposeMerge(ctx context.Context, req *model.MergeRequest) (*model.EntityMerge, error)
+	AcceptMerge(ctx context.Context, req *model.IDRequest) (*model.EntityMerge, error)
+	RevertMerge(ctx context.Context, req *model.IDRequest) (*model.EntityMerge, error)
+	SplitEntity(ctx context.Context, req *model.IDRequest) (*model.EntityMerge, error)
+
+	// Workspaces
+	CreateWorkspace(ctx context.Context, req *model.WorkspaceRequest) (*model.WorkspaceResponse, error)
+	ListWorkspaces(ctx context.Context) ([]model.WorkspaceResponse, error)
+	GetMemberRole(ctx context.Context, workspaceID, userID string) (string, error)
+	ListMembers(ctx context.Context, workspaceID string) ([]model.WorkspaceMember, error)
+	UpdateMemberRole(ctx context.Context, req *model.MemberRequest) error
+	RemoveMember(ctx context.Context, req *model.MemberRequest) error
+	CreateInvitation(ctx context.Context, req *model.MemberRequest) (*model.InvitationResponse, error)
+	ListInvitations(ctx context.Context) ([]model.InvitationResponse, error)
+	AnswerInvitation(ctx context.Context, req *model.InvitationIDRequest, accept bool) (*model.WorkspaceResponse, error)
+
+	// Graph events
+	GetEventsHead(ctx context.Context) (model.EventPosition, error)
+	ListGraphEvents(ctx context.Context, after model.EventPosition, kinds []string, limit int) ([]model.GraphEvent, error)
+	ListOwnerGraphEvents(ctx context.Context, ownerID string, after model.EventPosition, kinds []string, limit int) ([]model.GraphEvent, error)
+	ListEventOwners(ctx context.Context, after model.EventPosition, limit int) ([]model.GraphEvent, error)
+	PruneGraphEvents(ctx context.Context, before time.Time) (int64, error)
+
+	// Webhooks
+	CreateWebhook(ctx context.Context, req *model.WebhookRequest, secret string) (*model.Webhook, error)
+	ListWebhooks(ctx context.Context) ([]model.Webhook, error)
+	DeleteWebhook(ctx context.Context, req *model.WebhookIDRequest) error
+	ListWebhookDeliveries(ctx context.Context, req *model.WebhookDeliveriesRequest) ([]model.WebhookDelivery, error)
+	ListLeasableWebhooks(ctx context.Context, holder string) ([]model.Webhook, error)
+	LeaseWebhook(ctx context.Context, webhookID, holder string, duration time.Duration) (bool, error)
+	ReleaseWebhooks(ctx context.Context, holder string) error
+	AdvanceWebhookCursor(ctx context.Context, webhookID string, position model.EventPosition) error
+	CreateWebhookDelivery(ctx context.Context, delivery *model.WebhookDelivery) error
+	CreateWebhookDeadLetter(ctx context.Context, deadLetter *model.WebhookDeadLetter) error
+	PruneWebhookDeliveries(ctx context.Context, before time.Time) (int64, error)
+}
+
+var _ GraphRepository = (*Database)(nil)
@@ -0,0 +1,214 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/BwezB/Wikno-backend/internal/graph/model"
+
+	a "github.com/BwezB/Wikno-backend/pkg/auth"
+	e "github.com/BwezB/Wikno-backend/pkg/errors"
+	l "github.com/BwezB/Wikno-backend/pkg/log"
+	r "github.com/BwezB/Wikno-backend/pkg/requestid"
+)
+
+// readableOwnerCondition is the condition that the owner in the column is the user in the context
+// or a workspace they are a member of. Only they can read the revisions of the owner.
+func readableOwnerCondition(ctx context.Context, column string) (string, []interface{}, error) {
+	userID := a.GetUserID(ctx)
+	if userID == "" {
+		return "", nil, e.New("Failed to get user ID from context", ErrInternal, nil)
+	}
+	return `(` + column + ` = ? OR ` + column + ` IN (SELECT workspace_id FROM workspace_members WHERE user_id = ?))`,
+		[]interface{}{userID, userID}, nil
+}
+
+// ListRevisions lists the revisions of a users version of a shared node, newest first.
+// The version is the one of the owner in the context, unless another owner is requested.
+// Only the user's own revisions and those of their workspaces are listed.
+func (db *Database) ListRevisions(ctx context.Context, req *model.RevisionsRequest) ([]model.Revision, error) {
+	ownerID := req.UserID
+	if ownerID == "" {
+		ownerID = getOwnerID(ctx)
+	}
+	if ownerID == "" {
+		return nil, e.New("Failed to get owner ID from context", ErrInternal, nil)
+	}
+	readable, readableArgs, err := readableOwnerCondition(ctx, "user_id")
+	if err != nil {
+		return nil, err
+	}
+
+	l.Debug("Listing revisions",
+		l.String("owner_id", ownerID),
+		l.String("kind", req.Kind),
+		l.String("id", req.ID),
+		l.String("request_id", r.GetRequestID(ctx)))
+
+	var revisions []model.Revision
+	res := db.WithContext(ctx).
+		Where("user_id = ? AND kind = ? AND node_id = ?", ownerID, req.Kind, req.ID).
+		Where(readable, readableArgs...).
+		Order("created_at DESC").
+		Find(&revisions)
+	if res.Error != nil {
+		return nil, e.Wrap("Failed to list revisions", TranslateDatabaseError(res.Error))
+	}
+	return revisions, nil
+}
+
+// GetRevision gets a revision by ID, if it is one of the user's own or of their workspaces.
+func (db *Database) GetRevision(ctx context.Context, req *model.IDRequest) (*model.Revision, error) {
+	readable, readableArgs, err := readableOwnerCondition(ctx, "user_id")
+	if err != nil {
+		return nil, err
+	}
+
+	var revision model.Revision
+	if err := db.WithContext(ctx).Where(readable, readableArgs...).First(&revision, "id = ?", req.ID).Error; err != nil {
+		return nil, e.Wrap("Failed to get revision", TranslateDatabaseError(err))
+	}
+	return &revision, nil
+}
+
+// RevertToRevision sets the name and definition of a version of the owner in the context back to one of its revisions.
+// A version that was moved off its node is restored if the node still exists. The revert is recorded as a new revision.
+func (db *Database) RevertToRevision(ctx context.Context, req *model.IDRequest) (*model.Revision, error) {
+	// Get ID from context
+	ownerID := getOwnerID(ctx)
+	if ownerID == "" {
+		return nil, e.New("Failed to get owner ID from context", ErrInternal, nil)
+	}
+
+	l.Debug("Reverting to revision",
+		l.String("owner_id", ownerID),
+		l.String("revision_id", req.ID),
+		l.String("request_id", r.GetRequestID(ctx)))
+
+	// Start transaction
+	tx := db.WithContext(ctx).Begin()
+	if tx.Error != nil {
+		return nil, e.Wrap("Could not start transaction", TranslateDatabaseError(tx.Error))
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	// Only the owner's own revisions can be reverted to
+	var revision model.Revision
+	if err := tx.First(&revision, "id = ? AND user_id = ?", req.ID, ownerID).Error; err != nil {
+		tx.Rollback()
+		return nil, e.Wrap("Could not find revision", TranslateDatabaseError(err))
+	}
+	if revision.Action == model.RevisionDelete {
+		tx.Rollback()
+		return nil, e.New("Cannot revert to a revision that removed the version", ErrInvalidRequest, nil)
+	}
+
+	k := sharedKinds[revision.Kind]
+	res := tx.Table(k.table).
+		Where("user_id = ? AND "+k.idColumn+" = ?", ownerID, revision.NodeID).
+		Updates(map[string]interface{}{
+			"name":       revision.Name,
+			"definition": revision.Definition,
+		})
+	if res.Error != nil {
+		tx.Rollback()
+		return nil, e.Wrap("Could not revert version", TranslateDatabaseError(res.Error))
+	}
+	if res.RowsAffected == 0 {
+		// The version was moved off the node, restore it
+		var count int64
+		if err := tx.Table(k.nodeTable).Where("id = ?", revision.NodeID).Count(&count).Error; err != nil {
+			tx.Rollback()
+			return nil, e.Wrap("Could not find node", TranslateDatabaseError(err))
+		}
+		if count == 0 {
+			tx.Rollback()
+			return nil, e.New("The node of the revision no longer exists", ErrRecordNotFound, nil)
+		}
+		if err := tx.Exec(`INSERT INTO `+k.table+` (user_id, `+k.idColumn+`, name, definition) VALUES (?, ?, ?, ?)`,
+			ownerID, revision.NodeID, revision.Name, revision.Definition).Error; err != nil {
+			tx.Rollback()
+			return nil, e.Wrap("Could not restore version", TranslateDatabaseError(err))
+		}
+	}
+
+	reverted, err := recordRevision(tx, revision.Kind, model.RevisionRevert, ownerID, revision.NodeID, revision.Name, revision.Definition)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	// Commit transaction
+	if err := tx.Commit().Error; err != nil {
+		return nil, e.Wrap("Could not commit", TranslateDatabaseError(err))
+	}
+
+	return reverted, nil
+}
+
+// HELPER FUNCTIONS
+
+// recordRevision appends a revision of a users version, authored by the user in the transaction's context.
+func recordRevision(tx *gorm.DB, kind, action, ownerID, nodeID, name, definition string) (*model.Revision, error) {
+	authorID := a.GetUserID(tx.Statement.Context)
+	if authorID == "" {
+		authorID = ownerID
+	}
+
+	revision := model.Revision{
+		Kind:       kind,
+		NodeID:     nodeID,
+		UserID:     ownerID,
+		AuthorID:   authorID,
+		Action:     action,
+		Name:       name,
+		Definition: definition,
+	}
+	if err := tx.Create(&revision).Error; err != nil {
+		return nil, e.Wrap("Could not record revision", TranslateDatabaseError(err))
+	}
+	return &revision, nil
+}
+
+// getUserDataAsOf gets the owner's versions and connections as they were at a point in time.
+func (db *Database) getUserDataAsOf(ctx context.Context, ownerID string, asOf time.Time) (*model.UserDataResponse, error) {
+	var user model.GraphUser
+	if err := db.WithContext(ctx).First(&user, "id = ?", ownerID).Error; err != nil {
+		return nil, e.Wrap("Failed to get user", TranslateDatabaseError(err))
+	}
+
+	userData := model.UserDataResponse{ID: user.ID}
+	for kind, dest := range map[string]interface{}{
+		model.KindEntity:         &userData.Entities,
+		model.KindConnectionType: &userData.ConnectionTypes,
+		model.KindPropertyType:   &userData.PropertyTypes,
+		model.KindEntityClass:    &userData.EntityClasses,
+	} {
+		k := sharedKinds[kind]
+		table, args := versionsTable(kind, &asOf)
+		res := db.WithContext(ctx).
+			Raw(`SELECT uv.*`+k.columns+` FROM `+table+` uv`+k.join+` WHERE uv.user_id = ?`, append(args, ownerID)...).
+			Scan(dest)
+		if res.Error != nil {
+			return nil, e.Wrap("Failed to get versions of "+kind, TranslateDatabaseError(res.Error))
+		}
+	}
+	if err := db.loadConnectionTypes(ctx, userData.ConnectionTypes); err != nil {
+		return nil, err
+	}
+
+	res := db.WithContext(ctx).Unscoped().
+		Where("user_id = ? AND created_at <= ? AND (deleted_at IS NULL OR deleted_at > ?)", ownerID, asOf, asOf).
+		Find(&userData.Connections)
+	if res.Error != nil {
+		return nil, e.Wrap("Failed to get connections", TranslateDatabaseError(res.Error))
+	}
+
+	return &userData, nil
+}
@@ -0,0 +1,80 @@
+package db
+
+import (
+	"context"
+
+	"github.com/BwezB/Wikno-backend/internal/graph/model"
+
+	ad "github.com/BwezB/Wikno-backend/pkg/admin"
+	a "github.com/BwezB/Wikno-backend/pkg/auth"
+	e "github.com/BwezB/Wikno-backend/pkg/errors"
+	l "github.com/BwezB/Wikno-backend/pkg/log"
+)
+
+// Seed creates the users of the fixtures with their entities, entity classes, connection types and property types.
+// Users that already exist are skipped with their graphs, so seeding twice is harmless.
+func (db *Database) Seed(ctx context.Context, fixtures *ad.Fixtures) error {
+	for _, fixture := range fixtures.Users {
+		var existing int64
+		if err := db.WithContext(ctx).Model(&model.GraphUser{}).Where("id = ?", fixture.ID).Count(&existing).Error; err != nil {
+			return e.Wrap("Could not check for seeded user", TranslateDatabaseError(err))
+		}
+		if existing > 0 {
+			l.Info("Skipping existing user", l.String("user_id", fixture.ID))
+			continue
+		}
+
+		// The graph is created as the user, so revisions and events are recorded as for the API
+		userCtx := a.WithUserID(ctx, fixture.ID)
+		if err := db.seedUser(userCtx, fixture); err != nil {
+			return e.Wrap("Could not seed user "+fixture.ID, err)
+		}
+		l.Info("Seeded user",
+			l.String("user_id", fixture.ID),
+			l.Int("entities", len(fixture.Entities)),
+			l.Int("entity_classes", len(fixture.EntityClasses)),
+			l.Int("connection_types", len(fixture.ConnectionTypes)),
+			l.Int("property_types", len(fixture.PropertyTypes)))
+	}
+	return nil
+}
+
+// HELPER FUNCTIONS
+
+func (db *Database) seedUser(ctx context.Context, fixture ad.UserFixture) error {
+	if err := db.CreateUser(ctx, &model.UserRequest{ID: fixture.ID}); err != nil {
+		return err
+	}
+	for _, entity := range fixture.Entities {
+		if _, err := db.CreateEntity(ctx, &model.EntityRequest{Name: entity.Name, Definition: entity.Definition}); err != nil {
+			return err
+		}
+	}
+	for _, class := range fixture.EntityClasses {
+		if _, err := db.CreateEntityClass(ctx, &model.EntityClassRequest{Name: class.Name, Definition: class.Definition}); err != nil {
+			return err
+		}
+	}
+	for _, connectionType := range fixture.ConnectionTypes {
+		req := &model.ConnectionTypeRequest{
+			Name:       connectionType.Name,
+			Definition: connectionType.Definition,
+			Symmetric:  connectionType.Symmetric,
+			Transitive: connectionType.Transitive,
+		}
+		if _, err := db.CreateConnectionType(ctx, req); err != nil {
+			return err
+		}
+	}
+	for _, propertyType := range fixture.PropertyTypes {
+		req := &model.PropertyTypeRequest{
+			Name:       propertyType.Name,
+			Definition: propertyType.Definition,
+			ValueType:  propertyType.ValueType,
+		}
+		if _, err := db.CreatePropertyType(ctx, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
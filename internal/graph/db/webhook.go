@@ -0,0 +1,228 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/BwezB/Wikno-backend/internal/graph/model"
+
+	a "github.com/BwezB/Wikno-backend/pkg/auth"
+	e "github.com/BwezB/Wikno-backend/pkg/errors"
+	l "github.com/BwezB/Wikno-backend/pkg/log"
+	r "github.com/BwezB/Wikno-backend/pkg/requestid"
+)
+
+// leaseAvailableFilter selects the webhooks that are not leased by another holder
+const leaseAvailableFilter = `(leased_until IS NULL OR leased_until < now() OR lease_holder = ?)`
+
+// CreateWebhook subscribes a URL to the graph events of the owner in the context, starting after the current ones.
+func (db *Database) CreateWebhook(ctx context.Context, req *model.WebhookRequest, secret string) (*model.Webhook, error) {
+	// Get IDs from context
+	userID := a.GetUserID(ctx)
+	ownerID := getOwnerID(ctx)
+	if userID == "" || ownerID == "" {
+		return nil, e.New("Failed to get user ID from context", ErrInternal, nil)
+	}
+
+	l.Debug("Creating webhook",
+		l.String("user_id", ownerID),
+		l.String("url", req.URL),
+		l.String("request_id", r.GetRequestID(ctx)))
+
+	head, err := db.GetEventsHead(ctx)
+	if err != nil {
+		return nil, e.Wrap("Could not get head of graph events", err)
+	}
+
+	webhook := model.Webhook{
+		UserID:     ownerID,
+		URL:        req.URL,
+		Kinds:      req.Kinds,
+		Secret:     secret,
+		CursorTxID: head.TxID,
+		CursorSeq:  head.Seq,
+		CreatedBy:  userID,
+	}
+	if err := db.WithContext(ctx).Create(&webhook).Error; err != nil {
+		return nil, e.Wrap("Could not create webhook", TranslateDatabaseError(err))
+	}
+
+	l.Info("Created webhook",
+		l.String("webhook_id", webhook.ID),
+		l.String("user_id", ownerID),
+		l.String("request_id", r.GetRequestID(ctx)))
+
+	return &webhook, nil
+}
+
+// ListWebhooks lists the webhooks of the owner in the context.
+func (db *Database) ListWebhooks(ctx context.Context) ([]model.Webhook, error) {
+	ownerID := getOwnerID(ctx)
+	if ownerID == "" {
+		return nil, e.New("Failed to get owner ID from context", ErrInternal, nil)
+	}
+
+	l.Debug("Listing webhooks",
+		l.String("user_id", ownerID),
+		l.String("request_id", r.GetRequestID(ctx)))
+
+	var webhooks []model.Webhook
+	res := db.WithContext(ctx).
+		Where("user_id = ?", ownerID).
+		Order("created_at").
+		Find(&webhooks)
+	if res.Error != nil {
+		return nil, e.Wrap("Failed to list webhooks", TranslateDatabaseError(res.Error))
+	}
+	return webhooks, nil
+}
+
+// DeleteWebhook deletes a webhook of the owner in the context, with its deliveries and dead letters.
+// It returns ErrRecordNotFound if the owner has no such webhook.
+func (db *Database) DeleteWebhook(ctx context.Context, req *model.WebhookIDRequest) error {
+	ownerID := getOwnerID(ctx)
+	if ownerID == "" {
+		return e.New("Failed to get owner ID from context", ErrInternal, nil)
+	}
+
+	l.Debug("Deleting webhook",
+		l.String("webhook_id", req.ID),
+		l.String("user_id", ownerID),
+		l.String("request_id", r.GetRequestID(ctx)))
+
+	res := db.WithContext(ctx).
+		Where("id = ? AND user_id = ?", req.ID, ownerID).
+		Delete(&model.Webhook{})
+	if res.Error != nil {
+		return e.Wrap("Failed to delete webhook", TranslateDatabaseError(res.Error))
+	}
+	if res.RowsAffected == 0 {
+		return ErrRecordNotFound
+	}
+	return nil
+}
+
+// ListWebhookDeliveries lists the delivery log of a webhook of the owner in the context, newest first.
+// It returns ErrRecordNotFound if the owner has no such webhook.
+func (db *Database) ListWebhookDeliveries(ctx context.Context, req *model.WebhookDeliveriesRequest) ([]model.WebhookDelivery, error) {
+	ownerID := getOwnerID(ctx)
+	if ownerID == "" {
+		return nil, e.New("Failed to get owner ID from context", ErrInternal, nil)
+	}
+
+	l.Debug("Listing webhook deliveries",
+		l.String("webhook_id", req.WebhookID),
+		l.String("user_id", ownerID),
+		l.String("request_id", r.GetRequestID(ctx)))
+
+	if err := db.WithContext(ctx).First(&model.Webhook{}, "id = ? AND user_id = ?", req.WebhookID, ownerID).Error; err != nil {
+		return nil, e.Wrap("Could not find webhook", TranslateDatabaseError(err))
+	}
+
+	var deliveries []model.WebhookDelivery
+	res := db.WithContext(ctx).
+		Where("webhook_id = ?", req.WebhookID).
+		Order("created_at DESC").
+		Limit(req.Limit).
+		Find(&deliveries)
+	if res.Error != nil {
+		return nil, e.Wrap("Failed to list webhook deliveries", TranslateDatabaseError(res.Error))
+	}
+	return deliveries, nil
+}
+
+// DISPATCHING
+
+// ListLeasableWebhooks lists the webhooks of all owners that are not leased by another holder.
+func (db *Database) ListLeasableWebhooks(ctx context.Context, holder string) ([]model.Webhook, error) {
+	var webhooks []model.Webhook
+	res := db.WithContext(ctx).
+		Where(leaseAvailableFilter, holder).
+		Find(&webhooks)
+	if res.Error != nil {
+		return nil, e.Wrap("Failed to list leasable webhooks", TranslateDatabaseError(res.Error))
+	}
+	return webhooks, nil
+}
+
+// LeaseWebhook leases a webhook to a holder for a duration, or extends the holder's lease.
+// It reports whether the holder has the lease, which it does not if the webhook was deleted
+// or is leased by another holder.
+func (db *Database) LeaseWebhook(ctx context.Context, webhookID, holder string, duration time.Duration) (bool, error) {
+	res := db.WithContext(ctx).Model(&model.Webhook{}).
+		Where("id = ?", webhookID).
+		Where(leaseAvailableFilter, holder).
+		Updates(map[string]interface{}{
+			"lease_holder": holder,
+			"leased_until": gorm.Expr("now() + make_interval(secs => ?)", duration.Seconds()),
+		})
+	if res.Error != nil {
+		return false, e.Wrap("Failed to lease webhook", TranslateDatabaseError(res.Error))
+	}
+	return res.RowsAffected == 1, nil
+}
+
+// ReleaseWebhooks ends all leases of a holder
+func (db *Database) ReleaseWebhooks(ctx context.Context, holder string) error {
+	res := db.WithContext(ctx).Model(&model.Webhook{}).
+		Where("lease_holder = ?", holder).
+		Updates(map[string]interface{}{
+			"lease_holder": "",
+			"leased_until": nil,
+		})
+	if res.Error != nil {
+		return e.Wrap("Failed to release webhooks", TranslateDatabaseError(res.Error))
+	}
+	return nil
+}
+
+// AdvanceWebhookCursor moves the cursor of a webhook to the position of the last handled event.
+func (db *Database) AdvanceWebhookCursor(ctx context.Context, webhookID string, position model.EventPosition) error {
+	res := db.WithContext(ctx).Model(&model.Webhook{}).
+		Where("id = ?", webhookID).
+		Updates(map[string]interface{}{
+			"cursor_tx_id": position.TxID,
+			"cursor_seq":   position.Seq,
+		})
+	if res.Error != nil {
+		return e.Wrap("Failed to advance webhook cursor", TranslateDatabaseError(res.Error))
+	}
+	return nil
+}
+
+// CreateWebhookDelivery adds an attempt to the delivery log of a webhook.
+func (db *Database) CreateWebhookDelivery(ctx context.Context, delivery *model.WebhookDelivery) error {
+	if err := db.WithContext(ctx).Omit("Webhook").Create(delivery).Error; err != nil {
+		return e.Wrap("Failed to log webhook delivery", TranslateDatabaseError(err))
+	}
+	return nil
+}
+
+// CreateWebhookDeadLetter keeps an event that could not be delivered to a webhook.
+func (db *Database) CreateWebhookDeadLetter(ctx context.Context, deadLetter *model.WebhookDeadLetter) error {
+	l.Warn("Dead-lettering webhook event",
+		l.String("webhook_id", deadLetter.WebhookID),
+		l.String("event_id", deadLetter.EventID),
+		l.String("error", deadLetter.LastError),
+		l.String("request_id", r.GetRequestID(ctx)))
+
+	if err := db.WithContext(ctx).Omit("Webhook").Create(deadLetter).Error; err != nil {
+		return e.Wrap("Failed to dead-letter webhook event", TranslateDatabaseError(err))
+	}
+	return nil
+}
+
+// PruneWebhookDeliveries deletes the delivery log entries created before a point in time.
+func (db *Database) PruneWebhookDeliveries(ctx context.Context, before time.Time) (int64, error) {
+	l.Debug("Pruning webhook deliveries",
+		l.String("before", before.Format(time.RFC3339)),
+		l.String("request_id", r.GetRequestID(ctx)))
+
+	res := db.WithContext(ctx).Where("created_at < ?", before).Delete(&model.WebhookDelivery{})
+	if res.Error != nil {
+		return 0, e.Wrap("Failed to prune webhook deliveries", TranslateDatabaseError(res.Error))
+	}
+	return res.RowsAffected, nil
+}
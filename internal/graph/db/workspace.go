@@ -0,0 +1,357 @@
+package db
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/BwezB/Wikno-backend/internal/graph/model"
+
+	a "github.com/BwezB/Wikno-backend/pkg/auth"
+	e "github.com/BwezB/Wikno-backend/pkg/errors"
+	l "github.com/BwezB/Wikno-backend/pkg/log"
+	r "github.com/BwezB/Wikno-backend/pkg/requestid"
+)
+
+// CreateWorkspace creates a workspace, the graph user owning its versions, and makes the creator its admin.
+func (db *Database) CreateWorkspace(ctx context.Context, req *model.WorkspaceRequest) (*model.WorkspaceResponse, error) {
+	// Get ID from context
+	userID := a.GetUserID(ctx)
+	if userID == "" {
+		return nil, e.New("Failed to get user ID from context", ErrInternal, nil)
+	}
+
+	l.Debug("Creating workspace",
+		l.String("user_id", userID),
+		l.String("name", req.Name),
+		l.String("request_id", r.GetRequestID(ctx)))
+
+	// Start transaction
+	tx := db.WithContext(ctx).Begin()
+	if tx.Error != nil {
+		return nil, e.Wrap("Could not start transaction", TranslateDatabaseError(tx.Error))
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	// The workspace owns its versions through a graph user with the same ID
+	workspaceID := uuid.New().String()
+	if err := tx.Create(&model.GraphUser{ID: workspaceID}).Error; err != nil {
+		tx.Rollback()
+		return nil, e.Wrap("Could not create workspace owner", TranslateDatabaseError(err))
+	}
+
+	workspace := model.Workspace{
+		ID:        workspaceID,
+		Name:      req.Name,
+		CreatedBy: userID,
+		Members: []model.WorkspaceMember{{
+			UserID: userID,
+			Role:   model.RoleAdmin,
+		}},
+	}
+	if err := tx.Create(&workspace).Error; err != nil {
+		tx.Rollback()
+		return nil, e.Wrap("Could not create workspace", TranslateDatabaseError(err))
+	}
+
+	// Commit transaction
+	if err := tx.Commit().Error; err != nil {
+		return nil, e.Wrap("Could not commit", TranslateDatabaseError(err))
+	}
+
+	l.Info("Created workspace",
+		l.String("workspace_id", workspace.ID),
+		l.String("user_id", userID),
+		l.String("request_id", r.GetRequestID(ctx)))
+
+	return &model.WorkspaceResponse{
+		ID:   workspace.ID,
+		Name: workspace.Name,
+		Role: model.RoleAdmin,
+	}, nil
+}
+
+// ListWorkspaces lists the workspaces the user in the context is a member of, with the user's role.
+func (db *Database) ListWorkspaces(ctx context.Context) ([]model.WorkspaceResponse, error) {
+	// Get ID from context
+	userID := a.GetUserID(ctx)
+	if userID == "" {
+		return nil, e.New("Failed to get user ID from context", ErrInternal, nil)
+	}
+
+	l.Debug("Listing workspaces",
+		l.String("user_id", userID),
+		l.String("request_id", r.GetRequestID(ctx)))
+
+	var workspaces []model.WorkspaceResponse
+	res := db.WithContext(ctx).
+		Table("workspaces").
+		Select("workspaces.id, workspaces.name, workspace_members.role").
+		Joins("JOIN workspace_members ON workspace_members.workspace_id = workspaces.id").
+		Where("workspace_members.user_id = ?", userID).
+		Order("workspaces.name").
+		Scan(&workspaces)
+	if res.Error != nil {
+		return nil, e.Wrap("Failed to list workspaces", TranslateDatabaseError(res.Error))
+	}
+
+	return workspaces, nil
+}
+
+// GetMemberRole returns the role of a user in a workspace, or ErrRecordNotFound if the user is not a member.
+func (db *Database) GetMemberRole(ctx context.Context, workspaceID, userID string) (string, error) {
+	var member model.WorkspaceMember
+	res := db.WithContext(ctx).First(&member, "workspace_id = ? AND user_id = ?", workspaceID, userID)
+	if res.Error != nil {
+		return "", e.Wrap("Failed to get workspace member", TranslateDatabaseError(res.Error))
+	}
+	return member.Role, nil
+}
+
+// ListMembers lists all members of a workspace.
+func (db *Database) ListMembers(ctx context.Context, workspaceID string) ([]model.WorkspaceMember, error) {
+	l.Debug("Listing workspace members",
+		l.String("workspace_id", workspaceID),
+		l.String("request_id", r.GetRequestID(ctx)))
+
+	var members []model.WorkspaceMember
+	res := db.WithContext(ctx).
+		Where("workspace_id = ?", workspaceID).
+		Order("created_at").
+		Find(&members)
+	if res.Error != nil {
+		return nil, e.Wrap("Failed to list workspace members", TranslateDatabaseError(res.Error))
+	}
+	return members, nil
+}
+
+// UpdateMemberRole changes the role of an existing member.
+// The last admin of a workspace cannot be demoted, which returns ErrLastAdmin.
+func (db *Database) UpdateMemberRole(ctx context.Context, req *model.MemberRequest) error {
+	l.Debug("Updating workspace member role",
+		l.String("workspace_id", req.WorkspaceID),
+		l.String("member_id", req.UserID),
+		l.String("role", req.Role),
+		l.String("request_id", r.GetRequestID(ctx)))
+
+	return db.transaction(ctx, func(tx *gorm.DB) error {
+		if req.Role != model.RoleAdmin {
+			if err := keepAdmin(tx, req.WorkspaceID, req.UserID); err != nil {
+				return err
+			}
+		}
+
+		res := tx.Model(&model.WorkspaceMember{}).
+			Where("workspace_id = ? AND user_id = ?", req.WorkspaceID, req.UserID).
+			Update("role", req.Role)
+		if res.Error != nil {
+			return e.Wrap("Failed to update workspace member", TranslateDatabaseError(res.Error))
+		}
+		if res.RowsAffected == 0 {
+			return ErrRecordNotFound
+		}
+		return nil
+	})
+}
+
+// RemoveMember removes a user from a workspace.
+// The last admin of a workspace cannot be removed, which returns ErrLastAdmin.
+func (db *Database) RemoveMember(ctx context.Context, req *model.MemberRequest) error {
+	l.Debug("Removing workspace member",
+		l.String("workspace_id", req.WorkspaceID),
+		l.String("member_id", req.UserID),
+		l.String("request_id", r.GetRequestID(ctx)))
+
+	return db.transaction(ctx, func(tx *gorm.DB) error {
+		if err := keepAdmin(tx, req.WorkspaceID, req.UserID); err != nil {
+			return err
+		}
+
+		res := tx.Where("workspace_id = ? AND user_id = ?", req.WorkspaceID, req.UserID).
+			Delete(&model.WorkspaceMember{})
+		if res.Error != nil {
+			return e.Wrap("Failed to remove workspace member", TranslateDatabaseError(res.Error))
+		}
+		if res.RowsAffected == 0 {
+			return ErrRecordNotFound
+		}
+		return nil
+	})
+}
+
+// CreateInvitation invites a user to a workspace on behalf of the user in the context.
+func (db *Database) CreateInvitation(ctx context.Context, req *model.MemberRequest) (*model.InvitationResponse, error) {
+	// Get ID from context
+	userID := a.GetUserID(ctx)
+	if userID == "" {
+		return nil, e.New("Failed to get user ID from context", ErrInternal, nil)
+	}
+
+	l.Debug("Creating workspace invitation",
+		l.String("workspace_id", req.WorkspaceID),
+		l.String("inviter_id", userID),
+		l.String("invitee_id", req.UserID),
+		l.String("role", req.Role),
+		l.String("request_id", r.GetRequestID(ctx)))
+
+	// The invitee must be a graph user, and not the graph user of a workspace
+	if err := db.WithContext(ctx).First(&model.GraphUser{}, "id = ?", req.UserID).Error; err != nil {
+		return nil, e.Wrap("Could not find invitee", TranslateDatabaseError(err))
+	}
+	var workspaces int64
+	if err := db.WithContext(ctx).Model(&model.Workspace{}).Where("id = ?", req.UserID).Count(&workspaces).Error; err != nil {
+		return nil, e.Wrap("Could not check invitee", TranslateDatabaseError(err))
+	}
+	if workspaces > 0 {
+		return nil, e.New("Workspaces cannot be invited to workspaces", ErrInvalidRequest, nil)
+	}
+
+	// Members cannot be invited again
+	if _, err := db.GetMemberRole(ctx, req.WorkspaceID, req.UserID); err == nil {
+		return nil, e.New("User is already a member of the workspace", ErrDuplicateEntry, nil)
+	} else if !e.Is(err, ErrRecordNotFound) {
+		return nil, err
+	}
+
+	invitation := model.WorkspaceInvitation{
+		WorkspaceID: req.WorkspaceID,
+		InviterID:   userID,
+		InviteeID:   req.UserID,
+		Role:        req.Role,
+	}
+	if err := db.WithContext(ctx).Create(&invitation).Error; err != nil {
+		return nil, e.Wrap("Could not create invitation", TranslateDatabaseError(err))
+	}
+
+	if err := db.WithContext(ctx).Preload("Workspace").First(&invitation, "id = ?", invitation.ID).Error; err != nil {
+		return nil, e.Wrap("Could not load invitation", TranslateDatabaseError(err))
+	}
+
+	return translateInvitationToResponse(&invitation), nil
+}
+
+// ListInvitations lists the pending invitations of the user in the context.
+func (db *Database) ListInvitations(ctx context.Context) ([]model.InvitationResponse, error) {
+	// Get ID from context
+	userID := a.GetUserID(ctx)
+	if userID == "" {
+		return nil, e.New("Failed to get user ID from context", ErrInternal, nil)
+	}
+
+	l.Debug("Listing workspace invitations",
+		l.String("user_id", userID),
+		l.String("request_id", r.GetRequestID(ctx)))
+
+	var invitations []model.WorkspaceInvitation
+	res := db.WithContext(ctx).
+		Preload("Workspace").
+		Where("invitee_id = ?", userID).
+		Order("created_at").
+		Find(&invitations)
+	if res.Error != nil {
+		return nil, e.Wrap("Failed to list invitations", TranslateDatabaseError(res.Error))
+	}
+
+	responses := make([]model.InvitationResponse, len(invitations))
+	for i := range invitations {
+		responses[i] = *translateInvitationToResponse(&invitations[i])
+	}
+	return responses, nil
+}
+
+// AnswerInvitation deletes an invitation of the user in the context, adding the user to the workspace if accepted.
+func (db *Database) AnswerInvitation(ctx context.Context, req *model.InvitationIDRequest, accept bool) (*model.WorkspaceResponse, error) {
+	// Get ID from context
+	userID := a.GetUserID(ctx)
+	if userID == "" {
+		return nil, e.New("Failed to get user ID from context", ErrInternal, nil)
+	}
+
+	l.Debug("Answering workspace invitation",
+		l.String("user_id", userID),
+		l.String("invitation_id", req.ID),
+		l.Bool("accept", accept),
+		l.String("request_id", r.GetRequestID(ctx)))
+
+	// Start transaction
+	tx := db.WithContext(ctx).Begin()
+	if tx.Error != nil {
+		return nil, e.Wrap("Could not start transaction", TranslateDatabaseError(tx.Error))
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	// Only the invitee can answer the invitation
+	var invitation model.WorkspaceInvitation
+	if err := tx.Preload("Workspace").First(&invitation, "id = ? AND invitee_id = ?", req.ID, userID).Error; err != nil {
+		tx.Rollback()
+		return nil, e.Wrap("Could not find invitation", TranslateDatabaseError(err))
+	}
+
+	if err := tx.Delete(&invitation).Error; err != nil {
+		tx.Rollback()
+		return nil, e.Wrap("Could not delete invitation", TranslateDatabaseError(err))
+	}
+
+	if accept {
+		member := model.WorkspaceMember{
+			WorkspaceID: invitation.WorkspaceID,
+			UserID:      userID,
+			Role:        invitation.Role,
+		}
+		if err := tx.Create(&member).Error; err != nil {
+			tx.Rollback()
+			return nil, e.Wrap("Could not add workspace member", TranslateDatabaseError(err))
+		}
+	}
+
+	// Commit transaction
+	if err := tx.Commit().Error; err != nil {
+		return nil, e.Wrap("Could not commit", TranslateDatabaseError(err))
+	}
+
+	return &model.WorkspaceResponse{
+		ID:   invitation.Workspace.ID,
+		Name: invitation.Workspace.Name,
+		Role: invitation.Role,
+	}, nil
+}
+
+// HELPER FUNCTIONS
+
+// keepAdmin checks that the workspace keeps an admin if the user stops being one.
+// The admin rows are locked until the transaction ends, so concurrent demotions and removals are checked one after another.
+func keepAdmin(tx *gorm.DB, workspaceID, userID string) error {
+	var admins []string
+	res := tx.Model(&model.WorkspaceMember{}).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("workspace_id = ? AND role = ?", workspaceID, model.RoleAdmin).
+		Pluck("user_id", &admins)
+	if res.Error != nil {
+		return e.Wrap("Failed to lock workspace admins", TranslateDatabaseError(res.Error))
+	}
+	if len(admins) == 1 && admins[0] == userID {
+		return e.New("", ErrLastAdmin, nil)
+	}
+	return nil
+}
+
+func translateInvitationToResponse(invitation *model.WorkspaceInvitation) *model.InvitationResponse {
+	return &model.InvitationResponse{
+		ID:            invitation.ID,
+		WorkspaceID:   invitation.WorkspaceID,
+		WorkspaceName: invitation.Workspace.Name,
+		InviterID:     invitation.InviterID,
+		InviteeID:     invitation.InviteeID,
+		Role:          invitation.Role,
+	}
+}
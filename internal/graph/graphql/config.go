@@ -0,0 +1,53 @@
+package graphql
+
+import (
+	"strconv"
+
+	c "github.com/BwezB/Wikno-backend/pkg/configs"
+)
+
+type ServerConfig struct {
+	Host string `yaml:"host" validate:"required,hostname|ip"`
+	Port int    `yaml:"port" validate:"required,min=1,max=65535"`
+	Path string `yaml:"path" validate:"required,startswith=/"`
+	// MaxDepth is how deep queries may nest selections, so a single query cannot walk the whole graph
+	MaxDepth int `yaml:"max_depth" validate:"min=1,max=50"`
+}
+
+// DEFAULTS
+
+func (s *ServerConfig) SetDefaults() {
+	s.Host = "localhost"
+	s.Port = 8082
+	s.Path = "/graphql"
+	s.MaxDepth = 10
+}
+
+// ENV
+
+func (s *ServerConfig) AddFromEnv() {
+	c.SetEnvValue(&s.Host, "GRAPHQL_HOST")
+	c.SetEnvValue(&s.Port, "GRAPHQL_PORT")
+	c.SetEnvValue(&s.Path, "GRAPHQL_PATH")
+	c.SetEnvValue(&s.MaxDepth, "GRAPHQL_MAX_DEPTH")
+}
+
+// FLAGS
+
+var (
+	flagGraphQLHost     = c.NewFlag("graphql-host", "", "GraphQL server host")
+	flagGraphQLPort     = c.NewFlag("graphql-port", "", "GraphQL server port")
+	flagGraphQLPath     = c.NewFlag("graphql-path", "", "GraphQL server path")
+	flagGraphQLMaxDepth = c.NewFlag("graphql-max-depth", "", "Deepest selection nesting of GraphQL queries")
+)
+
+func (s *ServerConfig) AddFromFlags() {
+	c.SetFlagValue(&s.Host, flagGraphQLHost)
+	c.SetFlagValue(&s.Port, flagGraphQLPort)
+	c.SetFlagValue(&s.Path, flagGraphQLPath)
+	c.SetFlagValue(&s.MaxDepth, flagGraphQLMaxDepth)
+}
+
+func (s *ServerConfig) GetAddress() string {
+	return s.Host + ":" + strconv.Itoa(s.Port)
+}
@@ -0,0 +1,74 @@
+package graphql
+
+import (
+	"github.com/BwezB/Wikno-backend/internal/graph/db"
+	"github.com/BwezB/Wikno-backend/internal/graph/service"
+	e "github.com/BwezB/Wikno-backend/pkg/errors"
+)
+
+var (
+	// ErrInvalidRequest is returned when the request is invalid
+	ErrInvalidRequest = e.ErrInvalidRequest
+)
+
+// queryError is an error returned in the errors of a GraphQL response, with its code in the extensions
+type queryError struct {
+	code    string
+	message string
+}
+
+func (qe *queryError) Error() string {
+	return qe.message
+}
+
+// Extensions is read by graphql-go to fill the extensions of the error
+func (qe *queryError) Extensions() map[string]interface{} {
+	return map[string]interface{}{"code": qe.code}
+}
+
+// translateToQueryError translates an error into an error for the GraphQL response.
+// The codes are the names of the gRPC codes the gRPC API returns for the same errors.
+func translateToQueryError(err error) *queryError {
+	switch {
+	// Constraint errors, which wrap the database errors below
+	case e.Is(err, db.ErrDuplicateVersion):
+		return &queryError{"ALREADY_EXISTS", "You already have a version of this node"}
+	case e.Is(err, db.ErrDuplicateConnection):
+		return &queryError{"ALREADY_EXISTS", "Connection already exists"}
+	case e.Is(err, db.ErrDuplicateMember):
+		return &queryError{"ALREADY_EXISTS", "User is already a member of the workspace"}
+	case e.Is(err, db.ErrDuplicateInvitation):
+		return &queryError{"ALREADY_EXISTS", "User is already invited to the workspace"}
+	case e.Is(err, db.ErrInvalidValueType):
+		return &queryError{"INVALID_ARGUMENT", "Invalid property value type"}
+	case e.Is(err, db.ErrInvalidRole):
+		return &queryError{"INVALID_ARGUMENT", "Invalid workspace role"}
+
+	// Database errors
+	case e.Is(err, db.ErrRecordNotFound):
+		return &queryError{"NOT_FOUND", "Resource not found"}
+	case e.Is(err, db.ErrDuplicateEntry):
+		return &queryError{"ALREADY_EXISTS", "Resource already exists"}
+	case e.Is(err, db.ErrInvalidReference):
+		return &queryError{"INVALID_ARGUMENT", "Referenced resource does not exist"}
+	case e.Is(err, db.ErrCheckViolation):
+		return &queryError{"INVALID_ARGUMENT", "Value is not allowed"}
+	case e.Is(err, db.ErrTransactionConflict):
+		return &queryError{"ABORTED", "Transaction conflict, try again"}
+	case e.Is(err, db.ErrDatabaseConnection):
+		return &queryError{"UNAVAILABLE", "Database connection error"}
+
+	// Authorization errors
+	case e.Is(err, service.ErrPermissionDenied):
+		return &queryError{"PERMISSION_DENIED", "Permission denied"}
+
+	// General errors
+	case e.Is(err, e.ErrInvalidRequest):
+		return &queryError{"INVALID_ARGUMENT", "Invalid request"}
+	case e.Is(err, e.ErrInternal):
+		return &queryError{"INTERNAL", "Internal error"}
+
+	default:
+		return &queryError{"UNKNOWN", "Unknown error"}
+	}
+}
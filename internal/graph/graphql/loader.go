@@ -0,0 +1,274 @@
+package graphql
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/BwezB/Wikno-backend/internal/graph/model"
+	"github.com/BwezB/Wikno-backend/internal/graph/service"
+
+	a "github.com/BwezB/Wikno-backend/pkg/auth"
+
+	ap "github.com/BwezB/Wikno-backend/api/proto/auth"
+)
+
+// batchWait is how long a loader collects keys before fetching them
+const batchWait = time.Millisecond
+
+// maxBatch is the most keys a loader fetches at once. It is also the most list items resolved concurrently,
+// as a loader can only batch the loads of items that are being resolved.
+const maxBatch = 500
+
+// loader batches and caches the loads of one request, DataLoader style: the keys loaded while a batch is open
+// are fetched with a single call, and every key is fetched at most once per request.
+// This turns the database reads of a level of the query into one query, instead of one per node.
+type loader[K comparable, V any] struct {
+	ctx   context.Context
+	fetch func(ctx context.Context, keys []K) (map[K]V, error) // Keys that are not found are left out
+
+	mu      sync.Mutex
+	results map[K]*result[V]
+	pending []K
+}
+
+// result is the value of a key, available once done is closed
+type result[V any] struct {
+	done  chan struct{}
+	value V
+	err   error
+}
+
+func newLoader[K comparable, V any](ctx context.Context, fetch func(ctx context.Context, keys []K) (map[K]V, error)) *loader[K, V] {
+	return &loader[K, V]{
+		ctx:     ctx,
+		fetch:   fetch,
+		results: make(map[K]*result[V]),
+	}
+}
+
+// load returns the value of a key, or the zero value if it was not found
+func (ld *loader[K, V]) load(key K) (V, error) {
+	res := ld.enqueue(key)
+	<-res.done
+	return res.value, res.err
+}
+
+// loadAll returns the values of the keys in one batch
+func (ld *loader[K, V]) loadAll(keys []K) ([]V, error) {
+	results := make([]*result[V], len(keys))
+	for i, key := range keys {
+		results[i] = ld.enqueue(key)
+	}
+
+	values := make([]V, len(keys))
+	for i, res := range results {
+		<-res.done
+		if res.err != nil {
+			return nil, res.err
+		}
+		values[i] = res.value
+	}
+	return values, nil
+}
+
+// prime caches a value that was read by other means, so loading its key does not fetch it again
+func (ld *loader[K, V]) prime(key K, value V) {
+	ld.mu.Lock()
+	defer ld.mu.Unlock()
+	if _, ok := ld.results[key]; ok {
+		return
+	}
+	res := &result[V]{done: make(chan struct{}), value: value}
+	close(res.done)
+	ld.results[key] = res
+}
+
+// enqueue returns the result of a key, adding the key to the open batch if it was never loaded
+func (ld *loader[K, V]) enqueue(key K) *result[V] {
+	ld.mu.Lock()
+	defer ld.mu.Unlock()
+	if res, ok := ld.results[key]; ok {
+		return res
+	}
+
+	res := &result[V]{done: make(chan struct{})}
+	ld.results[key] = res
+	ld.pending = append(ld.pending, key)
+	switch len(ld.pending) {
+	case 1: // The first key opens the batch
+		time.AfterFunc(batchWait, ld.dispatch)
+	case maxBatch:
+		go ld.dispatch()
+	}
+	return res
+}
+
+// dispatch fetches the keys of the open batch, and closes it
+func (ld *loader[K, V]) dispatch() {
+	ld.mu.Lock()
+	keys := ld.pending
+	ld.pending = nil
+	results := make([]*result[V], len(keys))
+	for i, key := range keys {
+		results[i] = ld.results[key]
+	}
+	ld.mu.Unlock()
+	if len(keys) == 0 { // Already dispatched when it was full
+		return
+	}
+
+	values, err := ld.fetch(ld.ctx, keys)
+	for i, key := range keys {
+		results[i].value, results[i].err = values[key], err
+		close(results[i].done)
+	}
+}
+
+// LOADERS
+
+// nodeKey identifies a shared node as it was at a point in time, or now if asOf is zero
+type nodeKey struct {
+	id   string
+	asOf time.Time
+}
+
+// traversalKey identifies the connections going out of an entity at a point in time, or now if asOf is zero
+type traversalKey struct {
+	entityID         string
+	connectionTypeID string
+	asOf             time.Time
+}
+
+// loaders are the loaders of one request
+type loaders struct {
+	entities        *loader[nodeKey, *model.UsersEntity]
+	connectionTypes *loader[nodeKey, *model.UsersConnectionType]
+	connections     *loader[traversalKey, []model.Connection]
+	classes         *loader[string, []model.UsersEntityClass]
+	authors         *loader[string, *ap.Profile]
+}
+
+// newLoaders returns the loaders of a request, which look up authors with the token of the request
+func newLoaders(ctx context.Context, service *service.GraphService, authService *a.AuthService, token string) *loaders {
+	fetchAuthors := func(ctx context.Context, userIDs []string) (map[string]*ap.Profile, error) {
+		return authService.GetProfilesWithToken(ctx, token, userIDs)
+	}
+	return &loaders{
+		entities:        newLoader(ctx, fetchEntities(service)),
+		connectionTypes: newLoader(ctx, fetchConnectionTypes(service)),
+		connections:     newLoader(ctx, fetchConnections(service)),
+		classes:         newLoader(ctx, fetchClasses(service)),
+		authors:         newLoader(ctx, fetchAuthors),
+	}
+}
+
+type loadersKey struct{}
+
+func withLoaders(ctx context.Context, l *loaders) context.Context {
+	return context.WithValue(ctx, loadersKey{}, l)
+}
+
+func getLoaders(ctx context.Context) *loaders {
+	return ctx.Value(loadersKey{}).(*loaders)
+}
+
+// FETCH FUNCTIONS
+// Each makes one call per point in time in the batch, which is a single call for almost every query.
+
+func fetchEntities(s *service.GraphService) func(context.Context, []nodeKey) (map[nodeKey]*model.UsersEntity, error) {
+	return func(ctx context.Context, keys []nodeKey) (map[nodeKey]*model.UsersEntity, error) {
+		found := make(map[nodeKey]*model.UsersEntity, len(keys))
+		for asOf, ids := range groupByTime(keys) {
+			entities, err := s.GetEntities(ctx, &model.BatchRequest{IDs: ids, AsOf: optionalTime(asOf)})
+			if err != nil {
+				return nil, err
+			}
+			for i := range entities {
+				found[nodeKey{entities[i].EntityID, asOf}] = &entities[i]
+			}
+		}
+		return found, nil
+	}
+}
+
+func fetchConnectionTypes(s *service.GraphService) func(context.Context, []nodeKey) (map[nodeKey]*model.UsersConnectionType, error) {
+	return func(ctx context.Context, keys []nodeKey) (map[nodeKey]*model.UsersConnectionType, error) {
+		found := make(map[nodeKey]*model.UsersConnectionType, len(keys))
+		for asOf, ids := range groupByTime(keys) {
+			types, err := s.GetConnectionTypes(ctx, &model.BatchRequest{IDs: ids, AsOf: optionalTime(asOf)})
+			if err != nil {
+				return nil, err
+			}
+			for i := range types {
+				found[nodeKey{types[i].ConnectionTypeID, asOf}] = &types[i]
+			}
+		}
+		return found, nil
+	}
+}
+
+func fetchConnections(s *service.GraphService) func(context.Context, []traversalKey) (map[traversalKey][]model.Connection, error) {
+	return func(ctx context.Context, keys []traversalKey) (map[traversalKey][]model.Connection, error) {
+		// One traversal per connection type filter and point in time
+		type group struct {
+			connectionTypeID string
+			asOf             time.Time
+		}
+		groups := make(map[group][]string)
+		for _, key := range keys {
+			g := group{key.connectionTypeID, key.asOf}
+			groups[g] = append(groups[g], key.entityID)
+		}
+
+		found := make(map[traversalKey][]model.Connection, len(keys))
+		for g, entityIDs := range groups {
+			connections, err := s.GetConnectionsOfEntities(ctx, &model.BatchTraversalRequest{
+				EntityIDs:        entityIDs,
+				ConnectionTypeID: g.connectionTypeID,
+				AsOf:             optionalTime(g.asOf),
+			})
+			if err != nil {
+				return nil, err
+			}
+			for _, connection := range connections {
+				key := traversalKey{connection.FromEntityID, g.connectionTypeID, g.asOf}
+				found[key] = append(found[key], connection)
+			}
+		}
+		return found, nil
+	}
+}
+
+func fetchClasses(s *service.GraphService) func(context.Context, []string) (map[string][]model.UsersEntityClass, error) {
+	return func(ctx context.Context, entityIDs []string) (map[string][]model.UsersEntityClass, error) {
+		classes, err := s.GetClassesOfNodes(ctx, &model.BatchNodeRequest{Kind: model.KindEntity, IDs: entityIDs})
+		if err != nil {
+			return nil, err
+		}
+		found := make(map[string][]model.UsersEntityClass, len(entityIDs))
+		for _, class := range classes {
+			found[class.NodeID] = append(found[class.NodeID], class.UsersEntityClass)
+		}
+		return found, nil
+	}
+}
+
+// HELPER FUNCTIONS
+
+// groupByTime groups the IDs of node keys by their point in time
+func groupByTime(keys []nodeKey) map[time.Time][]string {
+	groups := make(map[time.Time][]string)
+	for _, key := range keys {
+		groups[key.asOf] = append(groups[key.asOf], key.id)
+	}
+	return groups
+}
+
+// optionalTime returns nil for the zero time, which stands for now
+func optionalTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
@@ -0,0 +1,441 @@
+package graphql
+
+import (
+	"context"
+	"time"
+
+	"github.com/BwezB/Wikno-backend/internal/graph/model"
+	"github.com/BwezB/Wikno-backend/internal/graph/service"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+
+	e "github.com/BwezB/Wikno-backend/pkg/errors"
+
+	ap "github.com/BwezB/Wikno-backend/api/proto/auth"
+	gql "github.com/graph-gophers/graphql-go"
+)
+
+// resolver resolves the root Query type. The nodes it returns load their neighbors through the request's loaders.
+type resolver struct {
+	service   *service.GraphService
+	validator *validator.Validate
+}
+
+// QUERY
+
+type nodeArgs struct {
+	ID   gql.ID
+	AsOf *gql.Time
+}
+
+type searchArgs struct {
+	Name string
+	AsOf *gql.Time
+}
+
+type entitySearchArgs struct {
+	Name    string
+	ClassID *gql.ID
+	AsOf    *gql.Time
+}
+
+func (r *resolver) Entity(ctx context.Context, args nodeArgs) (*entityResolver, error) {
+	key, err := r.nodeKey(args)
+	if err != nil {
+		return nil, err
+	}
+	entity, err := getLoaders(ctx).entities.load(key)
+	if err != nil {
+		return nil, translateToQueryError(err)
+	}
+	if entity == nil {
+		return nil, nil
+	}
+	return &entityResolver{entity, key.asOf}, nil
+}
+
+func (r *resolver) Entities(ctx context.Context, args entitySearchArgs) ([]*entityResolver, error) {
+	req := &model.SearchRequest{
+		Name: args.Name,
+		AsOf: timeArg(args.AsOf),
+	}
+	if args.ClassID != nil {
+		req.ClassID = string(*args.ClassID)
+	}
+	if err := r.validator.Struct(req); err != nil {
+		return nil, translateToQueryError(e.New("Request validation failed", ErrInvalidRequest, err))
+	}
+
+	entities, err := r.service.FindEntities(ctx, req)
+	if err != nil {
+		return nil, translateToQueryError(err)
+	}
+
+	asOf := keyTime(req.AsOf)
+	resolvers := make([]*entityResolver, len(entities))
+	for i := range entities {
+		getLoaders(ctx).entities.prime(nodeKey{entities[i].EntityID, asOf}, &entities[i])
+		resolvers[i] = &entityResolver{&entities[i], asOf}
+	}
+	return resolvers, nil
+}
+
+func (r *resolver) ConnectionType(ctx context.Context, args nodeArgs) (*connectionTypeResolver, error) {
+	key, err := r.nodeKey(args)
+	if err != nil {
+		return nil, err
+	}
+	connectionType, err := getLoaders(ctx).connectionTypes.load(key)
+	if err != nil {
+		return nil, translateToQueryError(err)
+	}
+	if connectionType == nil {
+		return nil, nil
+	}
+	return &connectionTypeResolver{connectionType, key.asOf}, nil
+}
+
+func (r *resolver) ConnectionTypes(ctx context.Context, args searchArgs) ([]*connectionTypeResolver, error) {
+	req := &model.SearchRequest{
+		Name: args.Name,
+		AsOf: timeArg(args.AsOf),
+	}
+	if err := r.validator.Struct(req); err != nil {
+		return nil, translateToQueryError(e.New("Request validation failed", ErrInvalidRequest, err))
+	}
+
+	types, err := r.service.FindConnectionTypes(ctx, req)
+	if err != nil {
+		return nil, translateToQueryError(err)
+	}
+
+	asOf := keyTime(req.AsOf)
+	resolvers := make([]*connectionTypeResolver, len(types))
+	for i := range types {
+		getLoaders(ctx).connectionTypes.prime(nodeKey{types[i].ConnectionTypeID, asOf}, &types[i])
+		resolvers[i] = &connectionTypeResolver{&types[i], asOf}
+	}
+	return resolvers, nil
+}
+
+func (r *resolver) PropertyTypes(ctx context.Context, args searchArgs) ([]*propertyTypeResolver, error) {
+	req := &model.SearchRequest{
+		Name: args.Name,
+		AsOf: timeArg(args.AsOf),
+	}
+	if err := r.validator.Struct(req); err != nil {
+		return nil, translateToQueryError(e.New("Request validation failed", ErrInvalidRequest, err))
+	}
+
+	types, err := r.service.FindPropertyTypes(ctx, req)
+	if err != nil {
+		return nil, translateToQueryError(err)
+	}
+
+	resolvers := make([]*propertyTypeResolver, len(types))
+	for i := range types {
+		resolvers[i] = &propertyTypeResolver{&types[i]}
+	}
+	return resolvers, nil
+}
+
+func (r *resolver) EntityClasses(ctx context.Context, args struct{ Name string }) ([]*classResolver, error) {
+	req := &model.SearchRequest{
+		Name: args.Name,
+	}
+	if err := r.validator.Struct(req); err != nil {
+		return nil, translateToQueryError(e.New("Request validation failed", ErrInvalidRequest, err))
+	}
+
+	classes, err := r.service.FindEntityClasses(ctx, req)
+	if err != nil {
+		return nil, translateToQueryError(err)
+	}
+
+	resolvers := make([]*classResolver, len(classes))
+	for i := range classes {
+		resolvers[i] = &classResolver{&classes[i]}
+	}
+	return resolvers, nil
+}
+
+// nodeKey validates the arguments of a node query, and returns the key to load the node with
+func (r *resolver) nodeKey(args nodeArgs) (nodeKey, error) {
+	if err := r.validator.Var(string(args.ID), "uuid"); err != nil {
+		return nodeKey{}, translateToQueryError(e.New("Request validation failed", ErrInvalidRequest, err))
+	}
+	return nodeKey{string(args.ID), keyTime(timeArg(args.AsOf))}, nil
+}
+
+// ENTITY
+
+type entityResolver struct {
+	entity *model.UsersEntity
+	asOf   time.Time
+}
+
+type traversalArgs struct {
+	ConnectionTypeID *gql.ID
+}
+
+func (r *entityResolver) ID() gql.ID         { return gql.ID(r.entity.EntityID) }
+func (r *entityResolver) UserID() gql.ID     { return gql.ID(r.entity.UserID) }
+func (r *entityResolver) Name() string       { return r.entity.Name }
+func (r *entityResolver) Definition() string { return r.entity.Definition }
+func (r *entityResolver) Score() int32       { return int32(r.entity.Score) }
+func (r *entityResolver) UserCount() int32   { return int32(r.entity.UserCount) }
+
+func (r *entityResolver) Author(ctx context.Context) (*authorResolver, error) {
+	return loadAuthor(ctx, r.entity.UserID)
+}
+
+// Classes are always the current ones, like in searches
+func (r *entityResolver) Classes(ctx context.Context) ([]*classResolver, error) {
+	classes, err := getLoaders(ctx).classes.load(r.entity.EntityID)
+	if err != nil {
+		return nil, translateToQueryError(err)
+	}
+	resolvers := make([]*classResolver, len(classes))
+	for i := range classes {
+		resolvers[i] = &classResolver{&classes[i]}
+	}
+	return resolvers, nil
+}
+
+func (r *entityResolver) Connections(ctx context.Context, args traversalArgs) ([]*connectionResolver, error) {
+	connections, err := r.connections(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+	resolvers := make([]*connectionResolver, len(connections))
+	for i := range connections {
+		resolvers[i] = &connectionResolver{&connections[i], r.asOf}
+	}
+	return resolvers, nil
+}
+
+func (r *entityResolver) Neighbors(ctx context.Context, args traversalArgs) ([]*entityResolver, error) {
+	connections, err := r.connections(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+
+	// An entity can be reached by connections of several types, but is a neighbor once
+	seen := make(map[string]bool, len(connections))
+	var keys []nodeKey
+	for _, connection := range connections {
+		if !seen[connection.ToEntityID] {
+			seen[connection.ToEntityID] = true
+			keys = append(keys, nodeKey{connection.ToEntityID, r.asOf})
+		}
+	}
+	entities, err := getLoaders(ctx).entities.loadAll(keys)
+	if err != nil {
+		return nil, translateToQueryError(err)
+	}
+
+	resolvers := make([]*entityResolver, 0, len(entities))
+	for _, entity := range entities {
+		if entity != nil {
+			resolvers = append(resolvers, &entityResolver{entity, r.asOf})
+		}
+	}
+	return resolvers, nil
+}
+
+func (r *entityResolver) connections(ctx context.Context, args traversalArgs) ([]model.Connection, error) {
+	key := traversalKey{entityID: r.entity.EntityID, asOf: r.asOf}
+	if args.ConnectionTypeID != nil {
+		key.connectionTypeID = string(*args.ConnectionTypeID)
+		if err := uuid.Validate(key.connectionTypeID); err != nil {
+			return nil, translateToQueryError(e.New("Request validation failed", ErrInvalidRequest, err))
+		}
+	}
+	connections, err := getLoaders(ctx).connections.load(key)
+	if err != nil {
+		return nil, translateToQueryError(err)
+	}
+	return connections, nil
+}
+
+// CONNECTION
+
+type connectionResolver struct {
+	connection *model.Connection
+	asOf       time.Time
+}
+
+func (r *connectionResolver) ID() *gql.ID {
+	if r.connection.ID == "" {
+		return nil
+	}
+	id := gql.ID(r.connection.ID)
+	return &id
+}
+func (r *connectionResolver) UserID() gql.ID { return gql.ID(r.connection.UserID) }
+func (r *connectionResolver) Inferred() bool { return r.connection.Inferred }
+
+func (r *connectionResolver) ConnectionType(ctx context.Context) (*connectionTypeResolver, error) {
+	return loadConnectionType(ctx, r.connection.ConnectionTypeID, r.asOf)
+}
+
+func (r *connectionResolver) From(ctx context.Context) (*entityResolver, error) {
+	return loadEntity(ctx, r.connection.FromEntityID, r.asOf)
+}
+
+func (r *connectionResolver) To(ctx context.Context) (*entityResolver, error) {
+	return loadEntity(ctx, r.connection.ToEntityID, r.asOf)
+}
+
+// CONNECTION TYPE
+
+type connectionTypeResolver struct {
+	connectionType *model.UsersConnectionType
+	asOf           time.Time
+}
+
+func (r *connectionTypeResolver) ID() gql.ID         { return gql.ID(r.connectionType.ConnectionTypeID) }
+func (r *connectionTypeResolver) UserID() gql.ID     { return gql.ID(r.connectionType.UserID) }
+func (r *connectionTypeResolver) Name() string       { return r.connectionType.Name }
+func (r *connectionTypeResolver) Definition() string { return r.connectionType.Definition }
+func (r *connectionTypeResolver) Score() int32       { return int32(r.connectionType.Score) }
+func (r *connectionTypeResolver) UserCount() int32   { return int32(r.connectionType.UserCount) }
+
+func (r *connectionTypeResolver) Author(ctx context.Context) (*authorResolver, error) {
+	return loadAuthor(ctx, r.connectionType.UserID)
+}
+
+func (r *connectionTypeResolver) Symmetric() bool {
+	return r.semantics().Symmetric
+}
+
+func (r *connectionTypeResolver) Transitive() bool {
+	return r.semantics().Transitive
+}
+
+func (r *connectionTypeResolver) Inverse(ctx context.Context) (*connectionTypeResolver, error) {
+	if r.semantics().InverseID == nil {
+		return nil, nil
+	}
+	return loadConnectionType(ctx, *r.semantics().InverseID, r.asOf)
+}
+
+func (r *connectionTypeResolver) Domain(ctx context.Context) (*entityResolver, error) {
+	if r.semantics().DomainID == nil {
+		return nil, nil
+	}
+	return loadEntity(ctx, *r.semantics().DomainID, r.asOf)
+}
+
+func (r *connectionTypeResolver) Range(ctx context.Context) (*entityResolver, error) {
+	if r.semantics().RangeID == nil {
+		return nil, nil
+	}
+	return loadEntity(ctx, *r.semantics().RangeID, r.asOf)
+}
+
+// semantics returns the semantics of the shared connection type, which the db loads with every version
+func (r *connectionTypeResolver) semantics() model.ConnectionSemantics {
+	if r.connectionType.ConnectionType == nil {
+		return model.ConnectionSemantics{}
+	}
+	return r.connectionType.ConnectionType.ConnectionSemantics
+}
+
+// PROPERTY TYPE
+
+type propertyTypeResolver struct {
+	propertyType *model.PropertyTypeResponse
+}
+
+func (r *propertyTypeResolver) ID() gql.ID         { return gql.ID(r.propertyType.PropertyTypeID) }
+func (r *propertyTypeResolver) UserID() gql.ID     { return gql.ID(r.propertyType.UserID) }
+func (r *propertyTypeResolver) Name() string       { return r.propertyType.Name }
+func (r *propertyTypeResolver) Definition() string { return r.propertyType.Definition }
+func (r *propertyTypeResolver) ValueType() string  { return r.propertyType.ValueType }
+func (r *propertyTypeResolver) Score() int32       { return int32(r.propertyType.Score) }
+func (r *propertyTypeResolver) UserCount() int32   { return int32(r.propertyType.UserCount) }
+
+func (r *propertyTypeResolver) Author(ctx context.Context) (*authorResolver, error) {
+	return loadAuthor(ctx, r.propertyType.UserID)
+}
+
+// ENTITY CLASS
+
+type classResolver struct {
+	class *model.UsersEntityClass
+}
+
+func (r *classResolver) ID() gql.ID         { return gql.ID(r.class.EntityClassID) }
+func (r *classResolver) UserID() gql.ID     { return gql.ID(r.class.UserID) }
+func (r *classResolver) Name() string       { return r.class.Name }
+func (r *classResolver) Definition() string { return r.class.Definition }
+func (r *classResolver) Score() int32       { return int32(r.class.Score) }
+func (r *classResolver) UserCount() int32   { return int32(r.class.UserCount) }
+
+func (r *classResolver) Author(ctx context.Context) (*authorResolver, error) {
+	return loadAuthor(ctx, r.class.UserID)
+}
+
+// AUTHOR
+
+type authorResolver struct {
+	profile *ap.Profile
+}
+
+func (r *authorResolver) UserID() gql.ID      { return gql.ID(r.profile.GetUserId()) }
+func (r *authorResolver) DisplayName() string { return r.profile.GetDisplayName() }
+func (r *authorResolver) AvatarURL() string   { return r.profile.GetAvatarUrl() }
+
+// HELPER FUNCTIONS
+
+// loadEntity loads an entity through the request's loader, nil if it does not exist
+func loadEntity(ctx context.Context, id string, asOf time.Time) (*entityResolver, error) {
+	entity, err := getLoaders(ctx).entities.load(nodeKey{id, asOf})
+	if err != nil {
+		return nil, translateToQueryError(err)
+	}
+	if entity == nil {
+		return nil, nil
+	}
+	return &entityResolver{entity, asOf}, nil
+}
+
+// loadConnectionType loads a connection type through the request's loader, nil if it does not exist
+func loadConnectionType(ctx context.Context, id string, asOf time.Time) (*connectionTypeResolver, error) {
+	connectionType, err := getLoaders(ctx).connectionTypes.load(nodeKey{id, asOf})
+	if err != nil {
+		return nil, translateToQueryError(err)
+	}
+	if connectionType == nil {
+		return nil, nil
+	}
+	return &connectionTypeResolver{connectionType, asOf}, nil
+}
+
+// loadAuthor loads the profile of a user through the request's loader, nil if the user has none, like workspaces
+func loadAuthor(ctx context.Context, userID string) (*authorResolver, error) {
+	profile, err := getLoaders(ctx).authors.load(userID)
+	if err != nil {
+		return nil, translateToQueryError(err)
+	}
+	if profile == nil {
+		return nil, nil
+	}
+	return &authorResolver{profile}, nil
+}
+
+func timeArg(t *gql.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	return &t.Time
+}
+
+// keyTime returns the point in time of loader keys, which is zero for now. Times are kept in UTC so equal times are equal keys.
+func keyTime(t *time.Time) time.Time {
+	if t == nil {
+		return time.Time{}
+	}
+	return t.UTC()
+}
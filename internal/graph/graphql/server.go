@@ -0,0 +1,170 @@
+// Package graphql serves a read-only GraphQL API over the graph, so clients can fetch an entity, its neighbors
+// and their types in one round trip. Nodes are loaded through per-request loaders that batch the reads of
+// each level of a query into one database query.
+package graphql
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/BwezB/Wikno-backend/internal/graph/model"
+	"github.com/BwezB/Wikno-backend/internal/graph/service"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+
+	a "github.com/BwezB/Wikno-backend/pkg/auth"
+	e "github.com/BwezB/Wikno-backend/pkg/errors"
+	l "github.com/BwezB/Wikno-backend/pkg/log"
+	r "github.com/BwezB/Wikno-backend/pkg/requestid"
+
+	gql "github.com/graph-gophers/graphql-go"
+)
+
+//go:embed schema.graphql
+var schema string
+
+// maxBodyBytes is the largest accepted request body
+const maxBodyBytes = 1 << 20
+
+type Server struct {
+	server      *http.Server
+	schema      *gql.Schema
+	service     *service.GraphService
+	authService *a.AuthService
+	validator   *validator.Validate
+}
+
+// request is the body of a GraphQL request
+type request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+func NewServer(service *service.GraphService,
+	authService *a.AuthService,
+	validator *validator.Validate,
+	config ServerConfig) (*Server, error) {
+
+	l.Debug("Creating GraphQL server")
+	parsed, err := gql.ParseSchema(schema,
+		&resolver{service: service, validator: validator},
+		gql.UseStringDescriptions(),
+		gql.MaxDepth(config.MaxDepth),
+		gql.MaxParallelism(maxBatch))
+	if err != nil {
+		return nil, e.Wrap("failed to parse GraphQL schema", err)
+	}
+
+	server := &Server{
+		schema:      parsed,
+		service:     service,
+		authService: authService,
+		validator:   validator,
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST "+config.Path, server.handle)
+	server.server = &http.Server{
+		Addr:              config.GetAddress(),
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	return server, nil
+}
+
+func (s *Server) Serve() {
+	l.Info("Starting GraphQL server", l.String("address", s.server.Addr))
+	go func() {
+		if err := s.server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			l.Error("GraphQL server error", l.ErrField(err))
+		}
+	}()
+}
+
+// Handler returns the handler of GraphQL requests, to serve them on a listener of the caller's
+func (s *Server) Handler() http.Handler {
+	return s.server.Handler
+}
+
+func (s *Server) Shutdown(ctx context.Context) error {
+	l.Info("Shutting down GraphQL server")
+	return s.server.Shutdown(ctx)
+}
+
+// handle executes a GraphQL request as the user of the token in the Authorization header,
+// scoped to the workspace in the Workspace-Id header if there is one
+func (s *Server) handle(w http.ResponseWriter, req *http.Request) {
+	ctx := r.WithRequestID(req.Context(), uuid.New().String())
+
+	var body request
+	if err := json.NewDecoder(http.MaxBytesReader(w, req.Body, maxBodyBytes)).Decode(&body); err != nil {
+		writeErrors(w, http.StatusBadRequest, &queryError{"INVALID_ARGUMENT", "invalid request body"})
+		return
+	}
+	l.Debug("GraphQL request",
+		l.String("operation", body.OperationName),
+		l.String("request_id", r.GetRequestID(ctx)))
+
+	token := req.Header.Get("Authorization")
+	if token == "" {
+		writeErrors(w, http.StatusUnauthorized, &queryError{"UNAUTHENTICATED", "missing authorization token"})
+		return
+	}
+	authCtx, err := s.authService.Authenticate(ctx, token)
+	if err != nil {
+		l.Warn("Token verification failed", l.String("request_id", r.GetRequestID(ctx)), l.ErrField(err))
+		writeErrors(w, http.StatusUnauthorized, &queryError{"UNAUTHENTICATED", "invalid token"})
+		return
+	}
+	ctx = authCtx
+
+	scopedCtx, status, qErr := s.scopeToWorkspace(ctx, req.Header.Get("Workspace-Id"))
+	if qErr != nil {
+		writeErrors(w, status, qErr)
+		return
+	}
+	ctx = scopedCtx
+
+	ctx = withLoaders(ctx, newLoaders(ctx, s.service, s.authService, token))
+	response := s.schema.Exec(ctx, body.Query, body.OperationName, body.Variables)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// scopeToWorkspace returns the context scoped to the workspace, if the user can view it.
+// Otherwise it returns the HTTP status and error to respond with.
+func (s *Server) scopeToWorkspace(ctx context.Context, workspaceID string) (context.Context, int, *queryError) {
+	if workspaceID == "" {
+		return ctx, http.StatusOK, nil
+	}
+	if err := s.validator.Var(workspaceID, "uuid"); err != nil {
+		return nil, http.StatusBadRequest, &queryError{"INVALID_ARGUMENT", "invalid workspace-id"}
+	}
+
+	role, err := s.service.GetMemberRole(ctx, workspaceID)
+	if err != nil {
+		l.Warn("Workspace membership check failed",
+			l.String("workspace_id", workspaceID),
+			l.String("request_id", r.GetRequestID(ctx)),
+			l.ErrField(err))
+		return nil, http.StatusForbidden, translateToQueryError(err)
+	}
+	if !model.HasRole(role, model.RoleViewer) {
+		return nil, http.StatusForbidden, &queryError{"PERMISSION_DENIED", "workspace role " + role + " cannot query the graph"}
+	}
+	return a.WithWorkspaceID(ctx, workspaceID), http.StatusOK, nil
+}
+
+// writeErrors writes a GraphQL response without data
+func writeErrors(w http.ResponseWriter, status int, err *queryError) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"errors": []map[string]interface{}{{"message": err.message, "extensions": err.Extensions()}},
+	})
+}
@@ -1,5 +1,10 @@
 package model
 
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
 
 // DB Models
 
@@ -12,6 +17,8 @@ type GraphUser struct { // To track what user has what entities in his personal
 	UsersEntities		[]UsersEntity `gorm:"foreignKey:UserID;references:ID"`
 	UsersConnectionTypes []UsersConnectionType `gorm:"foreignKey:UserID;references:ID"`
 	UsersPropertyTypes	[]UsersPropertyType `gorm:"foreignKey:UserID;references:ID"`
+	Connections			[]Connection `gorm:"foreignKey:UserID;references:ID"`
+	UsersEntityClasses	[]UsersEntityClass `gorm:"foreignKey:UserID;references:ID"`
 }
 
 func (gu *GraphUser) TableName() string {
@@ -24,6 +31,10 @@ type Entity struct {
 	ID					string `gorm:"type:uuid;primary_key;default:gen_random_uuid()" validate:"required,uuid"`
 
 	UsersEntities		[]UsersEntity `gorm:"foreignKey:EntityID;references:ID"`
+	OutgoingConnections	[]Connection `gorm:"foreignKey:FromEntityID;references:ID"`
+	IncomingConnections	[]Connection `gorm:"foreignKey:ToEntityID;references:ID"`
+	// Classes are the classes the entity is an instance of
+	Classes				[]EntityClass `gorm:"many2many:entity_instances"`
 }
 
 func (e *Entity) TableName() string {
@@ -36,6 +47,8 @@ type UsersEntity struct { // junction table for many-to-many relationship betwee
 	Definition			string `gorm:"type:varchar(4096);not null" validate:"required,max=4096"`
 	UserID				string `gorm:"type:uuid;primaryKey" validate:"required,uuid"`
 	EntityID			string `gorm:"type:uuid;primaryKey" validate:"required,uuid"`
+
+	Consensus
 }
 
 func (ue *UsersEntity) TableName() string {
@@ -48,7 +61,12 @@ type ConnectionType struct {
 	ID					string `gorm:"type:uuid;primary_key;default:gen_random_uuid()" validate:"required,uuid"`
 
 	UsersConnectionTypes []UsersConnectionType `gorm:"foreignKey:ConnectionTypeID;references:ID"`
-	// TODO Add InverseConnectionType
+	Connections          []Connection `gorm:"foreignKey:ConnectionTypeID;references:ID"`
+	// Classes are the classes whose instances the connection type applies to.
+	// Connections of a type with classes must go out of an instance of one of them or of their subclasses.
+	Classes              []EntityClass `gorm:"many2many:connection_type_classes"`
+
+	ConnectionSemantics
 }
 
 func (ct *ConnectionType) TableName() string {
@@ -62,12 +80,55 @@ type UsersConnectionType struct { // junction table for many-to-many relationshi
 
 	UserID           string `gorm:"type:uuid;primaryKey"`
 	ConnectionTypeID string `gorm:"type:uuid;primaryKey"`
+
+	Consensus
+	// ConnectionType is the shared connection type, loaded for its semantics
+	ConnectionType *ConnectionType `gorm:"foreignKey:ConnectionTypeID;-:migration" json:"connection_type,omitempty" validate:"-"`
 }
 
 func (uct *UsersConnectionType) TableName() string {
 	return "users_connection_types"
 }
 
+// ConnectionSemantics is how connections of a connection type are interpreted (same for all users).
+// They are set when the shared connection type is created.
+type ConnectionSemantics struct {
+	// InverseID is the connection type that reads connections of this type backwards, e.g. "works at" for "employs".
+	// Inverses always point at each other.
+	InverseID *string `gorm:"type:uuid;uniqueIndex" json:"inverse_id" validate:"omitempty,uuid"`
+	// Symmetric connections hold in both directions, e.g. "sibling of". Symmetric types have no inverse.
+	Symmetric bool `gorm:"not null;default:false" json:"symmetric"`
+	// Transitive connections chain, e.g. "part of": a part of b and b part of c means a part of c
+	Transitive bool `gorm:"not null;default:false" json:"transitive"`
+	// DomainID is the entity every from entity must be, or reach through transitive connections.
+	// It constrains the graph of each owner, while the classes of the connection type constrain the ontology.
+	DomainID *string `gorm:"type:uuid" json:"domain_id" validate:"omitempty,uuid"`
+	// RangeID is the entity every to entity must be, or reach through transitive connections
+	RangeID *string `gorm:"type:uuid" json:"range_id" validate:"omitempty,uuid"`
+}
+
+// Connection
+
+// Connection is a users directed edge between two entities.
+// Connections implied by inverse, symmetric and transitive connection types are inferred by queries and never stored.
+// Deleted connections are kept, so graphs can be read as they were at a point in time.
+type Connection struct {
+	ID               string         `gorm:"type:uuid;primary_key;default:gen_random_uuid()" validate:"required,uuid"`
+	UserID           string         `gorm:"type:uuid;not null;uniqueIndex:idx_connection,where:deleted_at IS NULL" validate:"required,uuid"`
+	ConnectionTypeID string         `gorm:"type:uuid;not null;uniqueIndex:idx_connection" validate:"required,uuid"`
+	FromEntityID     string         `gorm:"type:uuid;not null;uniqueIndex:idx_connection;index" validate:"required,uuid"`
+	ToEntityID       string         `gorm:"type:uuid;not null;uniqueIndex:idx_connection;index" validate:"required,uuid"`
+	CreatedAt        time.Time      `gorm:"autoCreateTime"`
+	DeletedAt        gorm.DeletedAt `gorm:"index" json:"-" validate:"-"`
+
+	// Inferred is set by traversal queries for connections that are implied, not stored
+	Inferred bool `gorm:"->;-:migration" json:"inferred"`
+}
+
+func (c *Connection) TableName() string {
+	return "connections"
+}
+
 // PropertyType
 // PropertyType is a type of a property in the graph (same for all users)
 type PropertyType struct { 
@@ -76,6 +137,8 @@ type PropertyType struct {
 	ValueType string `gorm:"type:varchar(10);check:value_type in ('string','int','float','boolean')" validate:"required,oneof=string int float boolean"`
 
 	UsersPropertyTypes []UsersPropertyType `gorm:"foreignKey:PropertyTypeID;references:ID"`
+	// Classes are the classes whose instances the property type applies to
+	Classes            []EntityClass `gorm:"many2many:property_type_classes"`
 }
 
 func (pt *PropertyType) TableName() string {
@@ -95,6 +158,340 @@ func (upt *UsersPropertyType) TableName() string {
 	return "users_property_types"
 }
 
+// EntityClass
+// EntityClass is a class of entities, like "Person" or "Vehicle" (same for all users).
+// Classes form a hierarchy: instances of a class are instances of all its parent classes.
+type EntityClass struct {
+	ID string `gorm:"type:uuid;primary_key;default:gen_random_uuid()" validate:"required,uuid"`
+
+	UsersEntityClasses []UsersEntityClass `gorm:"foreignKey:EntityClassID;references:ID"`
+	Parents            []EntityClass      `gorm:"many2many:entity_class_parents;joinForeignKey:EntityClassID;joinReferences:ParentID"`
+}
+
+func (ec *EntityClass) TableName() string {
+	return "entity_classes"
+}
+
+// UsersEntityClass is a specific users version of an entity class
+type UsersEntityClass struct { // junction table for many-to-many relationship between User and EntityClass
+	Name       string `gorm:"type:varchar(255);not null;index:idx_users_entity_class_name" validate:"required,max=255"`
+	Definition string `gorm:"type:varchar(4096);not null" validate:"required,max=4096"`
+
+	UserID        string `gorm:"type:uuid;primaryKey" validate:"required,uuid"`
+	EntityClassID string `gorm:"type:uuid;primaryKey" validate:"required,uuid"`
+
+	Consensus
+}
+
+func (uec *UsersEntityClass) TableName() string {
+	return "users_entity_classes"
+}
+
+// Consensus
+
+// Kinds of shared nodes that users write their own versions of
+const (
+	KindEntity         = "entity"
+	KindConnectionType = "connection_type"
+	KindPropertyType   = "property_type"
+	KindEntityClass    = "entity_class"
+)
+
+// DefinitionVote is a user's up- or downvote on another user's version of a shared node.
+// The highest scoring version of a shared node is its canonical version.
+type DefinitionVote struct {
+	VoterID   string    `gorm:"type:uuid;primaryKey" validate:"required,uuid"`
+	Kind      string    `gorm:"type:varchar(20);primaryKey;check:kind in ('entity','connection_type','property_type','entity_class')" validate:"required,oneof=entity connection_type property_type entity_class"`
+	TargetID  string    `gorm:"type:uuid;primaryKey;index:idx_definition_votes_target" validate:"required,uuid"` // ID of the shared node
+	AuthorID  string    `gorm:"type:uuid;primaryKey;index:idx_definition_votes_target" validate:"required,uuid"` // Owner of the version voted on
+	Value     int       `gorm:"type:smallint;not null;check:value in (-1,1)" validate:"required,oneof=-1 1"`
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime"`
+}
+
+func (dv *DefinitionVote) TableName() string {
+	return "definition_votes"
+}
+
+// Consensus is how a version ranks among all versions of its shared node.
+// It is computed by queries and never stored.
+type Consensus struct {
+	// Score is the sum of the votes the version received
+	Score int `gorm:"->;-:migration" json:"score"`
+	// UserCount is the number of users with a version of the shared node
+	UserCount int `gorm:"->;-:migration" json:"user_count"`
+}
+
+// Revision
+
+// Revision actions
+const (
+	RevisionCreate = "create" // The version was created
+	RevisionUpdate = "update" // The name or definition was edited
+	RevisionRevert = "revert" // The version was reverted to an earlier revision
+	RevisionMove   = "move"   // The version was moved onto the node by a merge or split
+	RevisionDelete = "delete" // The version was moved off or dropped from the node by a merge or split
+)
+
+// Revision is an append-only record of a users version of a shared node, written every time the version changes.
+// The latest revision of a version at a point in time is how the version looked then, unless it is a delete.
+type Revision struct {
+	ID         string    `gorm:"type:uuid;primary_key;default:gen_random_uuid()" validate:"required,uuid"`
+	Kind       string    `gorm:"type:varchar(20);not null;index:idx_revisions_version,priority:2;index:idx_revisions_time,priority:1;check:kind in ('entity','connection_type','property_type','entity_class')" validate:"required,oneof=entity connection_type property_type entity_class"`
+	NodeID     string    `gorm:"type:uuid;not null;index:idx_revisions_version,priority:3" validate:"required,uuid"` // ID of the shared node
+	UserID     string    `gorm:"type:uuid;not null;index:idx_revisions_version,priority:1" validate:"required,uuid"` // Owner of the version
+	AuthorID   string    `gorm:"type:uuid;not null" validate:"required,uuid"`                                        // User that made the change
+	Action     string    `gorm:"type:varchar(10);not null;check:action in ('create','update','revert','move','delete')" validate:"required,oneof=create update revert move delete"`
+	Name       string    `gorm:"type:varchar(255);not null" validate:"required,max=255"`
+	Definition string    `gorm:"type:varchar(4096);not null" validate:"required,max=4096"`
+	CreatedAt  time.Time `gorm:"autoCreateTime;index:idx_revisions_version,priority:4;index:idx_revisions_time,priority:2"`
+}
+
+func (rv *Revision) TableName() string {
+	return "revisions"
+}
+
+// Graph events
+
+// KindConnection is the kind of graph events about connections. The other kinds are the kinds of shared nodes.
+const KindConnection = "connection"
+
+// Graph event actions
+const (
+	EventCreate    = "create"
+	EventUpdate    = "update"
+	EventDelete    = "delete"
+	EventHeartbeat = "heartbeat" // Never stored, only sent to watchers to report their position
+)
+
+// GraphEvent is an outbox record of a change of a users version or connection. Events are written by database triggers
+// in the transaction of the change, and read in (TxID, Seq) order once no transaction before them can still commit.
+type GraphEvent struct {
+	Seq       int64                  `gorm:"primaryKey;autoIncrement;index:idx_graph_events_position,priority:2;index:idx_graph_events_owner,priority:3"`
+	TxID      int64                  `gorm:"not null;index:idx_graph_events_position,priority:1;index:idx_graph_events_owner,priority:2"` // Transaction that made the change
+	UserID    string                 `gorm:"type:uuid;not null;index:idx_graph_events_owner,priority:1"`                                   // Owner of the version or connection
+	Kind      string                 `gorm:"type:varchar(20);not null;check:kind in ('entity','connection_type','property_type','entity_class','connection')"`
+	NodeID    string                 `gorm:"type:uuid;not null"` // ID of the shared node, or of the connection
+	Action    string                 `gorm:"type:varchar(10);not null;check:action in ('create','update','delete')"`
+	Data      map[string]interface{} `gorm:"type:jsonb;serializer:json"` // The row after the change, or before it for deletes
+	CreatedAt time.Time              `gorm:"not null;index"`
+}
+
+func (ge *GraphEvent) TableName() string {
+	return "graph_events"
+}
+
+// EventPosition is a position in the stream of graph events
+type EventPosition struct {
+	TxID int64
+	Seq  int64
+}
+
+// Webhooks
+
+// Webhook delivery outcomes
+const (
+	DeliverySuccess    = "success"
+	DeliveryRetry      = "retry"       // Failed, the event will be delivered again
+	DeliveryDeadLetter = "dead_letter" // The last attempt failed, the event was moved to the dead letters
+)
+
+// Webhook subscribes a URL to the graph events of an owner. Its cursor is the position of the last event
+// that was delivered or dead-lettered, and its lease stops several service instances from delivering to it.
+type Webhook struct {
+	ID          string   `gorm:"type:uuid;primary_key;default:gen_random_uuid()" validate:"required,uuid"`
+	UserID      string   `gorm:"type:uuid;not null;index" validate:"required,uuid"` // Owner of the watched graph
+	URL         string   `gorm:"type:varchar(2048);not null" validate:"required,http_url,max=2048"`
+	Kinds       []string `gorm:"type:jsonb;serializer:json"` // Delivered kinds, all if empty
+	Secret      string   `gorm:"type:varchar(64);not null" json:"-"`
+	CursorTxID  int64    `gorm:"not null;default:0"`
+	CursorSeq   int64    `gorm:"not null;default:0"`
+	LeaseHolder string   `gorm:"type:varchar(36)"`
+	LeasedUntil *time.Time
+	CreatedBy   string    `gorm:"type:uuid;not null" validate:"required,uuid"`
+	CreatedAt   time.Time `gorm:"autoCreateTime"`
+}
+
+func (w *Webhook) TableName() string {
+	return "webhooks"
+}
+
+// WebhookDelivery is the log entry of an attempt to deliver a graph event to a webhook
+type WebhookDelivery struct {
+	ID         string    `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	WebhookID  string    `gorm:"type:uuid;not null;index:idx_webhook_deliveries,priority:1"`
+	EventID    string    `gorm:"type:varchar(41);not null"`
+	Attempt    int       `gorm:"not null"`
+	Outcome    string    `gorm:"type:varchar(12);not null;check:outcome in ('success','retry','dead_letter')"`
+	StatusCode int       `gorm:"not null;default:0"`
+	Error      string    `gorm:"type:varchar(1024)"`
+	DurationMs int64     `gorm:"not null;default:0"`
+	CreatedAt  time.Time `gorm:"not null;index:idx_webhook_deliveries,priority:2;index"`
+
+	Webhook Webhook `gorm:"foreignKey:WebhookID;references:ID;constraint:OnDelete:CASCADE" validate:"-"`
+}
+
+func (wd *WebhookDelivery) TableName() string {
+	return "webhook_deliveries"
+}
+
+// WebhookDeadLetter is a graph event that could not be delivered to a webhook, kept with its payload
+// so it can be inspected and replayed
+type WebhookDeadLetter struct {
+	ID        string    `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	WebhookID string    `gorm:"type:uuid;not null;index"`
+	EventID   string    `gorm:"type:varchar(41);not null"`
+	Payload   string    `gorm:"type:jsonb;not null"`
+	Attempts  int       `gorm:"not null"`
+	LastError string    `gorm:"type:varchar(1024)"`
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+
+	Webhook Webhook `gorm:"foreignKey:WebhookID;references:ID;constraint:OnDelete:CASCADE" validate:"-"`
+}
+
+func (wdl *WebhookDeadLetter) TableName() string {
+	return "webhook_dead_letters"
+}
+
+// Merge
+
+// Entity merge statuses
+const (
+	MergeProposed = "proposed" // Waiting for another user to accept it
+	MergeMerged   = "merged"   // Accepted, the source entity was merged into the target entity
+	MergeReverted = "reverted" // Merged, then split back into the original entities
+	MergeSplit    = "split"    // A user's version was split off the target entity into the new source entity
+)
+
+// EntityMerge is the audit record of a merge of two shared entities, or of a split of one.
+// Its snapshot holds everything that was moved, so merges can be reversed.
+type EntityMerge struct {
+	ID             string        `gorm:"type:uuid;primary_key;default:gen_random_uuid()" validate:"required,uuid"`
+	SourceID       string        `gorm:"type:uuid;not null;index" validate:"required,uuid"` // Entity that is merged away (or split off)
+	TargetID       string        `gorm:"type:uuid;not null;index" validate:"required,uuid"` // Entity that remains
+	ProposedBy     string        `gorm:"type:uuid;not null" validate:"required,uuid"`
+	ProposerUserID *string       `gorm:"type:uuid" validate:"omitempty,uuid"` // User that proposed, for a workspace the member that did
+	DecidedBy      *string       `gorm:"type:uuid" validate:"omitempty,uuid"` // User that accepted, reverted or split
+	Status         string        `gorm:"type:varchar(10);not null;check:status in ('proposed','merged','reverted','split')" validate:"required,oneof=proposed merged reverted split"`
+	Snapshot       MergeSnapshot `gorm:"type:jsonb;serializer:json" validate:"-"`
+	CreatedAt      time.Time     `gorm:"autoCreateTime"`
+	UpdatedAt      time.Time     `gorm:"autoUpdateTime"`
+}
+
+func (em *EntityMerge) TableName() string {
+	return "entity_merges"
+}
+
+// IsProposer reports whether the owner, or the user acting for it, proposed the merge
+func (em *EntityMerge) IsProposer(ownerID, userID string) bool {
+	return em.ProposedBy == ownerID || (em.ProposerUserID != nil && *em.ProposerUserID == userID)
+}
+
+// MergeSnapshot lists what a merge or split changed
+type MergeSnapshot struct {
+	// Moved are the versions that were moved from the source to the target entity (or split off the target)
+	Moved []MergedVersion `json:"moved"`
+	// Dropped are the source versions that were deleted because their owner already had a version of the target
+	Dropped []MergedVersion `json:"dropped"`
+	// Votes are the votes on the source versions before the merge
+	Votes []MergedVote `json:"votes"`
+	// Connections are the moved connections, as they were before the merge
+	Connections []MergedConnection `json:"connections"`
+	// DomainOf and RangeOf are the connection types whose domain or range was moved
+	DomainOf []string `json:"domain_of"`
+	RangeOf  []string `json:"range_of"`
+	// Classes are the classes the source entity was an instance of, and the target entity got
+	Classes []string `json:"classes"`
+}
+
+// MergedVersion is a users version of an entity in a merge snapshot
+type MergedVersion struct {
+	UserID     string `json:"user_id"`
+	Name       string `json:"name"`
+	Definition string `json:"definition"`
+}
+
+// MergedConnection is a connection in a merge snapshot
+type MergedConnection struct {
+	ID               string `json:"id"`
+	UserID           string `json:"user_id"`
+	ConnectionTypeID string `json:"connection_type_id"`
+	FromEntityID     string `json:"from_entity_id"`
+	ToEntityID       string `json:"to_entity_id"`
+	// Dropped connections were deleted because their owner already had the moved connection
+	Dropped bool `json:"dropped"`
+}
+
+// MergedVote is a vote in a merge snapshot
+type MergedVote struct {
+	VoterID  string `json:"voter_id"`
+	AuthorID string `json:"author_id"`
+	Value    int    `json:"value"`
+}
+
+// Workspace
+
+// Workspace roles, from least to most privileged
+const (
+	RoleViewer = "viewer"
+	RoleEditor = "editor"
+	RoleAdmin  = "admin"
+)
+
+var roleRanks = map[string]int{
+	RoleViewer: 1,
+	RoleEditor: 2,
+	RoleAdmin:  3,
+}
+
+// HasRole reports whether role grants at least the permissions of the required role
+func HasRole(role, required string) bool {
+	return roleRanks[role] >= roleRanks[required] && roleRanks[role] > 0
+}
+
+// Workspace is a graph shared by its members.
+// Its entity and type versions are owned by the GraphUser that has the same ID as the workspace.
+type Workspace struct {
+	ID        string    `gorm:"type:uuid;primary_key" validate:"required,uuid"`
+	Name      string    `gorm:"type:varchar(255);not null" validate:"required,max=255"`
+	CreatedBy string    `gorm:"type:uuid;not null" validate:"required,uuid"`
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+
+	Members []WorkspaceMember `gorm:"foreignKey:WorkspaceID;references:ID;constraint:OnDelete:CASCADE"`
+}
+
+func (w *Workspace) TableName() string {
+	return "workspaces"
+}
+
+// WorkspaceMember gives a user a role in a workspace
+type WorkspaceMember struct {
+	WorkspaceID string    `gorm:"type:uuid;primaryKey" validate:"required,uuid"`
+	UserID      string    `gorm:"type:uuid;primaryKey;index" validate:"required,uuid"`
+	Role        string    `gorm:"type:varchar(10);not null;check:role in ('viewer','editor','admin')" validate:"required,oneof=viewer editor admin"`
+	CreatedAt   time.Time `gorm:"autoCreateTime"`
+}
+
+func (wm *WorkspaceMember) TableName() string {
+	return "workspace_members"
+}
+
+// WorkspaceInvitation is a pending offer for a user to join a workspace. It is deleted once answered.
+type WorkspaceInvitation struct {
+	ID          string    `gorm:"type:uuid;primary_key;default:gen_random_uuid()" validate:"required,uuid"`
+	WorkspaceID string    `gorm:"type:uuid;not null;uniqueIndex:idx_workspace_invitee" validate:"required,uuid"`
+	InviterID   string    `gorm:"type:uuid;not null" validate:"required,uuid"`
+	InviteeID   string    `gorm:"type:uuid;not null;uniqueIndex:idx_workspace_invitee;index" validate:"required,uuid"`
+	Role        string    `gorm:"type:varchar(10);not null;check:role in ('viewer','editor','admin')" validate:"required,oneof=viewer editor admin"`
+	CreatedAt   time.Time `gorm:"autoCreateTime"`
+
+	Workspace Workspace `gorm:"foreignKey:WorkspaceID;references:ID;constraint:OnDelete:CASCADE" validate:"-"`
+}
+
+func (wi *WorkspaceInvitation) TableName() string {
+	return "workspace_invitations"
+}
+
 
 // DTOs
 
@@ -102,6 +499,15 @@ func (upt *UsersPropertyType) TableName() string {
 
 type SearchRequest struct {
 	Name string `json:"name" validate:"required,max=255"`
+	// ClassID limits entity searches to instances of the class and its subclasses
+	ClassID string `json:"class_id" validate:"omitempty,uuid"`
+	// AsOf searches the versions as they were at a point in time instead of the current ones
+	AsOf *time.Time `json:"as_of"`
+}
+
+type UserDataRequest struct {
+	// AsOf gets the graph as it was at a point in time instead of the current one
+	AsOf *time.Time `json:"as_of"`
 }
 
 type UserDataResponse struct {
@@ -110,6 +516,8 @@ type UserDataResponse struct {
 	Entities        []UsersEntity `json:"entities"`
 	ConnectionTypes []UsersConnectionType `json:"connection_types"`
 	PropertyTypes   []PropertyTypeResponse `json:"property_types"`
+	Connections     []Connection `json:"connections"`
+	EntityClasses   []UsersEntityClass `json:"entity_classes"`
 }
 
 // GraphUser
@@ -132,6 +540,85 @@ type ConnectionTypeRequest struct {
 	ID         string `json:"id" validate:"omitempty,uuid"`
 	Name       string `json:"name" validate:"required,max=255"`
 	Definition string `json:"definition" validate:"required,max=4096"`
+
+	// Semantics of a new shared connection type, must be empty when linking to an existing one
+	InverseID  string `json:"inverse_id" validate:"omitempty,uuid,excluded_with=ID Symmetric"`
+	Symmetric  bool   `json:"symmetric" validate:"excluded_with=ID"`
+	Transitive bool   `json:"transitive" validate:"excluded_with=ID"`
+	DomainID   string `json:"domain_id" validate:"omitempty,uuid,excluded_with=ID"`
+	RangeID    string `json:"range_id" validate:"omitempty,uuid,excluded_with=ID"`
+}
+
+// Connection
+
+type ConnectionRequest struct {
+	ConnectionTypeID string `json:"connection_type_id" validate:"required,uuid"`
+	FromEntityID     string `json:"from_entity_id" validate:"required,uuid"`
+	ToEntityID       string `json:"to_entity_id" validate:"required,uuid"`
+}
+
+type TraversalRequest struct {
+	EntityID         string `json:"entity_id" validate:"required,uuid"`
+	ConnectionTypeID string `json:"connection_type_id" validate:"omitempty,uuid"`
+	// AsOf traverses the connections as they were at a point in time instead of the current ones
+	AsOf *time.Time `json:"as_of"`
+}
+
+// Batches
+
+// BatchRequest reads many shared nodes of a kind by ID, for clients that resolve a graph a level at a time
+type BatchRequest struct {
+	IDs []string `json:"ids" validate:"max=1000,dive,uuid"`
+	// AsOf reads the versions as they were at a point in time instead of the current ones
+	AsOf *time.Time `json:"as_of"`
+}
+
+// BatchTraversalRequest traverses the connections going out of many entities at once
+type BatchTraversalRequest struct {
+	EntityIDs        []string `json:"entity_ids" validate:"max=1000,dive,uuid"`
+	ConnectionTypeID string   `json:"connection_type_id" validate:"omitempty,uuid"`
+	// AsOf traverses the connections as they were at a point in time instead of the current ones
+	AsOf *time.Time `json:"as_of"`
+}
+
+// BatchNodeRequest identifies many shared nodes of a kind
+type BatchNodeRequest struct {
+	Kind string   `json:"kind" validate:"required,oneof=entity connection_type property_type entity_class"`
+	IDs  []string `json:"ids" validate:"max=1000,dive,uuid"`
+}
+
+// NodeClass is a class directly linked to a shared node
+type NodeClass struct {
+	NodeID string `json:"node_id"`
+	UsersEntityClass
+}
+
+// EntityClass
+
+type EntityClassRequest struct {
+	ID         string `json:"id" validate:"omitempty,uuid"`
+	Name       string `json:"name" validate:"required,max=255"`
+	Definition string `json:"definition" validate:"required,max=4096"`
+	// ParentIDs of a new shared class, must be empty when linking to an existing one
+	ParentIDs []string `json:"parent_ids" validate:"excluded_with=ID,max=100,dive,uuid"`
+}
+
+// NodeRequest identifies a shared node of any kind
+type NodeRequest struct {
+	Kind string `json:"kind" validate:"required,oneof=entity connection_type property_type entity_class"`
+	ID   string `json:"id" validate:"required,uuid"`
+}
+
+// ClassesRequest sets the classes of a shared node: the classes of an entity, the parents of a class,
+// or the classes a connection or property type applies to
+type ClassesRequest struct {
+	NodeRequest
+	ClassIDs []string `json:"class_ids" validate:"max=100,dive,uuid"`
+}
+
+type ApplicableTypesResponse struct {
+	ConnectionTypes []UsersConnectionType  `json:"connection_types"`
+	PropertyTypes   []PropertyTypeResponse `json:"property_types"`
 }
 
 // PropertyType
@@ -149,4 +636,128 @@ type PropertyTypeResponse struct {
 	Name       string `json:"name" validate:"required,max=255"`
 	Definition string `json:"definition" validate:"required,max=4096"`
 	ValueType  string `json:"value_type" validate:"required,oneof=string int float boolean"`
+
+	Consensus
+}
+
+// Workspace
+
+type WorkspaceRequest struct {
+	Name string `json:"name" validate:"required,max=255"`
+}
+
+type WorkspaceIDRequest struct {
+	WorkspaceID string `json:"workspace_id" validate:"required,uuid"`
+}
+
+type WorkspaceResponse struct {
+	ID   string `json:"id" validate:"required,uuid"`
+	Name string `json:"name" validate:"required,max=255"`
+	Role string `json:"role" validate:"required,oneof=viewer editor admin"`
+}
+
+type MemberRequest struct {
+	WorkspaceID string `json:"workspace_id" validate:"required,uuid"`
+	UserID      string `json:"user_id" validate:"required,uuid"`
+	Role        string `json:"role" validate:"omitempty,oneof=viewer editor admin"`
+}
+
+type InvitationIDRequest struct {
+	ID string `json:"id" validate:"required,uuid"`
+}
+
+type InvitationResponse struct {
+	ID            string `json:"id" validate:"required,uuid"`
+	WorkspaceID   string `json:"workspace_id" validate:"required,uuid"`
+	WorkspaceName string `json:"workspace_name" validate:"required,max=255"`
+	InviterID     string `json:"inviter_id" validate:"required,uuid"`
+	InviteeID     string `json:"invitee_id" validate:"required,uuid"`
+	Role          string `json:"role" validate:"required,oneof=viewer editor admin"`
+}
+
+// Consensus
+
+type IDRequest struct {
+	ID string `json:"id" validate:"required,uuid"`
+}
+
+type VoteRequest struct {
+	Kind     string `json:"kind" validate:"required,oneof=entity connection_type property_type entity_class"`
+	ID       string `json:"id" validate:"required,uuid"`
+	AuthorID string `json:"author_id" validate:"required,uuid"`
+	Value    int    `json:"value" validate:"oneof=-1 0 1"`
+}
+
+// Revision
+
+type RevisionsRequest struct {
+	NodeRequest
+	// UserID is the owner of the version, the owner in the context if empty
+	UserID string `json:"user_id" validate:"omitempty,uuid"`
+}
+
+// Graph events
+
+type WatchRequest struct {
+	ResumeToken string   `json:"resume_token" validate:"max=128"`
+	Kinds       []string `json:"kinds" validate:"max=5,dive,oneof=entity connection_type property_type entity_class connection"`
+}
+
+// WatchEvent is a graph event, or a heartbeat, as sent to a watcher
+type WatchEvent struct {
+	ResumeToken      string    `json:"resume_token"`
+	Action           string    `json:"action"`
+	Kind             string    `json:"kind"`
+	ID               string    `json:"id"`
+	UserID           string    `json:"user_id"`
+	Name             string    `json:"name"`
+	Definition       string    `json:"definition"`
+	ConnectionTypeID string    `json:"connection_type_id"`
+	FromEntityID     string    `json:"from_entity_id"`
+	ToEntityID       string    `json:"to_entity_id"`
+	CreatedAt        time.Time `json:"created_at"`
+}
+
+// Webhook
+
+type WebhookRequest struct {
+	URL   string   `json:"url" validate:"required,http_url,max=2048"`
+	Kinds []string `json:"kinds" validate:"max=5,dive,oneof=entity connection_type property_type entity_class connection"`
+}
+
+type WebhookIDRequest struct {
+	ID string `json:"id" validate:"required,uuid"`
+}
+
+type WebhookDeliveriesRequest struct {
+	WebhookID string `json:"webhook_id" validate:"required,uuid"`
+	Limit     int    `json:"limit" validate:"min=0,max=1000"`
+}
+
+// WebhookPayload is the body POSTed to a webhook for a graph event
+type WebhookPayload struct {
+	EventID          string    `json:"event_id"`
+	WebhookID        string    `json:"webhook_id"`
+	Action           string    `json:"action"`
+	Kind             string    `json:"kind"`
+	ID               string    `json:"id"`
+	UserID           string    `json:"user_id"`
+	Name             string    `json:"name,omitempty"`
+	Definition       string    `json:"definition,omitempty"`
+	ConnectionTypeID string    `json:"connection_type_id,omitempty"`
+	FromEntityID     string    `json:"from_entity_id,omitempty"`
+	ToEntityID       string    `json:"to_entity_id,omitempty"`
+	CreatedAt        time.Time `json:"created_at"`
+}
+
+// Merge
+
+type MergeRequest struct {
+	SourceID string `json:"source_id" validate:"required,uuid,nefield=TargetID"`
+	TargetID string `json:"target_id" validate:"required,uuid"`
+}
+
+type SplitRequest struct {
+	MergeID  string `json:"merge_id" validate:"required_without=EntityID,excluded_with=EntityID,omitempty,uuid"`
+	EntityID string `json:"entity_id" validate:"required_without=MergeID,omitempty,uuid"`
 }
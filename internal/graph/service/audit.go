@@ -0,0 +1,46 @@
+package service
+
+import (
+	"context"
+
+	a "github.com/BwezB/Wikno-backend/pkg/auth"
+)
+
+// Audited actions of the graph service
+const (
+	ActionCreateUser           = "graph.create_user"
+	ActionCreateEntity         = "graph.create_entity"
+	ActionUpdateEntity         = "graph.update_entity"
+	ActionCreateConnectionType = "graph.create_connection_type"
+	ActionCreatePropertyType   = "graph.create_property_type"
+	ActionCreateEntityClass    = "graph.create_entity_class"
+	ActionSetClasses           = "graph.set_classes"
+	ActionCreateConnection     = "graph.create_connection"
+	ActionDeleteConnection     = "graph.delete_connection"
+	ActionVote                 = "graph.vote"
+	ActionProposeMerge         = "graph.propose_merge"
+	ActionAcceptMerge          = "graph.accept_merge"
+	ActionSplitEntity          = "graph.split_entity"
+	ActionRevertToRevision     = "graph.revert_to_revision"
+	ActionCreateWorkspace      = "graph.create_workspace"
+	ActionInviteMember         = "graph.invite_member"
+	ActionAcceptInvitation     = "graph.accept_invitation"
+	ActionDeclineInvitation    = "graph.decline_invitation"
+	ActionUpdateMemberRole     = "graph.update_member_role"
+	ActionRemoveMember         = "graph.remove_member"
+	ActionCreateWebhook        = "graph.create_webhook"
+	ActionDeleteWebhook        = "graph.delete_webhook"
+)
+
+// record records the outcome of a data-changing operation in the audit log,
+// together with the workspace the request is scoped to
+func (s *GraphService) record(ctx context.Context, action, targetID string, err error, details ...string) {
+	fields := make(map[string]string, len(details)/2+1)
+	for i := 0; i+1 < len(details); i += 2 {
+		fields[details[i]] = details[i+1]
+	}
+	if workspaceID := a.GetWorkspaceID(ctx); workspaceID != "" {
+		fields["workspace_id"] = workspaceID
+	}
+	s.auditor.RecordResult(ctx, action, targetID, err, fields)
+}
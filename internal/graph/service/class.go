@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+
+	"github.com/BwezB/Wikno-backend/internal/graph/model"
+
+	e "github.com/BwezB/Wikno-backend/pkg/errors"
+)
+
+// ENTITY CLASSES
+
+// CreateEntityClass creates a new entity class or links to an existing one
+func (s *GraphService) CreateEntityClass(ctx context.Context, req *model.EntityClassRequest) (*model.UsersEntityClass, error) {
+	entityClass, err := s.db.CreateEntityClass(ctx, req)
+	if err != nil {
+		s.record(ctx, ActionCreateEntityClass, req.ID, err, "name", req.Name)
+		return nil, e.Wrap("CreateEntityClass failed", err)
+	}
+	s.record(ctx, ActionCreateEntityClass, entityClass.EntityClassID, nil, "name", req.Name)
+	return entityClass, nil
+}
+
+// FindEntityClasses finds entity classes by name
+func (s *GraphService) FindEntityClasses(ctx context.Context, req *model.SearchRequest) ([]model.UsersEntityClass, error) {
+	classes, err := s.db.FindEntityClassesWithName(ctx, req)
+	if err != nil {
+		return nil, e.Wrap("FindEntityClasses failed", err)
+	}
+	return classes, nil
+}
+
+// SetClasses replaces the classes of a shared node. The user must have a version of the node.
+func (s *GraphService) SetClasses(ctx context.Context, req *model.ClassesRequest) (err error) {
+	defer func() { s.record(ctx, ActionSetClasses, req.ID, err, "kind", req.Kind) }()
+
+	ok, err := s.db.HasVersion(ctx, req.Kind, req.ID)
+	if err != nil {
+		return e.Wrap("SetClasses failed", err)
+	}
+	if !ok {
+		return e.New("Only users with a version of a node can set its classes", ErrPermissionDenied, nil)
+	}
+
+	if err := s.db.SetClasses(ctx, req); err != nil {
+		return e.Wrap("SetClasses failed", err)
+	}
+	return nil
+}
+
+// GetClasses gets the classes of a shared node
+func (s *GraphService) GetClasses(ctx context.Context, req *model.NodeRequest) ([]model.UsersEntityClass, error) {
+	classes, err := s.db.GetClasses(ctx, req)
+	if err != nil {
+		return nil, e.Wrap("GetClasses failed", err)
+	}
+	return classes, nil
+}
+
+// GetClassesOfNodes gets the classes of many shared nodes at once
+func (s *GraphService) GetClassesOfNodes(ctx context.Context, req *model.BatchNodeRequest) ([]model.NodeClass, error) {
+	classes, err := s.db.GetClassesOfNodes(ctx, req)
+	if err != nil {
+		return nil, e.Wrap("GetClassesOfNodes failed", err)
+	}
+	return classes, nil
+}
+
+// GetApplicableTypes gets the connection and property types that apply to instances of a class
+func (s *GraphService) GetApplicableTypes(ctx context.Context, req *model.IDRequest) (*model.ApplicableTypesResponse, error) {
+	types, err := s.db.GetApplicableTypes(ctx, req)
+	if err != nil {
+		return nil, e.Wrap("GetApplicableTypes failed", err)
+	}
+	return types, nil
+}
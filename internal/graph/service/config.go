@@ -0,0 +1,77 @@
+package service
+
+import (
+	"time"
+
+	c "github.com/BwezB/Wikno-backend/pkg/configs"
+)
+
+type ServiceConfig struct {
+	// WatchPollInterval is how often the graph event outbox is checked for changes to push to watchers
+	WatchPollInterval time.Duration `yaml:"watch_poll_interval" validate:"min=10ms"`
+	// WatchHeartbeatInterval is how often watchers are sent a heartbeat with their resume token
+	WatchHeartbeatInterval time.Duration `yaml:"watch_heartbeat_interval" validate:"min=1s"`
+	// EventRetention is how long graph events are kept. Resume tokens older than this expire
+	EventRetention time.Duration `yaml:"event_retention" validate:"min=1h"`
+
+	// WebhookTimeout is how long a webhook has to respond to a delivery
+	WebhookTimeout time.Duration `yaml:"webhook_timeout" validate:"min=1s,max=1m"`
+	// WebhookMaxAttempts is how often an event is delivered to a failing webhook before it is dead-lettered
+	WebhookMaxAttempts int `yaml:"webhook_max_attempts" validate:"min=1,max=20"`
+	// WebhookBackoff is the wait before the first retry of a delivery. It doubles with every retry
+	WebhookBackoff time.Duration `yaml:"webhook_backoff" validate:"min=10ms"`
+	// WebhookMaxBackoff is the longest wait between retries of a delivery
+	WebhookMaxBackoff time.Duration `yaml:"webhook_max_backoff" validate:"gtefield=WebhookBackoff,max=1m"`
+	// WebhookAllowPrivate lets webhooks deliver to loopback, link-local and private addresses. Only for development
+	WebhookAllowPrivate bool `yaml:"webhook_allow_private"`
+}
+
+// DEFAULTS
+
+func (sc *ServiceConfig) SetDefaults() {
+	sc.WatchPollInterval = 500 * time.Millisecond
+	sc.WatchHeartbeatInterval = 30 * time.Second
+	sc.EventRetention = 7 * 24 * time.Hour
+	sc.WebhookTimeout = 10 * time.Second
+	sc.WebhookMaxAttempts = 6
+	sc.WebhookBackoff = time.Second
+	sc.WebhookMaxBackoff = time.Minute
+	sc.WebhookAllowPrivate = false
+}
+
+// ENVIRONMENT VARIABLES
+
+func (sc *ServiceConfig) AddFromEnv() {
+	c.SetEnvValue(&sc.WatchPollInterval, "WATCH_POLL_INTERVAL")
+	c.SetEnvValue(&sc.WatchHeartbeatInterval, "WATCH_HEARTBEAT_INTERVAL")
+	c.SetEnvValue(&sc.EventRetention, "EVENT_RETENTION")
+	c.SetEnvValue(&sc.WebhookTimeout, "WEBHOOK_TIMEOUT")
+	c.SetEnvValue(&sc.WebhookMaxAttempts, "WEBHOOK_MAX_ATTEMPTS")
+	c.SetEnvValue(&sc.WebhookBackoff, "WEBHOOK_BACKOFF")
+	c.SetEnvValue(&sc.WebhookMaxBackoff, "WEBHOOK_MAX_BACKOFF")
+	c.SetEnvValue(&sc.WebhookAllowPrivate, "WEBHOOK_ALLOW_PRIVATE")
+}
+
+// FLAGS
+
+var (
+	flagWatchPollInterval      = c.NewFlag("watch-poll-interval", "", "Interval of checking for graph changes to push to watchers")
+	flagWatchHeartbeatInterval = c.NewFlag("watch-heartbeat-interval", "", "Interval of heartbeats sent to watchers")
+	flagEventRetention         = c.NewFlag("event-retention", "", "How long graph events are kept for resuming watchers")
+	flagWebhookTimeout         = c.NewFlag("webhook-timeout", "", "How long a webhook has to respond to a delivery")
+	flagWebhookMaxAttempts     = c.NewFlag("webhook-max-attempts", "", "Delivery attempts of an event before it is dead-lettered")
+	flagWebhookBackoff         = c.NewFlag("webhook-backoff", "", "Wait before the first retry of a webhook delivery")
+	flagWebhookMaxBackoff      = c.NewFlag("webhook-max-backoff", "", "Longest wait between retries of a webhook delivery")
+	flagWebhookAllowPrivate    = c.NewFlag("webhook-allow-private", "", "Allow webhooks to deliver to private addresses")
+)
+
+func (sc *ServiceConfig) AddFromFlags() {
+	c.SetFlagValue(&sc.WatchPollInterval, flagWatchPollInterval)
+	c.SetFlagValue(&sc.WatchHeartbeatInterval, flagWatchHeartbeatInterval)
+	c.SetFlagValue(&sc.EventRetention, flagEventRetention)
+	c.SetFlagValue(&sc.WebhookTimeout, flagWebhookTimeout)
+	c.SetFlagValue(&sc.WebhookMaxAttempts, flagWebhookMaxAttempts)
+	c.SetFlagValue(&sc.WebhookBackoff, flagWebhookBackoff)
+	c.SetFlagValue(&sc.WebhookMaxBackoff, flagWebhookMaxBackoff)
+	c.SetFlagValue(&sc.WebhookAllowPrivate, flagWebhookAllowPrivate)
+}
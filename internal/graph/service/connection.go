@@ -0,0 +1,50 @@
+package service
+
+import (
+	"context"
+
+	"github.com/BwezB/Wikno-backend/internal/graph/model"
+
+	e "github.com/BwezB/Wikno-backend/pkg/errors"
+)
+
+// CONNECTIONS
+
+// CreateConnection connects two entities in the user's graph
+func (s *GraphService) CreateConnection(ctx context.Context, req *model.ConnectionRequest) (*model.Connection, error) {
+	connection, err := s.db.CreateConnection(ctx, req)
+	if err != nil {
+		s.record(ctx, ActionCreateConnection, "", err, "from_entity_id", req.FromEntityID, "to_entity_id", req.ToEntityID)
+		return nil, e.Wrap("CreateConnection failed", err)
+	}
+	s.record(ctx, ActionCreateConnection, connection.ID, nil, "from_entity_id", req.FromEntityID, "to_entity_id", req.ToEntityID)
+	return connection, nil
+}
+
+// DeleteConnection deletes a connection of the user's graph
+func (s *GraphService) DeleteConnection(ctx context.Context, req *model.IDRequest) error {
+	err := s.db.DeleteConnection(ctx, req)
+	s.record(ctx, ActionDeleteConnection, req.ID, err)
+	if err != nil {
+		return e.Wrap("DeleteConnection failed", err)
+	}
+	return nil
+}
+
+// GetConnections gets the stored and inferred connections going out of an entity
+func (s *GraphService) GetConnections(ctx context.Context, req *model.TraversalRequest) ([]model.Connection, error) {
+	connections, err := s.db.GetConnections(ctx, req)
+	if err != nil {
+		return nil, e.Wrap("GetConnections failed", err)
+	}
+	return connections, nil
+}
+
+// GetConnectionsOfEntities gets the stored and inferred connections going out of many entities at once
+func (s *GraphService) GetConnectionsOfEntities(ctx context.Context, req *model.BatchTraversalRequest) ([]model.Connection, error) {
+	connections, err := s.db.GetConnectionsOfEntities(ctx, req)
+	if err != nil {
+		return nil, e.Wrap("GetConnectionsOfEntities failed", err)
+	}
+	return connections, nil
+}
@@ -0,0 +1,49 @@
+package service
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/BwezB/Wikno-backend/internal/graph/model"
+
+	e "github.com/BwezB/Wikno-backend/pkg/errors"
+)
+
+// CONSENSUS
+
+// Vote up- or downvotes another user's version of a shared node
+func (s *GraphService) Vote(ctx context.Context, req *model.VoteRequest) error {
+	err := s.db.Vote(ctx, req)
+	s.record(ctx, ActionVote, req.ID, err, "kind", req.Kind, "author_id", req.AuthorID, "value", strconv.Itoa(req.Value))
+	if err != nil {
+		return e.Wrap("Vote failed", err)
+	}
+	return nil
+}
+
+// GetEntityVersions gets all versions of an entity, the canonical version first
+func (s *GraphService) GetEntityVersions(ctx context.Context, req *model.IDRequest) ([]model.UsersEntity, error) {
+	versions, err := s.db.GetEntityVersions(ctx, req)
+	if err != nil {
+		return nil, e.Wrap("GetEntityVersions failed", err)
+	}
+	return versions, nil
+}
+
+// GetConnectionTypeVersions gets all versions of a connection type, the canonical version first
+func (s *GraphService) GetConnectionTypeVersions(ctx context.Context, req *model.IDRequest) ([]model.UsersConnectionType, error) {
+	versions, err := s.db.GetConnectionTypeVersions(ctx, req)
+	if err != nil {
+		return nil, e.Wrap("GetConnectionTypeVersions failed", err)
+	}
+	return versions, nil
+}
+
+// GetPropertyTypeVersions gets all versions of a property type, the canonical version first
+func (s *GraphService) GetPropertyTypeVersions(ctx context.Context, req *model.IDRequest) ([]model.PropertyTypeResponse, error) {
+	versions, err := s.db.GetPropertyTypeVersions(ctx, req)
+	if err != nil {
+		return nil, e.Wrap("GetPropertyTypeVersions failed", err)
+	}
+	return versions, nil
+}
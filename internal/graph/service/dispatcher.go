@@ -0,0 +1,423 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/BwezB/Wikno-backend/internal/graph/db"
+	"github.com/BwezB/Wikno-backend/internal/graph/model"
+
+	e "github.com/BwezB/Wikno-backend/pkg/errors"
+	l "github.com/BwezB/Wikno-backend/pkg/log"
+	r "github.com/BwezB/Wikno-backend/pkg/requestid"
+)
+
+// webhookSyncInterval is how often the dispatcher looks for webhooks it is not delivering to yet
+const webhookSyncInterval = 10 * time.Second
+
+// webhookLease is how long a dispatcher keeps a webhook to itself without renewing the lease.
+// It is renewed before every delivery attempt, so it must outlast an attempt and the wait before it.
+const webhookLease = 5 * time.Minute
+
+// maxErrorLength is the longest delivery error that is logged
+const maxErrorLength = 1024
+
+// Headers of webhook deliveries
+const (
+	headerEventID   = "X-Wikno-Event-Id"
+	headerTimestamp = "X-Wikno-Timestamp"
+	headerSignature = "X-Wikno-Signature"
+)
+
+// errLeaseLost is returned when a webhook was deleted or leased by another dispatcher during a delivery
+var errLeaseLost = e.NewErrorType("WEBHOOK_LEASE_LOST", "Webhook lease lost")
+
+// webhookDispatcher delivers graph events to webhooks. It runs a worker per webhook it holds the lease of,
+// which is woken by the watcher hub when the webhook owner's graph changes. An event is retried with
+// exponential backoff until it is delivered or all attempts failed, when it is dead-lettered,
+// and only then is the webhook's cursor moved past it, so deliveries are in order and at least once.
+type webhookDispatcher struct {
+	db     db.GraphRepository
+	hub    *watcherHub
+	config ServiceConfig
+	client *http.Client
+	holder string // Identifies the leases of this dispatcher
+
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	mu      sync.Mutex
+	running map[string]bool // Webhooks with a worker
+
+	syncNow chan struct{}
+	workers sync.WaitGroup
+	done    chan struct{}
+}
+
+func newWebhookDispatcher(database db.GraphRepository, hub *watcherHub, config ServiceConfig) *webhookDispatcher {
+	ctx, cancel := context.WithCancel(r.WithRequestID(context.Background(), "dispatcher"))
+	dispatcher := &webhookDispatcher{
+		db:      database,
+		hub:     hub,
+		config:  config,
+		client:  newWebhookClient(config),
+		holder:  uuid.New().String(),
+		ctx:     ctx,
+		cancel:  cancel,
+		running: make(map[string]bool),
+		syncNow: make(chan struct{}, 1),
+		done:    make(chan struct{}),
+	}
+	go dispatcher.run()
+	return dispatcher
+}
+
+// wake makes the dispatcher look for new webhooks now
+func (d *webhookDispatcher) wake() {
+	select {
+	case d.syncNow <- struct{}{}:
+	default: // Already woken
+	}
+}
+
+// stop stops all workers and releases their leases, so other dispatchers take over right away
+func (d *webhookDispatcher) stop() {
+	d.cancel()
+	<-d.done
+	d.workers.Wait()
+
+	ctx, cancel := context.WithTimeout(r.WithRequestID(context.Background(), "dispatcher"), 5*time.Second)
+	defer cancel()
+	if err := d.db.ReleaseWebhooks(ctx, d.holder); err != nil {
+		l.Warn("Failed to release webhooks", l.ErrField(err))
+	}
+}
+
+func (d *webhookDispatcher) run() {
+	defer close(d.done)
+
+	syncTicker := time.NewTicker(webhookSyncInterval)
+	defer syncTicker.Stop()
+	prune := time.NewTicker(pruneInterval)
+	defer prune.Stop()
+
+	d.startWorkers()
+	for {
+		select {
+		case <-d.ctx.Done():
+			return
+		case <-d.syncNow:
+			d.startWorkers()
+		case <-syncTicker.C:
+			d.startWorkers()
+		case <-prune.C:
+			d.prune()
+		}
+	}
+}
+
+// startWorkers starts a worker for each webhook without one that this dispatcher can lease
+func (d *webhookDispatcher) startWorkers() {
+	webhooks, err := d.db.ListLeasableWebhooks(d.ctx, d.holder)
+	if err != nil {
+		l.Warn("Failed to list webhooks", l.ErrField(err), l.String("request_id", r.GetRequestID(d.ctx)))
+		return
+	}
+
+	for _, webhook := range webhooks {
+		d.mu.Lock()
+		running := d.running[webhook.ID]
+		d.mu.Unlock()
+		if running {
+			continue
+		}
+
+		leased, err := d.db.LeaseWebhook(d.ctx, webhook.ID, d.holder, webhookLease)
+		if err != nil {
+			l.Warn("Failed to lease webhook", l.ErrField(err), l.String("webhook_id", webhook.ID))
+			continue
+		}
+		if !leased {
+			continue
+		}
+
+		d.mu.Lock()
+		d.running[webhook.ID] = true
+		d.mu.Unlock()
+		d.workers.Add(1)
+		go d.work(webhook)
+	}
+}
+
+// work delivers the events of a webhook until it loses the lease or the dispatcher stops
+func (d *webhookDispatcher) work(webhook model.Webhook) {
+	defer func() {
+		d.mu.Lock()
+		delete(d.running, webhook.ID)
+		d.mu.Unlock()
+		d.workers.Done()
+	}()
+
+	wake, unsubscribe := d.hub.subscribe(webhook.UserID)
+	defer unsubscribe()
+
+	// Renew the lease while idle, well before it runs out
+	renew := time.NewTicker(webhookLease / 3)
+	defer renew.Stop()
+
+	position := model.EventPosition{TxID: webhook.CursorTxID, Seq: webhook.CursorSeq}
+	for {
+		events, err := d.db.ListOwnerGraphEvents(d.ctx, webhook.UserID, position, webhook.Kinds, watchBatchSize)
+		if err != nil {
+			l.Warn("Failed to list webhook events", l.ErrField(err), l.String("webhook_id", webhook.ID))
+			events = nil
+		}
+
+		for i := range events {
+			if err := d.deliver(&webhook, &events[i]); err != nil {
+				if !e.Is(err, errLeaseLost) && d.ctx.Err() == nil {
+					l.Warn("Webhook worker stopped", l.ErrField(err), l.String("webhook_id", webhook.ID))
+				}
+				return
+			}
+			position = model.EventPosition{TxID: events[i].TxID, Seq: events[i].Seq}
+			if err := d.db.AdvanceWebhookCursor(d.ctx, webhook.ID, position); err != nil {
+				l.Warn("Failed to advance webhook cursor", l.ErrField(err), l.String("webhook_id", webhook.ID))
+			}
+		}
+		if len(events) == watchBatchSize {
+			continue // There may be more
+		}
+
+		select {
+		case <-d.ctx.Done():
+			return
+		case _, ok := <-wake:
+			if !ok {
+				return
+			}
+		case <-renew.C:
+			if leased, err := d.db.LeaseWebhook(d.ctx, webhook.ID, d.holder, webhookLease); err == nil && !leased {
+				return // Deleted, or taken over after the lease ran out
+			}
+		}
+	}
+}
+
+// deliver delivers an event to a webhook, retrying with exponential backoff, and dead-letters it if all attempts fail.
+// It only returns an error if the delivery was abandoned, because the dispatcher stopped or the lease was lost.
+func (d *webhookDispatcher) deliver(webhook *model.Webhook, event *model.GraphEvent) error {
+	eventID := fmt.Sprintf("%d.%d", event.TxID, event.Seq)
+	body, err := json.Marshal(translateWebhookPayload(webhook.ID, eventID, event))
+	if err != nil {
+		return e.New("Failed to encode webhook payload", ErrInternal, err)
+	}
+
+	backoff := d.config.WebhookBackoff
+	for attempt := 1; ; attempt++ {
+		// Renew the lease before every attempt, so no other dispatcher delivers the event meanwhile
+		leased, err := d.db.LeaseWebhook(d.ctx, webhook.ID, d.holder, webhookLease)
+		if err != nil {
+			return e.Wrap("Failed to renew webhook lease", err)
+		}
+		if !leased {
+			return errLeaseLost
+		}
+
+		start := time.Now()
+		statusCode, postErr := d.post(webhook, eventID, body)
+		if d.ctx.Err() != nil {
+			return d.ctx.Err()
+		}
+
+		delivery := model.WebhookDelivery{
+			WebhookID:  webhook.ID,
+			EventID:    eventID,
+			Attempt:    attempt,
+			Outcome:    model.DeliverySuccess,
+			StatusCode: statusCode,
+			DurationMs: time.Since(start).Milliseconds(),
+		}
+		if postErr != nil {
+			delivery.Error = truncate(postErr.Error(), maxErrorLength)
+			delivery.Outcome = model.DeliveryRetry
+			if attempt >= d.config.WebhookMaxAttempts {
+				delivery.Outcome = model.DeliveryDeadLetter
+			}
+		}
+		if err := d.db.CreateWebhookDelivery(d.ctx, &delivery); err != nil {
+			l.Warn("Failed to log webhook delivery", l.ErrField(err), l.String("webhook_id", webhook.ID))
+		}
+
+		switch delivery.Outcome {
+		case model.DeliverySuccess:
+			return nil
+		case model.DeliveryDeadLetter:
+			err := d.db.CreateWebhookDeadLetter(d.ctx, &model.WebhookDeadLetter{
+				WebhookID: webhook.ID,
+				EventID:   eventID,
+				Payload:   string(body),
+				Attempts:  attempt,
+				LastError: delivery.Error,
+			})
+			if err != nil {
+				return e.Wrap("Failed to dead-letter webhook event", err)
+			}
+			return nil
+		}
+
+		select {
+		case <-d.ctx.Done():
+			return d.ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff = min(2*backoff, d.config.WebhookMaxBackoff)
+	}
+}
+
+// post sends a signed payload to a webhook, returning the status of the response, if any,
+// and an error unless the response acknowledged the event
+func (d *webhookDispatcher) post(webhook *model.Webhook, eventID string, body []byte) (int, error) {
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	req, err := http.NewRequestWithContext(d.ctx, http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Wikno-Webhooks/1.0")
+	req.Header.Set(headerEventID, eventID)
+	req.Header.Set(headerTimestamp, timestamp)
+	req.Header.Set(headerSignature, "sha256="+signPayload(webhook.Secret, timestamp, body))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10)) // Let the connection be reused
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("unexpected response status %s", resp.Status)
+	}
+	return resp.StatusCode, nil
+}
+
+// prune deletes the delivery log entries older than the event retention
+func (d *webhookDispatcher) prune() {
+	pruned, err := d.db.PruneWebhookDeliveries(d.ctx, time.Now().Add(-d.config.EventRetention))
+	if err != nil {
+		l.Warn("Failed to prune webhook deliveries", l.ErrField(err), l.String("request_id", r.GetRequestID(d.ctx)))
+		return
+	}
+	if pruned > 0 {
+		l.Info("Pruned webhook deliveries", l.Int("deliveries", int(pruned)))
+	}
+}
+
+// HELPER FUNCTIONS
+
+// nonPublicPrefixes are the ranges of addresses that are not reachable from the internet,
+// other than the loopback, link-local and private ones the netip package knows
+var nonPublicPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),     // This network
+	netip.MustParsePrefix("100.64.0.0/10"), // Carrier-grade NAT
+	netip.MustParsePrefix("192.0.0.0/24"),  // IETF protocol assignments
+	netip.MustParsePrefix("198.18.0.0/15"), // Benchmarking
+	netip.MustParsePrefix("64:ff9b::/96"),  // NAT64, which can reach any IPv4 address
+}
+
+// newWebhookClient returns the client of webhook deliveries. Webhook URLs are chosen by users, so unless
+// private addresses are allowed it only connects to public addresses, which are checked once the URL's host is
+// resolved so DNS cannot point it into the internal network. It never follows redirects, which could point anywhere.
+func newWebhookClient(config ServiceConfig) *http.Client {
+	dialer := &net.Dialer{Timeout: config.WebhookTimeout}
+	if !config.WebhookAllowPrivate {
+		dialer.Control = func(_, address string, _ syscall.RawConn) error {
+			addrPort, err := netip.ParseAddrPort(address)
+			if err != nil {
+				return err
+			}
+			if !isPublicAddress(addrPort.Addr()) {
+				return fmt.Errorf("webhook address %s is not public", addrPort.Addr())
+			}
+			return nil
+		}
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil // A proxy would connect in the dialer's place
+	transport.DialContext = dialer.DialContext
+	return &http.Client{
+		Timeout:   config.WebhookTimeout,
+		Transport: transport,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse // The redirect is the response, and fails the delivery
+		},
+	}
+}
+
+// isPublicAddress reports whether an address is reachable from the internet
+func isPublicAddress(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	if !addr.IsGlobalUnicast() || addr.IsPrivate() {
+		return false
+	}
+	for _, prefix := range nonPublicPrefixes {
+		if prefix.Contains(addr) {
+			return false
+		}
+	}
+	return true
+}
+
+// signPayload signs a payload and the time it was sent with a webhook secret, as a hex HMAC-SHA256
+func signPayload(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func translateWebhookPayload(webhookID, eventID string, event *model.GraphEvent) *model.WebhookPayload {
+	change := translateGraphEvent(event)
+	return &model.WebhookPayload{
+		EventID:          eventID,
+		WebhookID:        webhookID,
+		Action:           change.Action,
+		Kind:             change.Kind,
+		ID:               change.ID,
+		UserID:           change.UserID,
+		Name:             change.Name,
+		Definition:       change.Definition,
+		ConnectionTypeID: change.ConnectionTypeID,
+		FromEntityID:     change.FromEntityID,
+		ToEntityID:       change.ToEntityID,
+		CreatedAt:        change.CreatedAt,
+	}
+}
+
+// truncate shortens a string to at most length bytes, without splitting a character
+func truncate(s string, length int) string {
+	if len(s) <= length {
+		return s
+	}
+	return strings.ToValidUTF8(s[:length], "")
+}
@@ -0,0 +1,18 @@
+package service
+
+import (
+	e "github.com/BwezB/Wikno-backend/pkg/errors"
+)
+
+var (
+	// ErrPermissionDenied is returned when the user's workspace role does not allow the operation
+	ErrPermissionDenied = e.NewErrorType("PERMISSION_DENIED", "Permission denied")
+	// ErrResumeTokenExpired is returned when the graph events after a resume token may have been pruned
+	ErrResumeTokenExpired = e.NewErrorType("RESUME_TOKEN_EXPIRED", "Resume token expired")
+	// ErrUnavailable is returned when the service is shutting down
+	ErrUnavailable = e.NewErrorType("UNAVAILABLE", "Service unavailable")
+	// ErrInvalidRequest is returned when the request is valid in form but not allowed in the current state
+	ErrInvalidRequest = e.ErrInvalidRequest
+	// ErrInternal is returned when an internal error occurs
+	ErrInternal = e.ErrInternal
+)
@@ -0,0 +1,97 @@
+package service
+
+import (
+	"context"
+
+	"github.com/BwezB/Wikno-backend/internal/graph/model"
+
+	e "github.com/BwezB/Wikno-backend/pkg/errors"
+)
+
+// MERGES
+
+// ProposeMerge proposes merging two entities. The user must have a version of one of them.
+func (s *GraphService) ProposeMerge(ctx context.Context, req *model.MergeRequest) (_ *model.EntityMerge, err error) {
+	defer func() { s.record(ctx, ActionProposeMerge, req.SourceID, err, "target_id", req.TargetID) }()
+
+	if err := s.requireEntityVersion(ctx, req.SourceID, req.TargetID); err != nil {
+		return nil, e.Wrap("ProposeMerge failed", err)
+	}
+
+	merge, err := s.db.ProposeMerge(ctx, req)
+	if err != nil {
+		return nil, e.Wrap("ProposeMerge failed", err)
+	}
+	return merge, nil
+}
+
+// AcceptMerge accepts a merge proposed by another user. The user must have a version of one of the entities.
+func (s *GraphService) AcceptMerge(ctx context.Context, req *model.IDRequest) (_ *model.EntityMerge, err error) {
+	defer func() { s.record(ctx, ActionAcceptMerge, req.ID, err) }()
+
+	merge, err := s.db.GetMerge(ctx, req)
+	if err != nil {
+		return nil, e.Wrap("AcceptMerge failed", err)
+	}
+	entities := []interface{}{"source_id", merge.SourceID, "target_id", merge.TargetID}
+	if err := s.requireEntityVersion(ctx, merge.SourceID, merge.TargetID); err != nil {
+		return nil, e.WithFields(e.Wrap("AcceptMerge failed", err), entities...)
+	}
+
+	merge, err = s.db.AcceptMerge(ctx, req)
+	if err != nil {
+		return nil, e.WithFields(e.Wrap("AcceptMerge failed", err), entities...)
+	}
+	return merge, nil
+}
+
+// SplitEntity reverts a merge, or splits the user's version off an entity
+func (s *GraphService) SplitEntity(ctx context.Context, req *model.SplitRequest) (_ *model.EntityMerge, err error) {
+	defer func() { s.record(ctx, ActionSplitEntity, req.MergeID+req.EntityID, err) }()
+
+	if req.EntityID != "" {
+		merge, err := s.db.SplitEntity(ctx, &model.IDRequest{ID: req.EntityID})
+		if err != nil {
+			return nil, e.Wrap("SplitEntity failed", err)
+		}
+		return merge, nil
+	}
+
+	// After a merge everyone involved has a version of the target entity
+	mergeReq := &model.IDRequest{ID: req.MergeID}
+	merge, err := s.db.GetMerge(ctx, mergeReq)
+	if err != nil {
+		return nil, e.Wrap("SplitEntity failed", err)
+	}
+	entities := []interface{}{"source_id", merge.SourceID, "target_id", merge.TargetID}
+	if err := s.requireEntityVersion(ctx, merge.TargetID); err != nil {
+		return nil, e.WithFields(e.Wrap("SplitEntity failed", err), entities...)
+	}
+
+	merge, err = s.db.RevertMerge(ctx, mergeReq)
+	if err != nil {
+		return nil, e.WithFields(e.Wrap("SplitEntity failed", err), entities...)
+	}
+	return merge, nil
+}
+
+// ListMerges lists the merges and splits of an entity
+func (s *GraphService) ListMerges(ctx context.Context, req *model.IDRequest) ([]model.EntityMerge, error) {
+	merges, err := s.db.ListMerges(ctx, req)
+	if err != nil {
+		return nil, e.Wrap("ListMerges failed", err)
+	}
+	return merges, nil
+}
+
+// requireEntityVersion checks that the user in the context has a version of one of the entities
+func (s *GraphService) requireEntityVersion(ctx context.Context, entityIDs ...string) error {
+	ok, err := s.db.HasVersion(ctx, model.KindEntity, entityIDs...)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return e.New("Only users with a version of the entities can merge or split them", ErrPermissionDenied, nil)
+	}
+	return nil
+}
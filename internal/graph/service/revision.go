@@ -0,0 +1,39 @@
+package service
+
+import (
+	"context"
+
+	"github.com/BwezB/Wikno-backend/internal/graph/model"
+
+	e "github.com/BwezB/Wikno-backend/pkg/errors"
+)
+
+// REVISIONS
+
+// ListRevisions lists the revisions of a users version of a shared node
+func (s *GraphService) ListRevisions(ctx context.Context, req *model.RevisionsRequest) ([]model.Revision, error) {
+	revisions, err := s.db.ListRevisions(ctx, req)
+	if err != nil {
+		return nil, e.Wrap("ListRevisions failed", err)
+	}
+	return revisions, nil
+}
+
+// GetRevision gets a revision
+func (s *GraphService) GetRevision(ctx context.Context, req *model.IDRequest) (*model.Revision, error) {
+	revision, err := s.db.GetRevision(ctx, req)
+	if err != nil {
+		return nil, e.Wrap("GetRevision failed", err)
+	}
+	return revision, nil
+}
+
+// RevertToRevision reverts the user's version of a shared node to one of its revisions
+func (s *GraphService) RevertToRevision(ctx context.Context, req *model.IDRequest) (*model.Revision, error) {
+	revision, err := s.db.RevertToRevision(ctx, req)
+	s.record(ctx, ActionRevertToRevision, req.ID, err)
+	if err != nil {
+		return nil, e.Wrap("RevertToRevision failed", err)
+	}
+	return revision, nil
+}
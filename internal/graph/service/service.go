@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 
+	au "github.com/BwezB/Wikno-backend/pkg/audit"
 	e "github.com/BwezB/Wikno-backend/pkg/errors"
 
 	"github.com/BwezB/Wikno-backend/internal/graph/db"
@@ -10,12 +11,21 @@ import (
 )
 
 type GraphService struct {
-	db *db.Database
+	db         db.GraphRepository
+	auditor    *au.Auditor
+	hub        *watcherHub
+	dispatcher *webhookDispatcher
+	config     ServiceConfig
 }
 
-func NewService(database *db.Database) *GraphService {
+func NewService(database db.GraphRepository, auditor *au.Auditor, config ServiceConfig) *GraphService {
+	hub := newWatcherHub(database, config)
 	graphService := &GraphService{
-		db: database,
+		db:         database,
+		auditor:    auditor,
+		hub:        hub,
+		dispatcher: newWebhookDispatcher(database, hub, config),
+		config:     config,
 	}
 	return graphService
 }
@@ -25,15 +35,16 @@ func NewService(database *db.Database) *GraphService {
 // CreateUser creates a new user
 func (s *GraphService) CreateUser(ctx context.Context, req *model.UserRequest) error { // This should only be called by authservice
     err := s.db.CreateUser(ctx, req)
+    s.record(ctx, ActionCreateUser, req.ID, err)
     if err != nil {
         return e.Wrap("CreateUser failed", err)
     }
     return nil
 }
 
-// GetUserData gets all user's entities and types
-func (s *GraphService) GetUserData(ctx context.Context) (*model.UserDataResponse, error) {
-	user, err := s.db.GetUserData(ctx)
+// GetUserData gets all user's entities and types, now or at a point in time
+func (s *GraphService) GetUserData(ctx context.Context, req *model.UserDataRequest) (*model.UserDataResponse, error) {
+	user, err := s.db.GetUserData(ctx, req)
 	if err != nil {
 		return nil, e.Wrap("GetUserData failed", err)
 	}
@@ -44,14 +55,18 @@ func (s *GraphService) GetUserData(ctx context.Context) (*model.UserDataResponse
 func (s *GraphService) CreateEntity(ctx context.Context, req *model.EntityRequest) (*model.UsersEntity, error) {
 	usersEntity, err := s.db.CreateEntity(ctx, req)
 	if err != nil {
+		s.record(ctx, ActionCreateEntity, req.ID, err, "name", req.Name)
 		return nil, e.Wrap("CreateEntity failed", err)
 	}
+	s.record(ctx, ActionCreateEntity, usersEntity.EntityID, nil, "name", req.Name)
 	return usersEntity, nil
 }
 
 // UpdateEntity updates an existing entity
 func (s *GraphService) UpdateEntity(ctx context.Context, req *model.EntityRequest) error {
-	if err := s.db.UpdateEntity(ctx, req); err != nil {
+	err := s.db.UpdateEntity(ctx, req)
+	s.record(ctx, ActionUpdateEntity, req.ID, err, "name", req.Name)
+	if err != nil {
 		return e.Wrap("UpdateEntity failed", err)
 	}
 	return nil
@@ -66,12 +81,23 @@ func (s *GraphService) FindEntities(ctx context.Context, req *model.SearchReques
 	return entities, nil
 }
 
+// GetEntities gets entities by ID
+func (s *GraphService) GetEntities(ctx context.Context, req *model.BatchRequest) ([]model.UsersEntity, error) {
+	entities, err := s.db.GetEntities(ctx, req)
+	if err != nil {
+		return nil, e.Wrap("GetEntities failed", err)
+	}
+	return entities, nil
+}
+
 // CreateConnectionType creates a new connection type or links to existing one
 func (s *GraphService) CreateConnectionType(ctx context.Context, req *model.ConnectionTypeRequest) (*model.UsersConnectionType, error) {
 	usersConnectionType, err := s.db.CreateConnectionType(ctx, req)
 	if err != nil {
+		s.record(ctx, ActionCreateConnectionType, req.ID, err, "name", req.Name)
 		return nil, e.Wrap("CreateConnectionType failed", err)
 	}
+	s.record(ctx, ActionCreateConnectionType, usersConnectionType.ConnectionTypeID, nil, "name", req.Name)
 
 	return usersConnectionType, nil
 }
@@ -85,12 +111,23 @@ func (s *GraphService) FindConnectionTypes(ctx context.Context, req *model.Searc
 	return types, nil
 }
 
+// GetConnectionTypes gets connection types by ID
+func (s *GraphService) GetConnectionTypes(ctx context.Context, req *model.BatchRequest) ([]model.UsersConnectionType, error) {
+	types, err := s.db.GetConnectionTypes(ctx, req)
+	if err != nil {
+		return nil, e.Wrap("GetConnectionTypes failed", err)
+	}
+	return types, nil
+}
+
 // CreatePropertyType creates a new property type or links to existing one
 func (s *GraphService) CreatePropertyType(ctx context.Context, req *model.PropertyTypeRequest) (*model.PropertyTypeResponse, error) {
 	usersPropertyType, err := s.db.CreatePropertyType(ctx, req)
 	if err != nil {
+		s.record(ctx, ActionCreatePropertyType, req.ID, err, "name", req.Name)
 		return nil, e.Wrap("CreatePropertyType failed", err)
 	}
+	s.record(ctx, ActionCreatePropertyType, usersPropertyType.PropertyTypeID, nil, "name", req.Name)
 	return usersPropertyType, nil
 }
 
@@ -0,0 +1,294 @@
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/BwezB/Wikno-backend/internal/graph/db"
+	"github.com/BwezB/Wikno-backend/internal/graph/model"
+
+	a "github.com/BwezB/Wikno-backend/pkg/auth"
+	e "github.com/BwezB/Wikno-backend/pkg/errors"
+	l "github.com/BwezB/Wikno-backend/pkg/log"
+	r "github.com/BwezB/Wikno-backend/pkg/requestid"
+)
+
+// watchBatchSize is the number of graph events read at a time, by a watcher and by the watcher hub
+const watchBatchSize = 500
+
+// pruneInterval is how often graph events older than the retention are deleted
+const pruneInterval = time.Hour
+
+// WATCHING
+
+// WatchGraph sends the changes of the graph of the owner in the context until the context is done.
+// It starts after the resume token if one is given, and at the current state otherwise.
+func (s *GraphService) WatchGraph(ctx context.Context, req *model.WatchRequest, send func(*model.WatchEvent) error) error {
+	ownerID := a.GetWorkspaceID(ctx)
+	if ownerID == "" {
+		ownerID = a.GetUserID(ctx)
+	}
+
+	position, err := s.startPosition(ctx, req.ResumeToken)
+	if err != nil {
+		return e.Wrap("WatchGraph failed", err)
+	}
+
+	// Subscribe before reading, so no change between the read and the subscription is missed
+	wake, unsubscribe := s.hub.subscribe(ownerID)
+	defer unsubscribe()
+
+	heartbeat := time.NewTicker(s.config.WatchHeartbeatInterval)
+	defer heartbeat.Stop()
+	if err := send(heartbeatEvent(ownerID, position)); err != nil {
+		return e.Wrap("WatchGraph failed", err)
+	}
+
+	for {
+		events, err := s.db.ListGraphEvents(ctx, position, req.Kinds, watchBatchSize)
+		if err != nil {
+			return e.Wrap("WatchGraph failed", err)
+		}
+		for i := range events {
+			position = model.EventPosition{TxID: events[i].TxID, Seq: events[i].Seq}
+			if err := send(translateGraphEvent(&events[i])); err != nil {
+				return e.Wrap("WatchGraph failed", err)
+			}
+		}
+		if len(events) == watchBatchSize {
+			continue // There may be more
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case _, ok := <-wake:
+			if !ok {
+				return e.New("The service is shutting down", ErrUnavailable, nil)
+			}
+		case <-heartbeat.C:
+			if err := send(heartbeatEvent(ownerID, position)); err != nil {
+				return e.Wrap("WatchGraph failed", err)
+			}
+		}
+	}
+}
+
+// Shutdown stops the webhook dispatcher and the watcher hub, which ends all watch streams
+func (s *GraphService) Shutdown() {
+	s.dispatcher.stop()
+	s.hub.stop()
+}
+
+// startPosition gets the position a watcher starts after
+func (s *GraphService) startPosition(ctx context.Context, resumeToken string) (model.EventPosition, error) {
+	if resumeToken == "" {
+		return s.db.GetEventsHead(ctx)
+	}
+
+	position, issuedAt, err := decodeResumeToken(resumeToken)
+	if err != nil {
+		return model.EventPosition{}, err
+	}
+	if time.Since(issuedAt) > s.config.EventRetention {
+		return model.EventPosition{}, e.New("The events after the resume token may have been pruned", ErrResumeTokenExpired, nil)
+	}
+	return position, nil
+}
+
+// WATCHER HUB
+
+// watcherHub polls the graph event outbox with one query for all watchers,
+// and wakes the watchers of the owners whose graphs changed, so they read their new events.
+type watcherHub struct {
+	db     db.GraphRepository
+	config ServiceConfig
+
+	mu          sync.Mutex
+	subscribers map[string]map[chan struct{}]bool // Wake channels of the watchers of each owner
+	stopped     bool
+
+	done chan struct{}
+}
+
+func newWatcherHub(database db.GraphRepository, config ServiceConfig) *watcherHub {
+	hub := &watcherHub{
+		db:          database,
+		config:      config,
+		subscribers: make(map[string]map[chan struct{}]bool),
+		done:        make(chan struct{}),
+	}
+	go hub.run()
+	return hub
+}
+
+// subscribe returns a channel that receives a value when the owner's graph changes and is closed when the hub stops,
+// and a function that cancels the subscription.
+func (h *watcherHub) subscribe(ownerID string) (<-chan struct{}, func()) {
+	wake := make(chan struct{}, 1)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.stopped {
+		close(wake)
+		return wake, func() {}
+	}
+	if h.subscribers[ownerID] == nil {
+		h.subscribers[ownerID] = make(map[chan struct{}]bool)
+	}
+	h.subscribers[ownerID][wake] = true
+
+	return wake, func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		if !h.stopped {
+			delete(h.subscribers[ownerID], wake)
+			if len(h.subscribers[ownerID]) == 0 {
+				delete(h.subscribers, ownerID)
+			}
+		}
+	}
+}
+
+func (h *watcherHub) run() {
+	ctx := r.WithRequestID(context.Background(), "watcher")
+
+	position, err := h.db.GetEventsHead(ctx)
+	if err != nil {
+		l.Warn("Failed to get head of graph events, starting from the beginning", l.ErrField(err))
+	}
+
+	poll := time.NewTicker(h.config.WatchPollInterval)
+	defer poll.Stop()
+	prune := time.NewTicker(pruneInterval)
+	defer prune.Stop()
+
+	for {
+		select {
+		case <-h.done:
+			return
+		case <-poll.C:
+			position = h.poll(ctx, position)
+		case <-prune.C:
+			h.prune(ctx)
+		}
+	}
+}
+
+// poll wakes the watchers of the owners with events after the position, and returns the position of the last event
+func (h *watcherHub) poll(ctx context.Context, position model.EventPosition) model.EventPosition {
+	for {
+		events, err := h.db.ListEventOwners(ctx, position, watchBatchSize)
+		if err != nil {
+			l.Warn("Failed to poll graph events", l.ErrField(err), l.String("request_id", r.GetRequestID(ctx)))
+			return position
+		}
+		if len(events) == 0 {
+			return position
+		}
+
+		h.mu.Lock()
+		for _, event := range events {
+			for wake := range h.subscribers[event.UserID] {
+				select {
+				case wake <- struct{}{}:
+				default: // Already woken
+				}
+			}
+		}
+		h.mu.Unlock()
+
+		last := events[len(events)-1]
+		position = model.EventPosition{TxID: last.TxID, Seq: last.Seq}
+		if len(events) < watchBatchSize {
+			return position
+		}
+	}
+}
+
+// prune deletes the graph events older than the retention. Events are kept for an extra prune interval,
+// so the events after a resume token that has not expired yet are kept even if their transaction ran for a while.
+func (h *watcherHub) prune(ctx context.Context) {
+	before := time.Now().Add(-h.config.EventRetention - pruneInterval)
+	pruned, err := h.db.PruneGraphEvents(ctx, before)
+	if err != nil {
+		l.Warn("Failed to prune graph events", l.ErrField(err), l.String("request_id", r.GetRequestID(ctx)))
+		return
+	}
+	if pruned > 0 {
+		l.Info("Pruned graph events", l.Int("events", int(pruned)))
+	}
+}
+
+// stop stops polling and closes the wake channels of all watchers
+func (h *watcherHub) stop() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.stopped {
+		return
+	}
+	h.stopped = true
+	close(h.done)
+	for _, watchers := range h.subscribers {
+		for wake := range watchers {
+			close(wake)
+		}
+	}
+	h.subscribers = nil
+}
+
+// HELPER FUNCTIONS
+
+// encodeResumeToken encodes a position in the graph events, and when it was issued, as an opaque token
+func encodeResumeToken(position model.EventPosition, issuedAt time.Time) string {
+	token := fmt.Sprintf("%d.%d.%d", position.TxID, position.Seq, issuedAt.Unix())
+	return base64.RawURLEncoding.EncodeToString([]byte(token))
+}
+
+// decodeResumeToken decodes a token made by encodeResumeToken
+func decodeResumeToken(resumeToken string) (model.EventPosition, time.Time, error) {
+	token, err := base64.RawURLEncoding.DecodeString(resumeToken)
+	if err != nil {
+		return model.EventPosition{}, time.Time{}, e.New("Invalid resume token", ErrInvalidRequest, err)
+	}
+
+	var position model.EventPosition
+	var issuedAt int64
+	if _, err := fmt.Sscanf(string(token), "%d.%d.%d", &position.TxID, &position.Seq, &issuedAt); err != nil {
+		return model.EventPosition{}, time.Time{}, e.New("Invalid resume token", ErrInvalidRequest, err)
+	}
+	return position, time.Unix(issuedAt, 0), nil
+}
+
+func heartbeatEvent(ownerID string, position model.EventPosition) *model.WatchEvent {
+	return &model.WatchEvent{
+		ResumeToken: encodeResumeToken(position, time.Now()),
+		Action:      model.EventHeartbeat,
+		UserID:      ownerID,
+		CreatedAt:   time.Now(),
+	}
+}
+
+func translateGraphEvent(event *model.GraphEvent) *model.WatchEvent {
+	field := func(key string) string {
+		value, _ := event.Data[key].(string)
+		return value
+	}
+
+	return &model.WatchEvent{
+		ResumeToken:      encodeResumeToken(model.EventPosition{TxID: event.TxID, Seq: event.Seq}, time.Now()),
+		Action:           event.Action,
+		Kind:             event.Kind,
+		ID:               event.NodeID,
+		UserID:           event.UserID,
+		Name:             field("name"),
+		Definition:       field("definition"),
+		ConnectionTypeID: field("connection_type_id"),
+		FromEntityID:     field("from_entity_id"),
+		ToEntityID:       field("to_entity_id"),
+		CreatedAt:        event.CreatedAt,
+	}
+}
@@ -0,0 +1,90 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+
+	"github.com/BwezB/Wikno-backend/internal/graph/model"
+
+	e "github.com/BwezB/Wikno-backend/pkg/errors"
+)
+
+// maxWebhooks is the most webhooks a user or workspace can have
+const maxWebhooks = 10
+
+// defaultDeliveriesLimit is the number of deliveries listed when the request sets no limit
+const defaultDeliveriesLimit = 100
+
+// WEBHOOKS
+
+// CreateWebhook subscribes a URL to the changes of the owner's graph, with a new signing secret
+func (s *GraphService) CreateWebhook(ctx context.Context, req *model.WebhookRequest) (_ *model.Webhook, err error) {
+	var webhookID string
+	defer func() { s.record(ctx, ActionCreateWebhook, webhookID, err, "url", req.URL) }()
+
+	webhooks, err := s.db.ListWebhooks(ctx)
+	if err != nil {
+		return nil, e.Wrap("CreateWebhook failed", err)
+	}
+	if len(webhooks) >= maxWebhooks {
+		return nil, e.New("Too many webhooks", ErrInvalidRequest, nil)
+	}
+
+	secret, err := newWebhookSecret()
+	if err != nil {
+		return nil, e.Wrap("CreateWebhook failed", err)
+	}
+
+	webhook, err := s.db.CreateWebhook(ctx, req, secret)
+	if err != nil {
+		return nil, e.Wrap("CreateWebhook failed", err)
+	}
+	webhookID = webhook.ID
+
+	s.dispatcher.wake()
+	return webhook, nil
+}
+
+// ListWebhooks lists the owner's webhooks
+func (s *GraphService) ListWebhooks(ctx context.Context) ([]model.Webhook, error) {
+	webhooks, err := s.db.ListWebhooks(ctx)
+	if err != nil {
+		return nil, e.Wrap("ListWebhooks failed", err)
+	}
+	return webhooks, nil
+}
+
+// DeleteWebhook deletes one of the owner's webhooks
+func (s *GraphService) DeleteWebhook(ctx context.Context, req *model.WebhookIDRequest) error {
+	err := s.db.DeleteWebhook(ctx, req)
+	s.record(ctx, ActionDeleteWebhook, req.ID, err)
+	if err != nil {
+		return e.Wrap("DeleteWebhook failed", err)
+	}
+	return nil
+}
+
+// ListWebhookDeliveries lists the delivery log of one of the owner's webhooks
+func (s *GraphService) ListWebhookDeliveries(ctx context.Context, req *model.WebhookDeliveriesRequest) ([]model.WebhookDelivery, error) {
+	if req.Limit == 0 {
+		req.Limit = defaultDeliveriesLimit
+	}
+
+	deliveries, err := s.db.ListWebhookDeliveries(ctx, req)
+	if err != nil {
+		return nil, e.Wrap("ListWebhookDeliveries failed", err)
+	}
+	return deliveries, nil
+}
+
+// HELPER FUNCTIONS
+
+// newWebhookSecret generates a random key for signing webhook payloads
+func newWebhookSecret() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", e.New("Failed to generate webhook secret", ErrInternal, err)
+	}
+	return hex.EncodeToString(secret), nil
+}
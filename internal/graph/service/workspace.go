@@ -0,0 +1,162 @@
+package service
+
+import (
+	"context"
+
+	"github.com/BwezB/Wikno-backend/internal/graph/db"
+	"github.com/BwezB/Wikno-backend/internal/graph/model"
+
+	a "github.com/BwezB/Wikno-backend/pkg/auth"
+	e "github.com/BwezB/Wikno-backend/pkg/errors"
+)
+
+// WORKSPACES
+
+// CreateWorkspace creates a new workspace with the user as its admin
+func (s *GraphService) CreateWorkspace(ctx context.Context, req *model.WorkspaceRequest) (*model.WorkspaceResponse, error) {
+	workspace, err := s.db.CreateWorkspace(ctx, req)
+	if err != nil {
+		s.record(ctx, ActionCreateWorkspace, "", err, "name", req.Name)
+		return nil, e.Wrap("CreateWorkspace failed", err)
+	}
+	s.record(ctx, ActionCreateWorkspace, workspace.ID, nil, "name", req.Name)
+	return workspace, nil
+}
+
+// ListWorkspaces lists the user's workspaces
+func (s *GraphService) ListWorkspaces(ctx context.Context) ([]model.WorkspaceResponse, error) {
+	workspaces, err := s.db.ListWorkspaces(ctx)
+	if err != nil {
+		return nil, e.Wrap("ListWorkspaces failed", err)
+	}
+	return workspaces, nil
+}
+
+// GetMemberRole returns the role of the user in the context in the workspace
+func (s *GraphService) GetMemberRole(ctx context.Context, workspaceID string) (string, error) {
+	role, err := s.db.GetMemberRole(ctx, workspaceID, a.GetUserID(ctx))
+	if err != nil {
+		if e.Is(err, db.ErrRecordNotFound) {
+			return "", e.New("User is not a member of the workspace", ErrPermissionDenied, err)
+		}
+		return "", e.Wrap("GetMemberRole failed", err)
+	}
+	return role, nil
+}
+
+// ListMembers lists the members of a workspace the user belongs to
+func (s *GraphService) ListMembers(ctx context.Context, req *model.WorkspaceIDRequest) ([]model.WorkspaceMember, error) {
+	if err := s.requireRole(ctx, req.WorkspaceID, model.RoleViewer); err != nil {
+		return nil, e.Wrap("ListMembers failed", err)
+	}
+
+	members, err := s.db.ListMembers(ctx, req.WorkspaceID)
+	if err != nil {
+		return nil, e.Wrap("ListMembers failed", err)
+	}
+	return members, nil
+}
+
+// InviteMember invites a user to a workspace. Only admins can invite.
+func (s *GraphService) InviteMember(ctx context.Context, req *model.MemberRequest) (_ *model.InvitationResponse, err error) {
+	defer func() { s.record(ctx, ActionInviteMember, req.UserID, err, "workspace_id", req.WorkspaceID, "role", req.Role) }()
+
+	if req.Role == "" {
+		return nil, e.New("Role is required for invitations", ErrInvalidRequest, nil)
+	}
+	if err := s.requireRole(ctx, req.WorkspaceID, model.RoleAdmin); err != nil {
+		return nil, e.Wrap("InviteMember failed", err)
+	}
+
+	invitation, err := s.db.CreateInvitation(ctx, req)
+	if err != nil {
+		return nil, e.Wrap("InviteMember failed", err)
+	}
+	return invitation, nil
+}
+
+// ListInvitations lists the user's pending invitations
+func (s *GraphService) ListInvitations(ctx context.Context) ([]model.InvitationResponse, error) {
+	invitations, err := s.db.ListInvitations(ctx)
+	if err != nil {
+		return nil, e.Wrap("ListInvitations failed", err)
+	}
+	return invitations, nil
+}
+
+// AcceptInvitation makes the user a member of the workspace they were invited to
+func (s *GraphService) AcceptInvitation(ctx context.Context, req *model.InvitationIDRequest) (*model.WorkspaceResponse, error) {
+	workspace, err := s.db.AnswerInvitation(ctx, req, true)
+	s.record(ctx, ActionAcceptInvitation, req.ID, err)
+	if err != nil {
+		return nil, e.Wrap("AcceptInvitation failed", err)
+	}
+	return workspace, nil
+}
+
+// DeclineInvitation deletes the user's invitation without joining the workspace
+func (s *GraphService) DeclineInvitation(ctx context.Context, req *model.InvitationIDRequest) error {
+	_, err := s.db.AnswerInvitation(ctx, req, false)
+	s.record(ctx, ActionDeclineInvitation, req.ID, err)
+	if err != nil {
+		return e.Wrap("DeclineInvitation failed", err)
+	}
+	return nil
+}
+
+// UpdateMemberRole changes a member's role. Only admins can change roles, and the last admin cannot be demoted.
+func (s *GraphService) UpdateMemberRole(ctx context.Context, req *model.MemberRequest) (err error) {
+	defer func() { s.record(ctx, ActionUpdateMemberRole, req.UserID, err, "workspace_id", req.WorkspaceID, "role", req.Role) }()
+
+	if req.Role == "" {
+		return e.New("Role is required when updating a member", ErrInvalidRequest, nil)
+	}
+	if err := s.requireRole(ctx, req.WorkspaceID, model.RoleAdmin); err != nil {
+		return e.Wrap("UpdateMemberRole failed", err)
+	}
+
+	if err := s.db.UpdateMemberRole(ctx, req); err != nil {
+		return e.Wrap("UpdateMemberRole failed", translateLastAdmin(err))
+	}
+	return nil
+}
+
+// RemoveMember removes a member from a workspace. Admins can remove anyone, other members can only leave.
+func (s *GraphService) RemoveMember(ctx context.Context, req *model.MemberRequest) (err error) {
+	defer func() { s.record(ctx, ActionRemoveMember, req.UserID, err, "workspace_id", req.WorkspaceID) }()
+
+	if req.UserID != a.GetUserID(ctx) {
+		if err := s.requireRole(ctx, req.WorkspaceID, model.RoleAdmin); err != nil {
+			return e.Wrap("RemoveMember failed", err)
+		}
+	} else if err := s.requireRole(ctx, req.WorkspaceID, model.RoleViewer); err != nil {
+		return e.Wrap("RemoveMember failed", err)
+	}
+
+	if err := s.db.RemoveMember(ctx, req); err != nil {
+		return e.Wrap("RemoveMember failed", translateLastAdmin(err))
+	}
+	return nil
+}
+
+// HELPER FUNCTIONS
+
+// requireRole checks that the user in the context has at least the required role in the workspace
+func (s *GraphService) requireRole(ctx context.Context, workspaceID, required string) error {
+	role, err := s.GetMemberRole(ctx, workspaceID)
+	if err != nil {
+		return err
+	}
+	if !model.HasRole(role, required) {
+		return e.New("Workspace role "+role+" cannot perform this operation", ErrPermissionDenied, nil)
+	}
+	return nil
+}
+
+// translateLastAdmin turns the database refusing to leave a workspace without admins into an invalid request
+func translateLastAdmin(err error) error {
+	if e.Is(err, db.ErrLastAdmin) {
+		return e.New("A workspace must keep at least one admin", ErrInvalidRequest, err)
+	}
+	return err
+}
@@ -0,0 +1,87 @@
+package admin
+
+import (
+	"flag"
+	"io"
+	"net"
+	"strings"
+
+	e "github.com/BwezB/Wikno-backend/pkg/errors"
+)
+
+// Options are the flags of the destructive admin commands
+type Options struct {
+	// Confirm must be set for the command to run at all
+	Confirm bool
+	// Force allows the command to run outside development and test, or against a database that is not local
+	Force bool
+	// Args are the arguments after the flags
+	Args []string
+}
+
+// devEnvironments are the environments whose databases hold nothing worth keeping.
+// Any other environment, including a misspelled one, is treated like production.
+var devEnvironments = map[string]bool{
+	"development": true,
+	"test":        true,
+}
+
+// localHosts are the database hosts that are considered local, besides loopback addresses
+var localHosts = map[string]bool{
+	"localhost":              true,
+	"host.docker.internal":   true, // The host machine, from docker compose
+	"host.minikube.internal": true, // The host machine, from minikube
+}
+
+// ParseOptions parses the flags of the named admin command from its arguments
+func ParseOptions(command string, args []string) (Options, error) {
+	var options Options
+	flags := flag.NewFlagSet(command, flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	flags.BoolVar(&options.Confirm, "confirm", false, "Confirm that the command may change the database")
+	flags.BoolVar(&options.Force, "force", false, "Allow running outside development and test, or against a non-local database")
+	if err := flags.Parse(args); err != nil {
+		return Options{}, e.New("Invalid "+command+" flags", e.ErrInvalidRequest, err)
+	}
+	options.Args = flags.Args()
+	return options, nil
+}
+
+// CheckTarget makes sure a destructive command may run against the database host in the environment.
+// The command needs -confirm, and -force as well outside the development and test environments
+// or for a host that is not local.
+func CheckTarget(command string, options Options, environment, host string) error {
+	if !options.Confirm {
+		return e.New(command+" changes the database, run it again with -confirm", e.ErrInvalidRequest, nil)
+	}
+	if options.Force {
+		return nil
+	}
+	if !devEnvironments[environment] {
+		return e.New(command+" refuses to run in environment "+environment+" without -force, "+
+			"it only runs in development and test", e.ErrInvalidRequest, nil)
+	}
+	if !IsLocalHost(host) {
+		return e.New(command+" refuses to run against non-local database host "+host+" without -force", e.ErrInvalidRequest, nil)
+	}
+	return nil
+}
+
+// CheckDevEnvironment makes sure a command that only makes sense for development, like seeding test users
+// with known passwords, runs in the development or test environment. Unlike CheckTarget, -force does not override it.
+func CheckDevEnvironment(command, environment string) error {
+	if !devEnvironments[environment] {
+		return e.New(command+" only runs in development and test, not in environment "+environment, e.ErrInvalidRequest, nil)
+	}
+	return nil
+}
+
+// IsLocalHost reports whether a database host is on this machine
+func IsLocalHost(host string) bool {
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	if localHosts[host] {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
@@ -0,0 +1,65 @@
+package admin
+
+import (
+	"os"
+
+	"github.com/go-playground/validator/v10"
+	"gopkg.in/yaml.v3"
+
+	e "github.com/BwezB/Wikno-backend/pkg/errors"
+)
+
+// Fixtures are the seed data of the services. Each service seeds the part it stores,
+// so the same file seeds the auth service users and their graphs in the graph service.
+type Fixtures struct {
+	Users []UserFixture `yaml:"users" validate:"dive"`
+}
+
+// UserFixture is a user with a fixed ID, so the services agree on it, and the user's graph
+type UserFixture struct {
+	ID          string `yaml:"id" validate:"required,uuid"`
+	Email       string `yaml:"email" validate:"required,email,max=255"`
+	Password    string `yaml:"password" validate:"required,min=8,max=32"`
+	DisplayName string `yaml:"display_name" validate:"max=64"`
+
+	Entities        []NodeFixture           `yaml:"entities" validate:"dive"`
+	EntityClasses   []NodeFixture           `yaml:"entity_classes" validate:"dive"`
+	ConnectionTypes []ConnectionTypeFixture `yaml:"connection_types" validate:"dive"`
+	PropertyTypes   []PropertyTypeFixture   `yaml:"property_types" validate:"dive"`
+}
+
+// NodeFixture is a named and defined node of the user's graph
+type NodeFixture struct {
+	Name       string `yaml:"name" validate:"required,max=255"`
+	Definition string `yaml:"definition" validate:"required,max=4096"`
+}
+
+// ConnectionTypeFixture is a connection type of the user's graph
+type ConnectionTypeFixture struct {
+	NodeFixture `yaml:",inline"`
+	Symmetric   bool `yaml:"symmetric"`
+	Transitive  bool `yaml:"transitive"`
+}
+
+// PropertyTypeFixture is a property type of the user's graph
+type PropertyTypeFixture struct {
+	NodeFixture `yaml:",inline"`
+	ValueType   string `yaml:"value_type" validate:"required,oneof=string int float boolean"`
+}
+
+// LoadFixtures reads and validates the fixtures in a YAML file
+func LoadFixtures(path string, validator *validator.Validate) (*Fixtures, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, e.New("Could not read fixtures file "+path, e.ErrInvalidRequest, err)
+	}
+
+	var fixtures Fixtures
+	if err := yaml.Unmarshal(content, &fixtures); err != nil {
+		return nil, e.New("Could not parse fixtures file "+path, e.ErrInvalidRequest, err)
+	}
+	if err := validator.Struct(&fixtures); err != nil {
+		return nil, e.New("Invalid fixtures in "+path, e.ErrInvalidRequest, err)
+	}
+	return &fixtures, nil
+}
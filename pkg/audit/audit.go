@@ -0,0 +1,239 @@
+// Package audit records security-relevant and data-changing operations in an append-only,
+// hash-chained Postgres table, or in memory when a service keeps no database. Every event stores
+// the hash of the event before it, so removing or changing an event breaks the chain from that event on.
+package audit
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	"gorm.io/gorm"
+
+	a "github.com/BwezB/Wikno-backend/pkg/auth"
+	l "github.com/BwezB/Wikno-backend/pkg/log"
+	r "github.com/BwezB/Wikno-backend/pkg/requestid"
+)
+
+// Outcomes of an audited operation
+const (
+	OutcomeSuccess = "success"
+	OutcomeFailure = "failure"
+)
+
+// Limits of a query of the audit log
+const (
+	DefaultLimit = 100
+	MaxLimit     = 1000
+)
+
+// verifyBatchSize is the number of events read at a time when verifying the chain
+const verifyBatchSize = 1000
+
+// Event is an entry of the audit log
+type Event struct {
+	Seq       int64             `gorm:"primaryKey;autoIncrement"`
+	Service   string            `gorm:"type:varchar(32);not null"`
+	Action    string            `gorm:"type:varchar(64);not null;index:idx_audit_events_action"`
+	UserID    string            `gorm:"type:varchar(36);not null;default:'';index:idx_audit_events_user"` // Empty for unauthenticated requests
+	TargetID  string            `gorm:"type:varchar(255);not null;default:''"`
+	Outcome   string            `gorm:"type:varchar(10);not null;check:outcome IN ('success','failure')"`
+	RequestID string            `gorm:"type:varchar(64);not null;default:''"`
+	Details   map[string]string `gorm:"type:jsonb;serializer:json"`
+	CreatedAt time.Time         `gorm:"not null;index:idx_audit_events_created_at"`
+	PrevHash  string            `gorm:"type:varchar(64);not null;default:''"` // Empty for the first event
+	Hash      string            `gorm:"type:varchar(64);not null;uniqueIndex"`
+}
+
+func (Event) TableName() string {
+	return "audit_events"
+}
+
+// Filter selects events of the audit log
+type Filter struct {
+	UserID string
+	Action string
+	From   *time.Time
+	To     *time.Time
+	Limit  int
+	Before int64 // Only events with a lower sequence number, for paging
+}
+
+// log is where the events of an auditor are kept
+type log interface {
+	// append chains the event to the last event of the log, and appends it
+	append(ctx context.Context, event *Event) error
+	// query gets up to limit events that match the filter, newest first
+	query(ctx context.Context, filter Filter, limit int) ([]Event, error)
+	// after gets up to limit events with a higher sequence number, oldest first
+	after(ctx context.Context, seq int64, limit int) ([]Event, error)
+}
+
+// Auditor appends the events of one service to the audit log.
+// A nil Auditor records nothing.
+type Auditor struct {
+	log     log
+	service string
+}
+
+// New returns an auditor that keeps the log in the audit_events table of the database
+func New(db *gorm.DB, service string) *Auditor {
+	return &Auditor{
+		log:     &postgresLog{db: db},
+		service: service,
+	}
+}
+
+// NewMemory returns an auditor that keeps the log in memory, for services that keep no database
+func NewMemory(service string) *Auditor {
+	return &Auditor{
+		log:     &memoryLog{},
+		service: service,
+	}
+}
+
+// Record appends an event to the log. The service, request ID, time and hashes are set by the auditor,
+// and the user defaults to the authenticated user in the context.
+func (au *Auditor) Record(ctx context.Context, event Event) error {
+	if au == nil {
+		return nil
+	}
+
+	event.Seq = 0
+	event.Service = au.service
+	event.RequestID = r.GetRequestID(ctx)
+	if event.UserID == "" {
+		event.UserID = a.GetUserID(ctx)
+	}
+	if event.Outcome == "" {
+		event.Outcome = OutcomeSuccess
+	}
+	event.CreatedAt = time.Now().UTC().Truncate(time.Microsecond) // The precision postgres stores
+
+	return au.log.append(ctx, &event)
+}
+
+// RecordResult records the outcome of an operation: a success, or a failure with the error as the "error" detail.
+// Recording errors are logged and not returned, so auditing never fails the operation itself.
+func (au *Auditor) RecordResult(ctx context.Context, action, targetID string, err error, details map[string]string) {
+	if au == nil {
+		return
+	}
+
+	event := Event{
+		Action:   action,
+		TargetID: targetID,
+		Outcome:  OutcomeSuccess,
+		Details:  details,
+	}
+	if err != nil {
+		event.Outcome = OutcomeFailure
+		event.Details = make(map[string]string, len(details)+1)
+		for key, value := range details {
+			event.Details[key] = value
+		}
+		event.Details["error"] = err.Error()
+	}
+
+	if err := au.Record(ctx, event); err != nil {
+		l.Warn("Failed to record audit event",
+			l.String("action", action),
+			l.String("target_id", targetID),
+			l.ErrField(err),
+			l.String("request_id", r.GetRequestID(ctx)))
+	}
+}
+
+// Query gets the events that match the filter, newest first
+func (au *Auditor) Query(ctx context.Context, filter Filter) ([]Event, error) {
+	if au == nil {
+		return nil, nil
+	}
+
+	l.Debug("Querying audit log",
+		l.String("user_id", filter.UserID),
+		l.String("action", filter.Action),
+		l.Int("limit", filter.Limit),
+		l.String("request_id", r.GetRequestID(ctx)))
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+	if limit > MaxLimit {
+		limit = MaxLimit
+	}
+
+	return au.log.query(ctx, filter, limit)
+}
+
+// Verify walks the whole chain and returns the sequence number of the first event that does not
+// match its hash or the hash of the event before it, or 0 if the chain is intact.
+func (au *Auditor) Verify(ctx context.Context) (int64, error) {
+	if au == nil {
+		return 0, nil
+	}
+
+	l.Debug("Verifying audit log", l.String("request_id", r.GetRequestID(ctx)))
+
+	prevHash := ""
+	var after int64
+	for {
+		events, err := au.log.after(ctx, after, verifyBatchSize)
+		if err != nil {
+			return 0, err
+		}
+
+		for i := range events {
+			if events[i].PrevHash != prevHash || events[i].Hash != events[i].computeHash() {
+				return events[i].Seq, nil
+			}
+			prevHash = events[i].Hash
+			after = events[i].Seq
+		}
+		if len(events) < verifyBatchSize {
+			return 0, nil
+		}
+	}
+}
+
+// HELPER FUNCTIONS
+
+// chain links the event to the last event of the log, which is empty for the first event
+func (ev *Event) chain(last Event) {
+	ev.PrevHash = last.Hash
+	ev.Hash = ev.computeHash()
+}
+
+// matches reports whether the event is selected by the filter
+func (ev *Event) matches(filter Filter) bool {
+	return (filter.UserID == "" || ev.UserID == filter.UserID) &&
+		(filter.Action == "" || ev.Action == filter.Action) &&
+		(filter.From == nil || !ev.CreatedAt.Before(*filter.From)) &&
+		(filter.To == nil || ev.CreatedAt.Before(*filter.To)) &&
+		(filter.Before <= 0 || ev.Seq < filter.Before)
+}
+
+// computeHash hashes the event's fields together with the hash of the event before it
+func (ev *Event) computeHash() string {
+	details, _ := json.Marshal(ev.Details) // Map keys are sorted, so equal details marshal the same
+
+	h := sha256.New()
+	for _, field := range []string{
+		ev.PrevHash,
+		ev.Service,
+		ev.Action,
+		ev.UserID,
+		ev.TargetID,
+		ev.Outcome,
+		ev.RequestID,
+		string(details),
+		ev.CreatedAt.UTC().Format(time.RFC3339Nano),
+	} {
+		h.Write([]byte(field))
+		h.Write([]byte{0}) // Separate fields, so they cannot be shifted into each other
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,41 @@
+package audit
+
+import (
+	"strings"
+
+	c "github.com/BwezB/Wikno-backend/pkg/configs"
+)
+
+type AuditConfig struct {
+	AdminEmails string `yaml:"admin_emails"` // Comma separated emails of the users that can query the audit log
+}
+
+func (ac *AuditConfig) SetDefaults() {
+	ac.AdminEmails = "" // Nobody can query the audit log unless configured
+}
+
+func (ac *AuditConfig) AddFromEnv() {
+	c.SetEnvValue(&ac.AdminEmails, "AUDIT_ADMIN_EMAILS")
+}
+
+var (
+	flagAuditAdminEmails = c.NewFlag("audit-admin-emails", "", "Comma separated emails of the audit log admins")
+)
+
+func (ac *AuditConfig) AddFromFlags() {
+	c.SetFlagValue(&ac.AdminEmails, flagAuditAdminEmails)
+}
+
+// HELPER FUNCTIONS
+
+// GetAdmins returns the set of emails of the audit log admins
+func (ac *AuditConfig) GetAdmins() map[string]bool {
+	admins := make(map[string]bool)
+	for _, email := range strings.Split(ac.AdminEmails, ",") {
+		email = strings.TrimSpace(email)
+		if email != "" {
+			admins[email] = true
+		}
+	}
+	return admins
+}
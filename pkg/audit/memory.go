@@ -0,0 +1,48 @@
+package audit
+
+import (
+	"context"
+	"sync"
+)
+
+// memoryLog keeps the audit log in memory, so it is lost on shutdown
+type memoryLog struct {
+	mu     sync.Mutex
+	events []Event // Ordered by sequence number, which starts at 1
+}
+
+func (ml *memoryLog) append(_ context.Context, event *Event) error {
+	ml.mu.Lock()
+	defer ml.mu.Unlock()
+
+	var last Event
+	if len(ml.events) > 0 {
+		last = ml.events[len(ml.events)-1]
+	}
+	event.Seq = last.Seq + 1
+	event.chain(last)
+	ml.events = append(ml.events, *event)
+	return nil
+}
+
+func (ml *memoryLog) query(_ context.Context, filter Filter, limit int) ([]Event, error) {
+	ml.mu.Lock()
+	defer ml.mu.Unlock()
+
+	var events []Event
+	for i := len(ml.events) - 1; i >= 0 && len(events) < limit; i-- {
+		if ml.events[i].matches(filter) {
+			events = append(events, ml.events[i])
+		}
+	}
+	return events, nil
+}
+
+func (ml *memoryLog) after(_ context.Context, seq int64, limit int) ([]Event, error) {
+	ml.mu.Lock()
+	defer ml.mu.Unlock()
+
+	start := min(max(int(seq), 0), len(ml.events)) // The event with sequence number n is at n-1
+	end := min(start+limit, len(ml.events))
+	return append([]Event(nil), ml.events[start:end]...), nil
+}
@@ -0,0 +1,90 @@
+package audit
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	e "github.com/BwezB/Wikno-backend/pkg/errors"
+)
+
+// postgresLog keeps the audit log in the audit_events table
+type postgresLog struct {
+	db *gorm.DB
+}
+
+func (pl *postgresLog) append(ctx context.Context, event *Event) error {
+	// Start transaction
+	tx := pl.db.WithContext(ctx).Begin()
+	if tx.Error != nil {
+		return e.New("Could not start transaction", e.ErrInternal, tx.Error)
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	// Events are chained one at a time
+	if err := tx.Exec(`SELECT pg_advisory_xact_lock(hashtext('audit_events'))`).Error; err != nil {
+		tx.Rollback()
+		return e.New("Could not lock the audit log", e.ErrInternal, err)
+	}
+
+	var last Event
+	res := tx.Order("seq DESC").Limit(1).Find(&last)
+	if res.Error != nil {
+		tx.Rollback()
+		return e.New("Could not get the last audit event", e.ErrInternal, res.Error)
+	}
+	event.chain(last)
+
+	if err := tx.Create(event).Error; err != nil {
+		tx.Rollback()
+		return e.New("Could not record audit event", e.ErrInternal, err)
+	}
+
+	// Commit transaction
+	if err := tx.Commit().Error; err != nil {
+		return e.New("Could not commit", e.ErrInternal, err)
+	}
+	return nil
+}
+
+func (pl *postgresLog) query(ctx context.Context, filter Filter, limit int) ([]Event, error) {
+	query := pl.db.WithContext(ctx).Model(&Event{})
+	if filter.UserID != "" {
+		query = query.Where("user_id = ?", filter.UserID)
+	}
+	if filter.Action != "" {
+		query = query.Where("action = ?", filter.Action)
+	}
+	if filter.From != nil {
+		query = query.Where("created_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("created_at < ?", *filter.To)
+	}
+	if filter.Before > 0 {
+		query = query.Where("seq < ?", filter.Before)
+	}
+
+	var events []Event
+	if err := query.Order("seq DESC").Limit(limit).Find(&events).Error; err != nil {
+		return nil, e.New("Failed to query audit log", e.ErrInternal, err)
+	}
+	return events, nil
+}
+
+func (pl *postgresLog) after(ctx context.Context, seq int64, limit int) ([]Event, error) {
+	var events []Event
+	res := pl.db.WithContext(ctx).
+		Where("seq > ?", seq).
+		Order("seq ASC").
+		Limit(limit).
+		Find(&events)
+	if res.Error != nil {
+		return nil, e.New("Failed to read audit log", e.ErrInternal, res.Error)
+	}
+	return events, nil
+}
@@ -0,0 +1,160 @@
+package audit
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+
+	a "github.com/BwezB/Wikno-backend/pkg/auth"
+	l "github.com/BwezB/Wikno-backend/pkg/log"
+	r "github.com/BwezB/Wikno-backend/pkg/requestid"
+
+	pb "github.com/BwezB/Wikno-backend/api/proto/audit"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ActionQuery is recorded whenever the audit log is queried, so access to it is audited as well
+const ActionQuery = "audit.query"
+
+// Server serves the audit log of a service to its administrators.
+// It expects the authentication interceptor of the service to have set the user's email in the context.
+type Server struct {
+	pb.UnimplementedAuditServiceServer
+	auditor   *Auditor
+	validator *validator.Validate
+	admins    map[string]bool
+}
+
+func NewServer(auditor *Auditor, validator *validator.Validate, config AuditConfig) *Server {
+	return &Server{
+		auditor:   auditor,
+		validator: validator,
+		admins:    config.GetAdmins(),
+	}
+}
+
+// RegisterServer registers the audit server on a gRPC server
+func RegisterServer(grpcServer *grpc.Server, server *Server) {
+	pb.RegisterAuditServiceServer(grpcServer, server)
+}
+
+// queryRequest is the validated form of an AuditLogRequest
+type queryRequest struct {
+	UserID string `validate:"omitempty,uuid"`
+	Action string `validate:"max=64"`
+	Limit  int    `validate:"min=0,max=1000"`
+	Before int64  `validate:"min=0"`
+}
+
+func (s *Server) QueryAuditLog(ctx context.Context, req *pb.AuditLogRequest) (*pb.AuditLog, error) {
+	l.Debug("Querying audit log",
+		l.String("user_id", req.GetUserId()),
+		l.String("action", req.GetAction()),
+		l.String("request_id", r.GetRequestID(ctx)))
+
+	// Only administrators can read the audit log
+	if !s.admins[a.GetUserEmail(ctx)] {
+		s.auditor.RecordResult(ctx, ActionQuery, "", status.Error(codes.PermissionDenied, "not an audit log admin"), nil)
+		return nil, status.Error(codes.PermissionDenied, "Permission denied")
+	}
+
+	// Translate the request
+	request := queryRequest{
+		UserID: req.GetUserId(),
+		Action: req.GetAction(),
+		Limit:  int(req.GetLimit()),
+		Before: req.GetBefore(),
+	}
+
+	// Validate the request
+	if err := s.validator.Struct(request); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid request")
+	}
+	filter := Filter{
+		UserID: request.UserID,
+		Action: request.Action,
+		Limit:  request.Limit,
+		Before: request.Before,
+	}
+	var err error
+	if filter.From, err = translateTimestamp(req.GetFrom()); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid from time")
+	}
+	if filter.To, err = translateTimestamp(req.GetTo()); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid to time")
+	}
+
+	// Query the log
+	events, err := s.auditor.Query(ctx, filter)
+	if err != nil {
+		l.Warn("Failed to query audit log:", l.ErrField(err))
+		return nil, status.Error(codes.Internal, "Internal error")
+	}
+
+	// Translate the response
+	res := &pb.AuditLog{
+		Events: make([]*pb.AuditEvent, len(events)),
+	}
+	for i := range events {
+		res.Events[i] = translateEventToProto(&events[i])
+	}
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+	if len(events) == limit {
+		res.NextBefore = events[len(events)-1].Seq
+	}
+
+	if req.GetVerifyChain() {
+		brokenAt, err := s.auditor.Verify(ctx)
+		if err != nil {
+			l.Warn("Failed to verify audit log:", l.ErrField(err))
+			return nil, status.Error(codes.Internal, "Internal error")
+		}
+		res.ChainIntact = brokenAt == 0
+		res.BrokenAt = brokenAt
+	}
+
+	s.auditor.RecordResult(ctx, ActionQuery, "", nil, map[string]string{
+		"events":       strconv.Itoa(len(events)),
+		"verify_chain": strconv.FormatBool(req.GetVerifyChain()),
+	})
+
+	return res, nil
+}
+
+// HELPER FUNCTIONS
+
+// translateTimestamp translates an optional timestamp, returning nil if it is not set
+func translateTimestamp(ts *timestamppb.Timestamp) (*time.Time, error) {
+	if ts == nil {
+		return nil, nil
+	}
+	if err := ts.CheckValid(); err != nil {
+		return nil, err
+	}
+	t := ts.AsTime()
+	return &t, nil
+}
+
+func translateEventToProto(event *Event) *pb.AuditEvent {
+	return &pb.AuditEvent{
+		Seq:       event.Seq,
+		Service:   event.Service,
+		Action:    event.Action,
+		UserId:    event.UserID,
+		TargetId:  event.TargetID,
+		Outcome:   event.Outcome,
+		RequestId: event.RequestID,
+		Details:   event.Details,
+		CreatedAt: timestamppb.New(event.CreatedAt),
+		PrevHash:  event.PrevHash,
+		Hash:      event.Hash,
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"strings"
 
+	ce "github.com/BwezB/Wikno-backend/pkg/certs"
 	e "github.com/BwezB/Wikno-backend/pkg/errors"
 	l "github.com/BwezB/Wikno-backend/pkg/log"
 	r "github.com/BwezB/Wikno-backend/pkg/requestid"
@@ -11,7 +12,6 @@ import (
 	pb "github.com/BwezB/Wikno-backend/api/proto/auth"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
@@ -24,8 +24,15 @@ type contextKey string
 const userIDKey contextKey = "user_id"
 // userEmailKey is the key used to store the user email in the context
 const userEmailKey contextKey = "user_email"
+// workspaceIDKey is the key used to store the workspace scope of a request in the context
+const workspaceIDKey contextKey = "workspace_id"
 // authorizationKey is the key used to store the authorization token in the context
 const authorizationKey = "authorization"
+// workspaceMetadataKey is the gRPC metadata key clients use to scope a request to a workspace
+const workspaceMetadataKey = "workspace-id"
+
+// MaxProfilesPerRequest is the most user IDs one BatchGetProfiles request can contain
+const MaxProfilesPerRequest = 100
 
 
 // GET/SET CONTEXT VALUES
@@ -60,6 +67,35 @@ func GetUserEmail(ctx context.Context) string {
 	return ""
 }
 
+// Workspace
+
+// WithWorkspaceID adds the workspace the request is scoped to to the context.
+// Only set it after the user's membership has been checked.
+func WithWorkspaceID(ctx context.Context, workspaceID string) context.Context {
+	return context.WithValue(ctx, workspaceIDKey, workspaceID)
+}
+
+// GetWorkspaceID returns the workspace the request is scoped to, or "" for the user's personal graph
+func GetWorkspaceID(ctx context.Context) string {
+	if workspaceID, ok := ctx.Value(workspaceIDKey).(string); ok {
+		return workspaceID
+	}
+	return ""
+}
+
+// GetWorkspaceMetadata returns the (unchecked) workspace scope sent by the client in the incoming metadata
+func GetWorkspaceMetadata(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	workspaceIDs := md.Get(workspaceMetadataKey)
+	if len(workspaceIDs) == 0 {
+		return ""
+	}
+	return workspaceIDs[0]
+}
+
 // Authentication token
 
 // WithAuthorizationToken adds an authorization token to the OUTGOING context TO BE SENT OVER GRPC
@@ -92,17 +128,58 @@ type AuthService struct {
 
 func NewAuthService(config AuthConfig) (*AuthService, error) {
 	l.Debug("Connecting to auth service", l.String("address", config.GetAddress()))
-	conn, err := grpc.Dial(config.GetAddress(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	creds, err := ce.ClientCredentials(config.TLS)
+	if err != nil {
+		return nil, e.New("Failed to load auth service credentials", e.ErrConnectionFailed, err)
+	}
+	conn, err := grpc.Dial(config.GetAddress(), grpc.WithTransportCredentials(creds))
 	if err != nil {
 		return nil, e.New("Failed to connect to auth service", e.ErrConnectionFailed, err)
 	}
 
 	l.Info("Connected to auth service", l.String("address", config.GetAddress()))
 
+	return NewAuthServiceWithConn(conn), nil
+}
+
+// NewAuthServiceWithConn calls the auth service over an existing connection, like the in-process one of the test harness
+func NewAuthServiceWithConn(conn grpc.ClientConnInterface) *AuthService {
 	return &AuthService{
 		authClient: pb.NewAuthServiceClient(conn),
 		authHealthClient: grpc_health_v1.NewHealthClient(conn),
-	}, nil
+	}
+}
+
+// GetProfiles resolves user IDs into profiles, forwarding the caller's authorization token.
+// The IDs are requested in batches of at most MaxProfilesPerRequest, the limit of BatchGetProfiles.
+// The returned map is keyed by user ID and only contains users that have a profile.
+func (s *AuthService) GetProfiles(ctx context.Context, userIDs []string) (map[string]*pb.Profile, error) {
+	return s.GetProfilesWithToken(ctx, GetAuthorizationToken(ctx), userIDs)
+}
+
+// GetProfilesWithToken resolves user IDs into profiles like GetProfiles, with the given authorization token.
+// It is used by callers that are not handling a gRPC call, like the GraphQL server.
+func (s *AuthService) GetProfilesWithToken(ctx context.Context, token string, userIDs []string) (map[string]*pb.Profile, error) {
+	profiles := make(map[string]*pb.Profile, len(userIDs))
+	if len(userIDs) == 0 {
+		return profiles, nil
+	}
+
+	outCtx := WithAuthorizationToken(ctx, token)
+	for start := 0; start < len(userIDs); start += MaxProfilesPerRequest {
+		end := min(start+MaxProfilesPerRequest, len(userIDs))
+		resp, err := s.authClient.BatchGetProfiles(outCtx, &pb.BatchGetProfilesRequest{
+			UserIds: userIDs[start:end],
+		})
+		if err != nil {
+			return nil, e.Wrap("BatchGetProfiles failed", err)
+		}
+
+		for _, profile := range resp.GetProfiles() {
+			profiles[profile.GetUserId()] = profile
+		}
+	}
+	return profiles, nil
 }
 
 // Health checks - NO!! Kubernetes doesnt open endpoints if service is not healthy
@@ -125,48 +202,81 @@ func NewAuthService(config AuthConfig) (*AuthService, error) {
 // UnaryAuthInterceptor returns a new unary interceptor that performs token validation
 func UnaryAuthInterceptor(authService *AuthService, allowedMethods []string) grpc.UnaryServerInterceptor {
 	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
-		if info.FullMethod == "/grpc.health.v1.Health/Check" { // Skip health checks
-            return handler(ctx, req)
-        }
-
-		// Check if method is allowed
-		for _, method := range allowedMethods {
-			if method == getMethodName(info.FullMethod) {
-				return handler(ctx, req)
-			}
+		ctx, err := authenticate(ctx, authService, info.FullMethod, allowedMethods)
+		if err != nil {
+			return nil, err
 		}
 
-		// Extract token from metadata
-		md, ok := metadata.FromIncomingContext(ctx)
-		if !ok {
-			l.Warn("Missing metadata", l.String("request_id", r.GetRequestID(ctx)))
-			return nil, status.Error(codes.Unauthenticated, "missing metadata")
-		}
+		// Call the handler
+		return handler(ctx, req)
+	}
+}
 
-		tokens := md.Get(authorizationKey)
-		if len(tokens) == 0 {
-			l.Warn("Missing authorization token", l.String("request_id", r.GetRequestID(ctx)))
-			return nil, status.Error(codes.Unauthenticated, "missing authorization token")
+// StreamAuthInterceptor returns a new stream interceptor that performs token validation
+func StreamAuthInterceptor(authService *AuthService, allowedMethods []string) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := authenticate(ss.Context(), authService, info.FullMethod, allowedMethods)
+		if err != nil {
+			return err
 		}
 
-		// Verify token with auth service
-		resp, err := authService.authClient.VerifyToken(ctx, &pb.VerifyTokenRequest{
-			Token: tokens[0],
-		})
-		if err != nil {
-			l.Warn("Token verification failed",
-				l.String("request_id", r.GetRequestID(ctx)),
-				l.ErrField(err))
-			return nil, status.Error(codes.Unauthenticated, "invalid token")
+		// Call the handler
+		return handler(srv, r.WrapServerStream(ss, ctx))
+	}
+}
+
+// authenticate verifies the token of a call to a method that is not allowed without one,
+// and returns the context with the user's info
+func authenticate(ctx context.Context, authService *AuthService, fullMethod string, allowedMethods []string) (context.Context, error) {
+	if strings.HasPrefix(fullMethod, "/grpc.health.v1.Health/") { // Skip health checks
+		return ctx, nil
+	}
+
+	// Check if method is allowed
+	for _, method := range allowedMethods {
+		if method == getMethodName(fullMethod) {
+			return ctx, nil
 		}
+	}
 
-		// Add user info to context
-		ctx = WithUserID(ctx, resp.UserId)
-		ctx = WithUserEmail(ctx, resp.Email)
+	// Extract token from metadata
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		l.Warn("Missing metadata", l.String("request_id", r.GetRequestID(ctx)))
+		return nil, status.Error(codes.Unauthenticated, "missing metadata")
+	}
 
-		// Call the handler
-		return handler(ctx, req)
+	tokens := md.Get(authorizationKey)
+	if len(tokens) == 0 {
+		l.Warn("Missing authorization token", l.String("request_id", r.GetRequestID(ctx)))
+		return nil, status.Error(codes.Unauthenticated, "missing authorization token")
 	}
+
+	// Verify token with auth service
+	authCtx, err := authService.Authenticate(ctx, tokens[0])
+	if err != nil {
+		l.Warn("Token verification failed",
+			l.String("request_id", r.GetRequestID(ctx)),
+			l.ErrField(err))
+		return nil, status.Error(codes.Unauthenticated, "invalid token")
+	}
+	return authCtx, nil
+}
+
+// Authenticate verifies a token with the auth service, and returns the context with the user's info.
+// Servers that do not receive the token as gRPC metadata call it directly.
+func (s *AuthService) Authenticate(ctx context.Context, token string) (context.Context, error) {
+	resp, err := s.authClient.VerifyToken(ctx, &pb.VerifyTokenRequest{
+		Token: token,
+	})
+	if err != nil {
+		return nil, e.Wrap("VerifyToken failed", err)
+	}
+
+	// Add user info to context
+	ctx = WithUserID(ctx, resp.UserId)
+	ctx = WithUserEmail(ctx, resp.Email)
+	return ctx, nil
 }
 
 // Helpers
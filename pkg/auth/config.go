@@ -1,37 +1,58 @@
 package auth
 
 import (
+	ce "github.com/BwezB/Wikno-backend/pkg/certs"
 	c "github.com/BwezB/Wikno-backend/pkg/configs"
 	"strconv"
 )
 
 type AuthConfig struct {
-	Host string `yaml:"host" validate:"required,hostname"`
-	Port int `yaml:"port" validate:"required,min=1,max=65535"`
+	Host string             `yaml:"host" validate:"required,hostname"`
+	Port int                `yaml:"port" validate:"required,min=1,max=65535"`
+	TLS  ce.ClientTLSConfig `yaml:"tls"`
 }
 
 func (a *AuthConfig) SetDefaults() {
 	a.Host = "localhost"
 	a.Port = 50051
+	a.TLS.SetDefaults()
 }
 
 func (a *AuthConfig) AddFromEnv() {
 	c.SetEnvValue(&a.Host, "AUTH_HOST")
 	c.SetEnvValue(&a.Port, "AUTH_PORT")
+	c.SetEnvValue(&a.TLS.Mode, "AUTH_TLS_MODE")
+	c.SetEnvValue(&a.TLS.CAFile, "AUTH_TLS_CA_FILE")
+	c.SetEnvValue(&a.TLS.CertFile, "AUTH_TLS_CERT_FILE")
+	c.SetEnvValue(&a.TLS.KeyFile, "AUTH_TLS_KEY_FILE")
+	c.SetEnvValue(&a.TLS.ServerName, "AUTH_TLS_SERVER_NAME")
+	c.SetEnvValue(&a.TLS.DevDir, "AUTH_TLS_DEV_DIR")
 }
 
 var (
-	flagAuthHost = c.NewFlag("auth-host", "", "Auth Host")
-	flagAuthPort = c.NewFlag("auth-port", "", "Auth Port")
+	flagAuthHost          = c.NewFlag("auth-host", "", "Auth Host")
+	flagAuthPort          = c.NewFlag("auth-port", "", "Auth Port")
+	flagAuthTLSMode       = c.NewFlag("auth-tls-mode", "", "TLS mode of the auth connection: disabled, files or dev")
+	flagAuthTLSCAFile     = c.NewFlag("auth-tls-ca-file", "", "CA file of the auth service certificate")
+	flagAuthTLSCertFile   = c.NewFlag("auth-tls-cert-file", "", "Client certificate file for the auth service")
+	flagAuthTLSKeyFile    = c.NewFlag("auth-tls-key-file", "", "Client key file for the auth service")
+	flagAuthTLSServerName = c.NewFlag("auth-tls-server-name", "", "Name to verify the auth service certificate against")
+	flagAuthTLSDevDir     = c.NewFlag("auth-tls-dev-dir", "", "Directory of the dev CA")
 )
+
 func (a *AuthConfig) AddFromFlags() {
 	c.SetFlagValue(&a.Host, flagAuthHost)
 	c.SetFlagValue(&a.Port, flagAuthPort)
+	c.SetFlagValue(&a.TLS.Mode, flagAuthTLSMode)
+	c.SetFlagValue(&a.TLS.CAFile, flagAuthTLSCAFile)
+	c.SetFlagValue(&a.TLS.CertFile, flagAuthTLSCertFile)
+	c.SetFlagValue(&a.TLS.KeyFile, flagAuthTLSKeyFile)
+	c.SetFlagValue(&a.TLS.ServerName, flagAuthTLSServerName)
+	c.SetFlagValue(&a.TLS.DevDir, flagAuthTLSDevDir)
 }
 
-
 // HELPER FUNCTIONS
 
 func (a *AuthConfig) GetAddress() string {
 	return a.Host + ":" + strconv.Itoa(a.Port)
-}
\ No newline at end of file
+}
@@ -0,0 +1,108 @@
+package certs
+
+import (
+	"strings"
+
+	c "github.com/BwezB/Wikno-backend/pkg/configs"
+)
+
+// Modes of a TLS config
+const (
+	// ModeDisabled uses plaintext connections
+	ModeDisabled = "disabled"
+	// ModeFiles uses the certificates in the configured files, reloading them when they change
+	ModeFiles = "files"
+	// ModeDev uses certificates issued at startup by a self-signed CA kept in the dev directory.
+	// Every service started with the same dev directory trusts the others. Not for production.
+	ModeDev = "dev"
+)
+
+// ServerTLSConfig is the TLS config of a gRPC listener
+type ServerTLSConfig struct {
+	Mode     string `yaml:"mode" validate:"oneof=disabled files dev"`
+	CertFile string `yaml:"cert_file" validate:"required_if=Mode files"`
+	KeyFile  string `yaml:"key_file" validate:"required_if=Mode files"`
+	// RequireClientCert turns on mTLS: clients must present a certificate issued by the client CA
+	RequireClientCert bool `yaml:"require_client_cert"`
+	// ClientCAFile is the CA that issues client certificates. In dev mode the dev CA is used
+	ClientCAFile string `yaml:"client_ca_file" validate:"required_if=Mode files RequireClientCert true"`
+	// DevDir is where the dev CA is kept
+	DevDir string `yaml:"dev_dir" validate:"required_if=Mode dev"`
+	// DevHosts are the comma separated names the dev certificate is issued for, besides localhost
+	DevHosts string `yaml:"dev_hosts"`
+}
+
+func (s *ServerTLSConfig) SetDefaults() {
+	s.Mode = ModeDisabled
+	s.CertFile = ""
+	s.KeyFile = ""
+	s.RequireClientCert = false
+	s.ClientCAFile = ""
+	s.DevDir = ".dev-certs"
+	s.DevHosts = ""
+}
+
+func (s *ServerTLSConfig) AddFromEnv() {
+	c.SetEnvValue(&s.Mode, "TLS_MODE")
+	c.SetEnvValue(&s.CertFile, "TLS_CERT_FILE")
+	c.SetEnvValue(&s.KeyFile, "TLS_KEY_FILE")
+	c.SetEnvValue(&s.RequireClientCert, "TLS_REQUIRE_CLIENT_CERT")
+	c.SetEnvValue(&s.ClientCAFile, "TLS_CLIENT_CA_FILE")
+	c.SetEnvValue(&s.DevDir, "TLS_DEV_DIR")
+	c.SetEnvValue(&s.DevHosts, "TLS_DEV_HOSTS")
+}
+
+var (
+	flagTLSMode              = c.NewFlag("tls-mode", "", "TLS mode of the gRPC server: disabled, files or dev")
+	flagTLSCertFile          = c.NewFlag("tls-cert-file", "", "TLS certificate file of the gRPC server")
+	flagTLSKeyFile           = c.NewFlag("tls-key-file", "", "TLS key file of the gRPC server")
+	flagTLSRequireClientCert = c.NewFlag("tls-require-client-cert", "", "Require client certificates (mTLS)")
+	flagTLSClientCAFile      = c.NewFlag("tls-client-ca-file", "", "CA file of the client certificates")
+	flagTLSDevDir            = c.NewFlag("tls-dev-dir", "", "Directory of the dev CA")
+	flagTLSDevHosts          = c.NewFlag("tls-dev-hosts", "", "Comma separated names of the dev certificate")
+)
+
+func (s *ServerTLSConfig) AddFromFlags() {
+	c.SetFlagValue(&s.Mode, flagTLSMode)
+	c.SetFlagValue(&s.CertFile, flagTLSCertFile)
+	c.SetFlagValue(&s.KeyFile, flagTLSKeyFile)
+	c.SetFlagValue(&s.RequireClientCert, flagTLSRequireClientCert)
+	c.SetFlagValue(&s.ClientCAFile, flagTLSClientCAFile)
+	c.SetFlagValue(&s.DevDir, flagTLSDevDir)
+	c.SetFlagValue(&s.DevHosts, flagTLSDevHosts)
+}
+
+// GetDevHosts returns the names of the dev certificate
+func (s *ServerTLSConfig) GetDevHosts() []string {
+	hosts := []string{"localhost", "127.0.0.1", "::1"}
+	for _, host := range strings.Split(s.DevHosts, ",") {
+		if host = strings.TrimSpace(host); host != "" {
+			hosts = append(hosts, host)
+		}
+	}
+	return hosts
+}
+
+// ClientTLSConfig is the TLS config of a connection to a service.
+// Its env values and flags are added by the config of the connection, as they are prefixed with the service name.
+type ClientTLSConfig struct {
+	Mode string `yaml:"mode" validate:"oneof=disabled files dev"`
+	// CAFile is the CA that issues the server certificate. Empty uses the system roots
+	CAFile string `yaml:"ca_file"`
+	// CertFile and KeyFile are the client certificate, for servers that require mTLS
+	CertFile string `yaml:"cert_file" validate:"required_with=KeyFile"`
+	KeyFile  string `yaml:"key_file" validate:"required_with=CertFile"`
+	// ServerName overrides the name the server certificate is verified against, which is the host by default
+	ServerName string `yaml:"server_name"`
+	// DevDir is where the dev CA is kept
+	DevDir string `yaml:"dev_dir" validate:"required_if=Mode dev"`
+}
+
+func (cc *ClientTLSConfig) SetDefaults() {
+	cc.Mode = ModeDisabled
+	cc.CAFile = ""
+	cc.CertFile = ""
+	cc.KeyFile = ""
+	cc.ServerName = ""
+	cc.DevDir = ".dev-certs"
+}
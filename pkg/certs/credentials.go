@@ -0,0 +1,129 @@
+// Package certs provides the transport credentials of the gRPC servers and of the connections between the services:
+// plaintext, TLS or mTLS from certificate files that are reloaded when they change, or certificates issued at
+// startup by a self-signed dev CA.
+package certs
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+
+	e "github.com/BwezB/Wikno-backend/pkg/errors"
+	l "github.com/BwezB/Wikno-backend/pkg/log"
+)
+
+// ServerCredentials returns the transport credentials of a gRPC server
+func ServerCredentials(tlsConfig *tls.Config) credentials.TransportCredentials {
+	if tlsConfig == nil {
+		l.Warn("TLS is disabled, the gRPC server accepts plaintext connections")
+		return insecure.NewCredentials()
+	}
+	return credentials.NewTLS(tlsConfig)
+}
+
+// ServerTLS returns the TLS config of a server, or nil if TLS is disabled
+func ServerTLS(config ServerTLSConfig) (*tls.Config, error) {
+	var getCertificate func() *tls.Certificate
+	var getClientCAs func() *x509.CertPool
+
+	switch config.Mode {
+	case ModeDisabled:
+		return nil, nil
+
+	case ModeFiles:
+		caFile := ""
+		if config.RequireClientCert {
+			caFile = config.ClientCAFile
+		}
+		r, err := newReloader(config.CertFile, config.KeyFile, caFile)
+		if err != nil {
+			return nil, e.Wrap("failed to load server certificate", err)
+		}
+		getCertificate, getClientCAs = r.getCertificate, r.getPool
+
+	case ModeDev:
+		ca, err := loadOrCreateDevCA(config.DevDir)
+		if err != nil {
+			return nil, e.Wrap("failed to load dev CA", err)
+		}
+		cert, err := ca.issue(config.GetDevHosts())
+		if err != nil {
+			return nil, err
+		}
+		pool := ca.pool()
+		getCertificate = func() *tls.Certificate { return cert }
+		getClientCAs = func() *x509.CertPool { return pool }
+		l.Warn("TLS is in dev mode, do not use it in production", l.String("dev_dir", config.DevDir))
+
+	default:
+		return nil, e.New("unknown TLS mode "+config.Mode, e.ErrInvalidFunctionArgument, nil)
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		NextProtos: []string{"h2"},
+		GetCertificate: func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+			return getCertificate(), nil
+		},
+	}
+	if config.RequireClientCert {
+		// The client CAs are looked up per connection, so a reloaded CA file applies to new connections
+		tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+		tlsConfig.GetConfigForClient = func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			perClient := tlsConfig.Clone()
+			perClient.GetConfigForClient = nil
+			perClient.ClientCAs = getClientCAs()
+			return perClient, nil
+		}
+	}
+	return tlsConfig, nil
+}
+
+// ClientCredentials returns the transport credentials of a connection to a service
+func ClientCredentials(config ClientTLSConfig) (credentials.TransportCredentials, error) {
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ServerName: config.ServerName,
+	}
+
+	switch config.Mode {
+	case ModeDisabled:
+		return insecure.NewCredentials(), nil
+
+	case ModeFiles:
+		if config.CAFile != "" {
+			pool, err := loadPool(config.CAFile)
+			if err != nil {
+				return nil, e.Wrap("failed to load CA", err)
+			}
+			tlsConfig.RootCAs = pool
+		}
+		if config.CertFile != "" {
+			r, err := newReloader(config.CertFile, config.KeyFile, "")
+			if err != nil {
+				return nil, e.Wrap("failed to load client certificate", err)
+			}
+			tlsConfig.GetClientCertificate = func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+				return r.getCertificate(), nil
+			}
+		}
+
+	case ModeDev:
+		ca, err := loadOrCreateDevCA(config.DevDir)
+		if err != nil {
+			return nil, e.Wrap("failed to load dev CA", err)
+		}
+		cert, err := ca.issue([]string{"localhost"})
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.RootCAs = ca.pool()
+		tlsConfig.Certificates = []tls.Certificate{*cert}
+
+	default:
+		return nil, e.New("unknown TLS mode "+config.Mode, e.ErrInvalidFunctionArgument, nil)
+	}
+	return credentials.NewTLS(tlsConfig), nil
+}
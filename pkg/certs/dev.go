@@ -0,0 +1,191 @@
+package certs
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+
+	e "github.com/BwezB/Wikno-backend/pkg/errors"
+	l "github.com/BwezB/Wikno-backend/pkg/log"
+)
+
+const (
+	devCAFile    = "ca.pem"
+	devCAKeyFile = "ca-key.pem"
+	// devCAValidity is how long a dev CA is valid. Delete the dev directory to get a new one
+	devCAValidity = 365 * 24 * time.Hour
+	// devCertValidity is how long the certificates issued at startup are valid
+	devCertValidity = 30 * 24 * time.Hour
+)
+
+// devCA is the self-signed CA of dev mode
+type devCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+// loadOrCreateDevCA loads the dev CA from the directory, creating it first if there is none.
+// Services started at the same time may both try to create it; the one that loses loads the winner's.
+func loadOrCreateDevCA(dir string) (*devCA, error) {
+	ca, err := loadDevCA(dir)
+	if err == nil {
+		return ca, nil
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+
+	l.Info("Creating dev CA", l.String("dir", dir))
+	ca, err = createDevCA(dir)
+	if errors.Is(err, os.ErrExist) {
+		time.Sleep(100 * time.Millisecond) // Let the other service finish writing it
+		return loadDevCA(dir)
+	}
+	return ca, err
+}
+
+func loadDevCA(dir string) (*devCA, error) {
+	certPEM, err := os.ReadFile(filepath.Join(dir, devCAFile))
+	if err != nil {
+		return nil, err
+	}
+	keyPEM, err := os.ReadFile(filepath.Join(dir, devCAKeyFile))
+	if err != nil {
+		return nil, err
+	}
+
+	certBlock, _ := pem.Decode(certPEM)
+	keyBlock, _ := pem.Decode(keyPEM)
+	if certBlock == nil || keyBlock == nil {
+		return nil, e.New("invalid dev CA in "+dir, e.ErrInternal, nil)
+	}
+	cert, err := x509.ParseCertificate(certBlock.Bytes)
+	if err != nil {
+		return nil, e.Wrap("failed to parse dev CA certificate", err)
+	}
+	key, err := x509.ParseECPrivateKey(keyBlock.Bytes)
+	if err != nil {
+		return nil, e.Wrap("failed to parse dev CA key", err)
+	}
+	return &devCA{cert: cert, key: key}, nil
+}
+
+func createDevCA(dir string) (*devCA, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, e.Wrap("failed to generate dev CA key", err)
+	}
+	serial, err := newSerialNumber()
+	if err != nil {
+		return nil, err
+	}
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{Organization: []string{"Wikno"}, CommonName: "Wikno dev CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(devCAValidity),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return nil, e.Wrap("failed to create dev CA certificate", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, e.Wrap("failed to parse dev CA certificate", err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, e.Wrap("failed to marshal dev CA key", err)
+	}
+
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, e.Wrap("failed to create dev CA directory", err)
+	}
+	// The key is written first, so a CA certificate is only there once its key is
+	if err := writePEM(filepath.Join(dir, devCAKeyFile), "EC PRIVATE KEY", keyDER, 0o600); err != nil {
+		return nil, err
+	}
+	if err := writePEM(filepath.Join(dir, devCAFile), "CERTIFICATE", der, 0o644); err != nil {
+		return nil, err
+	}
+	return &devCA{cert: cert, key: key}, nil
+}
+
+// issue returns a certificate for the hosts, usable by both servers and clients
+func (ca *devCA) issue(hosts []string) (*tls.Certificate, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, e.Wrap("failed to generate dev certificate key", err)
+	}
+	serial, err := newSerialNumber()
+	if err != nil {
+		return nil, err
+	}
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{Organization: []string{"Wikno"}, CommonName: hosts[0]},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(devCertValidity),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	for _, host := range hosts {
+		if ip := net.ParseIP(host); ip != nil {
+			template.IPAddresses = append(template.IPAddresses, ip)
+		} else {
+			template.DNSNames = append(template.DNSNames, host)
+		}
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+	if err != nil {
+		return nil, e.Wrap("failed to create dev certificate", err)
+	}
+	return &tls.Certificate{
+		Certificate: [][]byte{der, ca.cert.Raw},
+		PrivateKey:  key,
+	}, nil
+}
+
+// pool returns a pool with only the dev CA
+func (ca *devCA) pool() *x509.CertPool {
+	pool := x509.NewCertPool()
+	pool.AddCert(ca.cert)
+	return pool
+}
+
+// HELPER FUNCTIONS
+
+func newSerialNumber() (*big.Int, error) {
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, e.Wrap("failed to generate serial number", err)
+	}
+	return serial, nil
+}
+
+// writePEM writes a PEM block to a file that must not exist yet
+func writePEM(path, blockType string, der []byte, perm os.FileMode) error {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, perm)
+	if err != nil {
+		return err // Not wrapped, so callers can check for os.ErrExist
+	}
+	defer file.Close()
+	if err := pem.Encode(file, &pem.Block{Type: blockType, Bytes: der}); err != nil {
+		return e.Wrap("failed to write "+path, err)
+	}
+	return nil
+}
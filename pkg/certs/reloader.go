@@ -0,0 +1,128 @@
+package certs
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"os"
+	"sync/atomic"
+	"time"
+
+	e "github.com/BwezB/Wikno-backend/pkg/errors"
+	l "github.com/BwezB/Wikno-backend/pkg/log"
+)
+
+// reloadInterval is how often the certificate files are checked for changes
+const reloadInterval = 10 * time.Second
+
+// reloader keeps a certificate and CA pool loaded from files, and loads them again when the files change,
+// so renewed certificates are used without a restart. Files that fail to load keep the previous ones in use.
+type reloader struct {
+	certFile string
+	keyFile  string
+	caFile   string // Optional
+
+	cert    atomic.Pointer[tls.Certificate]
+	pool    atomic.Pointer[x509.CertPool]
+	modTime time.Time
+}
+
+func newReloader(certFile, keyFile, caFile string) (*reloader, error) {
+	r := &reloader{
+		certFile: certFile,
+		keyFile:  keyFile,
+		caFile:   caFile,
+	}
+	if err := r.load(); err != nil {
+		return nil, err
+	}
+	go r.watch()
+	return r, nil
+}
+
+// getCertificate returns the current certificate
+func (r *reloader) getCertificate() *tls.Certificate {
+	return r.cert.Load()
+}
+
+// getPool returns the current CA pool, or nil if there is no CA file
+func (r *reloader) getPool() *x509.CertPool {
+	return r.pool.Load()
+}
+
+func (r *reloader) load() error {
+	modTime, err := r.lastModified()
+	if err != nil {
+		return err
+	}
+
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return e.Wrap("failed to load certificate", err)
+	}
+	if r.caFile != "" {
+		pool, err := loadPool(r.caFile)
+		if err != nil {
+			return err
+		}
+		r.pool.Store(pool)
+	}
+	r.cert.Store(&cert)
+	r.modTime = modTime
+	return nil
+}
+
+// watch loads the files again whenever they were modified since the last load
+func (r *reloader) watch() {
+	ticker := time.NewTicker(reloadInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		modTime, err := r.lastModified()
+		if err != nil {
+			l.Warn("Failed to check certificate files", l.String("cert_file", r.certFile), l.ErrField(err))
+			continue
+		}
+		if !modTime.After(r.modTime) {
+			continue
+		}
+
+		if err := r.load(); err != nil {
+			l.Warn("Failed to reload certificate, keeping the previous one",
+				l.String("cert_file", r.certFile),
+				l.ErrField(err))
+			continue
+		}
+		l.Info("Reloaded certificate", l.String("cert_file", r.certFile))
+	}
+}
+
+// lastModified returns the latest modification time of the files
+func (r *reloader) lastModified() (time.Time, error) {
+	var latest time.Time
+	for _, file := range []string{r.certFile, r.keyFile, r.caFile} {
+		if file == "" {
+			continue
+		}
+		info, err := os.Stat(file)
+		if err != nil {
+			return time.Time{}, e.Wrap("failed to stat certificate file", err)
+		}
+		if info.ModTime().After(latest) {
+			latest = info.ModTime()
+		}
+	}
+	return latest, nil
+}
+
+// loadPool returns a pool of the certificates in a PEM file
+func loadPool(caFile string) (*x509.CertPool, error) {
+	pem, err := os.ReadFile(caFile)
+	if err != nil {
+		return nil, e.Wrap("failed to read CA file", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, e.New("no certificates in CA file "+caFile, e.ErrInvalidFunctionArgument, nil)
+	}
+	return pool, nil
+}
@@ -56,9 +56,18 @@ func ParseFlags() {
 	}
 }
 
+// flags are the flags created with NewFlag, by name
+var flags = make(map[string]*string)
+
 // NewFlag creates a new flag with the given name, value, and usage.
+// Packages of different services can create the same flag, like the database flags, when the services are built
+// into one binary, as the test harness does. They share the flag, and the first usage is shown.
 func NewFlag(name, value, usage string) *string {
-	return flag.String(name, value, usage)
+	if existing, ok := flags[name]; ok {
+		return existing
+	}
+	flags[name] = flag.String(name, value, usage)
+	return flags[name]
 }
 
 // SetFlagValue sets the value of the previous value to the flag value if the flag value is not empty.
@@ -1,21 +1,48 @@
 package errors
 
 import (
-	"fmt"
 	"errors"
+	"fmt"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap/zapcore"
 )
 
+// maxStackDepth is the number of frames kept of a stack trace
+const maxStackDepth = 32
+
+// unknownCode is the code of errors created without an error type
+const unknownCode = "UNKNOWN_ERROR"
+
 // Error struct
 type AppError struct {
 	Code string
 	Msg  string
 	Err  error
+
+	// Fields are keys and values that describe the error, in the order they were added
+	Fields []Field
+
+	// stack is where an internal error was created, nil for other errors
+	// and if the wrapped error already has a stack
+	stack []uintptr
+}
+
+// Field is a key and value that describes an error, like the ID of the resource it is about
+type Field struct {
+	Key   string
+	Value interface{}
 }
 
 func (e *AppError) Error() string {
 	if e.Err == nil {
 		return e.Msg
 	}
+	if e.Msg == "" {
+		return e.Err.Error() // Only adds fields to the wrapped error
+	}
 	return fmt.Sprintf("%s: %s", e.Msg, e.Err.Error())
 }
 
@@ -31,6 +58,42 @@ func (e *AppError) Is(target error) bool {
 	return e.Code == t.Code
 }
 
+// StackTrace returns where the internal error in the chain was created, one "function file:line" per frame,
+// or "" if there is none.
+func (e *AppError) StackTrace() string {
+	stack := e.origin()
+	if stack == nil {
+		return ""
+	}
+
+	var trace strings.Builder
+	frames := runtime.CallersFrames(stack)
+	for {
+		frame, more := frames.Next()
+		trace.WriteString(frame.Function + " " + frame.File + ":" + strconv.Itoa(frame.Line) + "\n")
+		if !more {
+			break
+		}
+	}
+	return strings.TrimSuffix(trace.String(), "\n")
+}
+
+// MarshalLogObject logs the error with zap: its message, code and fields, the chain of wrapped errors with theirs,
+// and where it was created.
+func (e *AppError) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddString("message", e.Error())
+	if e.Code != "" {
+		enc.AddString("code", e.Code)
+	}
+	if err := enc.AddArray("chain", chain{e}); err != nil {
+		return err
+	}
+	if trace := e.StackTrace(); trace != "" {
+		enc.AddString("stack", trace)
+	}
+	return nil
+}
+
 
 // FUNCTIONS FOR CREATING ERRORS
 
@@ -46,26 +109,60 @@ func NewErrorType(code, default_msg string) *AppError {
 // New returns a new checkable error.
 // External errors should be wrapped with this function.
 // If message is empty, the default message of the error type is used.
+// Internal and unknown errors get the stack trace of where they were created.
 func New(msg string, errorType *AppError, err error) error {
 	if errorType == nil {
-		errorType = NewErrorType("UNKNOWN_ERROR", "An unknown error occurred")
+		errorType = NewErrorType(unknownCode, "An unknown error occurred")
 	}
 	if msg == "" {
 		msg = errorType.Msg
 	}
-	return &AppError{
+	appErr := &AppError{
 		Code: errorType.Code,
 		Msg:  msg,
 		Err:  err,
 	}
+	if errorType.Code == ErrInternal.Code || errorType.Code == unknownCode {
+		appErr.stack = callers(err) // Expected errors, like missing records, are not worth the cost
+	}
+	return appErr
 }
 
 // Wrap adds context to existing errors.
+// The error keeps the code of the wrapped error, so it can be logged and checked with its category.
 func Wrap(msg string, err error) error {
 	return &AppError{
-		Msg: msg,
-		Err: err,
+		Code: CodeOf(err),
+		Msg:  msg,
+		Err:  err,
+	}
+}
+
+// WithFields returns the error with fields added from alternating keys and values.
+// AppErrors are copied with the fields, other errors are wrapped.
+func WithFields(err error, keysAndValues ...interface{}) error {
+	if err == nil {
+		return nil
+	}
+
+	appErr, ok := err.(*AppError)
+	if ok {
+		copied := *appErr
+		copied.Fields = append([]Field(nil), appErr.Fields...)
+		appErr = &copied
+	} else {
+		appErr = &AppError{Err: err}
+	}
+
+	for i := 0; i < len(keysAndValues); i += 2 {
+		key := fmt.Sprint(keysAndValues[i])
+		var value interface{} = "(missing value)"
+		if i+1 < len(keysAndValues) {
+			value = keysAndValues[i+1]
+		}
+		appErr.Fields = append(appErr.Fields, Field{Key: key, Value: value})
 	}
+	return appErr
 }
 
 func Is(err, target error) bool {
@@ -74,4 +171,97 @@ func Is(err, target error) bool {
 
 func As(err error, target interface{}) bool {
 	return errors.As(err, target)
-}
\ No newline at end of file
+}
+
+// CodeOf returns the code of the outermost AppError with one in the chain of the error, "" if there is none
+func CodeOf(err error) string {
+	for err != nil {
+		if appErr, ok := err.(*AppError); ok && appErr.Code != "" {
+			return appErr.Code
+		}
+		err = errors.Unwrap(err)
+	}
+	return ""
+}
+
+// FieldsOf returns the fields of all AppErrors in the chain of the error, the outermost first
+func FieldsOf(err error) []Field {
+	var fields []Field
+	for err != nil {
+		if appErr, ok := err.(*AppError); ok {
+			fields = append(fields, appErr.Fields...)
+		}
+		err = errors.Unwrap(err)
+	}
+	return fields
+}
+
+
+// HELPER FUNCTIONS
+
+// callers returns the stack of the caller of New,
+// or nil if the wrapped error already has a stack, which is closer to where the problem is
+func callers(wrapped error) []uintptr {
+	for err := wrapped; err != nil; err = errors.Unwrap(err) {
+		if appErr, ok := err.(*AppError); ok && appErr.stack != nil {
+			return nil
+		}
+	}
+
+	pcs := make([]uintptr, maxStackDepth)
+	n := runtime.Callers(3, pcs) // Skip runtime.Callers, callers and New
+	return pcs[:n]
+}
+
+// origin returns the stack of the innermost AppError with one
+func (e *AppError) origin() []uintptr {
+	var stack []uintptr
+	var err error = e
+	for err != nil {
+		if appErr, ok := err.(*AppError); ok && appErr.stack != nil {
+			stack = appErr.stack
+		}
+		err = errors.Unwrap(err)
+	}
+	return stack
+}
+
+// chain logs the errors of a chain, each with its own message, code and fields
+type chain struct {
+	err error
+}
+
+func (c chain) MarshalLogArray(enc zapcore.ArrayEncoder) error {
+	for err := c.err; err != nil; err = errors.Unwrap(err) {
+		if err := enc.AppendObject(link{err}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// link logs one error of a chain, without the errors it wraps
+type link struct {
+	err error
+}
+
+func (l link) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	appErr, ok := l.err.(*AppError)
+	if !ok {
+		enc.AddString("message", l.err.Error())
+		return nil
+	}
+
+	if appErr.Msg != "" {
+		enc.AddString("message", appErr.Msg)
+	}
+	if appErr.Code != "" {
+		enc.AddString("code", appErr.Code)
+	}
+	for _, field := range appErr.Fields {
+		if err := enc.AddReflected(field.Key, field.Value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,179 @@
+package errors
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap/zapcore"
+)
+
+var errMissing = NewErrorType("MISSING", "Missing")
+
+// newInternal creates an internal error, so its stack trace starts here
+func newInternal() error {
+	return New("Query failed", ErrInternal, errors.New("connection reset"))
+}
+
+func TestStackTrace(t *testing.T) {
+	t.Run("Internal Errors", func(t *testing.T) {
+		err := newInternal().(*AppError)
+		trace := err.StackTrace()
+		if !strings.HasPrefix(trace, "github.com/BwezB/Wikno-backend/pkg/errors.newInternal ") {
+			t.Errorf("Expected the trace to start where the error was created, got:\n%s", trace)
+		}
+		if !strings.Contains(trace, "errors_test.go:") {
+			t.Errorf("Expected the file and line of the frames, got:\n%s", trace)
+		}
+	})
+
+	t.Run("Unknown Errors", func(t *testing.T) {
+		err := New("Failed", nil, nil).(*AppError)
+		if err.Code != unknownCode || err.StackTrace() == "" {
+			t.Errorf("Expected an unknown error with a stack trace, got %q:\n%s", err.Code, err.StackTrace())
+		}
+	})
+
+	t.Run("Expected Errors", func(t *testing.T) {
+		err := New("Entity not found", errMissing, nil).(*AppError)
+		if err.stack != nil || err.StackTrace() != "" {
+			t.Errorf("Expected no stack trace, got:\n%s", err.StackTrace())
+		}
+	})
+
+	t.Run("Wrapped Errors", func(t *testing.T) {
+		inner := newInternal().(*AppError)
+		wrapped := Wrap("GetEntity failed", inner).(*AppError)
+		if wrapped.stack != nil {
+			t.Errorf("Expected Wrap not to capture a stack")
+		}
+		if wrapped.StackTrace() != inner.StackTrace() {
+			t.Errorf("Expected the stack trace of the wrapped error, got:\n%s", wrapped.StackTrace())
+		}
+
+		again := New("Request failed", ErrInternal, wrapped).(*AppError)
+		if again.stack != nil || again.StackTrace() != inner.StackTrace() {
+			t.Errorf("Expected only the innermost stack to be kept, got:\n%s", again.StackTrace())
+		}
+	})
+}
+
+func TestWrap(t *testing.T) {
+	t.Run("Inherits Code", func(t *testing.T) {
+		err := Wrap("Outer", Wrap("Inner", New("", errMissing, nil)))
+		if CodeOf(err) != "MISSING" {
+			t.Errorf("Expected the code MISSING, got %q", CodeOf(err))
+		}
+		if !Is(err, errMissing) {
+			t.Errorf("Expected the wrapped error to be of its type")
+		}
+		if err.Error() != "Outer: Inner: Missing" {
+			t.Errorf("Expected the messages of the chain, got %q", err.Error())
+		}
+	})
+
+	t.Run("Outermost Code", func(t *testing.T) {
+		err := New("Lookup failed", ErrInternal, New("", errMissing, nil))
+		if code := CodeOf(Wrap("Outer", err)); code != ErrInternal.Code {
+			t.Errorf("Expected the outermost code, got %q", code)
+		}
+	})
+
+	t.Run("External Errors", func(t *testing.T) {
+		if code := CodeOf(Wrap("Outer", errors.New("external"))); code != "" {
+			t.Errorf("Expected no code, got %q", code)
+		}
+		if code := CodeOf(nil); code != "" {
+			t.Errorf("Expected no code of nil, got %q", code)
+		}
+	})
+}
+
+func TestWithFields(t *testing.T) {
+	t.Run("Copies AppErrors", func(t *testing.T) {
+		original := New("Entity not found", errMissing, nil)
+		err := WithFields(original, "entity_id", "a", "attempt", 2)
+
+		expected := []Field{{Key: "entity_id", Value: "a"}, {Key: "attempt", Value: 2}}
+		if !reflect.DeepEqual(FieldsOf(err), expected) {
+			t.Errorf("Expected %v, got %v", expected, FieldsOf(err))
+		}
+		if len(FieldsOf(original)) != 0 {
+			t.Errorf("Expected the original error to be unchanged, got %v", FieldsOf(original))
+		}
+		if !Is(err, errMissing) || err.Error() != original.Error() {
+			t.Errorf("Expected the same error with fields, got %v", err)
+		}
+	})
+
+	t.Run("Wraps Other Errors", func(t *testing.T) {
+		external := errors.New("external")
+		err := WithFields(external, "url", "https://example.com")
+		if !Is(err, external) || err.Error() != "external" {
+			t.Errorf("Expected the wrapped error with its message, got %v", err)
+		}
+		if CodeOf(err) != "" {
+			t.Errorf("Expected no code, got %q", CodeOf(err))
+		}
+	})
+
+	t.Run("Chain Order", func(t *testing.T) {
+		inner := WithFields(New("", errMissing, nil), "entity_id", "a")
+		err := WithFields(Wrap("Outer", inner), "merge_id", "m", "odd")
+
+		expected := []Field{
+			{Key: "merge_id", Value: "m"},
+			{Key: "odd", Value: "(missing value)"},
+			{Key: "entity_id", Value: "a"},
+		}
+		if !reflect.DeepEqual(FieldsOf(err), expected) {
+			t.Errorf("Expected %v, got %v", expected, FieldsOf(err))
+		}
+	})
+
+	t.Run("Nil", func(t *testing.T) {
+		if err := WithFields(nil, "key", "value"); err != nil {
+			t.Errorf("Expected nil, got %v", err)
+		}
+	})
+}
+
+func TestMarshalLogObject(t *testing.T) {
+	inner := WithFields(newInternal(), "entity_id", "a")
+	err := Wrap("GetEntity failed", inner).(*AppError)
+
+	enc := zapcore.NewMapObjectEncoder()
+	if err := err.MarshalLogObject(enc); err != nil {
+		t.Fatalf("Marshalling failed: %v", err)
+	}
+
+	if enc.Fields["message"] != "GetEntity failed: Query failed: connection reset" {
+		t.Errorf("Expected the message of the chain, got %v", enc.Fields["message"])
+	}
+	if enc.Fields["code"] != ErrInternal.Code {
+		t.Errorf("Expected the inherited code, got %v", enc.Fields["code"])
+	}
+	if enc.Fields["stack"] != err.StackTrace() || enc.Fields["stack"] == "" {
+		t.Errorf("Expected the stack trace, got %v", enc.Fields["stack"])
+	}
+
+	expected := []interface{}{
+		map[string]interface{}{"message": "GetEntity failed", "code": ErrInternal.Code},
+		map[string]interface{}{"message": "Query failed", "code": ErrInternal.Code, "entity_id": "a"},
+		map[string]interface{}{"message": "connection reset"},
+	}
+	if !reflect.DeepEqual(enc.Fields["chain"], expected) {
+		t.Errorf("Expected the chain %v, got %v", expected, enc.Fields["chain"])
+	}
+
+	t.Run("Expected Errors", func(t *testing.T) {
+		enc := zapcore.NewMapObjectEncoder()
+		if err := New("", errMissing, nil).(*AppError).MarshalLogObject(enc); err != nil {
+			t.Fatalf("Marshalling failed: %v", err)
+		}
+		if _, ok := enc.Fields["stack"]; ok {
+			t.Errorf("Expected no stack trace, got %v", enc.Fields["stack"])
+		}
+	})
+}
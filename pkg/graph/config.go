@@ -1,34 +1,55 @@
 package graph
 
 import (
+	ce "github.com/BwezB/Wikno-backend/pkg/certs"
 	c "github.com/BwezB/Wikno-backend/pkg/configs"
 )
 
 type GraphConfig struct {
 	Host string
 	Port string
+	TLS  ce.ClientTLSConfig `yaml:"tls"`
 }
 
 func (gc *GraphConfig) SetDefaults() {
 	gc.Host = "localhost"
 	gc.Port = "50052"
+	gc.TLS.SetDefaults()
 }
 
 func (gc *GraphConfig) AddFromEnv() {
 	c.SetEnvValue(&gc.Host, "GRAPH_HOST")
 	c.SetEnvValue(&gc.Port, "GRAPH_PORT")
+	c.SetEnvValue(&gc.TLS.Mode, "GRAPH_TLS_MODE")
+	c.SetEnvValue(&gc.TLS.CAFile, "GRAPH_TLS_CA_FILE")
+	c.SetEnvValue(&gc.TLS.CertFile, "GRAPH_TLS_CERT_FILE")
+	c.SetEnvValue(&gc.TLS.KeyFile, "GRAPH_TLS_KEY_FILE")
+	c.SetEnvValue(&gc.TLS.ServerName, "GRAPH_TLS_SERVER_NAME")
+	c.SetEnvValue(&gc.TLS.DevDir, "GRAPH_TLS_DEV_DIR")
 }
 
 var (
-	flagGraphHost = c.NewFlag("graph-host", "", "Graph Host")
-	flagGraphPort = c.NewFlag("graph-port", "", "Graph Port")
+	flagGraphHost          = c.NewFlag("graph-host", "", "Graph Host")
+	flagGraphPort          = c.NewFlag("graph-port", "", "Graph Port")
+	flagGraphTLSMode       = c.NewFlag("graph-tls-mode", "", "TLS mode of the graph connection: disabled, files or dev")
+	flagGraphTLSCAFile     = c.NewFlag("graph-tls-ca-file", "", "CA file of the graph service certificate")
+	flagGraphTLSCertFile   = c.NewFlag("graph-tls-cert-file", "", "Client certificate file for the graph service")
+	flagGraphTLSKeyFile    = c.NewFlag("graph-tls-key-file", "", "Client key file for the graph service")
+	flagGraphTLSServerName = c.NewFlag("graph-tls-server-name", "", "Name to verify the graph service certificate against")
+	flagGraphTLSDevDir     = c.NewFlag("graph-tls-dev-dir", "", "Directory of the dev CA")
 )
+
 func (gc *GraphConfig) AddFromFlags() {
 	c.SetFlagValue(&gc.Host, flagGraphHost)
 	c.SetFlagValue(&gc.Port, flagGraphPort)
+	c.SetFlagValue(&gc.TLS.Mode, flagGraphTLSMode)
+	c.SetFlagValue(&gc.TLS.CAFile, flagGraphTLSCAFile)
+	c.SetFlagValue(&gc.TLS.CertFile, flagGraphTLSCertFile)
+	c.SetFlagValue(&gc.TLS.KeyFile, flagGraphTLSKeyFile)
+	c.SetFlagValue(&gc.TLS.ServerName, flagGraphTLSServerName)
+	c.SetFlagValue(&gc.TLS.DevDir, flagGraphTLSDevDir)
 }
 
-
 // HELPER FUNCTIONS
 
 func (gc *GraphConfig) GetAddress() string {
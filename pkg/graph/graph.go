@@ -3,33 +3,49 @@ package graph
 import (
 	"context"
 
+	ce "github.com/BwezB/Wikno-backend/pkg/certs"
 	e "github.com/BwezB/Wikno-backend/pkg/errors"
 	l "github.com/BwezB/Wikno-backend/pkg/log"
 	a "github.com/BwezB/Wikno-backend/pkg/auth"
 
 	pb "github.com/BwezB/Wikno-backend/api/proto/graph"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
 )
 
+// GraphProvisioner creates the graph users of new users. GraphService creates them in the graph service,
+// and memory.Provisioner only remembers them, for tests without a graph service.
+type GraphProvisioner interface {
+	// CreateUser creates the graph user with the ID, authorized with the token of the calling service
+	CreateUser(id, token string) error
+}
+
 type GraphService struct {
 	graphClient pb.GraphServiceClient
 }
 
+var _ GraphProvisioner = (*GraphService)(nil)
+
 func NewGraphService(config GraphConfig) (*GraphService, error) {
 	l.Debug("Connecting to graph service", l.String("address", config.GetAddress()))
-	conn, err := grpc.Dial(config.GetAddress(), grpc.WithTransportCredentials(
-		insecure.NewCredentials()),
-	)
+	creds, err := ce.ClientCredentials(config.TLS)
+	if err != nil {
+		return nil, e.New("Failed to load graph service credentials", e.ErrConnectionFailed, err)
+	}
+	conn, err := grpc.Dial(config.GetAddress(), grpc.WithTransportCredentials(creds))
 	if err != nil {
 		return nil, e.New("Failed to connect to graph service", e.ErrConnectionFailed, err)
 	}
 
 	l.Info("Connected to graph service", l.String("address", config.GetAddress()))
 
+	return NewGraphServiceWithConn(conn), nil
+}
+
+// NewGraphServiceWithConn calls the graph service over an existing connection, like the in-process one of the test harness
+func NewGraphServiceWithConn(conn grpc.ClientConnInterface) *GraphService {
 	return &GraphService{
 		graphClient: pb.NewGraphServiceClient(conn),
-	}, nil
+	}
 }
 
 func (gs *GraphService) CreateUser(id, token string) error {
@@ -0,0 +1,60 @@
+// Package memory provisions graph users in memory, for tests without a graph service.
+package memory
+
+import (
+	"sort"
+	"sync"
+
+	e "github.com/BwezB/Wikno-backend/pkg/errors"
+	g "github.com/BwezB/Wikno-backend/pkg/graph"
+)
+
+// Provisioner is a graph.GraphProvisioner that remembers the graph users it created.
+// Like the graph service, it needs a token and refuses to create a user twice.
+type Provisioner struct {
+	mu    sync.Mutex
+	users map[string]bool
+}
+
+var _ g.GraphProvisioner = (*Provisioner)(nil)
+
+// New creates a provisioner without users.
+func New() *Provisioner {
+	return &Provisioner{users: make(map[string]bool)}
+}
+
+func (p *Provisioner) CreateUser(id, token string) error {
+	if token == "" {
+		return e.Wrap("CreateUser failed", e.New("Missing authorization token", e.ErrInvalidRequest, nil))
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.users[id] {
+		return e.Wrap("CreateUser failed", e.New("User already exists", e.ErrInvalidRequest, nil))
+	}
+	p.users[id] = true
+	return nil
+}
+
+// HasUser reports whether the graph user with the ID was created
+func (p *Provisioner) HasUser(id string) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.users[id]
+}
+
+// Users returns the IDs of the created graph users, sorted
+func (p *Provisioner) Users() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	ids := make([]string, 0, len(p.users))
+	for id := range p.users {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
@@ -0,0 +1,113 @@
+package grpcerrors
+
+import (
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	e "github.com/BwezB/Wikno-backend/pkg/errors"
+)
+
+// UnavailableRetryDelay is how long clients are asked to wait before retrying calls that failed as unavailable
+const UnavailableRetryDelay = time.Second
+
+// Resource is the resource a call is about, reported with the errors that did not find it
+type Resource struct {
+	// Type is the kind of resource, like "entity" or "webhook"
+	Type string
+	// Name is the ID of the resource
+	Name string
+}
+
+// Error returns the gRPC error with the code and message, and the details of the error:
+//   - ErrorInfo with the code of the error, in the domain of the service
+//   - BadRequest with the field violations of failed request validations
+//   - ResourceInfo with the resource of the call, if it was not found
+//   - RetryInfo if the service is unavailable
+func Error(err error, code codes.Code, message, domain string, resource *Resource) error {
+	var details []protoadapt.MessageV1
+
+	if reason := e.CodeOf(err); reason != "" {
+		details = append(details, &errdetails.ErrorInfo{Reason: reason, Domain: domain})
+	}
+
+	var validationErrors validator.ValidationErrors
+	if e.As(err, &validationErrors) {
+		badRequest := &errdetails.BadRequest{}
+		for _, fieldError := range validationErrors {
+			badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+				Field:       fieldOf(fieldError),
+				Description: describe(fieldError),
+			})
+		}
+		details = append(details, badRequest)
+	}
+
+	if code == codes.NotFound && resource != nil {
+		details = append(details, &errdetails.ResourceInfo{
+			ResourceType: resource.Type,
+			ResourceName: resource.Name,
+			Description:  resource.Type + " " + resource.Name + " not found",
+		})
+	}
+
+	if code == codes.Unavailable {
+		details = append(details, &errdetails.RetryInfo{RetryDelay: durationpb.New(UnavailableRetryDelay)})
+	}
+
+	st, detailsErr := status.New(code, message).WithDetails(details...)
+	if detailsErr != nil {
+		return status.Error(code, message)
+	}
+	return st.Err()
+}
+
+// HELPER FUNCTIONS
+
+// fieldOf returns the path of the field in the validated struct, without the struct itself
+func fieldOf(fieldError validator.FieldError) string {
+	namespace := fieldError.Namespace()
+	if i := strings.Index(namespace, "."); i >= 0 {
+		return namespace[i+1:]
+	}
+	return namespace
+}
+
+// describe describes the validation a field failed
+func describe(fieldError validator.FieldError) string {
+	param := fieldError.Param()
+	switch fieldError.Tag() {
+	case "required":
+		return "is required"
+	case "email":
+		return "must be an email address"
+	case "uuid", "uuid4":
+		return "must be a UUID"
+	case "url", "http_url":
+		return "must be a URL"
+	case "min":
+		return "must be at least " + param
+	case "max":
+		return "must be at most " + param
+	case "len":
+		return "must have length " + param
+	case "oneof":
+		return "must be one of " + param
+	case "nefield":
+		return "must differ from " + param
+	case "eqfield":
+		return "must equal " + param
+	case "gtefield":
+		return "must be at least " + param
+	}
+	if param != "" {
+		return "fails " + fieldError.Tag() + "=" + param
+	}
+	return "fails " + fieldError.Tag()
+}
@@ -0,0 +1,55 @@
+package grpcweb
+
+import (
+	"strconv"
+	"strings"
+
+	c "github.com/BwezB/Wikno-backend/pkg/configs"
+)
+
+type GrpcWebServerConfig struct {
+	Host string `yaml:"host" validate:"required"`
+	Port int    `yaml:"port" validate:"required,min=1,max=65535"`
+	// AllowedOrigins are the comma separated origins browsers may call the service from, or "*" for any.
+	// Empty allows only same origin requests.
+	AllowedOrigins string `yaml:"allowed_origins"`
+}
+
+func (gsc *GrpcWebServerConfig) SetDefaults() {
+	gsc.Host = "localhost"
+	gsc.Port = 8081
+	gsc.AllowedOrigins = ""
+}
+
+func (gsc *GrpcWebServerConfig) AddFromEnv() {
+	c.SetEnvValue(&gsc.Host, "GRPC_WEB_HOST")
+	c.SetEnvValue(&gsc.Port, "GRPC_WEB_PORT")
+	c.SetEnvValue(&gsc.AllowedOrigins, "GRPC_WEB_ALLOWED_ORIGINS")
+}
+
+var (
+	flagGrpcWebHost           = c.NewFlag("grpc-web-host", "", "gRPC-Web server host")
+	flagGrpcWebPort           = c.NewFlag("grpc-web-port", "", "gRPC-Web server port")
+	flagGrpcWebAllowedOrigins = c.NewFlag("grpc-web-allowed-origins", "", "Comma separated origins allowed to call the gRPC-Web server, or * for any")
+)
+
+func (gsc *GrpcWebServerConfig) AddFromFlags() {
+	c.SetFlagValue(&gsc.Host, flagGrpcWebHost)
+	c.SetFlagValue(&gsc.Port, flagGrpcWebPort)
+	c.SetFlagValue(&gsc.AllowedOrigins, flagGrpcWebAllowedOrigins)
+}
+
+func (gsc *GrpcWebServerConfig) GetAddress() string {
+	return gsc.Host + ":" + strconv.Itoa(gsc.Port)
+}
+
+// GetAllowedOrigins returns the allowed origins as a list, without empty entries
+func (gsc *GrpcWebServerConfig) GetAllowedOrigins() []string {
+	var origins []string
+	for _, origin := range strings.Split(gsc.AllowedOrigins, ",") {
+		if origin = strings.TrimSpace(origin); origin != "" {
+			origins = append(origins, origin)
+		}
+	}
+	return origins
+}
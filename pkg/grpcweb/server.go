@@ -0,0 +1,113 @@
+// Package grpcweb serves a gRPC server to browsers over gRPC-Web, so web clients can call the services
+// without a proxy. Calls are handed to the gRPC server unchanged, so they go through the same interceptors,
+// and the authorization and workspace-id headers arrive as the metadata of the same name.
+package grpcweb
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/improbable-eng/grpc-web/go/grpcweb"
+	"google.golang.org/grpc"
+
+	l "github.com/BwezB/Wikno-backend/pkg/log"
+)
+
+// allowedHeaders are the request headers browsers may send cross origin.
+// The grpc-web clients send the ones besides the metadata the services read.
+var allowedHeaders = []string{
+	"authorization",
+	"workspace-id",
+	"content-type",
+	"x-grpc-web",
+	"x-user-agent",
+	"grpc-timeout",
+}
+
+type GrpcWebServer struct {
+	server *http.Server
+}
+
+// NewGrpcWebServer returns a server of the gRPC server's services for browsers.
+// If the gRPC server uses TLS, its TLS config is passed so calls from browsers are encrypted with the same
+// certificate. Client certificates are not required, as browsers do not have them.
+func NewGrpcWebServer(grpcServer *grpc.Server, config GrpcWebServerConfig, tlsConfig *tls.Config) *GrpcWebServer {
+	wrapped := grpcweb.WrapServer(grpcServer,
+		grpcweb.WithOriginFunc(originFunc(config.GetAllowedOrigins())),
+		grpcweb.WithAllowedRequestHeaders(allowedHeaders),
+	)
+
+	// Only gRPC-Web calls and their preflights are served, the rest is not found
+	handler := http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if wrapped.IsGrpcWebRequest(req) || wrapped.IsAcceptableGrpcCorsRequest(req) {
+			wrapped.ServeHTTP(w, replaceForwardedFor(req))
+			return
+		}
+		http.NotFound(w, req)
+	})
+
+	server := &http.Server{
+		Addr:              config.GetAddress(),
+		Handler:           handler,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	if tlsConfig != nil {
+		server.TLSConfig = tlsConfig.Clone()
+		server.TLSConfig.ClientAuth = tls.NoClientCert
+		server.TLSConfig.GetConfigForClient = nil
+		server.TLSConfig.NextProtos = []string{"h2", "http/1.1"}
+	}
+	return &GrpcWebServer{server: server}
+}
+
+// Serve starts the gRPC-Web server in a new goroutine
+func (s *GrpcWebServer) Serve() {
+	l.Info("Starting gRPC-Web server", l.String("address", s.server.Addr))
+	go func() {
+		var err error
+		if s.server.TLSConfig != nil {
+			err = s.server.ListenAndServeTLS("", "") // The certificate is in the TLS config
+		} else {
+			err = s.server.ListenAndServe()
+		}
+		if err != nil && err != http.ErrServerClosed {
+			l.Error("gRPC-Web server error", l.ErrField(err))
+		}
+	}()
+}
+
+// Handler returns the handler of gRPC-Web calls, to serve them on a listener of the caller's
+func (s *GrpcWebServer) Handler() http.Handler {
+	return s.server.Handler
+}
+
+// Shutdown gracefully shuts down the gRPC-Web server
+func (s *GrpcWebServer) Shutdown(ctx context.Context) error {
+	return s.server.Shutdown(ctx)
+}
+
+// replaceForwardedFor sets the X-Forwarded-For header of a call to the address it came from.
+// All headers reach the services as metadata, so a client's own header would let it pick its rate limit key
+// wherever the address of the gRPC-Web server is a trusted proxy.
+func replaceForwardedFor(req *http.Request) *http.Request {
+	req = req.Clone(req.Context())
+	req.Header.Del("X-Forwarded-For")
+	if client, _, err := net.SplitHostPort(req.RemoteAddr); err == nil {
+		req.Header.Set("X-Forwarded-For", client)
+	}
+	return req
+}
+
+// originFunc returns whether an origin is allowed to call the server
+func originFunc(allowedOrigins []string) func(origin string) bool {
+	if slices.Contains(allowedOrigins, "*") {
+		return func(string) bool { return true }
+	}
+	return func(origin string) bool {
+		return slices.Contains(allowedOrigins, origin)
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"time"
 
 	"go.uber.org/zap"
+
+	e "github.com/BwezB/Wikno-backend/pkg/errors"
 )
 
 // LOGGING FUNCTIONS
@@ -56,8 +58,11 @@ func Duration(key string, val time.Duration) zap.Field {
 	return zap.Duration(key, val)
 }
 
-// ErrField: Calls zap.Error function
+// ErrField logs an error. AppErrors are logged with the chain of wrapped errors, their codes and fields, and the stack trace.
 func ErrField(err error) zap.Field {
+	if appErr, ok := err.(*e.AppError); ok {
+		return zap.Object("error", appErr)
+	}
 	return zap.Error(err)
 }
 
@@ -24,37 +24,46 @@ type MetricsService struct {
 	InFlightGauge *prometheus.GaugeVec
 	// RequestDuration is a histogram for the duration of gRPC requests for each method
 	RequestDuration *prometheus.HistogramVec
+	// ThrottledCounter is a counter for the gRPC requests refused by the rate limiter. Use "user" or "ip" as key labels
+	ThrottledCounter *prometheus.CounterVec
 }
 
 func NewMetrics(namespace string) *MetricsService {
+	// The metrics are registered with the registry of the service only, so services can run in one process
+	registry := prometheus.NewRegistry()
+	factory := promauto.With(registry)
+
 	// Create a new Metrics struct
 	metrics := &MetricsService{
-		registry: prometheus.NewRegistry(),
-		RequestCounter: promauto.NewCounterVec(prometheus.CounterOpts{
+		registry: registry,
+		RequestCounter: factory.NewCounterVec(prometheus.CounterOpts{
 			Namespace: namespace,
 			Name:      "grpc_requests_total",
 			Help:      "Total number of gRPC requests",
 		}, []string{"method", "status"}),
-		ErrorCounter: promauto.NewCounterVec(prometheus.CounterOpts{
+		ErrorCounter: factory.NewCounterVec(prometheus.CounterOpts{
 			Namespace: namespace,
 			Name:      "grpc_errors_total",
 			Help:      "Total number of gRPC errors",
 		}, []string{"method", "status"}),
-		InFlightGauge: promauto.NewGaugeVec(prometheus.GaugeOpts{
+		InFlightGauge: factory.NewGaugeVec(prometheus.GaugeOpts{
 			Namespace: namespace,
 			Name:      "grpc_requests_in_flight",
 			Help:      "Number of gRPC requests in flight",
 		}, []string{"method"}),
-		RequestDuration: promauto.NewHistogramVec(prometheus.HistogramOpts{
+		RequestDuration: factory.NewHistogramVec(prometheus.HistogramOpts{
 			Namespace: namespace,
 			Name:      "grpc_request_duration_seconds",
 			Help:      "Duration of gRPC requests in seconds",
 			Buckets:   prometheus.DefBuckets,
 		}, []string{"method"}),
+		ThrottledCounter: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "grpc_requests_throttled_total",
+			Help:      "Total number of gRPC requests refused by the rate limiter",
+		}, []string{"method", "key"}),
 	}
 
-	// Register the metrics with the default registry
-	metrics.Register(metrics.RequestCounter, metrics.ErrorCounter, metrics.RequestDuration)
 	return metrics
 }
 
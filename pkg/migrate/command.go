@@ -0,0 +1,70 @@
+package migrate
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	e "github.com/BwezB/Wikno-backend/pkg/errors"
+)
+
+// Usage describes the arguments of the migrate subcommand
+const Usage = "migrate up | down | status | to <version>"
+
+// Run runs the migrate subcommand with its arguments, writing the status of the migrations to out
+func Run(ctx context.Context, migrator *Migrator, args []string, out io.Writer) error {
+	if len(args) == 0 {
+		return e.New("Missing migrate command, usage: "+Usage, e.ErrInvalidRequest, nil)
+	}
+
+	switch args[0] {
+	case "up":
+		if err := migrator.Up(ctx); err != nil {
+			return err
+		}
+	case "down":
+		if err := migrator.Down(ctx); err != nil {
+			return err
+		}
+	case "to":
+		if len(args) != 2 {
+			return e.New("Missing version, usage: "+Usage, e.ErrInvalidRequest, nil)
+		}
+		version, err := strconv.ParseInt(args[1], 10, 64)
+		if err != nil {
+			return e.New("Invalid version "+args[1], e.ErrInvalidRequest, err)
+		}
+		if err := migrator.To(ctx, version); err != nil {
+			return err
+		}
+	case "status":
+	default:
+		return e.New("Unknown migrate command "+args[0]+", usage: "+Usage, e.ErrInvalidRequest, nil)
+	}
+
+	statuses, err := migrator.Status(ctx)
+	if err != nil {
+		return err
+	}
+	return writeStatus(out, statuses)
+}
+
+// writeStatus writes a table of the migrations and when they were applied
+func writeStatus(out io.Writer, statuses []Status) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED AT")
+	for _, status := range statuses {
+		applied := "pending"
+		if status.AppliedAt != nil {
+			applied = status.AppliedAt.UTC().Format(time.RFC3339)
+		}
+		if status.Missing {
+			applied += " (unknown to this binary)"
+		}
+		fmt.Fprintf(w, "%04d\t%s\t%s\n", status.Version, status.Name, applied)
+	}
+	return w.Flush()
+}
@@ -0,0 +1,310 @@
+package migrate
+
+import (
+	"context"
+	"hash/fnv"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"gorm.io/gorm"
+
+	e "github.com/BwezB/Wikno-backend/pkg/errors"
+	l "github.com/BwezB/Wikno-backend/pkg/log"
+)
+
+// Migration is a numbered schema change with the SQL that applies it and the SQL that reverts it
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+}
+
+// Status is a migration and when it was applied, if it was
+type Status struct {
+	Migration
+	AppliedAt *time.Time
+	// Missing is set for applied migrations that this binary does not know about
+	Missing bool
+}
+
+// Migrator applies the migrations of a service to its database. The versions applied are recorded
+// in the schema_migrations table, and an advisory lock keeps replicas from migrating at the same time.
+type Migrator struct {
+	db         *gorm.DB
+	migrations []Migration
+	lockID     int64
+}
+
+// fileName matches migration files, like 0001_create_users.up.sql
+var fileName = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+const createMigrationsTable = `CREATE TABLE IF NOT EXISTS schema_migrations (
+	version bigint PRIMARY KEY,
+	name text NOT NULL,
+	applied_at timestamptz NOT NULL DEFAULT now()
+)`
+
+// appliedMigration is a row of the schema_migrations table
+type appliedMigration struct {
+	Version   int64
+	Name      string
+	AppliedAt time.Time
+}
+
+func (appliedMigration) TableName() string {
+	return "schema_migrations"
+}
+
+// New reads the migrations in the root of files. Every version needs both an up and a down file.
+// The service names the advisory lock, so services sharing a database server do not wait on each other.
+func New(db *gorm.DB, files fs.FS, service string) (*Migrator, error) {
+	entries, err := fs.ReadDir(files, ".")
+	if err != nil {
+		return nil, e.New("Could not read migrations", e.ErrInternal, err)
+	}
+
+	byVersion := map[int64]*Migration{}
+	for _, entry := range entries {
+		match := fileName.FindStringSubmatch(entry.Name())
+		if entry.IsDir() || match == nil {
+			continue
+		}
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil || version <= 0 {
+			return nil, e.New("Invalid migration version in "+entry.Name(), e.ErrInvalidFunctionArgument, err)
+		}
+		content, err := fs.ReadFile(files, entry.Name())
+		if err != nil {
+			return nil, e.New("Could not read migration "+entry.Name(), e.ErrInternal, err)
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = migration
+		}
+		if migration.Name != match[2] {
+			return nil, e.New("Migration version "+match[1]+" is used by more than one name", e.ErrInvalidFunctionArgument, nil)
+		}
+		if match[3] == "up" {
+			migration.Up = string(content)
+		} else {
+			migration.Down = string(content)
+		}
+	}
+
+	migrator := &Migrator{db: db, lockID: lockID(service)}
+	for _, migration := range byVersion {
+		if migration.Up == "" || migration.Down == "" {
+			return nil, e.New("Migration "+strconv.FormatInt(migration.Version, 10)+" needs an up and a down file", e.ErrInvalidFunctionArgument, nil)
+		}
+		migrator.migrations = append(migrator.migrations, *migration)
+	}
+	sort.Slice(migrator.migrations, func(i, j int) bool {
+		return migrator.migrations[i].Version < migrator.migrations[j].Version
+	})
+	return migrator, nil
+}
+
+// Latest returns the version of the last migration, or 0 if there are none
+func (mi *Migrator) Latest() int64 {
+	if len(mi.migrations) == 0 {
+		return 0
+	}
+	return mi.migrations[len(mi.migrations)-1].Version
+}
+
+// Up applies all migrations that have not been applied yet, in order
+func (mi *Migrator) Up(ctx context.Context) error {
+	return mi.To(ctx, mi.Latest())
+}
+
+// Down reverts the last applied migration
+func (mi *Migrator) Down(ctx context.Context) error {
+	return mi.locked(ctx, func(conn *gorm.DB, applied map[int64]appliedMigration) error {
+		var last int64
+		for version := range applied {
+			if version > last {
+				last = version
+			}
+		}
+		if last == 0 {
+			l.Info("No migrations to revert")
+			return nil
+		}
+		migration, ok := mi.find(last)
+		if !ok {
+			return e.New("Applied migration "+strconv.FormatInt(last, 10)+" is unknown to this binary", e.ErrInvalidRequest, nil)
+		}
+		return mi.revert(conn, migration)
+	})
+}
+
+// To applies or reverts migrations until the database is at the given version.
+// Migrations after the version are reverted, newest first, and the ones up to it are applied, oldest first.
+func (mi *Migrator) To(ctx context.Context, version int64) error {
+	if version < 0 || (version > 0 && !mi.has(version)) {
+		return e.New("Unknown migration version "+strconv.FormatInt(version, 10), e.ErrInvalidRequest, nil)
+	}
+
+	return mi.locked(ctx, func(conn *gorm.DB, applied map[int64]appliedMigration) error {
+		reverts, applies, err := mi.plan(applied, version)
+		if err != nil {
+			return err
+		}
+		for _, migration := range reverts {
+			if err := mi.revert(conn, migration); err != nil {
+				return err
+			}
+		}
+		for _, migration := range applies {
+			if err := mi.apply(conn, migration); err != nil {
+				return err
+			}
+		}
+		if len(reverts)+len(applies) == 0 {
+			l.Debug("Database schema is up to date", l.Int("version", int(version)))
+		}
+		return nil
+	})
+}
+
+// Status lists the known migrations and whether they were applied, followed by applied migrations
+// that this binary does not know about
+func (mi *Migrator) Status(ctx context.Context) ([]Status, error) {
+	var statuses []Status
+	err := mi.locked(ctx, func(conn *gorm.DB, applied map[int64]appliedMigration) error {
+		for _, migration := range mi.migrations {
+			status := Status{Migration: migration}
+			if row, ok := applied[migration.Version]; ok {
+				status.AppliedAt = &row.AppliedAt
+			}
+			statuses = append(statuses, status)
+		}
+		var missing []Status
+		for version, row := range applied {
+			if !mi.has(version) {
+				missing = append(missing, Status{
+					Migration: Migration{Version: version, Name: row.Name},
+					AppliedAt: &row.AppliedAt,
+					Missing:   true,
+				})
+			}
+		}
+		sort.Slice(missing, func(i, j int) bool { return missing[i].Version < missing[j].Version })
+		statuses = append(statuses, missing...)
+		return nil
+	})
+	return statuses, err
+}
+
+// HELPER FUNCTIONS
+
+// locked runs fn on a single connection that holds the advisory lock, with the migrations applied so far
+func (mi *Migrator) locked(ctx context.Context, fn func(conn *gorm.DB, applied map[int64]appliedMigration) error) error {
+	return mi.db.WithContext(ctx).Connection(func(conn *gorm.DB) error {
+		l.Debug("Waiting for the migration lock")
+		if err := conn.Exec("SELECT pg_advisory_lock(?)", mi.lockID).Error; err != nil {
+			return e.New("Could not take the migration lock", e.ErrInternal, err)
+		}
+		defer func() {
+			// Unlock even if the context was cancelled, as the connection goes back to the pool
+			if err := conn.WithContext(context.Background()).Exec("SELECT pg_advisory_unlock(?)", mi.lockID).Error; err != nil {
+				l.Warn("Could not release the migration lock", l.ErrField(err))
+			}
+		}()
+
+		if err := conn.Exec(createMigrationsTable).Error; err != nil {
+			return e.New("Could not create the schema_migrations table", e.ErrInternal, err)
+		}
+		var rows []appliedMigration
+		if err := conn.Order("version").Find(&rows).Error; err != nil {
+			return e.New("Could not read applied migrations", e.ErrInternal, err)
+		}
+		applied := make(map[int64]appliedMigration, len(rows))
+		for _, row := range rows {
+			applied[row.Version] = row
+		}
+		return fn(conn, applied)
+	})
+}
+
+// plan returns the migrations to revert, newest first, and the ones to apply, oldest first,
+// to bring a database with the applied migrations to the version.
+// Applied migrations after the version that this binary does not know about cannot be reverted.
+func (mi *Migrator) plan(applied map[int64]appliedMigration, version int64) ([]Migration, []Migration, error) {
+	for appliedVersion := range applied {
+		if appliedVersion > version && !mi.has(appliedVersion) {
+			return nil, nil, e.New("Applied migration "+strconv.FormatInt(appliedVersion, 10)+" is unknown to this binary", e.ErrInvalidRequest, nil)
+		}
+	}
+
+	var reverts, applies []Migration
+	for i := len(mi.migrations) - 1; i >= 0; i-- {
+		migration := mi.migrations[i]
+		if _, ok := applied[migration.Version]; ok && migration.Version > version {
+			reverts = append(reverts, migration)
+		}
+	}
+	for _, migration := range mi.migrations {
+		if _, ok := applied[migration.Version]; !ok && migration.Version <= version {
+			applies = append(applies, migration)
+		}
+	}
+	return reverts, applies, nil
+}
+
+// apply runs a migration and records it in one transaction
+func (mi *Migrator) apply(conn *gorm.DB, migration Migration) error {
+	l.Info("Applying migration", l.Int("version", int(migration.Version)), l.String("name", migration.Name))
+	err := conn.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(migration.Up).Error; err != nil {
+			return err
+		}
+		return tx.Create(&appliedMigration{Version: migration.Version, Name: migration.Name, AppliedAt: time.Now()}).Error
+	})
+	if err != nil {
+		return e.New("Migration "+strconv.FormatInt(migration.Version, 10)+"_"+migration.Name+" failed", e.ErrInternal, err)
+	}
+	return nil
+}
+
+// revert reverts a migration and removes its record in one transaction
+func (mi *Migrator) revert(conn *gorm.DB, migration Migration) error {
+	l.Info("Reverting migration", l.Int("version", int(migration.Version)), l.String("name", migration.Name))
+	err := conn.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(migration.Down).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&appliedMigration{}, "version = ?", migration.Version).Error
+	})
+	if err != nil {
+		return e.New("Reverting migration "+strconv.FormatInt(migration.Version, 10)+"_"+migration.Name+" failed", e.ErrInternal, err)
+	}
+	return nil
+}
+
+func (mi *Migrator) find(version int64) (Migration, bool) {
+	for _, migration := range mi.migrations {
+		if migration.Version == version {
+			return migration, true
+		}
+	}
+	return Migration{}, false
+}
+
+func (mi *Migrator) has(version int64) bool {
+	_, ok := mi.find(version)
+	return ok
+}
+
+// lockID derives the advisory lock key of a service
+func lockID(service string) int64 {
+	hash := fnv.New64a()
+	hash.Write([]byte("schema_migrations:" + service))
+	return int64(hash.Sum64())
+}
@@ -0,0 +1,198 @@
+package migrate
+
+import (
+	"context"
+	"io/fs"
+	"testing"
+	"testing/fstest"
+
+	e "github.com/BwezB/Wikno-backend/pkg/errors"
+)
+
+// migrationFiles returns files with an up and a down migration for each of the names
+func migrationFiles(names ...string) fstest.MapFS {
+	files := fstest.MapFS{}
+	for _, name := range names {
+		files[name+".up.sql"] = &fstest.MapFile{Data: []byte("-- up " + name)}
+		files[name+".down.sql"] = &fstest.MapFile{Data: []byte("-- down " + name)}
+	}
+	return files
+}
+
+// unreadableFS is a file system whose files cannot be opened
+type unreadableFS struct{}
+
+func (unreadableFS) Open(name string) (fs.File, error) {
+	return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrPermission}
+}
+
+// versions returns the versions of migrations
+func versions(migrations []Migration) []int64 {
+	result := []int64{}
+	for _, migration := range migrations {
+		result = append(result, migration.Version)
+	}
+	return result
+}
+
+func equalVersions(a, b []int64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestNew(t *testing.T) {
+	t.Run("Parses And Orders Files", func(t *testing.T) {
+		files := migrationFiles("0010_add_index", "0002_add_column", "0001_create_users")
+		files["README.md"] = &fstest.MapFile{Data: []byte("not a migration")}
+		files["0003_notes.sql"] = &fstest.MapFile{Data: []byte("not a migration either")}
+		files["0004_nested.up.sql/file"] = &fstest.MapFile{Data: []byte("in a directory")}
+
+		migrator, err := New(nil, files, "test")
+		if err != nil {
+			t.Fatalf("Reading migrations failed: %v", err)
+		}
+		if got := versions(migrator.migrations); !equalVersions(got, []int64{1, 2, 10}) {
+			t.Errorf("Expected the versions in order, got %v", got)
+		}
+		first := migrator.migrations[0]
+		if first.Name != "create_users" || first.Up != "-- up 0001_create_users" || first.Down != "-- down 0001_create_users" {
+			t.Errorf("Unexpected migration %+v", first)
+		}
+		if migrator.Latest() != 10 {
+			t.Errorf("Expected the latest version 10, got %d", migrator.Latest())
+		}
+	})
+
+	t.Run("No Migrations", func(t *testing.T) {
+		migrator, err := New(nil, fstest.MapFS{}, "test")
+		if err != nil {
+			t.Fatalf("Reading migrations failed: %v", err)
+		}
+		if migrator.Latest() != 0 {
+			t.Errorf("Expected the latest version 0, got %d", migrator.Latest())
+		}
+	})
+
+	invalid := []struct {
+		name  string
+		files fstest.MapFS
+	}{
+		{"Missing Down", fstest.MapFS{"0001_create_users.up.sql": {Data: []byte("up")}}},
+		{"Missing Up", fstest.MapFS{"0001_create_users.down.sql": {Data: []byte("down")}}},
+		{"Empty Up", fstest.MapFS{
+			"0001_create_users.up.sql":   {Data: []byte("")},
+			"0001_create_users.down.sql": {Data: []byte("down")},
+		}},
+		{"Duplicate Name", fstest.MapFS{
+			"0001_create_users.up.sql":   {Data: []byte("up")},
+			"0001_create_users.down.sql": {Data: []byte("down")},
+			"0001_create_people.up.sql":  {Data: []byte("up")},
+		}},
+		{"Version Zero", migrationFiles("0000_create_users")},
+		{"Version Out Of Range", migrationFiles("99999999999999999999_create_users")},
+	}
+	for _, tt := range invalid {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := New(nil, tt.files, "test"); !e.Is(err, e.ErrInvalidFunctionArgument) {
+				t.Errorf("Expected the migrations to be refused, got: %v", err)
+			}
+		})
+	}
+
+	t.Run("Unreadable Directory", func(t *testing.T) {
+		if _, err := New(nil, unreadableFS{}, "test"); !e.Is(err, e.ErrInternal) {
+			t.Errorf("Expected an internal error, got: %v", err)
+		}
+	})
+}
+
+func TestPlan(t *testing.T) {
+	migrator, err := New(nil, migrationFiles("0001_a", "0002_b", "0003_c", "0004_d"), "test")
+	if err != nil {
+		t.Fatalf("Reading migrations failed: %v", err)
+	}
+	appliedUpTo := func(versions ...int64) map[int64]appliedMigration {
+		applied := map[int64]appliedMigration{}
+		for _, version := range versions {
+			applied[version] = appliedMigration{Version: version}
+		}
+		return applied
+	}
+
+	tests := []struct {
+		name    string
+		applied map[int64]appliedMigration
+		version int64
+		reverts []int64
+		applies []int64
+	}{
+		{"Fresh Database", appliedUpTo(), 4, []int64{}, []int64{1, 2, 3, 4}},
+		{"Up To Date", appliedUpTo(1, 2, 3, 4), 4, []int64{}, []int64{}},
+		{"Partly Applied", appliedUpTo(1, 2), 4, []int64{}, []int64{3, 4}},
+		{"Gap", appliedUpTo(1, 3), 4, []int64{}, []int64{2, 4}},
+		{"Revert Newest First", appliedUpTo(1, 2, 3, 4), 1, []int64{4, 3, 2}, []int64{}},
+		{"Revert All", appliedUpTo(1, 2, 3, 4), 0, []int64{4, 3, 2, 1}, []int64{}},
+		{"Revert And Apply", appliedUpTo(1, 3), 2, []int64{3}, []int64{2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reverts, applies, err := migrator.plan(tt.applied, tt.version)
+			if err != nil {
+				t.Fatalf("Planning failed: %v", err)
+			}
+			if !equalVersions(versions(reverts), tt.reverts) || !equalVersions(versions(applies), tt.applies) {
+				t.Errorf("Expected to revert %v and apply %v, got %v and %v",
+					tt.reverts, tt.applies, versions(reverts), versions(applies))
+			}
+		})
+	}
+
+	t.Run("Unknown Applied Version", func(t *testing.T) {
+		applied := appliedUpTo(1, 2, 3, 4, 5)
+		if _, _, err := migrator.plan(applied, 4); !e.Is(err, e.ErrInvalidRequest) {
+			t.Errorf("Expected an unknown applied migration to be refused, got: %v", err)
+		}
+		if _, _, err := migrator.plan(applied, 2); !e.Is(err, e.ErrInvalidRequest) {
+			t.Errorf("Expected an unknown applied migration not to be reverted, got: %v", err)
+		}
+	})
+
+	t.Run("Unknown Version Kept", func(t *testing.T) {
+		// A migration whose files were removed stays applied, as long as it need not be reverted
+		removed, err := New(nil, migrationFiles("0001_a", "0003_c"), "test")
+		if err != nil {
+			t.Fatalf("Reading migrations failed: %v", err)
+		}
+		reverts, applies, err := removed.plan(appliedUpTo(1, 2), 3)
+		if err != nil {
+			t.Fatalf("Planning failed: %v", err)
+		}
+		if len(reverts) != 0 || !equalVersions(versions(applies), []int64{3}) {
+			t.Errorf("Expected to apply only 3, got %v and %v", versions(reverts), versions(applies))
+		}
+	})
+
+	t.Run("Unknown Target Version", func(t *testing.T) {
+		for _, version := range []int64{-1, 5} {
+			if err := migrator.To(context.Background(), version); !e.Is(err, e.ErrInvalidRequest) {
+				t.Errorf("Expected version %d to be refused, got: %v", version, err)
+			}
+		}
+	})
+}
+
+func TestLockID(t *testing.T) {
+	if lockID("authservice") == lockID("graphservice") {
+		t.Errorf("Expected services to have their own lock")
+	}
+	if lockID("authservice") != lockID("authservice") {
+		t.Errorf("Expected the lock of a service to be stable")
+	}
+}
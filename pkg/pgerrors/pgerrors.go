@@ -0,0 +1,136 @@
+package pgerrors
+
+import (
+	"database/sql/driver"
+	"net"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+
+	e "github.com/BwezB/Wikno-backend/pkg/errors"
+)
+
+// SQLSTATE codes of the postgres errors that are translated,
+// see https://www.postgresql.org/docs/current/errcodes-appendix.html
+const (
+	CodeNotNullViolation     = "23502"
+	CodeForeignKeyViolation  = "23503"
+	CodeUniqueViolation      = "23505"
+	CodeCheckViolation       = "23514"
+	CodeSerializationFailure = "40001"
+	CodeDeadlockDetected     = "40P01"
+	ClassConnectionException = "08"
+)
+
+// Error types of database errors. The database packages of the services share them.
+var (
+	ErrDatabaseConnection  = e.NewErrorType("DB_CONNECTION_ERROR", "database connection error")
+	ErrDuplicateEntry      = e.NewErrorType("DB_DUPLICATE_ENTRY", "resource already exists")
+	ErrRecordNotFound      = e.NewErrorType("DB_NOT_FOUND", "resource not found")
+	ErrInvalidReference    = e.NewErrorType("DB_INVALID_REFERENCE", "referenced resource does not exist")
+	ErrCheckViolation      = e.NewErrorType("DB_CHECK_VIOLATION", "value is not allowed")
+	ErrMissingValue        = e.NewErrorType("DB_MISSING_VALUE", "required value is missing")
+	ErrTransactionConflict = e.NewErrorType("DB_TRANSACTION_CONFLICT", "transaction conflicts with another transaction")
+)
+
+// Violation is a postgres error with what it is about, as far as postgres reports it
+type Violation struct {
+	// Code is the SQLSTATE code of the error
+	Code string
+	// Table is the table of the violated constraint
+	Table string
+	// Constraint is the name of the violated constraint, like "idx_users_email"
+	Constraint string
+	// Column is the column of a not-null violation
+	Column string
+
+	Err *pgconn.PgError
+}
+
+func (v *Violation) Error() string {
+	return v.Err.Error()
+}
+
+func (v *Violation) Unwrap() error {
+	return v.Err
+}
+
+// ViolationOf returns the violation in the chain of a translated error
+func ViolationOf(err error) (*Violation, bool) {
+	var violation *Violation
+	if e.As(err, &violation) {
+		return violation, true
+	}
+	return nil, false
+}
+
+// Constraints maps constraint names of a schema to error types that are more specific than the type of their SQLSTATE code.
+// The specific errors wrap the general ones, so both can be checked with e.Is.
+type Constraints map[string]*e.AppError
+
+// Translate translates gorm and postgres errors into application errors, by the SQLSTATE code of postgres errors
+// and the violated constraint.
+func Translate(err error, constraints Constraints) error {
+	if err == nil {
+		return nil
+	}
+
+	// Check for GORM-specific errors
+	switch {
+	case e.Is(err, gorm.ErrRecordNotFound):
+		return e.New("", ErrRecordNotFound, err)
+	case e.Is(err, gorm.ErrInvalidDB):
+		return e.New("Invalid database connection", ErrDatabaseConnection, err)
+	case e.Is(err, gorm.ErrDuplicatedKey):
+		return e.New("Resource already exists", ErrDuplicateEntry, err)
+	}
+
+	var pgErr *pgconn.PgError
+	if !e.As(err, &pgErr) {
+		if connectionError(err) {
+			return e.New("Database connection failed", ErrDatabaseConnection, err)
+		}
+		return e.New("Unexpected database error", e.ErrInternal, err)
+	}
+
+	// Check for Postgres-specific errors
+	violation := &Violation{
+		Code:       pgErr.Code,
+		Table:      pgErr.TableName,
+		Constraint: pgErr.ConstraintName,
+		Column:     pgErr.ColumnName,
+		Err:        pgErr,
+	}
+	var translated error
+	switch {
+	case pgErr.Code == CodeUniqueViolation:
+		translated = e.New("Resource already exists", ErrDuplicateEntry, violation)
+	case pgErr.Code == CodeForeignKeyViolation:
+		translated = e.New("Referenced resource does not exist", ErrInvalidReference, violation)
+	case pgErr.Code == CodeCheckViolation:
+		translated = e.New("Value violates "+pgErr.ConstraintName, ErrCheckViolation, violation)
+	case pgErr.Code == CodeNotNullViolation:
+		translated = e.New("Missing value of "+pgErr.ColumnName, ErrMissingValue, violation)
+	case pgErr.Code == CodeSerializationFailure || pgErr.Code == CodeDeadlockDetected:
+		translated = e.New("Database transaction conflict", ErrTransactionConflict, violation)
+	case strings.HasPrefix(pgErr.Code, ClassConnectionException):
+		translated = e.New("Database connection failed", ErrDatabaseConnection, violation)
+	default:
+		return e.New("Unexpected database error", e.ErrInternal, violation)
+	}
+
+	if specific, ok := constraints[pgErr.ConstraintName]; ok {
+		return e.New("", specific, translated)
+	}
+	return translated
+}
+
+// HELPER FUNCTIONS
+
+// connectionError reports whether an error that does not come from postgres is about the connection to it
+func connectionError(err error) bool {
+	var connectErr *pgconn.ConnectError
+	var netErr net.Error
+	return e.As(err, &connectErr) || e.As(err, &netErr) || e.Is(err, driver.ErrBadConn)
+}
@@ -0,0 +1,119 @@
+package pgerrors
+
+import (
+	"database/sql/driver"
+	"errors"
+	"net"
+	"syscall"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+
+	e "github.com/BwezB/Wikno-backend/pkg/errors"
+)
+
+var (
+	errDuplicateEmail = e.NewErrorType("DUPLICATE_EMAIL", "email already registered")
+	errInvalidRole    = e.NewErrorType("INVALID_ROLE", "invalid role")
+
+	testConstraints = Constraints{
+		"idx_users_email":  errDuplicateEmail,
+		"chk_members_role": errInvalidRole,
+	}
+)
+
+func TestTranslate(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      *pgconn.PgError
+		expected *e.AppError
+		specific *e.AppError
+	}{
+		{"Unique Violation", &pgconn.PgError{Code: "23505", ConstraintName: "idx_entities_name"}, ErrDuplicateEntry, nil},
+		{"Unique Violation Of Mapped Constraint", &pgconn.PgError{Code: "23505", ConstraintName: "idx_users_email"}, ErrDuplicateEntry, errDuplicateEmail},
+		{"Foreign Key Violation", &pgconn.PgError{Code: "23503", ConstraintName: "fk_members_workspace"}, ErrInvalidReference, nil},
+		{"Check Violation", &pgconn.PgError{Code: "23514", ConstraintName: "chk_values_positive"}, ErrCheckViolation, nil},
+		{"Check Violation Of Mapped Constraint", &pgconn.PgError{Code: "23514", ConstraintName: "chk_members_role"}, ErrCheckViolation, errInvalidRole},
+		{"Not Null Violation", &pgconn.PgError{Code: "23502", ColumnName: "name"}, ErrMissingValue, nil},
+		{"Serialization Failure", &pgconn.PgError{Code: "40001"}, ErrTransactionConflict, nil},
+		{"Deadlock Detected", &pgconn.PgError{Code: "40P01"}, ErrTransactionConflict, nil},
+		{"Connection Exception", &pgconn.PgError{Code: "08006"}, ErrDatabaseConnection, nil},
+		{"Unknown Code", &pgconn.PgError{Code: "42P01", TableName: "missing"}, e.ErrInternal, nil},
+		{"Unknown Code Of Mapped Constraint", &pgconn.PgError{Code: "42P01", ConstraintName: "idx_users_email"}, e.ErrInternal, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Translate(tt.err, testConstraints)
+			if !e.Is(err, tt.expected) {
+				t.Errorf("Expected %s, got %q: %v", tt.expected.Code, e.CodeOf(err), err)
+			}
+			code := tt.expected.Code
+			if tt.specific != nil {
+				code = tt.specific.Code
+				if !e.Is(err, tt.specific) {
+					t.Errorf("Expected %s of the constraint, got: %v", tt.specific.Code, err)
+				}
+			}
+			if e.CodeOf(err) != code {
+				t.Errorf("Expected the code %s, got %s", code, e.CodeOf(err))
+			}
+
+			violation, ok := ViolationOf(err)
+			if !ok {
+				t.Fatalf("Expected the violation in the chain of %v", err)
+			}
+			if violation.Code != tt.err.Code || violation.Constraint != tt.err.ConstraintName ||
+				violation.Column != tt.err.ColumnName || violation.Table != tt.err.TableName || violation.Err != tt.err {
+				t.Errorf("Expected the violation of %+v, got %+v", tt.err, violation)
+			}
+		})
+	}
+
+	t.Run("Wrapped Postgres Errors", func(t *testing.T) {
+		err := Translate(e.Wrap("Could not create user", &pgconn.PgError{Code: "23505", ConstraintName: "idx_users_email"}), testConstraints)
+		if !e.Is(err, errDuplicateEmail) {
+			t.Errorf("Expected the error of the constraint, got: %v", err)
+		}
+	})
+
+	t.Run("Without Constraints", func(t *testing.T) {
+		err := Translate(&pgconn.PgError{Code: "23505", ConstraintName: "idx_users_email"}, nil)
+		if e.CodeOf(err) != ErrDuplicateEntry.Code || e.Is(err, errDuplicateEmail) {
+			t.Errorf("Expected only the error of the code, got: %v", err)
+		}
+	})
+}
+
+func TestTranslateOtherErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected *e.AppError
+	}{
+		{"Record Not Found", gorm.ErrRecordNotFound, ErrRecordNotFound},
+		{"Invalid DB", gorm.ErrInvalidDB, ErrDatabaseConnection},
+		{"Duplicated Key", gorm.ErrDuplicatedKey, ErrDuplicateEntry},
+		{"Connect Error", &pgconn.ConnectError{}, ErrDatabaseConnection},
+		{"Network Error", &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}, ErrDatabaseConnection},
+		{"Bad Connection", driver.ErrBadConn, ErrDatabaseConnection},
+		{"Other Errors", errors.New("unexpected"), e.ErrInternal},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Translate(tt.err, testConstraints)
+			if e.CodeOf(err) != tt.expected.Code {
+				t.Errorf("Expected %s, got %q: %v", tt.expected.Code, e.CodeOf(err), err)
+			}
+			if _, ok := ViolationOf(err); ok {
+				t.Errorf("Expected no violation in the chain of %v", err)
+			}
+		})
+	}
+
+	t.Run("Nil", func(t *testing.T) {
+		if err := Translate(nil, testConstraints); err != nil {
+			t.Errorf("Expected nil, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,121 @@
+package ratelimit
+
+import (
+	"net"
+	"strconv"
+	"strings"
+
+	c "github.com/BwezB/Wikno-backend/pkg/configs"
+	e "github.com/BwezB/Wikno-backend/pkg/errors"
+)
+
+type RateLimitConfig struct {
+	Enabled bool `yaml:"enabled"`
+	// PerMinute and Burst are the quota of every method without its own: each user (or IP, for calls without
+	// a user) may call a method PerMinute times a minute, and Burst times at once
+	PerMinute int `yaml:"per_minute" validate:"min=0"`
+	Burst     int `yaml:"burst" validate:"min=1"`
+	// Methods are the comma separated quotas of single methods, as Method=PerMinute/Burst.
+	// A quota of 0 per minute does not limit the method
+	Methods string `yaml:"methods"`
+	// TrustedProxies are the comma separated IPs or CIDRs of proxies, like the gateway, whose calls are
+	// limited by the client IP in their x-forwarded-for metadata instead of their own
+	TrustedProxies string `yaml:"trusted_proxies"`
+}
+
+// quota is how often a method may be called
+type quota struct {
+	perMinute int
+	burst     int
+}
+
+func (r *RateLimitConfig) SetDefaults() {
+	r.Enabled = true
+	r.PerMinute = 1200
+	r.Burst = 100
+	r.Methods = ""
+	r.TrustedProxies = ""
+}
+
+func (r *RateLimitConfig) AddFromEnv() {
+	c.SetEnvValue(&r.Enabled, "RATE_LIMIT_ENABLED")
+	c.SetEnvValue(&r.PerMinute, "RATE_LIMIT_PER_MINUTE")
+	c.SetEnvValue(&r.Burst, "RATE_LIMIT_BURST")
+	c.SetEnvValue(&r.Methods, "RATE_LIMIT_METHODS")
+	c.SetEnvValue(&r.TrustedProxies, "RATE_LIMIT_TRUSTED_PROXIES")
+}
+
+var (
+	flagRateLimitEnabled        = c.NewFlag("rate-limit-enabled", "", "Whether calls are rate limited")
+	flagRateLimitPerMinute      = c.NewFlag("rate-limit-per-minute", "", "Calls of a method per minute for each user or IP")
+	flagRateLimitBurst          = c.NewFlag("rate-limit-burst", "", "Calls of a method at once for each user or IP")
+	flagRateLimitMethods        = c.NewFlag("rate-limit-methods", "", "Comma separated quotas of single methods, as Method=PerMinute/Burst")
+	flagRateLimitTrustedProxies = c.NewFlag("rate-limit-trusted-proxies", "", "Comma separated IPs or CIDRs of proxies that forward the client IP")
+)
+
+func (r *RateLimitConfig) AddFromFlags() {
+	c.SetFlagValue(&r.Enabled, flagRateLimitEnabled)
+	c.SetFlagValue(&r.PerMinute, flagRateLimitPerMinute)
+	c.SetFlagValue(&r.Burst, flagRateLimitBurst)
+	c.SetFlagValue(&r.Methods, flagRateLimitMethods)
+	c.SetFlagValue(&r.TrustedProxies, flagRateLimitTrustedProxies)
+}
+
+// getMethodQuotas parses the quotas of single methods
+func (r *RateLimitConfig) getMethodQuotas() (map[string]quota, error) {
+	quotas := make(map[string]quota)
+	for _, entry := range splitList(r.Methods) {
+		method, value, found := strings.Cut(entry, "=")
+		perMinute, burst, isPair := strings.Cut(value, "/")
+		if !found || !isPair || method == "" {
+			return nil, e.New("invalid method quota "+entry+", expected Method=PerMinute/Burst", e.ErrInvalidFunctionArgument, nil)
+		}
+
+		var q quota
+		var err error
+		if q.perMinute, err = strconv.Atoi(perMinute); err != nil || q.perMinute < 0 {
+			return nil, e.New("invalid calls per minute in method quota "+entry, e.ErrInvalidFunctionArgument, err)
+		}
+		if q.burst, err = strconv.Atoi(burst); err != nil || (q.burst < 1 && q.perMinute > 0) {
+			return nil, e.New("invalid burst in method quota "+entry, e.ErrInvalidFunctionArgument, err)
+		}
+		quotas[strings.TrimSpace(method)] = q
+	}
+	return quotas, nil
+}
+
+// getTrustedProxies parses the trusted proxies, where single IPs become networks of one address
+func (r *RateLimitConfig) getTrustedProxies() ([]*net.IPNet, error) {
+	var networks []*net.IPNet
+	for _, entry := range splitList(r.TrustedProxies) {
+		if !strings.Contains(entry, "/") {
+			ip := net.ParseIP(entry)
+			if ip == nil {
+				return nil, e.New("invalid trusted proxy "+entry, e.ErrInvalidFunctionArgument, nil)
+			}
+			bits := 8 * len(ip.To16())
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), 32
+			}
+			networks = append(networks, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		_, network, err := net.ParseCIDR(entry)
+		if err != nil {
+			return nil, e.New("invalid trusted proxy "+entry, e.ErrInvalidFunctionArgument, err)
+		}
+		networks = append(networks, network)
+	}
+	return networks, nil
+}
+
+// splitList splits a comma separated list, without empty entries
+func splitList(list string) []string {
+	var entries []string
+	for _, entry := range strings.Split(list, ",") {
+		if entry = strings.TrimSpace(entry); entry != "" {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
@@ -0,0 +1,204 @@
+// Package ratelimit limits how often each user calls each gRPC method, with a token bucket per user and method.
+// Calls without a user, like Login, are limited by the IP of the caller instead.
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	a "github.com/BwezB/Wikno-backend/pkg/auth"
+	e "github.com/BwezB/Wikno-backend/pkg/errors"
+	l "github.com/BwezB/Wikno-backend/pkg/log"
+	m "github.com/BwezB/Wikno-backend/pkg/metrics"
+	r "github.com/BwezB/Wikno-backend/pkg/requestid"
+)
+
+// sweepInterval is how often buckets that refilled are dropped, as a full bucket is the same as none
+const sweepInterval = time.Minute
+
+// healthService is never limited, so probes keep working under load
+const healthService = "/grpc.health.v1.Health/"
+
+type Limiter struct {
+	enabled        bool
+	defaultQuota   quota
+	methodQuotas   map[string]quota
+	trustedProxies []*net.IPNet
+	metrics        *m.MetricsService
+
+	mu        sync.Mutex
+	buckets   map[string]*rate.Limiter
+	lastSweep time.Time
+}
+
+func NewLimiter(config RateLimitConfig, metrics *m.MetricsService) (*Limiter, error) {
+	methodQuotas, err := config.getMethodQuotas()
+	if err != nil {
+		return nil, e.Wrap("failed to parse method quotas", err)
+	}
+	trustedProxies, err := config.getTrustedProxies()
+	if err != nil {
+		return nil, e.Wrap("failed to parse trusted proxies", err)
+	}
+
+	return &Limiter{
+		enabled:        config.Enabled,
+		defaultQuota:   quota{perMinute: config.PerMinute, burst: config.Burst},
+		methodQuotas:   methodQuotas,
+		trustedProxies: trustedProxies,
+		metrics:        metrics,
+		buckets:        make(map[string]*rate.Limiter),
+		lastSweep:      time.Now(),
+	}, nil
+}
+
+// UnaryRateLimitInterceptor refuses calls over the quota of their method.
+// It must come after the auth interceptor, so the calls of users are limited by user.
+func UnaryRateLimitInterceptor(limiter *Limiter) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := limiter.allow(ctx, info.FullMethod); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamRateLimitInterceptor refuses streams over the quota of their method
+func StreamRateLimitInterceptor(limiter *Limiter) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := limiter.allow(ss.Context(), info.FullMethod); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
+
+// allow takes a token from the bucket of the caller and method,
+// or returns a RESOURCE_EXHAUSTED error with the time until there is one
+func (lm *Limiter) allow(ctx context.Context, fullMethod string) error {
+	if !lm.enabled || strings.HasPrefix(fullMethod, healthService) {
+		return nil
+	}
+	method := fullMethod[strings.LastIndex(fullMethod, "/")+1:]
+	q, ok := lm.methodQuotas[method]
+	if !ok {
+		q = lm.defaultQuota
+	}
+	if q.perMinute == 0 {
+		return nil
+	}
+
+	keyType, key := lm.callerKey(ctx)
+	now := time.Now()
+	reservation := lm.bucket(fullMethod+"|"+key, q, now).ReserveN(now, 1)
+	delay := reservation.DelayFrom(now)
+	if delay == 0 {
+		return nil
+	}
+	reservation.CancelAt(now) // The call is refused, so it does not use the token
+
+	lm.metrics.ThrottledCounter.WithLabelValues(method, keyType).Inc()
+	l.Warn("Rate limit exceeded",
+		l.String("method", method),
+		l.String(keyType, key),
+		l.String("request_id", r.GetRequestID(ctx)))
+
+	st, err := status.New(codes.ResourceExhausted, fmt.Sprintf("rate limit of %s exceeded, retry in %s", method, delay.Round(time.Millisecond))).
+		WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(delay)})
+	if err != nil {
+		return status.Error(codes.ResourceExhausted, "rate limit of "+method+" exceeded")
+	}
+	return st.Err()
+}
+
+// bucket returns the bucket of a key, creating it full if there is none
+func (lm *Limiter) bucket(key string, q quota, now time.Time) *rate.Limiter {
+	lm.mu.Lock()
+	defer lm.mu.Unlock()
+
+	if now.Sub(lm.lastSweep) >= sweepInterval {
+		for k, bucket := range lm.buckets {
+			if bucket.TokensAt(now) >= float64(bucket.Burst()) {
+				delete(lm.buckets, k)
+			}
+		}
+		lm.lastSweep = now
+	}
+
+	bucket, ok := lm.buckets[key]
+	if !ok {
+		bucket = rate.NewLimiter(rate.Limit(float64(q.perMinute)/60), q.burst)
+		lm.buckets[key] = bucket
+	}
+	return bucket
+}
+
+// callerKey returns whether the caller is a user or an IP, and which
+func (lm *Limiter) callerKey(ctx context.Context) (string, string) {
+	if userID := a.GetUserID(ctx); userID != "" {
+		return "user", userID
+	}
+	return "ip", lm.clientIP(ctx)
+}
+
+// clientIP returns the IP of the peer, or of the client a trusted proxy forwarded the call for.
+// Proxies append the address they received a call from to x-forwarded-for, so only the addresses
+// appended by trusted proxies are trustworthy: the client is the rightmost address that is not a trusted proxy.
+// An entry that is not an IP ends the search at the last trusted proxy, so it cannot become a key of its own.
+func (lm *Limiter) clientIP(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return "unknown"
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		host = p.Addr.String()
+	}
+
+	ip := net.ParseIP(host)
+	if ip == nil || !lm.isTrustedProxy(ip) {
+		return host
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	var addresses []string
+	for _, forwarded := range md.Get("x-forwarded-for") {
+		addresses = append(addresses, strings.Split(forwarded, ",")...)
+	}
+	for i := len(addresses) - 1; i >= 0; i-- {
+		address := strings.TrimSpace(addresses[i])
+		if address == "" {
+			continue
+		}
+		ip := net.ParseIP(address)
+		if ip == nil {
+			break
+		}
+		if !lm.isTrustedProxy(ip) {
+			return ip.String() // The same address is one key however it is written
+		}
+		host = ip.String() // Forwarded by another trusted proxy
+	}
+	return host
+}
+
+func (lm *Limiter) isTrustedProxy(ip net.IP) bool {
+	for _, network := range lm.trustedProxies {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,174 @@
+package ratelimit
+
+import (
+	"context"
+	"net"
+	"os"
+	"testing"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	l "github.com/BwezB/Wikno-backend/pkg/log"
+	m "github.com/BwezB/Wikno-backend/pkg/metrics"
+	r "github.com/BwezB/Wikno-backend/pkg/requestid"
+)
+
+func TestMain(main *testing.M) {
+	// Only problems are logged
+	logConfig := l.LoggerConfig{}
+	logConfig.SetDefaults()
+	logConfig.Level = "error"
+	if err := l.InitLogger(logConfig); err != nil {
+		panic(err)
+	}
+	os.Exit(main.Run())
+}
+
+// newTestLimiter returns a limiter of one Login at once, trusting the proxies
+func newTestLimiter(t *testing.T, trustedProxies string) *Limiter {
+	config := RateLimitConfig{}
+	config.SetDefaults()
+	config.Methods = "Login=1/1,Ping=0/0"
+	config.TrustedProxies = trustedProxies
+	limiter, err := NewLimiter(config, m.NewMetrics("ratelimit_test"))
+	if err != nil {
+		t.Fatalf("Could not create limiter: %v", err)
+	}
+	return limiter
+}
+
+// callFrom returns the context of a call from the peer, with the x-forwarded-for metadata
+func callFrom(peerAddr string, forwardedFor ...string) context.Context {
+	addr, err := net.ResolveTCPAddr("tcp", peerAddr)
+	if err != nil {
+		panic(err)
+	}
+	ctx := peer.NewContext(r.WithRequestID(context.Background(), "ratelimit-test"), &peer.Peer{Addr: addr})
+	if len(forwardedFor) > 0 {
+		md := metadata.MD{}
+		md.Append("x-forwarded-for", forwardedFor...)
+		ctx = metadata.NewIncomingContext(ctx, md)
+	}
+	return ctx
+}
+
+func TestClientIP(t *testing.T) {
+	limiter := newTestLimiter(t, "10.0.0.0/8,fd00::1")
+
+	tests := []struct {
+		name         string
+		peer         string
+		forwardedFor []string
+		expected     string
+	}{
+		{"Untrusted Peer", "203.0.113.7:1234", []string{"198.51.100.1"}, "203.0.113.7"},
+		{"Trusted Peer Without Header", "10.0.0.1:1234", nil, "10.0.0.1"},
+		{"Forwarded Client", "10.0.0.1:1234", []string{"198.51.100.1"}, "198.51.100.1"},
+		{"Spoofed Leading Entries", "10.0.0.1:1234", []string{"192.0.2.1, 192.0.2.2, 198.51.100.1"}, "198.51.100.1"},
+		{"Chain Of Trusted Proxies", "10.0.0.1:1234", []string{"192.0.2.1, 198.51.100.1, 10.0.0.2, 10.0.0.3"}, "198.51.100.1"},
+		{"Repeated Headers", "10.0.0.1:1234", []string{"192.0.2.1", "198.51.100.1, 10.0.0.2"}, "198.51.100.1"},
+		{"All Trusted", "10.0.0.1:1234", []string{"10.0.0.3, 10.0.0.2"}, "10.0.0.3"},
+		{"Malformed Entry", "10.0.0.1:1234", []string{"198.51.100.1, not-an-ip"}, "10.0.0.1"},
+		{"Malformed Behind Trusted Proxy", "10.0.0.1:1234", []string{"garbage, 10.0.0.2"}, "10.0.0.2"},
+		{"Empty Entries", "10.0.0.1:1234", []string{"198.51.100.1, , "}, "198.51.100.1"},
+		{"IPv6 Client", "10.0.0.1:1234", []string{"2001:db8::1"}, "2001:db8::1"},
+		{"IPv6 Trusted Proxy", "[fd00::1]:1234", []string{"2001:db8::1, fd00::1"}, "2001:db8::1"},
+		{"IPv6 Written Differently", "10.0.0.1:1234", []string{"2001:DB8:0::1"}, "2001:db8::1"},
+		{"IPv4 Mapped Client", "10.0.0.1:1234", []string{"::ffff:198.51.100.1"}, "198.51.100.1"},
+		{"IPv6 Untrusted Peer", "[2001:db8::2]:1234", []string{"198.51.100.1"}, "2001:db8::2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if ip := limiter.clientIP(callFrom(tt.peer, tt.forwardedFor...)); ip != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, ip)
+			}
+		})
+	}
+
+	t.Run("Without Peer", func(t *testing.T) {
+		if ip := limiter.clientIP(context.Background()); ip != "unknown" {
+			t.Errorf("Expected unknown, got %s", ip)
+		}
+	})
+}
+
+func TestAllow(t *testing.T) {
+	limiter := newTestLimiter(t, "10.0.0.1")
+	const login = "/auth.AuthService/Login"
+
+	t.Run("Quota Exceeded", func(t *testing.T) {
+		if err := limiter.allow(callFrom("10.0.0.1:1234", "198.51.100.1"), login); err != nil {
+			t.Fatalf("Expected the first call to be allowed, got: %v", err)
+		}
+		err := limiter.allow(callFrom("10.0.0.1:1234", "192.0.2.1, 198.51.100.1"), login)
+		if status.Code(err) != codes.ResourceExhausted {
+			t.Fatalf("Expected ResourceExhausted for a spoofed leading entry, got: %v", err)
+		}
+		var retryInfo *errdetails.RetryInfo
+		for _, detail := range status.Convert(err).Details() {
+			if info, ok := detail.(*errdetails.RetryInfo); ok {
+				retryInfo = info
+			}
+		}
+		if retryInfo.GetRetryDelay().AsDuration() <= 0 {
+			t.Errorf("Expected a retry delay, got: %v", retryInfo)
+		}
+	})
+
+	t.Run("Other Clients", func(t *testing.T) {
+		if err := limiter.allow(callFrom("10.0.0.1:1234", "198.51.100.2"), login); err != nil {
+			t.Errorf("Expected another client to have its own quota, got: %v", err)
+		}
+	})
+
+	t.Run("Unlimited Methods", func(t *testing.T) {
+		for i := 0; i < 3; i++ {
+			if err := limiter.allow(callFrom("203.0.113.7:1234"), "/auth.AuthService/Ping"); err != nil {
+				t.Fatalf("Expected Ping not to be limited, got: %v", err)
+			}
+			if err := limiter.allow(callFrom("203.0.113.7:1234"), "/grpc.health.v1.Health/Check"); err != nil {
+				t.Fatalf("Expected health checks not to be limited, got: %v", err)
+			}
+		}
+	})
+}
+
+func TestConfig(t *testing.T) {
+	t.Run("Trusted Proxies", func(t *testing.T) {
+		config := RateLimitConfig{TrustedProxies: "10.0.0.1, 192.168.0.0/16, ::1"}
+		networks, err := config.getTrustedProxies()
+		if err != nil {
+			t.Fatalf("Parsing failed: %v", err)
+		}
+		if len(networks) != 3 || networks[0].String() != "10.0.0.1/32" || networks[2].String() != "::1/128" {
+			t.Errorf("Expected single addresses as networks of one, got %v", networks)
+		}
+		for _, invalid := range []string{"not-an-ip", "10.0.0.0/33"} {
+			config := RateLimitConfig{TrustedProxies: invalid}
+			if _, err := config.getTrustedProxies(); err == nil {
+				t.Errorf("Expected %q to be refused", invalid)
+			}
+		}
+	})
+
+	t.Run("Method Quotas", func(t *testing.T) {
+		config := RateLimitConfig{Methods: "Login=60/30, Ping=0/0"}
+		quotas, err := config.getMethodQuotas()
+		if err != nil {
+			t.Fatalf("Parsing failed: %v", err)
+		}
+		if quotas["Login"] != (quota{perMinute: 60, burst: 30}) || quotas["Ping"] != (quota{}) {
+			t.Errorf("Unexpected quotas %v", quotas)
+		}
+		for _, invalid := range []string{"Login", "Login=60", "=60/30", "Login=-1/1", "Login=60/0"} {
+			config := RateLimitConfig{Methods: invalid}
+			if _, err := config.getMethodQuotas(); err == nil {
+				t.Errorf("Expected %q to be refused", invalid)
+			}
+		}
+	})
+}
@@ -0,0 +1,152 @@
+package replicas
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"gorm.io/gorm"
+
+	e "github.com/BwezB/Wikno-backend/pkg/errors"
+	l "github.com/BwezB/Wikno-backend/pkg/log"
+)
+
+// lagQuery returns how many seconds a replica lags behind its primary.
+// A replica that replayed everything it received is caught up, however long ago the primary last wrote.
+// A server that is not a replica does not lag.
+const lagQuery = `SELECT CASE
+	WHEN pg_last_wal_receive_lsn() = pg_last_wal_replay_lsn() THEN 0
+	ELSE COALESCE(EXTRACT(EPOCH FROM now() - pg_last_xact_replay_timestamp()), 0)
+END`
+
+// Replica is a read replica of the primary database
+type Replica struct {
+	// Address is the host and port of the replica, for logging
+	Address string
+	// DB is the connection to the replica
+	DB *gorm.DB
+
+	available atomic.Bool
+}
+
+// Set routes read-only queries to the available replicas of a primary database, in turns.
+// A replica is available if it answers its checks and lags behind the primary by at most the maximum lag.
+// Without available replicas, the queries fall back to the primary.
+type Set struct {
+	replicas []*Replica
+	maxLag   time.Duration
+	next     atomic.Uint64
+	// measureLag measures how far a replica lags behind the primary
+	measureLag func(ctx context.Context, db *gorm.DB) (time.Duration, error)
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// New checks the replicas and keeps checking them every interval, until the set is closed.
+func New(replicas []*Replica, maxLag, interval time.Duration) *Set {
+	return newSet(replicas, maxLag, interval, measureLag)
+}
+
+// newSet is New with the function that measures the lag of the replicas
+func newSet(replicas []*Replica, maxLag, interval time.Duration,
+	measureLag func(ctx context.Context, db *gorm.DB) (time.Duration, error)) *Set {
+	s := &Set{
+		replicas:   replicas,
+		maxLag:     maxLag,
+		measureLag: measureLag,
+		stop:       make(chan struct{}),
+		done:       make(chan struct{}),
+	}
+	// Replicas start available, so the first check only logs the ones that are not
+	for _, replica := range replicas {
+		replica.available.Store(true)
+	}
+	s.check(interval)
+	go s.run(interval)
+	return s
+}
+
+// Reader returns the connection to the next available replica, or nil if there is none and the primary must be used.
+func (s *Set) Reader() *gorm.DB {
+	if s == nil {
+		return nil
+	}
+	for range s.replicas {
+		replica := s.replicas[s.next.Add(1)%uint64(len(s.replicas))]
+		if replica.available.Load() {
+			return replica.DB
+		}
+	}
+	return nil
+}
+
+// Close stops checking the replicas and closes the connections to them.
+func (s *Set) Close() error {
+	if s == nil {
+		return nil
+	}
+	close(s.stop)
+	<-s.done
+
+	for _, replica := range s.replicas {
+		replica.available.Store(false)
+		sqlDB, err := replica.DB.DB()
+		if err != nil {
+			return e.New("Failed to get sql.DB from gorm.DB", e.ErrInternal, err)
+		}
+		if err := sqlDB.Close(); err != nil {
+			return e.New("Failed to close replica connection", e.ErrInternal, err)
+		}
+	}
+	return nil
+}
+
+// HELPER FUNCTIONS
+
+func (s *Set) run(interval time.Duration) {
+	defer close(s.done)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.stop:
+			return
+		case <-ticker.C:
+			s.check(interval)
+		}
+	}
+}
+
+// check updates which replicas are available, giving each check at most the interval
+func (s *Set) check(interval time.Duration) {
+	for _, replica := range s.replicas {
+		ctx, cancel := context.WithTimeout(context.Background(), interval)
+		lag, err := s.measureLag(ctx, replica.DB)
+		cancel()
+
+		available := err == nil && lag <= s.maxLag
+		if replica.available.Swap(available) == available {
+			continue // Only changes are logged
+		}
+		switch {
+		case available:
+			l.Info("Reading from replica", l.String("address", replica.Address), l.Duration("lag", lag))
+		case err != nil:
+			l.Warn("Replica unreachable, reading from the other replicas or the primary",
+				l.String("address", replica.Address), l.ErrField(err))
+		default:
+			l.Warn("Replica lags behind the primary, reading from the other replicas or the primary",
+				l.String("address", replica.Address), l.Duration("lag", lag), l.Duration("max_lag", s.maxLag))
+		}
+	}
+}
+
+func measureLag(ctx context.Context, db *gorm.DB) (time.Duration, error) {
+	var seconds float64
+	if err := db.WithContext(ctx).Raw(lagQuery).Scan(&seconds).Error; err != nil {
+		return 0, e.New("Failed to measure replica lag", e.ErrInternal, err)
+	}
+	return time.Duration(seconds * float64(time.Second)), nil
+}
@@ -0,0 +1,179 @@
+package replicas
+
+import (
+	"context"
+	"errors"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+
+	l "github.com/BwezB/Wikno-backend/pkg/log"
+)
+
+const maxLag = 5 * time.Second
+
+func TestMain(main *testing.M) {
+	// Only problems are logged
+	logConfig := l.LoggerConfig{}
+	logConfig.SetDefaults()
+	logConfig.Level = "error"
+	if err := l.InitLogger(logConfig); err != nil {
+		panic(err)
+	}
+	os.Exit(main.Run())
+}
+
+// lagProbe is a stubbed lag probe, which returns the lag or error set for each replica
+type lagProbe struct {
+	mu   sync.Mutex
+	lags map[*gorm.DB]time.Duration
+	errs map[*gorm.DB]error
+}
+
+func newLagProbe() *lagProbe {
+	return &lagProbe{lags: map[*gorm.DB]time.Duration{}, errs: map[*gorm.DB]error{}}
+}
+
+func (p *lagProbe) set(db *gorm.DB, lag time.Duration, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.lags[db] = lag
+	p.errs[db] = err
+}
+
+func (p *lagProbe) measure(_ context.Context, db *gorm.DB) (time.Duration, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.lags[db], p.errs[db]
+}
+
+// newTestSet returns a set of the replicas measured by the probe, checked every interval
+func newTestSet(t *testing.T, probe *lagProbe, interval time.Duration, dbs ...*gorm.DB) *Set {
+	replicas := []*Replica{}
+	for i, db := range dbs {
+		replicas = append(replicas, &Replica{Address: "replica-" + string(rune('a'+i)), DB: db})
+	}
+	s := newSet(replicas, maxLag, interval, probe.measure)
+	// The connections are stubs, so only the checks are stopped
+	t.Cleanup(func() {
+		close(s.stop)
+		<-s.done
+	})
+	return s
+}
+
+// readers returns how often each connection is returned by calls to Reader
+func readers(s *Set, calls int) map[*gorm.DB]int {
+	counts := map[*gorm.DB]int{}
+	for i := 0; i < calls; i++ {
+		counts[s.Reader()]++
+	}
+	return counts
+}
+
+func TestReader(t *testing.T) {
+	first, second := &gorm.DB{}, &gorm.DB{}
+
+	tests := []struct {
+		name       string
+		firstLag   time.Duration
+		firstErr   error
+		secondLag  time.Duration
+		secondErr  error
+		firstRead  bool
+		secondRead bool
+	}{
+		{"Both Available", 0, nil, time.Second, nil, true, true},
+		{"Lag At Maximum", maxLag, nil, 0, nil, true, true},
+		{"First Lags", maxLag + time.Millisecond, nil, 0, nil, false, true},
+		{"Second Unreachable", 0, nil, 0, errors.New("connection refused"), true, false},
+		{"None Available", time.Minute, nil, 0, errors.New("connection refused"), false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			probe := newLagProbe()
+			probe.set(first, tt.firstLag, tt.firstErr)
+			probe.set(second, tt.secondLag, tt.secondErr)
+			s := newTestSet(t, probe, time.Hour, first, second)
+
+			counts := readers(s, 10)
+			if (counts[first] > 0) != tt.firstRead || (counts[second] > 0) != tt.secondRead {
+				t.Errorf("Expected reads from first %v and second %v, got %d and %d",
+					tt.firstRead, tt.secondRead, counts[first], counts[second])
+			}
+			if tt.firstRead && tt.secondRead && counts[first] != counts[second] {
+				t.Errorf("Expected reads in turns, got %d and %d", counts[first], counts[second])
+			}
+			if !tt.firstRead && !tt.secondRead && counts[nil] != 10 {
+				t.Errorf("Expected every read to fall back to the primary, got %v", counts)
+			}
+		})
+	}
+
+	t.Run("Without Replicas", func(t *testing.T) {
+		if db := newTestSet(t, newLagProbe(), time.Hour).Reader(); db != nil {
+			t.Errorf("Expected the primary without replicas")
+		}
+		var s *Set
+		if db := s.Reader(); db != nil {
+			t.Errorf("Expected the primary without a set")
+		}
+		if err := s.Close(); err != nil {
+			t.Errorf("Expected closing no set to succeed, got: %v", err)
+		}
+	})
+}
+
+func TestCheck(t *testing.T) {
+	replica := &gorm.DB{}
+	probe := newLagProbe()
+	s := newTestSet(t, probe, time.Hour, replica)
+	if s.Reader() != replica {
+		t.Fatalf("Expected the replica to be available")
+	}
+
+	t.Run("Falls Back When Lagging", func(t *testing.T) {
+		probe.set(replica, time.Minute, nil)
+		s.check(time.Second)
+		if s.Reader() != nil {
+			t.Errorf("Expected a lagging replica to fall back to the primary")
+		}
+	})
+
+	t.Run("Returns When Caught Up", func(t *testing.T) {
+		probe.set(replica, time.Second, nil)
+		s.check(time.Second)
+		if s.Reader() != replica {
+			t.Errorf("Expected a caught up replica to be read again")
+		}
+	})
+
+	t.Run("Falls Back When Unreachable", func(t *testing.T) {
+		probe.set(replica, 0, errors.New("connection refused"))
+		s.check(time.Second)
+		if s.Reader() != nil {
+			t.Errorf("Expected an unreachable replica to fall back to the primary")
+		}
+	})
+
+	t.Run("Checks Every Interval", func(t *testing.T) {
+		other := &gorm.DB{}
+		probe.set(other, time.Minute, nil)
+		periodic := newTestSet(t, probe, 10*time.Millisecond, other)
+		if periodic.Reader() != nil {
+			t.Fatalf("Expected a lagging replica to fall back to the primary")
+		}
+
+		probe.set(other, 0, nil)
+		deadline := time.Now().Add(5 * time.Second)
+		for periodic.Reader() != other {
+			if time.Now().After(deadline) {
+				t.Fatalf("Expected the replica to be read again after a check")
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	})
+}
@@ -42,4 +42,25 @@ func UnaryRequestIDInterceptor(ctx context.Context, req interface{}, info *grpc.
 	ctx = WithRequestID(ctx, requestID)
 
 	return handler(ctx, req)
-}
\ No newline at end of file
+}
+func StreamRequestIDInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	requestID := uuid.New().String()
+	ctx := WithRequestID(ss.Context(), requestID)
+
+	return handler(srv, WrapServerStream(ss, ctx))
+}
+
+// wrappedStream is a server stream with a different context
+type wrappedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (ws *wrappedStream) Context() context.Context {
+	return ws.ctx
+}
+
+// WrapServerStream returns the stream with the given context, for stream interceptors that add values to the context
+func WrapServerStream(ss grpc.ServerStream, ctx context.Context) grpc.ServerStream {
+	return &wrappedStream{ServerStream: ss, ctx: ctx}
+}
@@ -0,0 +1,119 @@
+package retry
+
+import (
+	"context"
+	"database/sql/driver"
+	"io"
+	"math/rand/v2"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+
+	e "github.com/BwezB/Wikno-backend/pkg/errors"
+	l "github.com/BwezB/Wikno-backend/pkg/log"
+	pe "github.com/BwezB/Wikno-backend/pkg/pgerrors"
+	r "github.com/BwezB/Wikno-backend/pkg/requestid"
+)
+
+// Config limits the retries of a transaction
+type Config struct {
+	// MaxAttempts is how often the transaction is run at most, including the first time
+	MaxAttempts int
+	// Backoff is the longest wait before the first retry. It doubles with every retry, and each wait is random up to it.
+	Backoff time.Duration
+	// Budget is how long the transaction can keep retrying, from the start of the first attempt
+	Budget time.Duration
+}
+
+// Transaction runs fn in a transaction, and runs it again while it fails with a retryable error, within the limits of the config.
+// Errors of fn are returned as they are, errors of starting and committing the transaction are translated with translate.
+// fn can run several times, so it must not keep state from a failed attempt.
+func Transaction(ctx context.Context, db *gorm.DB, config Config, translate func(error) error, fn func(tx *gorm.DB) error) error {
+	return retry(ctx, config, func() error {
+		return run(ctx, db, translate, fn)
+	})
+}
+
+// Retryable reports whether a failed transaction can be run again: on serialization failures, deadlocks,
+// and connections that were lost without the server committing the transaction.
+func Retryable(err error) bool {
+	var pgErr *pgconn.PgError
+	if e.As(err, &pgErr) {
+		return pgErr.Code == pe.CodeSerializationFailure ||
+			pgErr.Code == pe.CodeDeadlockDetected ||
+			strings.HasPrefix(pgErr.Code, pe.ClassConnectionException)
+	}
+	if e.Is(err, errCommitUnknown) {
+		return false // The transaction may have been committed, running it again could apply it twice
+	}
+	return pgconn.SafeToRetry(err) ||
+		e.Is(err, driver.ErrBadConn) ||
+		e.Is(err, syscall.ECONNRESET) ||
+		e.Is(err, io.ErrUnexpectedEOF)
+}
+
+// HELPER FUNCTIONS
+
+// errCommitUnknown marks a connection lost while committing, after which it is unknown whether the transaction was committed
+var errCommitUnknown = e.NewErrorType("DB_COMMIT_UNKNOWN", "transaction commit outcome unknown")
+
+// retry calls attempt, and calls it again while it fails with a retryable error, within the limits of the config
+func retry(ctx context.Context, config Config, attempt func() error) error {
+	start := time.Now()
+	backoff := config.Backoff
+	for attempts := 1; ; attempts++ {
+		err := attempt()
+		if err == nil || !Retryable(err) || attempts >= config.MaxAttempts {
+			return err
+		}
+
+		// Full jitter, so conflicting transactions do not retry in step
+		wait := rand.N(backoff + 1)
+		if time.Since(start)+wait > config.Budget {
+			return e.Wrap("Transaction retry budget exhausted", err)
+		}
+		l.Warn("Retrying transaction",
+			l.Int("attempt", attempts),
+			l.Duration("wait", wait),
+			l.ErrField(err),
+			l.String("request_id", r.GetRequestID(ctx)))
+
+		select {
+		case <-ctx.Done():
+			return e.Wrap("Transaction retry canceled", err)
+		case <-time.After(wait):
+		}
+		backoff *= 2
+	}
+}
+
+// run runs fn in a transaction once, rolling it back if fn fails or panics
+func run(ctx context.Context, db *gorm.DB, translate func(error) error, fn func(tx *gorm.DB) error) error {
+	tx := db.WithContext(ctx).Begin()
+	if tx.Error != nil {
+		return e.Wrap("Could not start transaction", translate(tx.Error))
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	if err := fn(tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		var pgErr *pgconn.PgError
+		if !e.As(err, &pgErr) && !pgconn.SafeToRetry(err) {
+			return e.New("Could not commit", errCommitUnknown, translate(err))
+		}
+		return e.Wrap("Could not commit", translate(err))
+	}
+	return nil
+}
@@ -0,0 +1,150 @@
+package retry
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+
+	e "github.com/BwezB/Wikno-backend/pkg/errors"
+	l "github.com/BwezB/Wikno-backend/pkg/log"
+	r "github.com/BwezB/Wikno-backend/pkg/requestid"
+)
+
+func TestMain(main *testing.M) {
+	// Only problems are logged
+	logConfig := l.LoggerConfig{}
+	logConfig.SetDefaults()
+	logConfig.Level = "error"
+	if err := l.InitLogger(logConfig); err != nil {
+		panic(err)
+	}
+	os.Exit(main.Run())
+}
+
+var errSerialization = &pgconn.PgError{Code: "40001", Message: "could not serialize access"}
+
+func TestRetryable(t *testing.T) {
+	tests := []struct {
+		name      string
+		err       error
+		retryable bool
+	}{
+		{"Serialization Failure", errSerialization, true},
+		{"Deadlock Detected", &pgconn.PgError{Code: "40P01"}, true},
+		{"Connection Failure", &pgconn.PgError{Code: "08006"}, true},
+		{"Admin Shutdown", &pgconn.PgError{Code: "57P01"}, false},
+		{"Unique Violation", &pgconn.PgError{Code: "23505"}, false},
+		{"Foreign Key Violation", &pgconn.PgError{Code: "23503"}, false},
+		{"Transaction Rollback Class", &pgconn.PgError{Code: "40000"}, false},
+		{"Undefined Table", &pgconn.PgError{Code: "42P01"}, false},
+		{"Wrapped Serialization Failure", e.Wrap("Could not create entity", errSerialization), true},
+		{"Bad Connection", driver.ErrBadConn, true},
+		{"Connection Reset", e.Wrap("Query failed", syscall.ECONNRESET), true},
+		{"Unexpected EOF", io.ErrUnexpectedEOF, true},
+		{"Commit Outcome Unknown", e.New("Could not commit", errCommitUnknown, io.ErrUnexpectedEOF), false},
+		{"Other Errors", errors.New("entity not found"), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Retryable(tt.err); got != tt.retryable {
+				t.Errorf("Expected retryable %v, got %v", tt.retryable, got)
+			}
+		})
+	}
+}
+
+// attempts returns an attempt that fails with the errors in turn, and succeeds after them, and the count of its calls
+func attempts(errs ...error) (func() error, *int) {
+	calls := 0
+	return func() error {
+		calls++
+		if calls <= len(errs) {
+			return errs[calls-1]
+		}
+		return nil
+	}, &calls
+}
+
+func TestRetry(t *testing.T) {
+	ctx := r.WithRequestID(context.Background(), "retry-test")
+	config := Config{MaxAttempts: 3, Backoff: time.Millisecond, Budget: time.Minute}
+
+	t.Run("Succeeds After Retries", func(t *testing.T) {
+		attempt, calls := attempts(errSerialization, errSerialization)
+		if err := retry(ctx, config, attempt); err != nil {
+			t.Errorf("Expected success, got: %v", err)
+		}
+		if *calls != 3 {
+			t.Errorf("Expected 3 attempts, got %d", *calls)
+		}
+	})
+
+	t.Run("Stops At Attempt Limit", func(t *testing.T) {
+		attempt, calls := attempts(errSerialization, errSerialization, errSerialization, errSerialization)
+		if err := retry(ctx, config, attempt); err != errSerialization {
+			t.Errorf("Expected the error of the last attempt, got: %v", err)
+		}
+		if *calls != config.MaxAttempts {
+			t.Errorf("Expected %d attempts, got %d", config.MaxAttempts, *calls)
+		}
+	})
+
+	t.Run("Does Not Retry Other Errors", func(t *testing.T) {
+		unique := &pgconn.PgError{Code: "23505"}
+		attempt, calls := attempts(unique)
+		if err := retry(ctx, config, attempt); err != unique {
+			t.Errorf("Expected the error of the attempt, got: %v", err)
+		}
+		if *calls != 1 {
+			t.Errorf("Expected 1 attempt, got %d", *calls)
+		}
+	})
+
+	t.Run("Stops At Budget", func(t *testing.T) {
+		budget := Config{MaxAttempts: 3, Backoff: time.Hour, Budget: time.Millisecond}
+		attempt, calls := attempts(errSerialization, errSerialization)
+		err := retry(ctx, budget, attempt)
+		if !e.Is(err, errSerialization) || *calls != 1 {
+			t.Errorf("Expected the budget to stop the retries after 1 attempt, got %d: %v", *calls, err)
+		}
+	})
+
+	t.Run("Stops When Canceled", func(t *testing.T) {
+		canceled, cancel := context.WithCancel(ctx)
+		cancel()
+		long := Config{MaxAttempts: 3, Backoff: time.Hour, Budget: 24 * time.Hour}
+		attempt, calls := attempts(errSerialization, errSerialization)
+
+		start := time.Now()
+		err := retry(canceled, long, attempt)
+		if !e.Is(err, errSerialization) || *calls != 1 {
+			t.Errorf("Expected the cancellation to stop the retries after 1 attempt, got %d: %v", *calls, err)
+		}
+		if time.Since(start) > time.Second {
+			t.Errorf("Expected the cancellation to end the wait, waited %v", time.Since(start))
+		}
+	})
+
+	t.Run("Stops When Canceled During Wait", func(t *testing.T) {
+		canceling, cancel := context.WithCancel(ctx)
+		defer cancel()
+		long := Config{MaxAttempts: 3, Backoff: time.Hour, Budget: 24 * time.Hour}
+		calls := 0
+		attempt := func() error {
+			calls++
+			time.AfterFunc(10*time.Millisecond, cancel)
+			return errSerialization
+		}
+
+		if err := retry(canceling, long, attempt); !e.Is(err, errSerialization) || calls != 1 {
+			t.Errorf("Expected the cancellation to stop the retries after 1 attempt, got %d: %v", calls, err)
+		}
+	})
+}
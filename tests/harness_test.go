@@ -0,0 +1,367 @@
+// tests/harness_test.go
+package tests
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/test/bufconn"
+
+	audit "github.com/BwezB/Wikno-backend/api/proto/audit"
+	auth "github.com/BwezB/Wikno-backend/api/proto/auth"
+	graph "github.com/BwezB/Wikno-backend/api/proto/graph"
+
+	authapi "github.com/BwezB/Wikno-backend/internal/auth/api"
+	authdb "github.com/BwezB/Wikno-backend/internal/auth/db"
+	authmemory "github.com/BwezB/Wikno-backend/internal/auth/db/memory"
+	authservice "github.com/BwezB/Wikno-backend/internal/auth/service"
+	"github.com/BwezB/Wikno-backend/internal/gateway"
+	graphapi "github.com/BwezB/Wikno-backend/internal/graph/api"
+	graphdb "github.com/BwezB/Wikno-backend/internal/graph/db"
+	graphmemory "github.com/BwezB/Wikno-backend/internal/graph/db/memory"
+	"github.com/BwezB/Wikno-backend/internal/graph/graphql"
+	graphservice "github.com/BwezB/Wikno-backend/internal/graph/service"
+
+	"github.com/go-playground/validator/v10"
+
+	au "github.com/BwezB/Wikno-backend/pkg/audit"
+	a "github.com/BwezB/Wikno-backend/pkg/auth"
+	e "github.com/BwezB/Wikno-backend/pkg/errors"
+	g "github.com/BwezB/Wikno-backend/pkg/graph"
+	h "github.com/BwezB/Wikno-backend/pkg/health"
+	l "github.com/BwezB/Wikno-backend/pkg/log"
+	m "github.com/BwezB/Wikno-backend/pkg/metrics"
+)
+
+// The tests run against services started in-process by the harness, unless TEST_EXTERNAL_SERVICES is set,
+// in which case they call the services and the gateway running at the hard-coded addresses.
+// The in-process services run the tests on every storage: in memory, and on postgres if a database server
+// is configured with DB_HOST, using the auth_db and graph_db databases of the server of the DB_* variables.
+// TEST_STORAGE runs them on one storage only, "memory" or "postgres".
+const (
+	envExternalServices = "TEST_EXTERNAL_SERVICES"
+	envStorage          = "TEST_STORAGE"
+	envDatabaseHost     = "DB_HOST"
+)
+
+// sharedHarness runs the services for all tests of the package, nil if the tests use external services
+var sharedHarness *harness
+
+// The HTTP endpoints of the services, which the harness points at its own servers
+var (
+	gatewayURL      = "http://" + gateway_host + ":" + gateway_port
+	graphQLURL      = graphql_url
+	authGrpcWebURL  = auth_grpc_web_url
+	graphGrpcWebURL = graph_grpc_web_url
+)
+
+func TestMain(tm *testing.M) {
+	if os.Getenv(envExternalServices) != "" {
+		os.Exit(tm.Run())
+	}
+
+	code := 0
+	for _, storage := range testStorages() {
+		if storageCode := runOnStorage(tm, storage); storageCode != 0 {
+			code = storageCode
+		}
+	}
+	os.Exit(code)
+}
+
+// testStorages returns the storages to run the tests on, the one of TEST_STORAGE if it is set
+func testStorages() []string {
+	if storage := os.Getenv(envStorage); storage != "" {
+		return []string{storage}
+	}
+	if os.Getenv(envDatabaseHost) == "" {
+		fmt.Println("Skipping the tests on postgres, as no database server is configured with " + envDatabaseHost)
+		return []string{graphdb.StorageMemory}
+	}
+	return []string{graphdb.StorageMemory, graphdb.StoragePostgres}
+}
+
+// runOnStorage runs the tests against a harness on the storage, and returns their exit code
+func runOnStorage(tm *testing.M, storage string) int {
+	fmt.Println("Running the tests on " + storage)
+	var err error
+	sharedHarness, err = newHarness(storage)
+	if err != nil {
+		l.Fatal("Could not start test harness:", l.ErrField(err))
+	}
+	defer sharedHarness.Close()
+
+	gatewayURL = sharedHarness.gateway.URL
+	graphQLURL = sharedHarness.graphQL.URL + sharedHarness.graphQLPath
+	authGrpcWebURL = sharedHarness.authGrpcWeb.URL
+	graphGrpcWebURL = sharedHarness.graphGrpcWeb.URL
+	return tm.Run()
+}
+
+// harness runs the auth and graph services in-process, on in-memory listeners,
+// and their HTTP APIs and the gateway on local test servers
+type harness struct {
+	authConn  *grpc.ClientConn
+	graphConn *grpc.ClientConn
+
+	authServer  *authapi.Server
+	graphServer *graphapi.Server
+
+	gateway      *httptest.Server
+	graphQL      *httptest.Server
+	graphQLPath  string
+	authGrpcWeb  *httptest.Server
+	graphGrpcWeb *httptest.Server
+}
+
+// newHarness starts both services on the storage, "memory" if it is empty.
+// The auth service creates graph users through the graph service, and the graph service verifies tokens
+// with the auth service, both over in-memory connections. The gateway calls both over the same connections.
+func newHarness(storage string) (*harness, error) {
+	// Only problems are logged, unless LOG_LEVEL asks for more
+	logConfig := l.LoggerConfig{}
+	logConfig.SetDefaults()
+	logConfig.Level = "error"
+	logConfig.AddFromEnv()
+	if err := l.InitLogger(logConfig); err != nil {
+		return nil, e.Wrap("Could not initialize logger", err)
+	}
+
+	// The auth service reads its secrets from the environment only
+	setEnvDefault("JWT_SECRET", "test-harness-jwt-secret")
+	setEnvDefault("AUTH_PASSWORD", "test-harness-password")
+
+	authListener := bufconn.Listen(1 << 20)
+	graphListener := bufconn.Listen(1 << 20)
+	harness := &harness{
+		authConn:  dialListener(authListener),
+		graphConn: dialListener(graphListener),
+	}
+
+	validator := validator.New()
+	auditConfig := au.AuditConfig{AdminEmails: audit_admin_email}
+	healthConfig := h.HealthServiceConfig{}
+	healthConfig.SetDefaults()
+
+	// STORAGE
+	var userStore authdb.UserStore
+	var repository graphdb.GraphRepository
+	var authAuditor, graphAuditor *au.Auditor
+	switch storage {
+	case "", graphdb.StorageMemory:
+		userStore = authmemory.New()
+		repository = graphmemory.New()
+		authAuditor = au.NewMemory("authservice")
+		graphAuditor = au.NewMemory("graphservice")
+	case graphdb.StoragePostgres:
+		authDatabase, graphDatabase, err := connectDatabases()
+		if err != nil {
+			return nil, err
+		}
+		userStore, repository = authDatabase, graphDatabase
+		authAuditor = au.New(authDatabase.DB, "authservice")
+		graphAuditor = au.New(graphDatabase.DB, "graphservice")
+	default:
+		return nil, e.New("Unknown storage "+storage+", storages are memory and postgres", e.ErrInvalidRequest, nil)
+	}
+
+	// GRAPH SERVICE
+	graphConfig := graphServerConfig()
+	graphConfig.Listener = graphListener
+	graphServiceConfig := graphservice.ServiceConfig{}
+	graphServiceConfig.SetDefaults()
+	graphServiceConfig.WebhookAllowPrivate = true // The test receivers listen on loopback
+
+	graphService := graphservice.NewService(repository, graphAuditor, graphServiceConfig)
+	graphAuthService := a.NewAuthServiceWithConn(harness.authConn)
+
+	graphServer, err := graphapi.NewServer(
+		graphService,
+		h.NewHealthService(healthConfig),
+		m.NewMetrics("graphservice"),
+		graphAuthService,
+		au.NewServer(graphAuditor, validator, auditConfig),
+		validator,
+		graphConfig)
+	if err != nil {
+		return nil, e.Wrap("Could not create graph server", err)
+	}
+	harness.graphServer = graphServer
+
+	graphQLConfig := graphql.ServerConfig{}
+	graphQLConfig.SetDefaults()
+	graphQLServer, err := graphql.NewServer(graphService, graphAuthService, validator, graphQLConfig)
+	if err != nil {
+		return nil, e.Wrap("Could not create GraphQL server", err)
+	}
+
+	// AUTH SERVICE
+	authConfig := authServerConfig()
+	authConfig.Listener = authListener
+	authServiceConfig := authservice.ServiceConfig{}
+	authServiceConfig.SetDefaults()
+	authServiceConfig.AddFromEnv()
+
+	authService, err := authservice.NewAuthService(userStore, g.NewGraphServiceWithConn(harness.graphConn), authAuditor, authServiceConfig)
+	if err != nil {
+		return nil, e.Wrap("Could not create auth service", err)
+	}
+	authServer, err := authapi.NewServer(
+		authService,
+		h.NewHealthService(healthConfig),
+		m.NewMetrics("authservice"),
+		au.NewServer(authAuditor, validator, auditConfig),
+		validator,
+		authConfig)
+	if err != nil {
+		return nil, e.Wrap("Could not create auth server", err)
+	}
+	harness.authServer = authServer
+
+	// GATEWAY
+	gatewayConfig := gateway.ServerConfig{}
+	gatewayConfig.SetDefaults()
+	gatewayServer, err := gateway.New(gatewayConfig, gateway.Services(harness.authConn, harness.graphConn)...)
+	if err != nil {
+		return nil, e.Wrap("Could not create gateway", err)
+	}
+
+	graphServer.Serve()
+	authServer.Serve()
+	harness.gateway = httptest.NewServer(gatewayServer.Handler())
+	harness.graphQL = httptest.NewServer(graphQLServer.Handler())
+	harness.graphQLPath = graphQLConfig.Path
+	harness.authGrpcWeb = httptest.NewServer(authServer.GrpcWebHandler())
+	harness.graphGrpcWeb = httptest.NewServer(graphServer.GrpcWebHandler())
+	return harness, nil
+}
+
+// Close stops both services and closes the connections to them
+func (hs *harness) Close() {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	hs.gateway.Close()
+	hs.graphQL.Close()
+	hs.authGrpcWeb.Close()
+	hs.graphGrpcWeb.Close()
+	hs.authConn.Close()
+	hs.graphConn.Close()
+	if err := hs.authServer.Shutdown(ctx); err != nil {
+		l.Warn("Could not shut down auth server", l.ErrField(err))
+	}
+	if err := hs.graphServer.Shutdown(ctx); err != nil {
+		l.Warn("Could not shut down graph server", l.ErrField(err))
+	}
+}
+
+// clients returns clients of both services, with a context that times out like the one of setupClients
+func (hs *harness) clients() *testClients {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	return &testClients{
+		authClient:  auth.NewAuthServiceClient(hs.authConn),
+		graphClient: graph.NewGraphServiceClient(hs.graphConn),
+		authAudit:   audit.NewAuditServiceClient(hs.authConn),
+		graphAudit:  audit.NewAuditServiceClient(hs.graphConn),
+		ctx:         ctx,
+		cancel:      cancel,
+	}
+}
+
+// HELPERS FOR TESTS
+
+// registerUser registers a user with the test password, and returns the context and token of the user.
+// Users that are already registered are logged in.
+func registerUser(t *testing.T, clients *testClients, email string) (context.Context, string) {
+	t.Helper()
+	resp, err := clients.authClient.Register(clients.ctx, &auth.AuthRequest{
+		Email:    email,
+		Password: "testpassword123",
+	})
+	if err != nil {
+		return getAuthenticatedContextFor(t, clients, email)
+	}
+	return metadata.NewOutgoingContext(clients.ctx, metadata.Pairs("authorization", resp.Token)), resp.Token
+}
+
+// HELPER FUNCTIONS
+
+func dialListener(listener *bufconn.Listener) *grpc.ClientConn {
+	// Dialing does not connect yet, so it cannot fail
+	conn, _ := grpc.Dial("bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	return conn
+}
+
+// graphServerConfig returns the default server config of the graph service, with the HTTP servers on free ports
+// and gRPC-Web calls allowed from the test origin
+func graphServerConfig() graphapi.ServerConfig {
+	config := graphapi.ServerConfig{}
+	config.SetDefaults()
+	config.Metrics.Port = 0
+	config.GrpcWeb.Port = 0
+	config.GrpcWeb.AllowedOrigins = grpc_web_origin
+	return config
+}
+
+// authServerConfig returns the default server config of the auth service, with the HTTP servers on free ports,
+// gRPC-Web calls allowed from the test origin and calls without a user not rate limited
+func authServerConfig() authapi.ServerConfig {
+	config := authapi.ServerConfig{}
+	config.SetDefaults()
+	config.Metrics.Port = 0
+	config.GrpcWeb.Port = 0
+	config.GrpcWeb.AllowedOrigins = grpc_web_origin
+	// Every in-process call comes from the same address, so the per-IP quotas of logins would throttle the tests
+	config.RateLimit.Methods = "Login=0/0,Register=0/0,VerifyToken=0/0,Ping=0/0"
+	return config
+}
+
+// connectDatabases connects to the databases of both services and applies their migrations
+func connectDatabases() (*authdb.Database, *graphdb.Database, error) {
+	ctx := context.Background()
+
+	// Both services use the server of the DB_* variables, each with its own database
+	authConfig := authdb.DatabaseConfig{}
+	authConfig.SetDefaults()
+	authConfig.AddFromEnv()
+	authConfig.DBName = "auth_db"
+	authDatabase, err := authdb.New(authConfig)
+	if err != nil {
+		return nil, nil, e.Wrap("Could not connect to auth database", err)
+	}
+	if err := authDatabase.Migrate(ctx); err != nil {
+		return nil, nil, e.Wrap("Could not migrate auth database", err)
+	}
+
+	graphConfig := graphdb.DatabaseConfig{}
+	graphConfig.SetDefaults()
+	graphConfig.AddFromEnv()
+	graphConfig.DBName = "graph_db"
+	graphDatabase, err := graphdb.New(graphConfig)
+	if err != nil {
+		return nil, nil, e.Wrap("Could not connect to graph database", err)
+	}
+	if err := graphDatabase.Migrate(ctx); err != nil {
+		return nil, nil, e.Wrap("Could not migrate graph database", err)
+	}
+
+	return authDatabase, graphDatabase, nil
+}
+
+func setEnvDefault(key, value string) {
+	if os.Getenv(key) == "" {
+		os.Setenv(key, value)
+	}
+}
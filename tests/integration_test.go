@@ -2,18 +2,47 @@
 package tests
 
 import (
+    "bytes"
     "context"
+    "crypto/hmac"
+    "crypto/sha256"
+    "encoding/binary"
+    "encoding/hex"
+    "encoding/json"
+    "io"
+    "net"
+    "net/http"
+    "net/http/httptest"
+    "strconv"
+    "strings"
     "testing"
     "time"
 
+    "google.golang.org/genproto/googleapis/rpc/errdetails"
     "google.golang.org/grpc"
     "google.golang.org/grpc/credentials/insecure"
     "google.golang.org/grpc/status"
     "google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
+    "google.golang.org/protobuf/proto"
+    "google.golang.org/protobuf/types/known/timestamppb"
 
+    audit "github.com/BwezB/Wikno-backend/api/proto/audit"
     auth "github.com/BwezB/Wikno-backend/api/proto/auth"
     graph "github.com/BwezB/Wikno-backend/api/proto/graph"
+
+    authdb "github.com/BwezB/Wikno-backend/internal/auth/db"
+    authmemory "github.com/BwezB/Wikno-backend/internal/auth/db/memory"
+    authmodel "github.com/BwezB/Wikno-backend/internal/auth/model"
+    authservice "github.com/BwezB/Wikno-backend/internal/auth/service"
+    "github.com/BwezB/Wikno-backend/internal/gateway"
+    ad "github.com/BwezB/Wikno-backend/pkg/admin"
+    e "github.com/BwezB/Wikno-backend/pkg/errors"
+    graphmemory "github.com/BwezB/Wikno-backend/pkg/graph/memory"
+    gw "github.com/BwezB/Wikno-backend/pkg/grpcweb"
+    l "github.com/BwezB/Wikno-backend/pkg/log"
+    m "github.com/BwezB/Wikno-backend/pkg/metrics"
+    rl "github.com/BwezB/Wikno-backend/pkg/ratelimit"
 )
 
 const (
@@ -21,16 +50,31 @@ const (
     auth_port = "50051"
     graph_host = "localhost"
     graph_port = "50052"
+    gateway_host = "localhost"
+    gateway_port = "8080"
+    graphql_url = "http://localhost:8082/graphql"
+    auth_grpc_web_url = "http://localhost:8081"
+    graph_grpc_web_url = "http://localhost:8083"
+    // Both services must be started with this origin in GRPC_WEB_ALLOWED_ORIGINS
+    grpc_web_origin = "http://localhost:3000"
+    // Both services must be started with this email in AUDIT_ADMIN_EMAILS
+    audit_admin_email = "auditor@example.com"
 )
 
 type testClients struct {
     authClient  auth.AuthServiceClient
     graphClient graph.GraphServiceClient
+    authAudit   audit.AuditServiceClient
+    graphAudit  audit.AuditServiceClient
     ctx         context.Context
     cancel      context.CancelFunc
 }
 
 func setupClients(t *testing.T) *testClients {
+    if sharedHarness != nil {
+        return sharedHarness.clients()
+    }
+
     ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
     
     // Connect to auth service
@@ -48,6 +92,8 @@ func setupClients(t *testing.T) *testClients {
     return &testClients{
         authClient:  auth.NewAuthServiceClient(authConn),
         graphClient: graph.NewGraphServiceClient(graphConn),
+        authAudit:   audit.NewAuditServiceClient(authConn),
+        graphAudit:  audit.NewAuditServiceClient(graphConn),
         ctx:         ctx,
         cancel:      cancel,
     }
@@ -140,6 +186,180 @@ func TestAuthService(t *testing.T) {
     })
 }
 
+// Test the auth service without postgres or a graph service
+
+func newHermeticAuthService(t *testing.T) (*authservice.AuthService, *authmemory.Store, *graphmemory.Provisioner) {
+    logConfig := l.LoggerConfig{}
+    logConfig.SetDefaults()
+    l.InitLogger(logConfig)
+
+    t.Setenv("JWT_SECRET", "hermetic-test-secret")
+    t.Setenv("AUTH_PASSWORD", "hermetic-service-password")
+    config := authservice.ServiceConfig{}
+    config.SetDefaults()
+    config.AddFromEnv()
+
+    store := authmemory.New()
+    provisioner := graphmemory.New()
+    service, err := authservice.NewAuthService(store, provisioner, nil, config)
+    if err != nil {
+        t.Fatalf("Could not create auth service: %v", err)
+    }
+    return service, store, provisioner
+}
+
+func TestAuthServiceInMemory(t *testing.T) {
+    service, store, provisioner := newHermeticAuthService(t)
+    ctx := context.Background()
+    request := &authmodel.AuthRequest{Email: "hermetic@example.com", Password: "testpassword123"}
+
+    var registered *authmodel.AuthResponse
+    t.Run("Register Success", func(t *testing.T) {
+        resp, err := service.RegisterUser(ctx, request)
+        if err != nil {
+            t.Fatalf("Registration failed: %v", err)
+        }
+        if resp.Token == "" {
+            t.Error("Expected token in response")
+        }
+        if !provisioner.HasUser(resp.User.ID) {
+            t.Error("Expected the graph user to be created")
+        }
+        profile, err := store.GetProfile(ctx, resp.User.ID)
+        if err != nil || profile.DisplayName != "hermetic" {
+            t.Errorf("Expected default profile, got %+v, %v", profile, err)
+        }
+        registered = resp
+    })
+
+    t.Run("Register Duplicate", func(t *testing.T) {
+        _, err := service.RegisterUser(ctx, request)
+        if !e.Is(err, authdb.ErrDuplicateEntry) {
+            t.Errorf("Expected duplicate entry error, got: %v", err)
+        }
+        if len(provisioner.Users()) != 1 {
+            t.Errorf("Expected one graph user, got %d", len(provisioner.Users()))
+        }
+    })
+
+    t.Run("Login Success", func(t *testing.T) {
+        resp, err := service.LoginUser(ctx, request)
+        if err != nil {
+            t.Fatalf("Login failed: %v", err)
+        }
+        if resp.User.ID != registered.User.ID {
+            t.Errorf("Expected user %s, got %s", registered.User.ID, resp.User.ID)
+        }
+    })
+
+    t.Run("Login Invalid", func(t *testing.T) {
+        _, err := service.LoginUser(ctx, &authmodel.AuthRequest{Email: request.Email, Password: "wrongpassword"})
+        if !e.Is(err, authservice.ErrInvalidPassword) {
+            t.Errorf("Expected invalid password error, got: %v", err)
+        }
+        _, err = service.LoginUser(ctx, &authmodel.AuthRequest{Email: "nobody@example.com", Password: "testpassword123"})
+        if !e.Is(err, authdb.ErrRecordNotFound) {
+            t.Errorf("Expected not found error, got: %v", err)
+        }
+    })
+
+    t.Run("Token Verification", func(t *testing.T) {
+        resp, err := service.VerifyToken(ctx, &authmodel.VerifyTokenRequest{Token: registered.Token})
+        if err != nil {
+            t.Fatalf("Token verification failed: %v", err)
+        }
+        if resp.User.Email != request.Email {
+            t.Errorf("Expected email %s, got %s", request.Email, resp.User.Email)
+        }
+    })
+
+    t.Run("Invalid Token Verification", func(t *testing.T) {
+        _, err := service.VerifyToken(ctx, &authmodel.VerifyTokenRequest{Token: "invalid.token.here"})
+        if !e.Is(err, authservice.ErrInvalidToken) {
+            t.Errorf("Expected invalid token error, got: %v", err)
+        }
+    })
+}
+
+// Test Profiles
+
+func TestProfiles(t *testing.T) {
+    clients := setupClients(t)
+    defer clients.cancel()
+
+    authCtx, token := getAuthenticatedContext(t, clients)
+    verifyResp, err := clients.authClient.VerifyToken(clients.ctx, &auth.VerifyTokenRequest{Token: token})
+    if err != nil {
+        t.Fatalf("Token verification failed: %v", err)
+    }
+    userID := verifyResp.UserId
+
+    // Test that profiles require authentication
+    t.Run("Get Profile No Token", func(t *testing.T) {
+        _, err := clients.authClient.GetProfile(clients.ctx, &auth.GetProfileRequest{UserId: userID})
+        if status.Code(err) != codes.Unauthenticated {
+            t.Errorf("Expected Unauthenticated error, got: %v", err)
+        }
+    })
+
+    // Test the default profile created at registration
+    t.Run("Get Default Profile", func(t *testing.T) {
+        profile, err := clients.authClient.GetProfile(authCtx, &auth.GetProfileRequest{UserId: userID})
+        if err != nil {
+            t.Fatalf("Getting profile failed: %v", err)
+        }
+        if profile.DisplayName == "" {
+            t.Error("Expected a default display name")
+        }
+    })
+
+    // Test updating the profile
+    t.Run("Update Profile", func(t *testing.T) {
+        profile, err := clients.authClient.UpdateProfile(authCtx, &auth.UpdateProfileRequest{
+            DisplayName: "Test User",
+            AvatarUrl:   "https://example.com/avatar.png",
+            Bio:         "Test Bio",
+        })
+        if err != nil {
+            t.Fatalf("Updating profile failed: %v", err)
+        }
+        if profile.DisplayName != "Test User" || profile.UserId != userID {
+            t.Errorf("Unexpected profile: %v", profile)
+        }
+    })
+
+    // Test resolving user IDs in bulk
+    t.Run("Batch Get Profiles", func(t *testing.T) {
+        profiles, err := clients.authClient.BatchGetProfiles(authCtx, &auth.BatchGetProfilesRequest{
+            UserIds: []string{userID, "123e4567-e89b-12d3-a456-426614174000"},
+        })
+        if err != nil {
+            t.Fatalf("Batch getting profiles failed: %v", err)
+        }
+        if len(profiles.Profiles) != 1 || profiles.Profiles[0].DisplayName != "Test User" {
+            t.Errorf("Expected only the updated profile, got: %v", profiles.Profiles)
+        }
+    })
+
+    // Test that the graph service resolves the authors of versions
+    t.Run("Graph Authors", func(t *testing.T) {
+        entity, err := clients.graphClient.CreateEntity(authCtx, &graph.EntityRequest{
+            Name:       "Authored Entity",
+            Definition: "Authored Definition",
+        })
+        if err != nil {
+            t.Fatalf("Entity creation failed: %v", err)
+        }
+        versions, err := clients.graphClient.GetEntityVersions(authCtx, &graph.IdRequest{Id: entity.EntityId})
+        if err != nil {
+            t.Fatalf("Getting entity versions failed: %v", err)
+        }
+        if len(versions.Entities) != 1 || versions.Entities[0].Author.GetDisplayName() != "Test User" {
+            t.Errorf("Expected the version to be authored by Test User, got: %v", versions.Entities)
+        }
+    })
+}
+
 // Test Graph Service
 
 func TestGraphService(t *testing.T) {
@@ -176,7 +396,7 @@ func TestGraphService(t *testing.T) {
 
     // Test getting user data
     t.Run("Get User Data", func(t *testing.T) {
-        userData, err := clients.graphClient.GetUserData(authCtx, &graph.Empty{})
+        userData, err := clients.graphClient.GetUserData(authCtx, &graph.UserDataRequest{})
         if err != nil {
             t.Fatalf("Getting user data failed: %v", err)
         }
@@ -279,10 +499,1701 @@ func TestGraphService(t *testing.T) {
 	})
 }
 
-// Helper function to get authenticated context
-func getAuthenticatedContext(t *testing.T, clients *testClients) (context.Context, string) {
+// Test Workspaces
+
+func TestWorkspaces(t *testing.T) {
+    clients := setupClients(t)
+    defer clients.cancel()
+
+    ownerCtx, _ := getAuthenticatedContext(t, clients)
+    memberResp, err := clients.authClient.Register(clients.ctx, &auth.AuthRequest{
+        Email:    "member@example.com",
+        Password: "testpassword123",
+    })
+    if err != nil {
+        t.Fatalf("Registering member failed: %v", err)
+    }
+    memberCtx := metadata.NewOutgoingContext(clients.ctx, metadata.Pairs("authorization", memberResp.Token))
+
+    // Test creating a workspace
+    var workspaceID string
+    t.Run("Create Workspace", func(t *testing.T) {
+        workspace, err := clients.graphClient.CreateWorkspace(ownerCtx, &graph.WorkspaceRequest{Name: "Test Workspace"})
+        if err != nil {
+            t.Fatalf("Workspace creation failed: %v", err)
+        }
+        if workspace.Role != "admin" {
+            t.Errorf("Expected creator to be admin, got %s", workspace.Role)
+        }
+        workspaceID = workspace.Id
+    })
+    ownerWorkspaceCtx := metadata.AppendToOutgoingContext(ownerCtx, "workspace-id", workspaceID)
+    memberWorkspaceCtx := metadata.AppendToOutgoingContext(memberCtx, "workspace-id", workspaceID)
+
+    // Test creating an entity in the workspace
+    var workspaceEntity *graph.UsersEntity
+    t.Run("Create Workspace Entity", func(t *testing.T) {
+        entity, err := clients.graphClient.CreateEntity(ownerWorkspaceCtx, &graph.EntityRequest{
+            Name:       "Workspace Entity",
+            Definition: "Workspace Definition",
+        })
+        if err != nil {
+            t.Fatalf("Workspace entity creation failed: %v", err)
+        }
+        if entity.UserId != workspaceID {
+            t.Errorf("Expected the workspace to own the entity, got owner %s", entity.UserId)
+        }
+        workspaceEntity = entity
+    })
+
+    // Test that workspace versions are only visible in the workspace
+    t.Run("Workspace Versions Private", func(t *testing.T) {
+        found, err := clients.graphClient.FindEntities(memberCtx, &graph.SearchRequest{Name: "Workspace Entity"})
+        if err == nil && len(found.Entities) != 0 {
+            t.Errorf("Expected no entities outside the workspace, got %v", found.Entities)
+        }
+        _, err = clients.graphClient.GetEntityVersions(memberCtx, &graph.IdRequest{Id: workspaceEntity.EntityId})
+        if status.Code(err) != codes.NotFound {
+            t.Errorf("Expected NotFound error outside the workspace, got: %v", err)
+        }
+        _, err = clients.graphClient.Vote(memberCtx, &graph.VoteRequest{
+            Kind:     "entity",
+            Id:       workspaceEntity.EntityId,
+            AuthorId: workspaceID,
+            Value:    1,
+        })
+        if status.Code(err) != codes.NotFound {
+            t.Errorf("Expected NotFound error voting outside the workspace, got: %v", err)
+        }
+
+        versions, err := clients.graphClient.GetEntityVersions(ownerWorkspaceCtx, &graph.IdRequest{Id: workspaceEntity.EntityId})
+        if err != nil {
+            t.Fatalf("Getting workspace entity versions failed: %v", err)
+        }
+        if len(versions.Entities) != 1 || versions.Entities[0].UserCount != 1 {
+            t.Errorf("Expected the workspace version, got %v", versions.Entities)
+        }
+    })
+
+    // Test that non-members cannot access the workspace
+    t.Run("Non Member Denied", func(t *testing.T) {
+        _, err := clients.graphClient.GetUserData(memberWorkspaceCtx, &graph.UserDataRequest{})
+        if status.Code(err) != codes.PermissionDenied {
+            t.Errorf("Expected PermissionDenied error, got: %v", err)
+        }
+        _, err = clients.graphClient.FindEntityClasses(memberWorkspaceCtx, &graph.SearchRequest{Name: "Workspace Class"})
+        if status.Code(err) != codes.PermissionDenied {
+            t.Errorf("Expected PermissionDenied error for a search, got: %v", err)
+        }
+    })
+
+    // Test that workspaces cannot be invited as members
+    t.Run("Invite Workspace Rejected", func(t *testing.T) {
+        other, err := clients.graphClient.CreateWorkspace(ownerCtx, &graph.WorkspaceRequest{Name: "Other Workspace"})
+        if err != nil {
+            t.Fatalf("Workspace creation failed: %v", err)
+        }
+        _, err = clients.graphClient.InviteMember(ownerCtx, &graph.MemberRequest{
+            WorkspaceId: workspaceID,
+            UserId:      other.Id,
+            Role:        "viewer",
+        })
+        if status.Code(err) != codes.InvalidArgument {
+            t.Errorf("Expected InvalidArgument error, got: %v", err)
+        }
+    })
+
+    // Test inviting and accepting
+    t.Run("Invite And Accept", func(t *testing.T) {
+        _, err := clients.graphClient.InviteMember(ownerCtx, &graph.MemberRequest{
+            WorkspaceId: workspaceID,
+            UserId:      memberResp.UserId,
+            Role:        "viewer",
+        })
+        if err != nil {
+            t.Fatalf("Inviting member failed: %v", err)
+        }
+
+        invitations, err := clients.graphClient.ListInvitations(memberCtx, &graph.Empty{})
+        if err != nil || len(invitations.Invitations) != 1 {
+            t.Fatalf("Expected one invitation, got %v (err: %v)", invitations, err)
+        }
+
+        workspace, err := clients.graphClient.AcceptInvitation(memberCtx, &graph.InvitationIdRequest{
+            Id: invitations.Invitations[0].Id,
+        })
+        if err != nil {
+            t.Fatalf("Accepting invitation failed: %v", err)
+        }
+        if workspace.Role != "viewer" {
+            t.Errorf("Expected viewer role, got %s", workspace.Role)
+        }
+    })
+
+    // Test that viewers can read but not write
+    t.Run("Viewer Permissions", func(t *testing.T) {
+        userData, err := clients.graphClient.GetUserData(memberWorkspaceCtx, &graph.UserDataRequest{})
+        if err != nil {
+            t.Fatalf("Getting workspace data failed: %v", err)
+        }
+        if len(userData.Entities) != 1 {
+            t.Errorf("Expected the workspace entity, got %v", userData.Entities)
+        }
+
+        _, err = clients.graphClient.CreateEntity(memberWorkspaceCtx, &graph.EntityRequest{
+            Name:       "Viewer Entity",
+            Definition: "Viewer Definition",
+        })
+        if status.Code(err) != codes.PermissionDenied {
+            t.Errorf("Expected PermissionDenied error, got: %v", err)
+        }
+    })
+
+    // Test that the last admin cannot leave
+    t.Run("Last Admin Cannot Leave", func(t *testing.T) {
+        members, err := clients.graphClient.ListMembers(memberCtx, &graph.WorkspaceIdRequest{WorkspaceId: workspaceID})
+        if err != nil || len(members.Members) != 2 {
+            t.Fatalf("Expected two members, got %v (err: %v)", members, err)
+        }
+
+        var adminID string
+        for _, member := range members.Members {
+            if member.Role == "admin" {
+                adminID = member.UserId
+            }
+        }
+        _, err = clients.graphClient.RemoveMember(ownerCtx, &graph.MemberRequest{
+            WorkspaceId: workspaceID,
+            UserId:      adminID,
+        })
+        if status.Code(err) != codes.InvalidArgument {
+            t.Errorf("Expected InvalidArgument error, got: %v", err)
+        }
+    })
+}
+
+// Test Consensus
+
+func TestConsensus(t *testing.T) {
+    clients := setupClients(t)
+    defer clients.cancel()
+
+    authorCtx, _ := getAuthenticatedContext(t, clients)
+    voterCtx, _ := getAuthenticatedContextFor(t, clients, "member@example.com")
+
+    // Create an entity and a second version of it
+    author, err := clients.graphClient.CreateEntity(authorCtx, &graph.EntityRequest{
+        Name:       "Consensus Entity",
+        Definition: "Author Definition",
+    })
+    if err != nil {
+        t.Fatalf("Entity creation failed: %v", err)
+    }
+    voter, err := clients.graphClient.CreateEntity(voterCtx, &graph.EntityRequest{
+        Id:         author.EntityId,
+        Name:       "Consensus Alias",
+        Definition: "Voter Definition",
+    })
+    if err != nil {
+        t.Fatalf("Linking entity failed: %v", err)
+    }
+
+    // Test voting on your own version
+    t.Run("Vote Own Version", func(t *testing.T) {
+        _, err := clients.graphClient.Vote(voterCtx, &graph.VoteRequest{
+            Kind:     "entity",
+            Id:       voter.EntityId,
+            AuthorId: voter.UserId,
+            Value:    1,
+        })
+        if status.Code(err) != codes.InvalidArgument {
+            t.Errorf("Expected InvalidArgument error, got: %v", err)
+        }
+    })
+
+    // Test that an upvote makes a version canonical
+    t.Run("Upvote Makes Canonical", func(t *testing.T) {
+        _, err := clients.graphClient.Vote(authorCtx, &graph.VoteRequest{
+            Kind:     "entity",
+            Id:       voter.EntityId,
+            AuthorId: voter.UserId,
+            Value:    1,
+        })
+        if err != nil {
+            t.Fatalf("Voting failed: %v", err)
+        }
+
+        found, err := clients.graphClient.FindEntities(authorCtx, &graph.SearchRequest{Name: "Consensus Entity"})
+        if err != nil {
+            t.Fatalf("Finding entity failed: %v", err)
+        }
+        if len(found.Entities) != 1 {
+            t.Fatalf("Expected one entity, got %v", found.Entities)
+        }
+        canonical := found.Entities[0]
+        if canonical.Name != "Consensus Alias" || canonical.Score != 1 || canonical.UserCount != 2 {
+            t.Errorf("Expected the upvoted version with 2 users, got %v", canonical)
+        }
+    })
+
+    // Test listing all versions
+    t.Run("Get Entity Versions", func(t *testing.T) {
+        versions, err := clients.graphClient.GetEntityVersions(authorCtx, &graph.IdRequest{Id: author.EntityId})
+        if err != nil {
+            t.Fatalf("Getting entity versions failed: %v", err)
+        }
+        if len(versions.Entities) != 2 || versions.Entities[0].UserId != voter.UserId {
+            t.Errorf("Expected the canonical version first, got %v", versions.Entities)
+        }
+    })
+}
+
+// Test Connections
+
+func TestConnections(t *testing.T) {
+    clients := setupClients(t)
+    defer clients.cancel()
+
+    ctx, _ := getAuthenticatedContext(t, clients)
+
+    createEntity := func(name string) string {
+        entity, err := clients.graphClient.CreateEntity(ctx, &graph.EntityRequest{Name: name, Definition: name})
+        if err != nil {
+            t.Fatalf("Entity creation failed: %v", err)
+        }
+        return entity.EntityId
+    }
+    person := createEntity("Connection Person")
+    company := createEntity("Connection Company")
+    department := createEntity("Connection Department")
+
+    employs, err := clients.graphClient.CreateConnectionType(ctx, &graph.ConnectionTypeRequest{
+        Name:       "Employs",
+        Definition: "Employment, from employer to employee",
+    })
+    if err != nil {
+        t.Fatalf("Connection type creation failed: %v", err)
+    }
+
+    var worksAt *graph.UsersConnectionType
+
+    // Test that new inverses are linked both ways
+    t.Run("Create Inverse Connection Type", func(t *testing.T) {
+        worksAt, err = clients.graphClient.CreateConnectionType(ctx, &graph.ConnectionTypeRequest{
+            Name:       "Works at",
+            Definition: "Employment, from employee to employer",
+            InverseId:  employs.ConnectionTypeId,
+        })
+        if err != nil {
+            t.Fatalf("Connection type creation failed: %v", err)
+        }
+        if worksAt.InverseId != employs.ConnectionTypeId {
+            t.Errorf("Expected inverse %s, got %v", employs.ConnectionTypeId, worksAt)
+        }
+
+        found, err := clients.graphClient.FindConnectionTypes(ctx, &graph.SearchRequest{Name: "Employs"})
+        if err != nil {
+            t.Fatalf("Finding connection type failed: %v", err)
+        }
+        if len(found.ConnectionTypes) != 1 || found.ConnectionTypes[0].InverseId != worksAt.ConnectionTypeId {
+            t.Errorf("Expected the inverse to be linked back, got %v", found.ConnectionTypes)
+        }
+    })
+
+    // Test that the inverse of a connection is inferred
+    t.Run("Inferred Inverse Connection", func(t *testing.T) {
+        _, err := clients.graphClient.CreateConnection(ctx, &graph.ConnectionRequest{
+            ConnectionTypeId: employs.ConnectionTypeId,
+            FromEntityId:     company,
+            ToEntityId:       person,
+        })
+        if err != nil {
+            t.Fatalf("Connection creation failed: %v", err)
+        }
+
+        connections, err := clients.graphClient.GetConnections(ctx, &graph.TraversalRequest{EntityId: person})
+        if err != nil {
+            t.Fatalf("Getting connections failed: %v", err)
+        }
+        if len(connections.Connections) != 1 {
+            t.Fatalf("Expected one inferred connection, got %v", connections.Connections)
+        }
+        inferred := connections.Connections[0]
+        if !inferred.Inferred || inferred.ConnectionTypeId != worksAt.ConnectionTypeId || inferred.ToEntityId != company {
+            t.Errorf("Expected the person to work at the company, got %v", inferred)
+        }
+
+        // The inverse is already implied
+        _, err = clients.graphClient.CreateConnection(ctx, &graph.ConnectionRequest{
+            ConnectionTypeId: worksAt.ConnectionTypeId,
+            FromEntityId:     person,
+            ToEntityId:       company,
+        })
+        if status.Code(err) != codes.AlreadyExists {
+            t.Errorf("Expected AlreadyExists error, got: %v", err)
+        }
+    })
+
+    // Test that transitive connections chain
+    t.Run("Inferred Transitive Connection", func(t *testing.T) {
+        partOf, err := clients.graphClient.CreateConnectionType(ctx, &graph.ConnectionTypeRequest{
+            Name:       "Part of",
+            Definition: "Composition, from part to whole",
+            Transitive: true,
+        })
+        if err != nil {
+            t.Fatalf("Connection type creation failed: %v", err)
+        }
+        for _, req := range []*graph.ConnectionRequest{
+            {ConnectionTypeId: partOf.ConnectionTypeId, FromEntityId: person, ToEntityId: department},
+            {ConnectionTypeId: partOf.ConnectionTypeId, FromEntityId: department, ToEntityId: company},
+        } {
+            if _, err := clients.graphClient.CreateConnection(ctx, req); err != nil {
+                t.Fatalf("Connection creation failed: %v", err)
+            }
+        }
+
+        connections, err := clients.graphClient.GetConnections(ctx, &graph.TraversalRequest{
+            EntityId:         person,
+            ConnectionTypeId: partOf.ConnectionTypeId,
+        })
+        if err != nil {
+            t.Fatalf("Getting connections failed: %v", err)
+        }
+        if len(connections.Connections) != 2 {
+            t.Errorf("Expected a stored and an inferred connection, got %v", connections.Connections)
+        }
+    })
+
+    // Test that the domain of a connection type is enforced
+    t.Run("Domain Violation", func(t *testing.T) {
+        manages, err := clients.graphClient.CreateConnectionType(ctx, &graph.ConnectionTypeRequest{
+            Name:       "Manages",
+            Definition: "Management, from a person to what they manage",
+            DomainId:   person,
+        })
+        if err != nil {
+            t.Fatalf("Connection type creation failed: %v", err)
+        }
+        _, err = clients.graphClient.CreateConnection(ctx, &graph.ConnectionRequest{
+            ConnectionTypeId: manages.ConnectionTypeId,
+            FromEntityId:     company,
+            ToEntityId:       department,
+        })
+        if status.Code(err) != codes.InvalidArgument {
+            t.Errorf("Expected InvalidArgument error, got: %v", err)
+        }
+    })
+}
+
+// Test Merges
+
+func TestMerges(t *testing.T) {
+    clients := setupClients(t)
+    defer clients.cancel()
+
+    proposerCtx, _ := getAuthenticatedContext(t, clients)
+    accepterCtx, _ := getAuthenticatedContextFor(t, clients, "member@example.com")
+
+    // Both users create their own entity for the same thing
+    source, err := clients.graphClient.CreateEntity(proposerCtx, &graph.EntityRequest{
+        Name:       "Merge Source",
+        Definition: "Proposer Definition",
+    })
+    if err != nil {
+        t.Fatalf("Entity creation failed: %v", err)
+    }
+    target, err := clients.graphClient.CreateEntity(accepterCtx, &graph.EntityRequest{
+        Name:       "Merge Target",
+        Definition: "Accepter Definition",
+    })
+    if err != nil {
+        t.Fatalf("Entity creation failed: %v", err)
+    }
+
+    var merge *graph.Merge
+
+    // Test that a merge needs two different entities
+    t.Run("Merge Same Entity", func(t *testing.T) {
+        _, err := clients.graphClient.ProposeMerge(proposerCtx, &graph.MergeRequest{
+            SourceId: source.EntityId,
+            TargetId: source.EntityId,
+        })
+        if status.Code(err) != codes.InvalidArgument {
+            t.Errorf("Expected InvalidArgument error, got: %v", err)
+        }
+    })
+
+    t.Run("Propose Merge", func(t *testing.T) {
+        merge, err = clients.graphClient.ProposeMerge(proposerCtx, &graph.MergeRequest{
+            SourceId: source.EntityId,
+            TargetId: target.EntityId,
+        })
+        if err != nil {
+            t.Fatalf("Proposing merge failed: %v", err)
+        }
+        if merge.Status != "proposed" {
+            t.Errorf("Expected proposed merge, got %v", merge)
+        }
+    })
+
+    // Test that proposers cannot accept their own merges
+    t.Run("Accept Own Merge", func(t *testing.T) {
+        _, err := clients.graphClient.AcceptMerge(proposerCtx, &graph.MergeIdRequest{Id: merge.Id})
+        if status.Code(err) != codes.InvalidArgument {
+            t.Errorf("Expected InvalidArgument error, got: %v", err)
+        }
+    })
+
+    // Test that proposers cannot accept their own merges for a workspace either
+    t.Run("Accept Own Merge As Workspace", func(t *testing.T) {
+        workspace, err := clients.graphClient.CreateWorkspace(proposerCtx, &graph.WorkspaceRequest{Name: "Merge Workspace"})
+        if err != nil {
+            t.Fatalf("Workspace creation failed: %v", err)
+        }
+        workspaceCtx := metadata.AppendToOutgoingContext(proposerCtx, "workspace-id", workspace.Id)
+        if _, err := clients.graphClient.CreateEntity(workspaceCtx, &graph.EntityRequest{
+            Id:         target.EntityId,
+            Name:       "Merge Target",
+            Definition: "Workspace Definition",
+        }); err != nil {
+            t.Fatalf("Linking entity failed: %v", err)
+        }
+
+        _, err = clients.graphClient.AcceptMerge(workspaceCtx, &graph.MergeIdRequest{Id: merge.Id})
+        if status.Code(err) != codes.InvalidArgument {
+            t.Errorf("Expected InvalidArgument error, got: %v", err)
+        }
+    })
+
+    t.Run("Accept Merge", func(t *testing.T) {
+        accepted, err := clients.graphClient.AcceptMerge(accepterCtx, &graph.MergeIdRequest{Id: merge.Id})
+        if err != nil {
+            t.Fatalf("Accepting merge failed: %v", err)
+        }
+        if accepted.Status != "merged" || accepted.MovedVersions != 1 {
+            t.Errorf("Expected merged with one moved version, got %v", accepted)
+        }
+
+        versions, err := clients.graphClient.GetEntityVersions(proposerCtx, &graph.IdRequest{Id: target.EntityId})
+        if err != nil {
+            t.Fatalf("Getting entity versions failed: %v", err)
+        }
+        if len(versions.Entities) != 2 {
+            t.Errorf("Expected both versions on the target entity, got %v", versions.Entities)
+        }
+    })
+
+    // Test reverting the merge restores the source entity
+    t.Run("Revert Merge", func(t *testing.T) {
+        reverted, err := clients.graphClient.SplitEntity(proposerCtx, &graph.SplitRequest{MergeId: merge.Id})
+        if err != nil {
+            t.Fatalf("Reverting merge failed: %v", err)
+        }
+        if reverted.Status != "reverted" {
+            t.Errorf("Expected reverted merge, got %v", reverted)
+        }
+
+        versions, err := clients.graphClient.GetEntityVersions(proposerCtx, &graph.IdRequest{Id: source.EntityId})
+        if err != nil {
+            t.Fatalf("Getting entity versions failed: %v", err)
+        }
+        if len(versions.Entities) != 1 || versions.Entities[0].Name != "Merge Source" {
+            t.Errorf("Expected the restored source version, got %v", versions.Entities)
+        }
+    })
+
+    // Test splitting a version off into a new entity
+    t.Run("Split Version", func(t *testing.T) {
+        _, err := clients.graphClient.CreateEntity(proposerCtx, &graph.EntityRequest{
+            Id:         target.EntityId,
+            Name:       "Merge Target Alias",
+            Definition: "Proposer Definition",
+        })
+        if err != nil {
+            t.Fatalf("Linking entity failed: %v", err)
+        }
+
+        split, err := clients.graphClient.SplitEntity(proposerCtx, &graph.SplitRequest{EntityId: target.EntityId})
+        if err != nil {
+            t.Fatalf("Splitting entity failed: %v", err)
+        }
+        if split.Status != "split" || split.TargetId != target.EntityId || split.SourceId == target.EntityId {
+            t.Errorf("Expected a split into a new entity, got %v", split)
+        }
+
+        merges, err := clients.graphClient.ListMerges(proposerCtx, &graph.IdRequest{Id: target.EntityId})
+        if err != nil {
+            t.Fatalf("Listing merges failed: %v", err)
+        }
+        if len(merges.Merges) != 2 || merges.Merges[0].Id != split.Id {
+            t.Errorf("Expected the split and the merge, newest first, got %v", merges.Merges)
+        }
+    })
+}
+
+// Helper function to get authenticated context
+func TestEntityClasses(t *testing.T) {
+    clients := setupClients(t)
+    defer clients.cancel()
+
+    ctx, _ := getAuthenticatedContext(t, clients)
+
+    agent, err := clients.graphClient.CreateEntityClass(ctx, &graph.EntityClassRequest{
+        Name:       "Class Agent",
+        Definition: "Something that acts",
+    })
+    if err != nil {
+        t.Fatalf("Entity class creation failed: %v", err)
+    }
+    person, err := clients.graphClient.CreateEntityClass(ctx, &graph.EntityClassRequest{
+        Name:       "Class Person",
+        Definition: "A human being",
+        ParentIds:  []string{agent.EntityClassId},
+    })
+    if err != nil {
+        t.Fatalf("Entity class creation failed: %v", err)
+    }
+
+    alice, err := clients.graphClient.CreateEntity(ctx, &graph.EntityRequest{Name: "Class Alice", Definition: "A person"})
+    if err != nil {
+        t.Fatalf("Entity creation failed: %v", err)
+    }
+
+    // Test that instances of a subclass are instances of its parents
+    t.Run("Find Instances", func(t *testing.T) {
+        _, err := clients.graphClient.SetClasses(ctx, &graph.ClassesRequest{
+            Kind:     "entity",
+            Id:       alice.EntityId,
+            ClassIds: []string{person.EntityClassId},
+        })
+        if err != nil {
+            t.Fatalf("Setting classes failed: %v", err)
+        }
+
+        classes, err := clients.graphClient.GetClasses(ctx, &graph.NodeRequest{Kind: "entity", Id: alice.EntityId})
+        if err != nil {
+            t.Fatalf("Getting classes failed: %v", err)
+        }
+        if len(classes.EntityClasses) != 1 || classes.EntityClasses[0].EntityClassId != person.EntityClassId {
+            t.Errorf("Expected the entity to be a person, got %v", classes.EntityClasses)
+        }
+
+        found, err := clients.graphClient.FindEntities(ctx, &graph.SearchRequest{Name: "Class Alice", ClassId: agent.EntityClassId})
+        if err != nil {
+            t.Fatalf("Finding entities failed: %v", err)
+        }
+        if len(found.Entities) != 1 || found.Entities[0].EntityId != alice.EntityId {
+            t.Errorf("Expected to find the entity as an agent, got %v", found.Entities)
+        }
+    })
+
+    // Test that a class cannot become its own ancestor
+    t.Run("Reject Cycle", func(t *testing.T) {
+        _, err := clients.graphClient.SetClasses(ctx, &graph.ClassesRequest{
+            Kind:     "entity_class",
+            Id:       agent.EntityClassId,
+            ClassIds: []string{person.EntityClassId},
+        })
+        if status.Code(err) != codes.InvalidArgument {
+            t.Errorf("Expected InvalidArgument error, got: %v", err)
+        }
+    })
+
+    // Test that types declared on a parent apply to its subclasses
+    t.Run("Applicable Types", func(t *testing.T) {
+        knows, err := clients.graphClient.CreateConnectionType(ctx, &graph.ConnectionTypeRequest{
+            Name:       "Class Knows",
+            Definition: "Acquaintance",
+        })
+        if err != nil {
+            t.Fatalf("Connection type creation failed: %v", err)
+        }
+        _, err = clients.graphClient.SetClasses(ctx, &graph.ClassesRequest{
+            Kind:     "connection_type",
+            Id:       knows.ConnectionTypeId,
+            ClassIds: []string{agent.EntityClassId},
+        })
+        if err != nil {
+            t.Fatalf("Setting classes failed: %v", err)
+        }
+
+        types, err := clients.graphClient.GetApplicableTypes(ctx, &graph.IdRequest{Id: person.EntityClassId})
+        if err != nil {
+            t.Fatalf("Getting applicable types failed: %v", err)
+        }
+        if len(types.ConnectionTypes) != 1 || types.ConnectionTypes[0].ConnectionTypeId != knows.ConnectionTypeId {
+            t.Errorf("Expected the inherited connection type, got %v", types.ConnectionTypes)
+        }
+
+        // Connections of the type can only go out of instances of its classes, including subclasses
+        rock, err := clients.graphClient.CreateEntity(ctx, &graph.EntityRequest{Name: "Class Rock", Definition: "Not an agent"})
+        if err != nil {
+            t.Fatalf("Entity creation failed: %v", err)
+        }
+        _, err = clients.graphClient.CreateConnection(ctx, &graph.ConnectionRequest{
+            ConnectionTypeId: knows.ConnectionTypeId,
+            FromEntityId:     rock.EntityId,
+            ToEntityId:       alice.EntityId,
+        })
+        if status.Code(err) != codes.InvalidArgument {
+            t.Errorf("Expected InvalidArgument error for a non-agent, got: %v", err)
+        }
+        _, err = clients.graphClient.CreateConnection(ctx, &graph.ConnectionRequest{
+            ConnectionTypeId: knows.ConnectionTypeId,
+            FromEntityId:     alice.EntityId,
+            ToEntityId:       rock.EntityId,
+        })
+        if err != nil {
+            t.Errorf("Expected a person to know others, got: %v", err)
+        }
+    })
+}
+
+func TestRevisions(t *testing.T) {
+    clients := setupClients(t)
+    defer clients.cancel()
+
+    ctx, _ := getAuthenticatedContext(t, clients)
+
+    entity, err := clients.graphClient.CreateEntity(ctx, &graph.EntityRequest{Name: "Revision Before", Definition: "First definition"})
+    if err != nil {
+        t.Fatalf("Entity creation failed: %v", err)
+    }
+    time.Sleep(10 * time.Millisecond)
+    beforeUpdate := timestamppb.Now()
+    time.Sleep(10 * time.Millisecond)
+
+    _, err = clients.graphClient.UpdateEntity(ctx, &graph.EntityRequest{Id: entity.EntityId, Name: "Revision After", Definition: "Second definition"})
+    if err != nil {
+        t.Fatalf("Entity update failed: %v", err)
+    }
+
+    // Test that every change is recorded
+    var revisions *graph.RevisionsList
+    t.Run("List Revisions", func(t *testing.T) {
+        revisions, err = clients.graphClient.ListRevisions(ctx, &graph.RevisionsRequest{Kind: "entity", Id: entity.EntityId})
+        if err != nil {
+            t.Fatalf("Listing revisions failed: %v", err)
+        }
+        if len(revisions.Revisions) != 2 {
+            t.Fatalf("Expected 2 revisions, got %v", revisions.Revisions)
+        }
+        if revisions.Revisions[0].Action != "update" || revisions.Revisions[1].Name != "Revision Before" {
+            t.Errorf("Expected the update first and the original version last, got %v", revisions.Revisions)
+        }
+    })
+
+    // Test that other users cannot read the history
+    t.Run("Other Owners Private", func(t *testing.T) {
+        otherCtx, _ := getAuthenticatedContextFor(t, clients, "member@example.com")
+        others, err := clients.graphClient.ListRevisions(otherCtx, &graph.RevisionsRequest{
+            Kind:   "entity",
+            Id:     entity.EntityId,
+            UserId: entity.UserId,
+        })
+        if err != nil {
+            t.Fatalf("Listing revisions failed: %v", err)
+        }
+        if len(others.Revisions) != 0 {
+            t.Errorf("Expected no revisions of another user, got %v", others.Revisions)
+        }
+
+        _, err = clients.graphClient.GetRevision(otherCtx, &graph.IdRequest{Id: revisions.Revisions[0].Id})
+        if status.Code(err) != codes.NotFound {
+            t.Errorf("Expected NotFound error, got: %v", err)
+        }
+    })
+
+    // Test that reads can look at the graph as it was
+    t.Run("Read As Of", func(t *testing.T) {
+        found, err := clients.graphClient.FindEntities(ctx, &graph.SearchRequest{Name: "Revision Before", AsOf: beforeUpdate})
+        if err != nil {
+            t.Fatalf("Finding entities failed: %v", err)
+        }
+        if len(found.Entities) != 1 || found.Entities[0].Definition != "First definition" {
+            t.Errorf("Expected the original version, got %v", found.Entities)
+        }
+
+        found, err = clients.graphClient.FindEntities(ctx, &graph.SearchRequest{Name: "Revision Before"})
+        if err != nil {
+            t.Fatalf("Finding entities failed: %v", err)
+        }
+        if len(found.Entities) != 0 {
+            t.Errorf("Expected no current entity with the old name, got %v", found.Entities)
+        }
+
+        userData, err := clients.graphClient.GetUserData(ctx, &graph.UserDataRequest{AsOf: beforeUpdate})
+        if err != nil {
+            t.Fatalf("Getting user data failed: %v", err)
+        }
+        var name string
+        for _, userEntity := range userData.Entities {
+            if userEntity.EntityId == entity.EntityId {
+                name = userEntity.Name
+            }
+        }
+        if name != "Revision Before" {
+            t.Errorf("Expected the entity to have its old name, got %q", name)
+        }
+    })
+
+    // Test that reverting restores the old definition and is recorded
+    t.Run("Revert To Revision", func(t *testing.T) {
+        original := revisions.Revisions[len(revisions.Revisions)-1]
+        reverted, err := clients.graphClient.RevertToRevision(ctx, &graph.IdRequest{Id: original.Id})
+        if err != nil {
+            t.Fatalf("Reverting failed: %v", err)
+        }
+        if reverted.Action != "revert" || reverted.Definition != "First definition" {
+            t.Errorf("Expected a revert to the first definition, got %v", reverted)
+        }
+
+        found, err := clients.graphClient.FindEntities(ctx, &graph.SearchRequest{Name: "Revision Before"})
+        if err != nil {
+            t.Fatalf("Finding entities failed: %v", err)
+        }
+        if len(found.Entities) != 1 {
+            t.Errorf("Expected the entity to have its old name again, got %v", found.Entities)
+        }
+    })
+
+    // Test that other users cannot revert a user's version
+    t.Run("Revert Other Users Revision", func(t *testing.T) {
+        otherCtx, _ := getAuthenticatedContextFor(t, clients, "member@example.com")
+        _, err := clients.graphClient.RevertToRevision(otherCtx, &graph.IdRequest{Id: revisions.Revisions[0].Id})
+        if status.Code(err) != codes.NotFound {
+            t.Errorf("Expected NotFound error, got: %v", err)
+        }
+    })
+}
+
+// Test Audit Log
+
+func TestAuditLog(t *testing.T) {
+    clients := setupClients(t)
+    defer clients.cancel()
+
+    // Log in even if the user was just registered, so there is a login to find
+    getAuthenticatedContext(t, clients)
+    authCtx, token := getAuthenticatedContextFor(t, clients, "test@example.com")
+    verifyResp, err := clients.authClient.VerifyToken(clients.ctx, &auth.VerifyTokenRequest{Token: token})
+    if err != nil {
+        t.Fatalf("Token verification failed: %v", err)
+    }
+    userID := verifyResp.UserId
+
+    // The auditor may already exist from an earlier run
+    clients.authClient.Register(clients.ctx, &auth.AuthRequest{
+        Email:    audit_admin_email,
+        Password: "testpassword123",
+    })
+    adminCtx, _ := getAuthenticatedContextFor(t, clients, audit_admin_email)
+
+    entity, err := clients.graphClient.CreateEntity(authCtx, &graph.EntityRequest{
+        Name:       "Audited Entity",
+        Definition: "Audited Definition",
+    })
+    if err != nil {
+        t.Fatalf("Entity creation failed: %v", err)
+    }
+
+    // Test that only admins can query the audit log
+    t.Run("Query Audit Log Not Admin", func(t *testing.T) {
+        _, err := clients.authAudit.QueryAuditLog(authCtx, &audit.AuditLogRequest{})
+        if status.Code(err) != codes.PermissionDenied {
+            t.Errorf("Expected PermissionDenied error, got: %v", err)
+        }
+        _, err = clients.graphAudit.QueryAuditLog(authCtx, &audit.AuditLogRequest{})
+        if status.Code(err) != codes.PermissionDenied {
+            t.Errorf("Expected PermissionDenied error, got: %v", err)
+        }
+    })
+
+    // Test that logins are recorded by the auth service
+    t.Run("Query Logins", func(t *testing.T) {
+        log, err := clients.authAudit.QueryAuditLog(adminCtx, &audit.AuditLogRequest{
+            UserId: userID,
+            Action: "auth.login",
+            Limit:  1,
+        })
+        if err != nil {
+            t.Fatalf("Querying audit log failed: %v", err)
+        }
+        if len(log.Events) != 1 || log.Events[0].Outcome != "success" || log.Events[0].Service != "authservice" {
+            t.Errorf("Expected a successful login, got: %v", log.Events)
+        }
+    })
+
+    // Test that data changes are recorded by the graph service, and the chain is intact
+    t.Run("Query Entity Creations", func(t *testing.T) {
+        log, err := clients.graphAudit.QueryAuditLog(adminCtx, &audit.AuditLogRequest{
+            UserId:      userID,
+            Action:      "graph.create_entity",
+            From:        timestamppb.New(time.Now().Add(-time.Minute)),
+            VerifyChain: true,
+        })
+        if err != nil {
+            t.Fatalf("Querying audit log failed: %v", err)
+        }
+        if len(log.Events) == 0 || log.Events[0].TargetId != entity.EntityId {
+            t.Errorf("Expected the entity creation first, got: %v", log.Events)
+        }
+        if !log.ChainIntact {
+            t.Errorf("Expected an intact chain, broken at %d", log.BrokenAt)
+        }
+    })
+
+    // Test invalid filters
+    t.Run("Query Invalid Limit", func(t *testing.T) {
+        _, err := clients.graphAudit.QueryAuditLog(adminCtx, &audit.AuditLogRequest{Limit: 1001})
+        if status.Code(err) != codes.InvalidArgument {
+            t.Errorf("Expected InvalidArgument error, got: %v", err)
+        }
+    })
+}
+
+// Test Graph Watching
+
+func TestWatchGraph(t *testing.T) {
+    clients := setupClients(t)
+    defer clients.cancel()
+
+    authCtx, _ := getAuthenticatedContext(t, clients)
+
+    // recvChange receives events until one that is not a heartbeat
+    recvChange := func(stream graph.GraphService_WatchGraphClient) (*graph.GraphEvent, error) {
+        for {
+            event, err := stream.Recv()
+            if err != nil || event.Action != "heartbeat" {
+                return event, err
+            }
+        }
+    }
+
+    stream, err := clients.graphClient.WatchGraph(authCtx, &graph.WatchRequest{Kinds: []string{"entity"}})
+    if err != nil {
+        t.Fatalf("Watching graph failed: %v", err)
+    }
+
+    // The stream starts with a heartbeat carrying the current position
+    first, err := stream.Recv()
+    if err != nil {
+        t.Fatalf("Receiving first event failed: %v", err)
+    }
+    if first.Action != "heartbeat" || first.ResumeToken == "" {
+        t.Errorf("Expected a heartbeat with a resume token, got: %v", first)
+    }
+
+    entity, err := clients.graphClient.CreateEntity(authCtx, &graph.EntityRequest{
+        Name:       "Watched Entity",
+        Definition: "Watched Definition",
+    })
+    if err != nil {
+        t.Fatalf("Entity creation failed: %v", err)
+    }
+
+    var created *graph.GraphEvent
+    t.Run("Receive Create", func(t *testing.T) {
+        created, err = recvChange(stream)
+        if err != nil {
+            t.Fatalf("Receiving event failed: %v", err)
+        }
+        if created.Action != "create" || created.Kind != "entity" || created.Id != entity.EntityId || created.Name != "Watched Entity" {
+            t.Errorf("Expected the entity creation, got: %v", created)
+        }
+    })
+
+    t.Run("Resume After Create", func(t *testing.T) {
+        if created == nil {
+            t.Skip("No create event to resume after")
+        }
+        _, err := clients.graphClient.UpdateEntity(authCtx, &graph.EntityRequest{
+            Id:         entity.EntityId,
+            Name:       "Watched Entity",
+            Definition: "Updated Definition",
+        })
+        if err != nil {
+            t.Fatalf("Entity update failed: %v", err)
+        }
+
+        resumed, err := clients.graphClient.WatchGraph(authCtx, &graph.WatchRequest{
+            ResumeToken: created.ResumeToken,
+            Kinds:       []string{"entity"},
+        })
+        if err != nil {
+            t.Fatalf("Resuming watch failed: %v", err)
+        }
+        event, err := recvChange(resumed)
+        if err != nil {
+            t.Fatalf("Receiving event failed: %v", err)
+        }
+        if event.Action != "update" || event.Id != entity.EntityId || event.Definition != "Updated Definition" {
+            t.Errorf("Expected the entity update, got: %v", event)
+        }
+    })
+
+    t.Run("Invalid Resume Token", func(t *testing.T) {
+        invalid, err := clients.graphClient.WatchGraph(authCtx, &graph.WatchRequest{ResumeToken: "not a token"})
+        if err == nil {
+            _, err = invalid.Recv()
+        }
+        if status.Code(err) != codes.InvalidArgument {
+            t.Errorf("Expected InvalidArgument error, got: %v", err)
+        }
+    })
+}
+
+// Test Webhooks
+
+func TestWebhooks(t *testing.T) {
+    clients := setupClients(t)
+    defer clients.cancel()
+
+    authCtx, _ := getAuthenticatedContext(t, clients)
+
+    // Receive the deliveries on a local server
+    type received struct {
+        header http.Header
+        body   []byte
+    }
+    deliveries := make(chan received, 100)
+    receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+        body, _ := io.ReadAll(req.Body)
+        deliveries <- received{header: req.Header, body: body}
+        w.WriteHeader(http.StatusNoContent)
+    }))
+    defer receiver.Close()
+
+    webhook, err := clients.graphClient.CreateWebhook(authCtx, &graph.WebhookRequest{
+        Url:   receiver.URL,
+        Kinds: []string{"entity"},
+    })
+    if err != nil {
+        t.Fatalf("Webhook creation failed: %v", err)
+    }
+    defer clients.graphClient.DeleteWebhook(authCtx, &graph.WebhookIdRequest{WebhookId: webhook.Id})
+    if webhook.Secret == "" {
+        t.Fatalf("Expected a secret, got: %v", webhook)
+    }
+
+    entity, err := clients.graphClient.CreateEntity(authCtx, &graph.EntityRequest{
+        Name:       "Hooked Entity",
+        Definition: "Hooked Definition",
+    })
+    if err != nil {
+        t.Fatalf("Entity creation failed: %v", err)
+    }
+
+    // Test that the change is delivered, signed with the secret
+    t.Run("Receive Delivery", func(t *testing.T) {
+        timeout := time.After(5 * time.Second)
+        for {
+            select {
+            case <-timeout:
+                t.Fatalf("No delivery of the entity creation")
+            case delivery := <-deliveries:
+                var payload struct {
+                    EventID string `json:"event_id"`
+                    Action  string `json:"action"`
+                    ID      string `json:"id"`
+                    Name    string `json:"name"`
+                }
+                if err := json.Unmarshal(delivery.body, &payload); err != nil {
+                    t.Fatalf("Invalid payload: %v", err)
+                }
+                if payload.ID != entity.EntityId {
+                    continue
+                }
+                if payload.Action != "create" || payload.Name != "Hooked Entity" || payload.EventID != delivery.header.Get("X-Wikno-Event-Id") {
+                    t.Errorf("Expected the entity creation, got: %s", delivery.body)
+                }
+
+                mac := hmac.New(sha256.New, []byte(webhook.Secret))
+                mac.Write([]byte(delivery.header.Get("X-Wikno-Timestamp") + "."))
+                mac.Write(delivery.body)
+                if delivery.header.Get("X-Wikno-Signature") != "sha256="+hex.EncodeToString(mac.Sum(nil)) {
+                    t.Errorf("Invalid signature: %s", delivery.header.Get("X-Wikno-Signature"))
+                }
+                return
+            }
+        }
+    })
+
+    t.Run("List Deliveries", func(t *testing.T) {
+        // The delivery is logged once the response arrived
+        time.Sleep(500 * time.Millisecond)
+        log, err := clients.graphClient.ListWebhookDeliveries(authCtx, &graph.WebhookDeliveriesRequest{WebhookId: webhook.Id})
+        if err != nil {
+            t.Fatalf("Listing deliveries failed: %v", err)
+        }
+        if len(log.Deliveries) == 0 || log.Deliveries[0].Outcome != "success" || log.Deliveries[0].StatusCode != http.StatusNoContent {
+            t.Errorf("Expected a successful delivery, got: %v", log.Deliveries)
+        }
+    })
+
+    t.Run("List Webhooks Hides Secret", func(t *testing.T) {
+        list, err := clients.graphClient.ListWebhooks(authCtx, &graph.Empty{})
+        if err != nil {
+            t.Fatalf("Listing webhooks failed: %v", err)
+        }
+        found := false
+        for _, w := range list.Webhooks {
+            if w.Id == webhook.Id {
+                found = true
+                if w.Secret != "" {
+                    t.Errorf("Expected no secret, got: %s", w.Secret)
+                }
+            }
+        }
+        if !found {
+            t.Errorf("Expected the webhook in the list, got: %v", list.Webhooks)
+        }
+    })
+
+    // Test that redirects are not followed, so they cannot point deliveries elsewhere
+    t.Run("Redirect Not Followed", func(t *testing.T) {
+        redirector := httptest.NewServer(http.RedirectHandler(receiver.URL, http.StatusFound))
+        defer redirector.Close()
+
+        redirected, err := clients.graphClient.CreateWebhook(authCtx, &graph.WebhookRequest{
+            Url:   redirector.URL,
+            Kinds: []string{"entity"},
+        })
+        if err != nil {
+            t.Fatalf("Webhook creation failed: %v", err)
+        }
+        defer clients.graphClient.DeleteWebhook(authCtx, &graph.WebhookIdRequest{WebhookId: redirected.Id})
+
+        if _, err := clients.graphClient.CreateEntity(authCtx, &graph.EntityRequest{
+            Name:       "Redirected Entity",
+            Definition: "Redirected Definition",
+        }); err != nil {
+            t.Fatalf("Entity creation failed: %v", err)
+        }
+
+        deadline := time.Now().Add(5 * time.Second)
+        for {
+            log, err := clients.graphClient.ListWebhookDeliveries(authCtx, &graph.WebhookDeliveriesRequest{WebhookId: redirected.Id})
+            if err != nil {
+                t.Fatalf("Listing deliveries failed: %v", err)
+            }
+            if len(log.Deliveries) > 0 {
+                if log.Deliveries[0].Outcome == "success" || log.Deliveries[0].StatusCode != http.StatusFound {
+                    t.Errorf("Expected the redirect to fail the delivery, got: %v", log.Deliveries)
+                }
+                return
+            }
+            if time.Now().After(deadline) {
+                t.Fatalf("No delivery to the redirecting webhook")
+            }
+            time.Sleep(100 * time.Millisecond)
+        }
+    })
+
+    t.Run("Create Invalid Webhook", func(t *testing.T) {
+        _, err := clients.graphClient.CreateWebhook(authCtx, &graph.WebhookRequest{Url: "ftp://example.com"})
+        if err == nil {
+            t.Errorf("Expected error for a non-HTTP URL")
+        }
+    })
+
+    t.Run("Delete Webhook", func(t *testing.T) {
+        _, err := clients.graphClient.DeleteWebhook(authCtx, &graph.WebhookIdRequest{WebhookId: webhook.Id})
+        if err != nil {
+            t.Fatalf("Deleting webhook failed: %v", err)
+        }
+        _, err = clients.graphClient.DeleteWebhook(authCtx, &graph.WebhookIdRequest{WebhookId: webhook.Id})
+        if status.Code(err) != codes.NotFound {
+            t.Errorf("Expected NotFound error, got: %v", err)
+        }
+    })
+}
+
+// Test the HTTP/JSON gateway
+
+func TestGateway(t *testing.T) {
+    clients := setupClients(t)
+    defer clients.cancel()
+    getAuthenticatedContext(t, clients)
+
+    call := func(method, path, token, body string) (int, map[string]interface{}) {
+        req, err := http.NewRequest(method, gatewayURL+path, strings.NewReader(body))
+        if err != nil {
+            t.Fatalf("Could not create request: %v", err)
+        }
+        if token != "" {
+            req.Header.Set("Authorization", token)
+        }
+        resp, err := http.DefaultClient.Do(req)
+        if err != nil {
+            t.Fatalf("Gateway request failed: %v", err)
+        }
+        defer resp.Body.Close()
+        var decoded map[string]interface{}
+        json.NewDecoder(resp.Body).Decode(&decoded)
+        return resp.StatusCode, decoded
+    }
+
+    code, login := call(http.MethodPost, "/v1/auth/login", "", `{"email":"test@example.com","password":"testpassword123"}`)
+    if code != http.StatusOK {
+        t.Fatalf("Login through the gateway failed: %d %v", code, login)
+    }
+    token, _ := login["token"].(string)
+
+    t.Run("Create And Find Entity", func(t *testing.T) {
+        code, body := call(http.MethodPost, "/v1/entities", token, `{"name":"Gateway Entity","definition":"Created over HTTP"}`)
+        if code != http.StatusOK {
+            t.Fatalf("Entity creation failed: %d %v", code, body)
+        }
+
+        code, body = call(http.MethodGet, "/v1/entities?name=Gateway+Entity", token, "")
+        if code != http.StatusOK {
+            t.Fatalf("Finding entities failed: %d %v", code, body)
+        }
+        entities, _ := body["entities"].([]interface{})
+        if len(entities) == 0 {
+            t.Errorf("Expected the created entity, got: %v", body)
+        }
+    })
+
+    t.Run("Unauthenticated", func(t *testing.T) {
+        code, body := call(http.MethodGet, "/v1/graph", "", "")
+        if code != http.StatusUnauthorized || body["code"] != float64(codes.Unauthenticated) {
+            t.Errorf("Expected 401 with a status body, got: %d %v", code, body)
+        }
+    })
+
+    t.Run("Unknown Parameter", func(t *testing.T) {
+        code, _ := call(http.MethodGet, "/v1/entities?bogus=1", token, "")
+        if code != http.StatusBadRequest {
+            t.Errorf("Expected 400, got: %d", code)
+        }
+    })
+
+    t.Run("OpenAPI Document", func(t *testing.T) {
+        code, body := call(http.MethodGet, "/openapi.json", "", "")
+        if code != http.StatusOK || body["openapi"] == nil {
+            t.Errorf("Expected the OpenAPI document, got: %d", code)
+        }
+    })
+}
+
+// Test the GraphQL API of the graph service
+
+func TestGraphQL(t *testing.T) {
+    clients := setupClients(t)
+    defer clients.cancel()
+
+    ctx, token := getAuthenticatedContext(t, clients)
+
+    query := func(t *testing.T, token, query string, variables map[string]interface{}) (int, map[string]interface{}) {
+        body, _ := json.Marshal(map[string]interface{}{"query": query, "variables": variables})
+        req, err := http.NewRequest(http.MethodPost, graphQLURL, strings.NewReader(string(body)))
+        if err != nil {
+            t.Fatalf("Could not create request: %v", err)
+        }
+        if token != "" {
+            req.Header.Set("Authorization", token)
+        }
+        resp, err := http.DefaultClient.Do(req)
+        if err != nil {
+            t.Fatalf("GraphQL request failed: %v", err)
+        }
+        defer resp.Body.Close()
+        var decoded map[string]interface{}
+        json.NewDecoder(resp.Body).Decode(&decoded)
+        return resp.StatusCode, decoded
+    }
+
+    // A hub entity connected to a few neighbors
+    createEntity := func(name string) string {
+        entity, err := clients.graphClient.CreateEntity(ctx, &graph.EntityRequest{Name: name, Definition: name})
+        if err != nil {
+            t.Fatalf("Entity creation failed: %v", err)
+        }
+        return entity.EntityId
+    }
+    hub := createEntity("GraphQL Hub")
+    knows, err := clients.graphClient.CreateConnectionType(ctx, &graph.ConnectionTypeRequest{
+        Name:       "GraphQL Knows",
+        Definition: "Acquaintance",
+        Symmetric:  true,
+    })
+    if err != nil {
+        t.Fatalf("Connection type creation failed: %v", err)
+    }
+    neighbors := map[string]bool{}
+    for _, name := range []string{"GraphQL Neighbor 1", "GraphQL Neighbor 2", "GraphQL Neighbor 3"} {
+        neighbor := createEntity(name)
+        neighbors[neighbor] = true
+        _, err := clients.graphClient.CreateConnection(ctx, &graph.ConnectionRequest{
+            ConnectionTypeId: knows.ConnectionTypeId,
+            FromEntityId:     hub,
+            ToEntityId:       neighbor,
+        })
+        if err != nil {
+            t.Fatalf("Connection creation failed: %v", err)
+        }
+    }
+
+    t.Run("Entity With Neighbors", func(t *testing.T) {
+        code, body := query(t, token, `query($id: ID!) {
+            entity(id: $id) {
+                name
+                userId
+                author { userId displayName }
+                neighbors { id name author { userId } neighbors { id } }
+                connections { inferred connectionType { name symmetric } }
+            }
+        }`, map[string]interface{}{"id": hub})
+        if code != http.StatusOK || body["errors"] != nil {
+            t.Fatalf("Query failed: %d %v", code, body)
+        }
+
+        entity := body["data"].(map[string]interface{})["entity"].(map[string]interface{})
+        if entity["name"] != "GraphQL Hub" {
+            t.Errorf("Expected the hub, got: %v", entity)
+        }
+        author, _ := entity["author"].(map[string]interface{})
+        if author == nil || author["userId"] != entity["userId"] {
+            t.Errorf("Expected the author of the hub, got: %v", entity["author"])
+        }
+        found := entity["neighbors"].([]interface{})
+        if len(found) != len(neighbors) {
+            t.Fatalf("Expected %d neighbors, got: %v", len(neighbors), found)
+        }
+        for _, n := range found {
+            neighbor := n.(map[string]interface{})
+            if !neighbors[neighbor["id"].(string)] {
+                t.Errorf("Unexpected neighbor: %v", neighbor)
+            }
+            if neighbor["author"] == nil {
+                t.Errorf("Expected the author of the neighbor, got: %v", neighbor)
+            }
+            // Symmetric connections lead back to the hub
+            back := neighbor["neighbors"].([]interface{})
+            if len(back) != 1 || back[0].(map[string]interface{})["id"] != hub {
+                t.Errorf("Expected the hub as the neighbor's neighbor, got: %v", back)
+            }
+        }
+        for _, c := range entity["connections"].([]interface{}) {
+            connectionType := c.(map[string]interface{})["connectionType"].(map[string]interface{})
+            if connectionType["name"] != "GraphQL Knows" || connectionType["symmetric"] != true {
+                t.Errorf("Expected the symmetric connection type, got: %v", connectionType)
+            }
+        }
+    })
+
+    t.Run("Unknown Entity", func(t *testing.T) {
+        _, body := query(t, token, `{ entity(id: "123e4567-e89b-12d3-a456-426614174000") { id } }`, nil)
+        if body["errors"] != nil || body["data"].(map[string]interface{})["entity"] != nil {
+            t.Errorf("Expected a null entity, got: %v", body)
+        }
+    })
+
+    t.Run("Invalid ID", func(t *testing.T) {
+        _, body := query(t, token, `{ entity(id: "not-a-uuid") { id } }`, nil)
+        errors, _ := body["errors"].([]interface{})
+        if len(errors) == 0 || errors[0].(map[string]interface{})["extensions"].(map[string]interface{})["code"] != "INVALID_ARGUMENT" {
+            t.Errorf("Expected an INVALID_ARGUMENT error, got: %v", body)
+        }
+    })
+
+    t.Run("Unauthenticated", func(t *testing.T) {
+        code, _ := query(t, "", `{ entities(name: "GraphQL Hub") { id } }`, nil)
+        if code != http.StatusUnauthorized {
+            t.Errorf("Expected 401, got: %d", code)
+        }
+    })
+}
+
+func TestGrpcWeb(t *testing.T) {
+    clients := setupClients(t)
+    defer clients.cancel()
+    getAuthenticatedContext(t, clients)
+
+    // call makes a unary gRPC-Web call like a browser on the allowed origin, and returns the grpc-status
+    call := func(t *testing.T, url, method, token string, req, resp proto.Message) string {
+        msg, err := proto.Marshal(req)
+        if err != nil {
+            t.Fatalf("Could not marshal request: %v", err)
+        }
+        frame := make([]byte, 5+len(msg))
+        binary.BigEndian.PutUint32(frame[1:5], uint32(len(msg)))
+        copy(frame[5:], msg)
+
+        httpReq, err := http.NewRequest(http.MethodPost, url+method, bytes.NewReader(frame))
+        if err != nil {
+            t.Fatalf("Could not create request: %v", err)
+        }
+        httpReq.Header.Set("Content-Type", "application/grpc-web+proto")
+        httpReq.Header.Set("X-Grpc-Web", "1")
+        httpReq.Header.Set("Origin", grpc_web_origin)
+        if token != "" {
+            httpReq.Header.Set("Authorization", token)
+        }
+        httpResp, err := http.DefaultClient.Do(httpReq)
+        if err != nil {
+            t.Fatalf("gRPC-Web request failed: %v", err)
+        }
+        defer httpResp.Body.Close()
+        if origin := httpResp.Header.Get("Access-Control-Allow-Origin"); origin != grpc_web_origin {
+            t.Errorf("Expected the origin to be allowed, got %q", origin)
+        }
+
+        // The body is a data frame followed by a trailer frame, unless the call failed before responding
+        body, err := io.ReadAll(httpResp.Body)
+        if err != nil {
+            t.Fatalf("Could not read response: %v", err)
+        }
+        grpcStatus := httpResp.Header.Get("Grpc-Status")
+        for len(body) >= 5 {
+            length := binary.BigEndian.Uint32(body[1:5])
+            payload := body[5 : 5+length]
+            if body[0]&0x80 == 0 {
+                if err := proto.Unmarshal(payload, resp); err != nil {
+                    t.Fatalf("Could not unmarshal response: %v", err)
+                }
+            } else {
+                for _, line := range strings.Split(string(payload), "\r\n") {
+                    if value, ok := strings.CutPrefix(strings.ToLower(line), "grpc-status:"); ok {
+                        grpcStatus = strings.TrimSpace(value)
+                    }
+                }
+            }
+            body = body[5+length:]
+        }
+        return grpcStatus
+    }
+
+    var token string
+    t.Run("Login", func(t *testing.T) {
+        resp := &auth.AuthResponse{}
+        grpcStatus := call(t, authGrpcWebURL, "/auth.AuthService/Login", "", &auth.AuthRequest{
+            Email:    "test@example.com",
+            Password: "testpassword123",
+        }, resp)
+        if grpcStatus != "0" {
+            t.Fatalf("Expected OK, got grpc-status %s", grpcStatus)
+        }
+        if resp.Token == "" {
+            t.Fatal("Expected a token")
+        }
+        token = resp.Token
+    })
+
+    t.Run("Authorized Call", func(t *testing.T) {
+        resp := &graph.EntitiesList{}
+        grpcStatus := call(t, graphGrpcWebURL, "/graph.GraphService/FindEntities", token, &graph.SearchRequest{
+            Name: "gRPC-Web Entity",
+        }, resp)
+        if grpcStatus != "0" {
+            t.Errorf("Expected OK, got grpc-status %s", grpcStatus)
+        }
+    })
+
+    t.Run("Missing Authorization", func(t *testing.T) {
+        grpcStatus := call(t, graphGrpcWebURL, "/graph.GraphService/FindEntities", "", &graph.SearchRequest{
+            Name: "gRPC-Web Entity",
+        }, &graph.EntitiesList{})
+        if grpcStatus != "16" {
+            t.Errorf("Expected Unauthenticated (16), got grpc-status %s", grpcStatus)
+        }
+    })
+
+    t.Run("Preflight", func(t *testing.T) {
+        preflight := func(origin string) *http.Response {
+            req, err := http.NewRequest(http.MethodOptions, graphGrpcWebURL+"/graph.GraphService/FindEntities", nil)
+            if err != nil {
+                t.Fatalf("Could not create request: %v", err)
+            }
+            req.Header.Set("Origin", origin)
+            req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+            req.Header.Set("Access-Control-Request-Headers", "authorization,content-type,workspace-id,x-grpc-web")
+            resp, err := http.DefaultClient.Do(req)
+            if err != nil {
+                t.Fatalf("Preflight request failed: %v", err)
+            }
+            resp.Body.Close()
+            return resp
+        }
+
+        if origin := preflight(grpc_web_origin).Header.Get("Access-Control-Allow-Origin"); origin != grpc_web_origin {
+            t.Errorf("Expected the origin to be allowed, got %q", origin)
+        }
+        if origin := preflight("http://evil.example.com").Header.Get("Access-Control-Allow-Origin"); origin != "" {
+            t.Errorf("Expected other origins to be refused, got %q", origin)
+        }
+    })
+}
+
+func TestRateLimit(t *testing.T) {
+    clients := setupClients(t)
+    defer clients.cancel()
+
+    // A user of its own, so its exhausted quota does not throttle the other tests
+    clients.authClient.Register(clients.ctx, &auth.AuthRequest{
+        Email:    "ratelimit@example.com",
+        Password: "testpassword123",
+    })
+    ctx, _ := getAuthenticatedContextFor(t, clients, "ratelimit@example.com")
+
+    t.Run("Throttled After Burst", func(t *testing.T) {
+        // The default quota allows 100 calls at once
+        var err error
+        for i := 0; i < 150 && err == nil; i++ {
+            _, err = clients.graphClient.FindEntities(ctx, &graph.SearchRequest{Name: "Rate Limited"})
+        }
+        if status.Code(err) != codes.ResourceExhausted {
+            t.Fatalf("Expected ResourceExhausted error, got: %v", err)
+        }
+
+        var retryInfo *errdetails.RetryInfo
+        for _, detail := range status.Convert(err).Details() {
+            if info, ok := detail.(*errdetails.RetryInfo); ok {
+                retryInfo = info
+            }
+        }
+        if retryInfo == nil || retryInfo.GetRetryDelay().AsDuration() <= 0 {
+            t.Errorf("Expected a retry delay, got: %v", retryInfo)
+        }
+    })
+
+    t.Run("Other Users And Methods Unaffected", func(t *testing.T) {
+        otherCtx, _ := getAuthenticatedContext(t, clients)
+        if _, err := clients.graphClient.FindEntities(otherCtx, &graph.SearchRequest{Name: "Rate Limited"}); err != nil {
+            t.Errorf("Expected another user to be allowed, got: %v", err)
+        }
+        if _, err := clients.graphClient.FindConnectionTypes(ctx, &graph.SearchRequest{Name: "Rate Limited"}); err != nil {
+            t.Errorf("Expected another method to be allowed, got: %v", err)
+        }
+    })
+}
+
+// Test that clients cannot choose the address they are limited by with their own X-Forwarded-For header
+// newLoginLimitedServer returns an auth service that only limits calls, with one login at once,
+// and trusts proxies on loopback
+func newLoginLimitedServer(t *testing.T) *grpc.Server {
+    config := rl.RateLimitConfig{}
+    config.SetDefaults()
+    config.Methods = "Login=1/1"
+    config.TrustedProxies = "127.0.0.1,::1"
+    limiter, err := rl.NewLimiter(config, m.NewMetrics("ratelimittest"))
+    if err != nil {
+        t.Fatalf("Could not create limiter: %v", err)
+    }
+    server := grpc.NewServer(grpc.UnaryInterceptor(rl.UnaryRateLimitInterceptor(limiter)))
+    auth.RegisterAuthServiceServer(server, auth.UnimplementedAuthServiceServer{})
+    return server
+}
+
+func TestRateLimitBehindGateway(t *testing.T) {
+    // The limited auth service behind a gateway on loopback it trusts
+    listener, err := net.Listen("tcp", "127.0.0.1:0")
+    if err != nil {
+        t.Fatalf("Could not listen: %v", err)
+    }
+    server := newLoginLimitedServer(t)
+    go server.Serve(listener)
+    defer server.Stop()
+
+    conn, err := grpc.Dial(listener.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+    if err != nil {
+        t.Fatalf("Could not connect: %v", err)
+    }
+    defer conn.Close()
+    gatewayConfig := gateway.ServerConfig{}
+    gatewayConfig.SetDefaults()
+    gatewayServer, err := gateway.New(gatewayConfig, gateway.Services(conn, conn)...)
+    if err != nil {
+        t.Fatalf("Could not create gateway: %v", err)
+    }
+    httpServer := httptest.NewServer(gatewayServer.Handler())
+    defer httpServer.Close()
+
+    login := func(forwardedFor string) int {
+        req, err := http.NewRequest(http.MethodPost, httpServer.URL+"/v1/auth/login",
+            strings.NewReader(`{"email":"spoofer@example.com","password":"testpassword123"}`))
+        if err != nil {
+            t.Fatalf("Could not create request: %v", err)
+        }
+        req.Header.Set("X-Forwarded-For", forwardedFor)
+        resp, err := http.DefaultClient.Do(req)
+        if err != nil {
+            t.Fatalf("Gateway request failed: %v", err)
+        }
+        resp.Body.Close()
+        return resp.StatusCode
+    }
+
+    if code := login("203.0.113.1"); code == http.StatusTooManyRequests {
+        t.Fatalf("Expected the first login to be allowed, got %d", code)
+    }
+    if code := login("203.0.113.2"); code != http.StatusTooManyRequests {
+        t.Errorf("Expected a spoofed address not to get a quota of its own, got %d", code)
+    }
+}
+
+func TestRateLimitBehindGrpcWeb(t *testing.T) {
+    // The limited auth service served over gRPC-Web on loopback, whose calls all come from a trusted address
+    server := newLoginLimitedServer(t)
+    defer server.Stop()
+    grpcWebConfig := gw.GrpcWebServerConfig{}
+    grpcWebConfig.SetDefaults()
+    grpcWebConfig.AllowedOrigins = "*"
+    httpServer := httptest.NewServer(gw.NewGrpcWebServer(server, grpcWebConfig, nil).Handler())
+    defer httpServer.Close()
+
+    login := func(forwardedFor string) string {
+        msg, err := proto.Marshal(&auth.AuthRequest{Email: "spoofer@example.com", Password: "testpassword123"})
+        if err != nil {
+            t.Fatalf("Could not marshal request: %v", err)
+        }
+        frame := make([]byte, 5+len(msg))
+        binary.BigEndian.PutUint32(frame[1:5], uint32(len(msg)))
+        copy(frame[5:], msg)
+
+        req, err := http.NewRequest(http.MethodPost, httpServer.URL+"/auth.AuthService/Login", bytes.NewReader(frame))
+        if err != nil {
+            t.Fatalf("Could not create request: %v", err)
+        }
+        req.Header.Set("Content-Type", "application/grpc-web+proto")
+        req.Header.Set("X-Grpc-Web", "1")
+        req.Header.Set("X-Forwarded-For", forwardedFor)
+        resp, err := http.DefaultClient.Do(req)
+        if err != nil {
+            t.Fatalf("gRPC-Web request failed: %v", err)
+        }
+        defer resp.Body.Close()
+        io.Copy(io.Discard, resp.Body)
+        return resp.Header.Get("Grpc-Status")
+    }
+
+    exhausted := strconv.Itoa(int(codes.ResourceExhausted))
+    if grpcStatus := login("203.0.113.1"); grpcStatus == exhausted {
+        t.Fatalf("Expected the first login to be allowed, got grpc-status %s", grpcStatus)
+    }
+    if grpcStatus := login("203.0.113.2"); grpcStatus != exhausted {
+        t.Errorf("Expected a spoofed address not to get a quota of its own, got grpc-status %s", grpcStatus)
+    }
+}
+
+func TestErrorDetails(t *testing.T) {
+    clients := setupClients(t)
+    defer clients.cancel()
+
+    ctx, _ := getAuthenticatedContext(t, clients)
+    missingID := "00000000-0000-4000-8000-000000000000"
+
+    t.Run("Field Violations", func(t *testing.T) {
+        _, err := clients.graphClient.ProposeMerge(ctx, &graph.MergeRequest{
+            SourceId: missingID,
+            TargetId: missingID,
+        })
+        if status.Code(err) != codes.InvalidArgument {
+            t.Fatalf("Expected InvalidArgument error, got: %v", err)
+        }
+
+        var badRequest *errdetails.BadRequest
+        var errorInfo *errdetails.ErrorInfo
+        for _, detail := range status.Convert(err).Details() {
+            switch detail := detail.(type) {
+            case *errdetails.BadRequest:
+                badRequest = detail
+            case *errdetails.ErrorInfo:
+                errorInfo = detail
+            }
+        }
+        if badRequest == nil || len(badRequest.GetFieldViolations()) != 1 || badRequest.GetFieldViolations()[0].GetField() != "SourceID" {
+            t.Errorf("Expected a violation of SourceID, got: %v", badRequest)
+        }
+        if errorInfo.GetReason() != "INVALID_REQUEST" || errorInfo.GetDomain() != "graphservice" {
+            t.Errorf("Expected the reason INVALID_REQUEST of graphservice, got: %v", errorInfo)
+        }
+    })
+
+    t.Run("Missing Resource", func(t *testing.T) {
+        _, err := clients.graphClient.GetRevision(ctx, &graph.IdRequest{Id: missingID})
+        if status.Code(err) != codes.NotFound {
+            t.Fatalf("Expected NotFound error, got: %v", err)
+        }
+
+        var resourceInfo *errdetails.ResourceInfo
+        for _, detail := range status.Convert(err).Details() {
+            if info, ok := detail.(*errdetails.ResourceInfo); ok {
+                resourceInfo = info
+            }
+        }
+        if resourceInfo.GetResourceType() != "revision" || resourceInfo.GetResourceName() != missingID {
+            t.Errorf("Expected the missing revision, got: %v", resourceInfo)
+        }
+    })
+
+    t.Run("Missing Split Entity", func(t *testing.T) {
+        _, err := clients.graphClient.SplitEntity(ctx, &graph.SplitRequest{EntityId: missingID})
+        if status.Code(err) != codes.NotFound {
+            t.Fatalf("Expected NotFound error, got: %v", err)
+        }
+
+        var resourceInfo *errdetails.ResourceInfo
+        for _, detail := range status.Convert(err).Details() {
+            if info, ok := detail.(*errdetails.ResourceInfo); ok {
+                resourceInfo = info
+            }
+        }
+        if resourceInfo.GetResourceType() != "entity" || resourceInfo.GetResourceName() != missingID {
+            t.Errorf("Expected the missing entity, got: %v", resourceInfo)
+        }
+    })
+}
+
+// Test the checks of the destructive admin commands
+
+func TestAdminCommandTargets(t *testing.T) {
+    confirmed := ad.Options{Confirm: true}
+    forced := ad.Options{Confirm: true, Force: true}
+
+    t.Run("Development Allowed", func(t *testing.T) {
+        for _, environment := range []string{"development", "test"} {
+            if err := ad.CheckTarget("reset", confirmed, environment, "localhost"); err != nil {
+                t.Errorf("Expected reset to run in %s, got: %v", environment, err)
+            }
+            if err := ad.CheckDevEnvironment("seed", environment); err != nil {
+                t.Errorf("Expected seed to run in %s, got: %v", environment, err)
+            }
+        }
+    })
+
+    t.Run("Other Environments Refused", func(t *testing.T) {
+        for _, environment := range []string{"production", "prod", "Production", "staging", ""} {
+            if err := ad.CheckTarget("reset", confirmed, environment, "localhost"); !e.Is(err, e.ErrInvalidRequest) {
+                t.Errorf("Expected reset to be refused in %q, got: %v", environment, err)
+            }
+            if err := ad.CheckDevEnvironment("seed", environment); !e.Is(err, e.ErrInvalidRequest) {
+                t.Errorf("Expected seed to be refused in %q, got: %v", environment, err)
+            }
+        }
+    })
+
+    t.Run("Force", func(t *testing.T) {
+        if err := ad.CheckTarget("reset", forced, "production", "db.example.com"); err != nil {
+            t.Errorf("Expected -force to allow reset, got: %v", err)
+        }
+        if err := ad.CheckTarget("reset", ad.Options{Force: true}, "development", "localhost"); !e.Is(err, e.ErrInvalidRequest) {
+            t.Errorf("Expected reset without -confirm to be refused, got: %v", err)
+        }
+    })
+}
+
+func getAuthenticatedContext(t *testing.T, clients *testClients) (context.Context, string) {
+    return registerUser(t, clients, "test@example.com")
+}
+
+// Helper function to get authenticated context of a registered user
+func getAuthenticatedContextFor(t *testing.T, clients *testClients, email string) (context.Context, string) {
     resp, err := clients.authClient.Login(clients.ctx, &auth.AuthRequest{
-        Email:    "test@example.com",
+        Email:    email,
         Password: "testpassword123",
     })
     if err != nil {